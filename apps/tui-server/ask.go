@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/access"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+const askUsage = `usage: ssh <host> ask "<question>" [--json] [--width N]`
+
+// askAnswer is the --json body of an answer
+type askAnswer struct {
+	Answer    string `json:"answer"`
+	Model     string `json:"model"`
+	LatencyMS int64  `json:"latency_ms"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// askError is the --json body when there is no answer
+type askError struct {
+	Error string `json:"error"`
+}
+
+// asker answers `ssh host ask "<question>"` without a PTY, so the chat
+// can be scripted: plain wrapped text by default, or JSON with --json
+type asker struct {
+	ai                ai.ChatService
+	site              *liveSite // for its FAQ
+	model             string
+	maxResponseLength int
+	logger            *telemetry.Logger
+	analytics         *telemetry.Analytics
+}
+
+// middleware serves ask commands and passes every other session on
+func (a asker) middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if cmd := s.Command(); len(cmd) == 0 || cmd[0] != "ask" {
+				next(s)
+				return
+			}
+			_ = s.Exit(a.serve(s, s.Command()[1:]))
+		}
+	}
+}
+
+// serve answers one question and returns the exit status
+func (a asker) serve(s ssh.Session, args []string) int {
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	asJSON := fs.Bool("json", false, "print a JSON body")
+	width := fs.Int("width", 80, "wrap plain answers at this width")
+
+	// Flags may follow the question, as in `ask "why Go?" --json`
+	var words []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			fmt.Fprintln(s.Stderr(), askUsage)
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		words = append(words, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	question := strings.TrimSpace(strings.Join(words, " "))
+	if question == "" || checkWidth(*width) != nil {
+		fmt.Fprintln(s.Stderr(), askUsage)
+		return 2
+	}
+
+	sessionID := telemetry.ExtractSessionInfo(s).SessionHash
+	a.analytics.TrackCommandExecuted(sessionID, "ask")
+	answer, err := a.answer(s, sessionID, question)
+	if err != nil {
+		a.logger.Warn("Ask failed", telemetry.Ctx("session_hash", sessionID, "error", err.Error()))
+		if *asJSON {
+			_ = json.NewEncoder(s).Encode(askError{Error: err.Error()})
+		} else {
+			fmt.Fprintln(s.Stderr(), "ask: "+err.Error())
+		}
+		return 1
+	}
+
+	if *asJSON {
+		_ = json.NewEncoder(s).Encode(answer)
+	} else {
+		fmt.Fprintln(s, plainMarkdown(answer.Answer, *width))
+	}
+	return 0
+}
+
+// plainMarkdown renders a reply's markdown as wrapped text without colors
+func plainMarkdown(text string, width int) string {
+	return ui.Plain(ui.NewMarkdownRendererWithWidth(ui.PlainStyles(width), width).Render(text))
+}
+
+// answer replies like the chat does: a canned FAQ answer when one
+// matches, otherwise the model's reply, capped in length
+func (a asker) answer(s ssh.Session, sessionID, question string) (askAnswer, error) {
+	start := time.Now()
+	if entry, ok := ai.MatchFAQ(a.site.Load().faq, question); ok {
+		return askAnswer{Answer: entry.Answer, Model: "faq", LatencyMS: time.Since(start).Milliseconds()}, nil
+	}
+	if a.ai == nil {
+		return askAnswer{}, errors.New("chat is not available")
+	}
+	if access.ReadOnly(s.Context()) {
+		return askAnswer{}, errors.New("chat is open to invited keys only")
+	}
+
+	var reply strings.Builder
+	var length int
+	truncated := false
+	ctx := ai.WithRateLimitKey(s.Context(), rateLimitKey(telemetry.ExtractSessionInfo(s)))
+	err := a.ai.ChatStream(ctx, sessionID, question, nil, func(chunk string) error {
+		runes := []rune(chunk)
+		if length+len(runes) > a.maxResponseLength {
+			chunk = string(runes[:a.maxResponseLength-length])
+			truncated = true
+		}
+		length += len(runes)
+		reply.WriteString(chunk)
+		if truncated {
+			return ai.ErrStopStream
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ai.ErrStopStream) {
+		return askAnswer{}, err
+	}
+	return askAnswer{
+		Answer:    strings.TrimSpace(reply.String()),
+		Model:     a.model,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Truncated: truncated,
+	}, nil
+}
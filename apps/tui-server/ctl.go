@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/control"
+)
+
+const ctlUsage = `usage: tui-server ctl [--socket PATH] <command>
+
+commands:
+  status                 version, uptime, sessions and content version
+  list-sessions          the open sessions, oldest first
+  kick <id>              disconnect the session whose ID starts with id
+  broadcast <message>    announce message to every session
+  reload-content         reread the content for new sessions
+  maintenance on|off     turn away new sessions but the admins'`
+
+// runCtl handles `tui-server ctl <command>`: it asks the server running
+// on this host, through its control socket, to do what the command says
+func runCtl(w io.Writer, socket string, args []string) error {
+	fs := flag.NewFlagSet("ctl", flag.ContinueOnError)
+	fs.StringVar(&socket, "socket", socket, "the server's control socket")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 {
+		return errors.New(ctlUsage)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	c := control.Dial(socket)
+
+	switch cmd, rest := args[0], args[1:]; {
+	case cmd == "status" && len(rest) == 0:
+		st, err := c.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(w, st)
+	case cmd == "list-sessions" && len(rest) == 0:
+		sessions, err := c.Sessions(ctx)
+		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			fmt.Fprintln(w, "No sessions")
+			return nil
+		}
+		for _, s := range sessions {
+			var flags []string
+			if s.Admin {
+				flags = append(flags, "admin")
+			}
+			if s.ReadOnly {
+				flags = append(flags, "read-only")
+			}
+			fmt.Fprintf(w, "%s  %s  %-16s %s\n", s.ID, s.Started.Format("2006-01-02 15:04"), s.Terminal, strings.Join(flags, ","))
+		}
+	case cmd == "kick" && len(rest) == 1:
+		s, err := c.Kick(ctx, rest[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Kicked %s\n", s.ID)
+	case cmd == "broadcast" && len(rest) > 0:
+		n, err := c.Broadcast(ctx, strings.Join(rest, " "))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Announced to %d sessions\n", n)
+	case cmd == "reload-content" && len(rest) == 0:
+		version, err := c.ReloadContent(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Content %s; new sessions get it\n", version)
+	case cmd == "maintenance" && len(rest) == 1 && (rest[0] == "on" || rest[0] == "off"):
+		st, err := c.SetMaintenance(ctx, rest[0] == "on")
+		if err != nil {
+			return err
+		}
+		printStatus(w, st)
+	default:
+		return errors.New(ctlUsage)
+	}
+	return nil
+}
+
+func printStatus(w io.Writer, st control.Status) {
+	maintenance := "off"
+	if st.Maintenance {
+		maintenance = "on"
+	}
+	fmt.Fprintf(w, "version      %s\n", st.Version)
+	fmt.Fprintf(w, "up           %s\n", time.Since(st.Started).Round(time.Second))
+	fmt.Fprintf(w, "sessions     %d\n", st.Sessions)
+	fmt.Fprintf(w, "content      %s\n", st.Content)
+	fmt.Fprintf(w, "maintenance  %s\n", maintenance)
+}
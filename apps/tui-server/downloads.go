@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish/scp"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/export"
+	"github.com/pkg/sftp"
+)
+
+// downloads serves the generated resume files by name, as in
+// `scp host:resume.pdf .`, and anything else as a /record cast. Both scp
+// protocols work: the legacy one through the scp middleware and SFTP,
+// which OpenSSH's scp uses by default since 9.0, as a subsystem.
+type downloads struct {
+	site  *liveSite // the resume files
+	casts castHandler
+}
+
+var _ scp.CopyToClientHandler = downloads{}
+
+func (d downloads) Glob(s ssh.Session, pattern string) ([]string, error) {
+	return d.casts.Glob(s, pattern)
+}
+
+func (d downloads) WalkDir(s ssh.Session, path string, fn fs.WalkDirFunc) error {
+	return d.casts.WalkDir(s, path, fn)
+}
+
+func (d downloads) NewDirEntry(_ ssh.Session, name string) (*scp.DirEntry, error) {
+	return nil, fmt.Errorf("%s: not a file", name)
+}
+
+func (d downloads) NewFileEntry(s ssh.Session, name string) (*scp.FileEntry, func() error, error) {
+	f, ok := d.site.Load().files[path.Base(name)]
+	if !ok {
+		return d.casts.NewFileEntry(s, name)
+	}
+	return &scp.FileEntry{
+		Name:     f.Name,
+		Filepath: f.Name,
+		Mode:     0o644,
+		Size:     int64(len(f.Data)),
+		Mtime:    f.ModTime.Unix(),
+		Atime:    f.ModTime.Unix(),
+		Reader:   bytes.NewReader(f.Data),
+	}, nil, nil
+}
+
+// sftp serves the same files read-only over SFTP. Listing the root shows
+// the resume files; casts are found only by exact name, as over scp.
+func (d downloads) sftp(s ssh.Session) {
+	h := sftpDownloads(d)
+	server := sftp.NewRequestServer(s, sftp.Handlers{FileGet: h, FilePut: h, FileCmd: h, FileList: h})
+	defer server.Close()
+	if err := server.Serve(); err != nil && !errors.Is(err, io.EOF) {
+		fmt.Fprintln(s.Stderr(), err)
+		_ = s.Exit(1)
+		return
+	}
+	_ = s.Exit(0)
+}
+
+// sftpDownloads is downloads as SFTP request handlers
+type sftpDownloads downloads
+
+// open finds a generated file or cast by name
+func (h sftpDownloads) open(name string) (io.ReaderAt, fs.FileInfo, error) {
+	if f, ok := h.site.Load().files[path.Base(name)]; ok {
+		return bytes.NewReader(f.Data), generatedInfo{f}, nil
+	}
+	f, info, err := h.casts.open(name)
+	if err != nil {
+		return nil, nil, sftp.ErrSSHFxNoSuchFile
+	}
+	return f, info, nil
+}
+
+func (h sftpDownloads) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	reader, _, err := h.open(r.Filepath)
+	return reader, err
+}
+
+func (h sftpDownloads) Filewrite(*sftp.Request) (io.WriterAt, error) {
+	return nil, sftp.ErrSSHFxPermissionDenied
+}
+
+func (h sftpDownloads) Filecmd(*sftp.Request) error {
+	return sftp.ErrSSHFxPermissionDenied
+}
+
+func (h sftpDownloads) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	if r.Filepath == "/" || r.Filepath == "." {
+		if r.Method == "List" {
+			var infos listing
+			for _, f := range h.site.Load().files {
+				infos = append(infos, generatedInfo{f})
+			}
+			slices.SortFunc(infos, func(a, b fs.FileInfo) int { return strings.Compare(a.Name(), b.Name()) })
+			return infos, nil
+		}
+		return listing{rootInfo{}}, nil
+	}
+	if r.Method == "List" {
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+	reader, info, err := h.open(r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := reader.(io.Closer); ok {
+		c.Close()
+	}
+	return listing{info}, nil
+}
+
+// listing is a fixed directory listing
+type listing []fs.FileInfo
+
+func (l listing) ListAt(dst []fs.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(dst, l[offset:])
+	if n < len(dst) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// generatedInfo describes a generated file as a read-only regular file
+type generatedInfo struct{ f export.File }
+
+func (i generatedInfo) Name() string       { return i.f.Name }
+func (i generatedInfo) Size() int64        { return int64(len(i.f.Data)) }
+func (i generatedInfo) Mode() fs.FileMode  { return 0o444 }
+func (i generatedInfo) ModTime() time.Time { return i.f.ModTime }
+func (i generatedInfo) IsDir() bool        { return false }
+func (i generatedInfo) Sys() any           { return nil }
+
+// rootInfo describes the one directory
+type rootInfo struct{}
+
+func (rootInfo) Name() string       { return "/" }
+func (rootInfo) Size() int64        { return 0 }
+func (rootInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (rootInfo) ModTime() time.Time { return time.Time{} }
+func (rootInfo) IsDir() bool        { return true }
+func (rootInfo) Sys() any           { return nil }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/app"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/notify"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+)
+
+// feedbackSink keeps /feedback submissions in the store and, when
+// notifier is set, pings the owner about each one
+type feedbackSink struct {
+	store    store.Store
+	notifier *notify.Notifier
+}
+
+var _ app.FeedbackSink = feedbackSink{}
+
+func (f feedbackSink) Submit(fb store.Feedback) error {
+	if err := f.store.SaveFeedback(fb); err != nil {
+		return err
+	}
+	f.notifier.Feedback(fb.Rating, fb.Text)
+	return nil
+}
+
+// runFeedback handles `tui-server feedback [--limit N]`: it prints the
+// latest /feedback submissions, newest first
+func runFeedback(w io.Writer, s store.Store, args []string) error {
+	fs := flag.NewFlagSet("feedback", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "how many submissions to show")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 {
+		return errors.New("usage: tui-server feedback [--limit N]")
+	}
+
+	recent, err := s.RecentFeedback(*limit)
+	if err != nil {
+		return err
+	}
+	if len(recent) == 0 {
+		fmt.Fprintln(w, "No feedback yet")
+		return nil
+	}
+	for _, fb := range recent {
+		// Enough of the key hash to tell visitors apart
+		who := "anonymous"
+		if fb.Visitor != "" {
+			who = fb.Visitor[:min(len(fb.Visitor), len("login:")+8)]
+		}
+		fmt.Fprintf(w, "%s  %s  %d/5  (%s)\n", fb.At.Format("2006-01-02 15:04"), strings.Repeat("*", fb.Rating), fb.Rating, who)
+		if fb.Text != "" {
+			fmt.Fprintf(w, "    %s\n", fb.Text)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+// Package access reads the allowlist and denylist: files of public keys,
+// key fingerprints and addresses. Clients on the denylist are refused;
+// when there is an allowlist, clients missing from it get a read-only
+// session. The admin list names the keys that get operator commands.
+// The files are reloaded as they change, without a restart.
+package access
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// reloadInterval is how often the files are checked for changes
+const reloadInterval = 5 * time.Second
+
+// list is one parsed file
+type list struct {
+	keys     map[string]bool // SHA256 fingerprints
+	prefixes []netip.Prefix
+}
+
+// matches reports whether addr or key is on the list
+func (l *list) matches(addr net.Addr, key ssh.PublicKey) bool {
+	if key != nil && l.keys[gossh.FingerprintSHA256(key)] {
+		return true
+	}
+	ip, ok := addrIP(addr)
+	if !ok {
+		return false
+	}
+	for _, p := range l.prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse reads a list, one entry per line: an authorized_keys line, a
+// SHA256:... fingerprint, an IP address or a CIDR range. Blank lines and
+// # comments are skipped, as are lines that don't parse, which come back
+// as problems so one typo doesn't drop the whole list.
+func parse(data string) (l *list, problems []string) {
+	l = &list{keys: map[string]bool{}}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, _, _ := strings.Cut(line, " ")
+		if strings.HasPrefix(entry, "SHA256:") {
+			l.keys[entry] = true
+			continue
+		}
+		if p, err := netip.ParsePrefix(entry); err == nil {
+			l.prefixes = append(l.prefixes, unmapPrefix(p))
+			continue
+		}
+		if ip, err := netip.ParseAddr(entry); err == nil {
+			ip = ip.Unmap()
+			l.prefixes = append(l.prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+			continue
+		}
+		key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: not a key, fingerprint or address", n))
+			continue
+		}
+		l.keys[gossh.FingerprintSHA256(key)] = true
+	}
+	return l, problems
+}
+
+// file is a list and the state of the file it was read from
+type file struct {
+	path    string
+	list    *list
+	modTime time.Time
+	size    int64
+	loaded  bool
+}
+
+// Config names the list files; any may be empty
+type Config struct {
+	AllowPath string
+	DenyPath  string
+	AdminPath string // only its keys count, never its addresses
+	// OnDeny, if set, is told of each session refused by the denylist
+	OnDeny func(s ssh.Session)
+}
+
+// Lists holds the current allowlist and denylist
+type Lists struct {
+	cfg    Config
+	logger *telemetry.Logger
+
+	mu    sync.RWMutex
+	allow *file // nil when there's no allowlist
+	deny  *file
+	admin *file
+}
+
+// New reads the lists cfg names. A file that doesn't exist yet counts as
+// empty: nobody denied, or nobody allowed.
+func New(cfg Config, logger *telemetry.Logger) *Lists {
+	l := &Lists{cfg: cfg, logger: logger}
+	if cfg.AllowPath != "" {
+		l.allow = &file{path: cfg.AllowPath, list: &list{}}
+	}
+	if cfg.DenyPath != "" {
+		l.deny = &file{path: cfg.DenyPath, list: &list{}}
+	}
+	if cfg.AdminPath != "" {
+		l.admin = &file{path: cfg.AdminPath, list: &list{}}
+	}
+	l.reload()
+	return l
+}
+
+// Denied reports whether a client is on the denylist
+func (l *Lists) Denied(addr net.Addr, key ssh.PublicKey) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.deny != nil && l.deny.list.matches(addr, key)
+}
+
+// Allowed reports whether a client gets the full TUI: always, unless
+// there's an allowlist it isn't on
+func (l *Lists) Allowed(addr net.Addr, key ssh.PublicKey) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.allow == nil || l.allow.list.matches(addr, key)
+}
+
+// Admin reports whether a client's key is on the admin list. Addresses
+// are shared and spoofable, so an admin is always a key.
+func (l *Lists) Admin(key ssh.PublicKey) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.admin != nil && key != nil && l.admin.list.keys[gossh.FingerprintSHA256(key)]
+}
+
+// readOnlyKey marks a session's context as read-only
+type readOnlyKey struct{}
+
+// ReadOnly reports whether the middleware found a session's client
+// missing from the allowlist
+func ReadOnly(ctx ssh.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// adminKey marks a session's context as the operator's
+type adminKey struct{}
+
+// Admin reports whether the middleware found a session's key on the
+// admin list
+func Admin(ctx ssh.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
+
+// Middleware refuses sessions from denied clients, marks those outside
+// the allowlist read-only and those on the admin list as the operator's
+func (l *Lists) Middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if l.Denied(s.RemoteAddr(), s.PublicKey()) {
+				l.logger.Info("Session denied", telemetry.Ctx("ip_hash", telemetry.ShortHash(hostOf(s.RemoteAddr()))))
+				if l.cfg.OnDeny != nil {
+					l.cfg.OnDeny(s)
+				}
+				fmt.Fprintln(s.Stderr(), "Access denied.")
+				_ = s.Exit(1)
+				return
+			}
+			if !l.Allowed(s.RemoteAddr(), s.PublicKey()) {
+				s.Context().SetValue(readOnlyKey{}, true)
+			}
+			if l.Admin(s.PublicKey()) {
+				s.Context().SetValue(adminKey{}, true)
+			}
+			next(s)
+		}
+	}
+}
+
+// Run reloads the lists whenever their files change, until ctx ends
+func (l *Lists) Run(ctx context.Context) {
+	if l.allow == nil && l.deny == nil && l.admin == nil {
+		return
+	}
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.reload()
+		}
+	}
+}
+
+// reload rereads each file whose size or modification time changed
+func (l *Lists) reload() {
+	for _, f := range []*file{l.allow, l.deny, l.admin} {
+		if f == nil {
+			continue
+		}
+		info, err := os.Stat(f.path)
+		var modTime time.Time
+		var size int64
+		if err == nil {
+			modTime, size = info.ModTime(), info.Size()
+		}
+		l.mu.RLock()
+		unchanged := f.loaded && f.modTime.Equal(modTime) && f.size == size
+		l.mu.RUnlock()
+		if unchanged {
+			continue
+		}
+
+		next := &list{}
+		if err == nil {
+			data, readErr := os.ReadFile(f.path)
+			if readErr != nil {
+				l.logger.Warn("Access list not read", telemetry.Ctx("path", f.path, "error", readErr.Error()))
+				continue
+			}
+			var problems []string
+			next, problems = parse(string(data))
+			for _, problem := range problems {
+				l.logger.Warn("Access list entry skipped", telemetry.Ctx("path", f.path, "problem", problem))
+			}
+		}
+
+		l.mu.Lock()
+		f.list, f.modTime, f.size, f.loaded = next, modTime, size, true
+		l.mu.Unlock()
+		if err != nil {
+			l.logger.Warn("Access list missing, treated as empty", telemetry.Ctx("path", f.path))
+			continue
+		}
+		l.logger.Info("Access list loaded", telemetry.Ctx(
+			"path", f.path,
+			"keys", len(next.keys),
+			"ranges", len(next.prefixes),
+		))
+	}
+}
+
+// unmapPrefix writes a range of IPv4-mapped addresses as plain IPv4, the
+// way client addresses are compared
+func unmapPrefix(p netip.Prefix) netip.Prefix {
+	if p.Addr().Is4In6() && p.Bits() >= 96 {
+		return netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96).Masked()
+	}
+	return p.Masked()
+}
+
+func addrIP(addr net.Addr) (netip.Addr, bool) {
+	if addr == nil {
+		return netip.Addr{}, false
+	}
+	ip, err := netip.ParseAddr(hostOf(addr))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+func hostOf(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
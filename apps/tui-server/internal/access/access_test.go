@@ -0,0 +1,135 @@
+package access
+
+import (
+	"crypto/ed25519"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func testKey(t *testing.T, seed byte) gossh.PublicKey {
+	t.Helper()
+	seedBytes := make([]byte, ed25519.SeedSize)
+	seedBytes[0] = seed
+	priv := ed25519.NewKeyFromSeed(seedBytes)
+	key, err := gossh.NewPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func addr(ip string) net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}
+}
+
+func TestParseReadsEveryEntryKind(t *testing.T) {
+	byKey, byFingerprint, other := testKey(t, 1), testKey(t, 2), testKey(t, 3)
+	data := strings.Join([]string{
+		"# beta testers",
+		strings.TrimSpace(string(gossh.MarshalAuthorizedKey(byKey))) + " alice@laptop",
+		gossh.FingerprintSHA256(byFingerprint) + " bob",
+		"198.51.100.7",
+		"203.0.113.0/24",
+		"",
+		"not an entry",
+	}, "\n")
+
+	l, problems := parse(data)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "line 7:") {
+		t.Errorf("problems = %q, want one for line 7", problems)
+	}
+	cases := []struct {
+		name string
+		addr net.Addr
+		key  gossh.PublicKey
+		want bool
+	}{
+		{"authorized_keys line", addr("192.0.2.1"), byKey, true},
+		{"fingerprint", addr("192.0.2.1"), byFingerprint, true},
+		{"address", addr("198.51.100.7"), nil, true},
+		{"IPv4-mapped address", addr("::ffff:198.51.100.7"), nil, true},
+		{"range", addr("203.0.113.200"), other, true},
+		{"neither", addr("192.0.2.1"), other, false},
+	}
+	for _, c := range cases {
+		if got := l.matches(c.addr, c.key); got != c.want {
+			t.Errorf("%s: matches = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestListsReloadWhenFilesChange(t *testing.T) {
+	logger := telemetry.NewLogger("test")
+	logger.SetOutput(io.Discard)
+	dir := t.TempDir()
+	allowPath := filepath.Join(dir, "allow")
+	denyPath := filepath.Join(dir, "deny")
+	key := testKey(t, 1)
+	client := addr("192.0.2.1")
+
+	if err := os.WriteFile(allowPath, []byte(gossh.FingerprintSHA256(key)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l := New(Config{AllowPath: allowPath, DenyPath: denyPath}, logger)
+	if !l.Allowed(client, key) || l.Allowed(client, testKey(t, 2)) {
+		t.Fatal("allowlist not applied")
+	}
+	if l.Denied(client, key) {
+		t.Fatal("missing denylist should deny nobody")
+	}
+
+	if err := os.WriteFile(denyPath, []byte("192.0.2.0/24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l.reload()
+	if !l.Denied(client, key) {
+		t.Error("denylist not reloaded once created")
+	}
+
+	// Same size as before, so only the modification time tells
+	if err := os.WriteFile(denyPath, []byte("192.0.3.0/24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(denyPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	l.reload()
+	if l.Denied(client, key) {
+		t.Error("denylist not reloaded after an edit")
+	}
+
+	if open := New(Config{}, logger); !open.Allowed(client, nil) || open.Denied(client, nil) {
+		t.Error("without lists everyone should get the full TUI")
+	}
+}
+
+func TestAdminListTakesOnlyKeys(t *testing.T) {
+	logger := telemetry.NewLogger("test")
+	logger.SetOutput(io.Discard)
+	adminPath := filepath.Join(t.TempDir(), "admins")
+	key := testKey(t, 1)
+
+	data := gossh.FingerprintSHA256(key) + "\n192.0.2.0/24\n"
+	if err := os.WriteFile(adminPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	l := New(Config{AdminPath: adminPath}, logger)
+	if !l.Admin(key) {
+		t.Error("listed key should be an admin")
+	}
+	if l.Admin(testKey(t, 2)) || l.Admin(nil) {
+		t.Error("only listed keys should be admins, whatever the address")
+	}
+	if open := New(Config{}, logger); open.Admin(key) {
+		t.Error("without an admin list nobody should be an admin")
+	}
+}
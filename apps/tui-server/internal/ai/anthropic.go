@@ -0,0 +1,182 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+)
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com/v1"
+	anthropicVersion = "2023-06-01"
+)
+
+// AnthropicProvider streams chat completions from the Anthropic Messages API.
+type AnthropicProvider struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAnthropicProvider creates an Anthropic provider.
+func NewAnthropicProvider(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{
+		apiKey:  apiKey,
+		baseURL: anthropicBaseURL,
+		httpClient: &http.Client{
+			Timeout:   120 * time.Second,
+			Transport: network.NewHTTPTransport(),
+		},
+	}
+}
+
+// StreamChat sends a streaming messages request and emits text deltas.
+func (p *AnthropicProvider) StreamChat(
+	ctx context.Context,
+	request CompletionRequest,
+	callback StreamCallback,
+) error {
+	if strings.TrimSpace(p.apiKey) == "" {
+		return errors.New("ANTHROPIC_API_KEY is required")
+	}
+
+	system, messages := anthropicMessages(request.Messages)
+	maxTokens := request.MaxTokens
+	if maxTokens <= 0 {
+		// Anthropic requires max_tokens
+		maxTokens = 1024
+	}
+
+	body, err := json.Marshal(anthropicRequest{
+		Model:       request.Model,
+		System:      system,
+		Messages:    messages,
+		MaxTokens:   maxTokens,
+		Temperature: request.Temperature,
+		Stream:      true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		p.baseURL+"/messages",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create provider request: %w", err)
+	}
+
+	httpRequest.Header.Set("x-api-key", p.apiKey)
+	httpRequest.Header.Set("anthropic-version", anthropicVersion)
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response, err := p.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to send provider request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return readProviderError(response)
+	}
+
+	return streamAnthropicEvents(ctx, response.Body, callback)
+}
+
+type anthropicRequest struct {
+	Model       string              `json:"model"`
+	System      string              `json:"system,omitempty"`
+	Messages    []CompletionMessage `json:"messages"`
+	MaxTokens   int                 `json:"max_tokens"`
+	Temperature float64             `json:"temperature,omitempty"`
+	Stream      bool                `json:"stream"`
+}
+
+type anthropicEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// anthropicMessages moves system messages into the separate system field
+// and merges consecutive turns from the same role, which the API rejects
+func anthropicMessages(messages []CompletionMessage) (string, []CompletionMessage) {
+	var system []string
+	out := make([]CompletionMessage, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = append(system, m.Content)
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].Role == m.Role {
+			out[n-1].Content += "\n\n" + m.Content
+			continue
+		}
+		out = append(out, m)
+	}
+	return strings.Join(system, "\n\n"), out
+}
+
+func streamAnthropicEvents(ctx context.Context, body io.Reader, callback StreamCallback) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var event anthropicEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			return fmt.Errorf("failed to parse provider stream: %w", err)
+		}
+
+		switch event.Type {
+		case "content_block_delta":
+			if event.Delta.Type != "text_delta" || event.Delta.Text == "" || callback == nil {
+				continue
+			}
+			if err := callback(event.Delta.Text); err != nil {
+				return err
+			}
+		case "message_stop":
+			return nil
+		case "error":
+			if err := streamError(event.Error.Type, event.Error.Message, 0); err != nil {
+				return err
+			}
+			return fmt.Errorf("AI provider error: %s", event.Error.Message)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read provider stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+)
+
+// ErrUnknownConversation is returned by a gateway that doesn't have a
+// request's conversation, say because it expired; the request goes again
+// with its history, which starts the conversation over
+var ErrUnknownConversation = errors.New("unknown conversation")
+
+// conversationIDLength is the length of a NewConversationID
+const conversationIDLength = 26
+
+type conversationKey struct{}
+
+// conversationTag is what WithConversation and WithNewConversation keep
+type conversationTag struct {
+	id    string
+	fresh bool
+}
+
+// WithConversation tags ChatStream requests with a conversation ID. A
+// gateway that keeps conversations (GatewayConfig.Conversations) is then
+// sent only the new message, not the history before it.
+func WithConversation(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, conversationKey{}, conversationTag{id: id})
+}
+
+// WithNewConversation is WithConversation for a conversation that starts
+// with this request, say one /regen started over: it goes with its whole
+// history, which the gateway starts the conversation from
+func WithNewConversation(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, conversationKey{}, conversationTag{id: id, fresh: true})
+}
+
+// conversationFrom is the context's conversation ID, or "", and whether
+// the conversation starts with this request
+func conversationFrom(ctx context.Context) (id string, fresh bool) {
+	tag, _ := ctx.Value(conversationKey{}).(conversationTag)
+	return tag.id, tag.fresh
+}
+
+// NewConversationID returns a random conversation ID. Whoever has it can
+// continue the conversation, so it can't be guessed.
+func NewConversationID() string {
+	return rand.Text()
+}
+
+// ParseConversationID normalizes an ID typed in by the visitor, reporting
+// whether it's one NewConversationID could have returned
+func ParseConversationID(id string) (string, bool) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	if len(id) != conversationIDLength {
+		return "", false
+	}
+	for _, r := range id {
+		if (r < 'A' || r > 'Z') && (r < '2' || r > '7') {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+// latestTurn is the request's messages without the conversation before
+// them: the system messages it opens with and the new message
+func (r CompletionRequest) latestTurn() []CompletionMessage {
+	if len(r.Messages) == 0 {
+		return nil
+	}
+	n := 0
+	for n < len(r.Messages)-1 && r.Messages[n].Role == "system" {
+		n++
+	}
+	return append(r.Messages[:n:n], r.Messages[len(r.Messages)-1])
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind is a failed reply the visitor can do something about
+type ErrorKind string
+
+const (
+	ErrorRateLimited     ErrorKind = "rate_limited"
+	ErrorModelOverloaded ErrorKind = "model_overloaded"
+	ErrorContentFiltered ErrorKind = "content_filtered"
+)
+
+// StreamError is a failed reply of a known kind. A gateway names the kind
+// in an error frame's code or an error body's type; the plain HTTP
+// statuses for it, 429 and 503, do too.
+type StreamError struct {
+	Kind    ErrorKind
+	Message string // the provider's, for the logs
+	// RetryAfter is how long the provider asked to wait, 0 if it didn't
+	RetryAfter time.Duration
+}
+
+func (e *StreamError) Error() string {
+	if e.Message == "" {
+		return string(e.Kind)
+	}
+	return e.Message
+}
+
+// errorKinds maps the names providers give these errors to their kind
+var errorKinds = map[string]ErrorKind{
+	"rate_limited":             ErrorRateLimited,
+	"rate_limit_error":         ErrorRateLimited, // Anthropic
+	"rate_limit_exceeded":      ErrorRateLimited, // OpenAI
+	"model_overloaded":         ErrorModelOverloaded,
+	"overloaded_error":         ErrorModelOverloaded, // Anthropic
+	"server_overloaded":        ErrorModelOverloaded,
+	"content_filtered":         ErrorContentFiltered,
+	"content_filter":           ErrorContentFiltered, // OpenAI's finish reason
+	"content_policy_violation": ErrorContentFiltered,
+}
+
+// streamError is the StreamError for a provider's error name, or nil
+// if it isn't one of the known kinds
+func streamError(name, message string, retryAfter time.Duration) error {
+	kind, ok := errorKinds[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+	return &StreamError{Kind: kind, Message: message, RetryAfter: retryAfter}
+}
+
+// statusError is the StreamError for an HTTP status that means one, or
+// nil
+func statusError(response *http.Response) error {
+	retryAfter := retryAfterHeader(response)
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return &StreamError{Kind: ErrorRateLimited, Message: errRateLimited.Message, RetryAfter: retryAfter}
+	case http.StatusServiceUnavailable, 529: // 529 is Anthropic's "overloaded"
+		return &StreamError{Kind: ErrorModelOverloaded, Message: "AI model overloaded", RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// retryAfterHeader is the wait a response's Retry-After asks for in
+// seconds, or 0
+func retryAfterHeader(response *http.Response) time.Duration {
+	if s, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+)
+
+// faqThreshold is the word overlap a message needs with an FAQ question
+// to be answered from the FAQ instead of the model
+const faqThreshold = 0.75
+
+// faqStopWords carry no meaning for matching questions
+var faqStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "was": true, "be": true,
+	"do": true, "does": true, "did": true, "can": true, "could": true, "i": true, "me": true,
+	"he": true, "him": true, "his": true, "mohak": true, "mohaks": true, "of": true, "to": true,
+	"for": true, "in": true, "on": true, "at": true, "with": true, "please": true, "you": true,
+	"tell": true, "about": true, "main": true,
+}
+
+// MatchFAQ returns the FAQ entry whose questions best match message, if
+// the match is confident enough to skip the model.
+func MatchFAQ(faq *content.FAQ, message string) (*content.FAQEntry, bool) {
+	if faq == nil {
+		return nil, false
+	}
+	words := faqWords(PreprocessMessage(message))
+	if len(words) == 0 {
+		return nil, false
+	}
+
+	var best *content.FAQEntry
+	bestScore := 0.0
+	for i := range faq.FAQs {
+		for _, q := range faq.FAQs[i].Questions {
+			if score := wordOverlap(words, faqWords(q)); score > bestScore {
+				best, bestScore = &faq.FAQs[i], score
+			}
+		}
+	}
+	if bestScore < faqThreshold {
+		return nil, false
+	}
+	return best, true
+}
+
+// faqWords splits text into a set of lowercased, roughly singular words
+func faqWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	}) {
+		w = strings.ReplaceAll(w, "'", "")
+		if faqStopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		words[w] = true
+	}
+	return words
+}
+
+// wordOverlap is the Jaccard similarity of two word sets
+func wordOverlap(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,258 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FilterRule names a check in the pre-send filter pipeline.
+type FilterRule string
+
+const (
+	RuleLength    FilterRule = "length"
+	RuleRepeat    FilterRule = "repeat"
+	RuleProfanity FilterRule = "profanity"
+	RuleInjection FilterRule = "injection"
+)
+
+// FilterAction is what happens to a message that trips a rule.
+type FilterAction string
+
+const (
+	// ActionWarn lets the message through and only logs and tracks it.
+	ActionWarn FilterAction = "warn"
+	// ActionBlock rejects the message with an explanation.
+	ActionBlock FilterAction = "block"
+	// ActionShadow silently rate limits the session for a while.
+	ActionShadow FilterAction = "shadow"
+)
+
+const (
+	// repeatWindow is how long an identical message counts as a repeat
+	repeatWindow = 2 * time.Minute
+	// shadowDuration is how long a shadow rate limit lasts
+	shadowDuration = 5 * time.Minute
+)
+
+// DefaultFilterActions are used for rules not set in FilterConfig.Actions.
+var DefaultFilterActions = map[FilterRule]FilterAction{
+	RuleLength:    ActionBlock,
+	RuleRepeat:    ActionShadow,
+	RuleProfanity: ActionBlock,
+	RuleInjection: ActionWarn,
+}
+
+// FilterConfig configures the pre-send filter pipeline.
+type FilterConfig struct {
+	MaxLength   int // characters; defaults to maxMessageLength
+	RepeatLimit int // identical messages in a row before RuleRepeat fires; defaults to 5
+	Actions     map[FilterRule]FilterAction
+}
+
+// FilterResult is the outcome of running a message through the filter.
+// Message is the text to send on, with injection attempts stripped.
+type FilterResult struct {
+	Message string
+	Rule    FilterRule // empty when no rule fired
+	Action  FilterAction
+}
+
+var (
+	profanityPattern = regexp.MustCompile(`(?i)\b(fuck\w*|shit\w*|bitch\w*|cunt\w*|asshole\w*|bastard\w*|dickhead\w*|motherfuck\w*|slut\w*|whore\w*)\b`)
+	injectionPattern = regexp.MustCompile(`(?i)(ignore|disregard|forget)\s+(all\s+|any\s+)?(the\s+)?(previous|prior|above|earlier|your)\s+(instructions|prompts?|rules|messages)` +
+		`|(reveal|print|show|repeat)\s+(me\s+)?(your|the)\s+(system\s+prompt|instructions|prompt)` +
+		`|you\s+are\s+now\s+(a|an|in)\b[^.!?\n]*` +
+		`|<\|(im_start|im_end|system|endoftext)\|>` +
+		`|^\s*#{1,3}\s*(system|instruction)s?\s*:?`)
+)
+
+// Filter screens visitor messages before they reach the model.
+type Filter struct {
+	maxLength   int
+	repeatLimit int
+	actions     map[FilterRule]FilterAction
+
+	mu       sync.Mutex
+	recent   map[string]repeatEntry
+	shadowed map[string]time.Time
+}
+
+type repeatEntry struct {
+	message string
+	count   int
+	at      time.Time
+}
+
+// NewFilter creates a filter, filling unset config with defaults.
+func NewFilter(cfg FilterConfig) *Filter {
+	f := &Filter{
+		maxLength:   cfg.MaxLength,
+		repeatLimit: cfg.RepeatLimit,
+		actions:     make(map[FilterRule]FilterAction),
+		recent:      make(map[string]repeatEntry),
+		shadowed:    make(map[string]time.Time),
+	}
+	if f.maxLength <= 0 {
+		f.maxLength = maxMessageLength
+	}
+	if f.repeatLimit <= 0 {
+		f.repeatLimit = 5
+	}
+	for rule, action := range DefaultFilterActions {
+		f.actions[rule] = action
+	}
+	for rule, action := range cfg.Actions {
+		f.actions[rule] = action
+	}
+	return f
+}
+
+// ParseFilterActions reads overrides like "profanity=warn,repeat=block".
+func ParseFilterActions(spec string) (map[FilterRule]FilterAction, error) {
+	actions := make(map[FilterRule]FilterAction)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		rule := FilterRule(strings.TrimSpace(name))
+		action := FilterAction(strings.TrimSpace(value))
+		if _, known := DefaultFilterActions[rule]; !ok || !known {
+			return nil, fmt.Errorf("unknown filter rule %q", name)
+		}
+		switch action {
+		case ActionWarn, ActionBlock, ActionShadow:
+		default:
+			return nil, fmt.Errorf("unknown filter action %q for %s", value, rule)
+		}
+		actions[rule] = action
+	}
+	return actions, nil
+}
+
+// Check runs message through every rule. The first rule that fires with
+// a block or shadow action decides the result; warnings let it through.
+func (f *Filter) Check(sessionID, message string) FilterResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.prune(now)
+
+	result := FilterResult{Message: message}
+	fire := func(rule FilterRule) bool {
+		action := f.actions[rule]
+		result.Rule = rule
+		result.Action = action
+		if action == ActionShadow {
+			f.shadowed[sessionID] = now.Add(shadowDuration)
+		}
+		return action != ActionWarn
+	}
+
+	// A shadow-limited session stays limited until it expires
+	if until, ok := f.shadowed[sessionID]; ok && now.Before(until) {
+		result.Action = ActionShadow
+		return result
+	}
+
+	if len([]rune(message)) > f.maxLength && fire(RuleLength) {
+		return result
+	}
+
+	normalized := strings.ToLower(strings.Join(strings.Fields(message), " "))
+	entry := f.recent[sessionID]
+	if entry.message == normalized {
+		entry.count++
+	} else {
+		entry = repeatEntry{message: normalized, count: 1}
+	}
+	entry.at = now
+	f.recent[sessionID] = entry
+	if entry.count >= f.repeatLimit && fire(RuleRepeat) {
+		return result
+	}
+
+	if profanityPattern.MatchString(message) && fire(RuleProfanity) {
+		return result
+	}
+
+	if injectionPattern.MatchString(message) {
+		result.Message = stripInjection(message)
+		if fire(RuleInjection) {
+			return result
+		}
+		if result.Message == "" {
+			// Nothing left once the injection is stripped
+			result.Action = ActionBlock
+			return result
+		}
+	}
+
+	return result
+}
+
+// History screens the visitor's earlier messages the way Check screened
+// them when sent: ones a rule stopped are dropped and injections are
+// stripped, so they don't reach the model as history on a later turn.
+// Repeats aren't counted again.
+func (f *Filter) History(history []Message) []Message {
+	kept := make([]Message, 0, len(history))
+	for _, msg := range history {
+		if msg.Role != "user" {
+			kept = append(kept, msg)
+			continue
+		}
+		if len([]rune(msg.Content)) > f.maxLength && f.actions[RuleLength] != ActionWarn {
+			continue
+		}
+		if profanityPattern.MatchString(msg.Content) && f.actions[RuleProfanity] != ActionWarn {
+			continue
+		}
+		if injectionPattern.MatchString(msg.Content) {
+			msg.Content = stripInjection(msg.Content)
+			if msg.Content == "" || f.actions[RuleInjection] != ActionWarn {
+				continue
+			}
+		}
+		kept = append(kept, msg)
+	}
+	return kept
+}
+
+// stripInjection removes injection attempts from message
+func stripInjection(message string) string {
+	return strings.Trim(injectionPattern.ReplaceAllString(message, ""), " \t\n.,;:!-")
+}
+
+// prune forgets repeat history and shadow limits that have expired
+func (f *Filter) prune(now time.Time) {
+	for id, entry := range f.recent {
+		if now.Sub(entry.at) > repeatWindow {
+			delete(f.recent, id)
+		}
+	}
+	for id, until := range f.shadowed {
+		if now.After(until) {
+			delete(f.shadowed, id)
+		}
+	}
+}
+
+// blockedMessage explains a blocked message to the visitor
+func blockedMessage(rule FilterRule, maxLength int) string {
+	switch rule {
+	case RuleLength:
+		return fmt.Sprintf("message too long (max %d characters)", maxLength)
+	case RuleRepeat:
+		return "you've sent that message several times - try asking something else"
+	case RuleProfanity:
+		return "let's keep it friendly - please rephrase your message"
+	default:
+		return "that message can't be sent - please rephrase it"
+	}
+}
@@ -0,0 +1,35 @@
+package ai
+
+import "context"
+
+// ReplyLanguages lists the language codes replies can be requested in
+var ReplyLanguages = []string{"en", "de", "es", "fr", "hi", "ja", "pt"}
+
+var languageNames = map[string]string{
+	"en": "English",
+	"de": "German",
+	"es": "Spanish",
+	"fr": "French",
+	"hi": "Hindi",
+	"ja": "Japanese",
+	"pt": "Portuguese",
+}
+
+type replyLanguageKey struct{}
+
+// WithReplyLanguage asks ChatStream to answer in the language with the
+// given code. English, the prompt's own language, needs no instruction.
+func WithReplyLanguage(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, replyLanguageKey{}, code)
+}
+
+// replyLanguageInstruction is the system message requesting the context's
+// reply language, or "" for English
+func replyLanguageInstruction(ctx context.Context) string {
+	code, _ := ctx.Value(replyLanguageKey{}).(string)
+	name, ok := languageNames[code]
+	if !ok || code == "en" {
+		return ""
+	}
+	return "Reply in " + name + ", whatever language the visitor writes in. Keep names, project titles and code in their original form."
+}
@@ -3,15 +3,22 @@ package ai
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/availability"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
 )
 
 // PromptBuilder builds system prompts using embedded portfolio content.
 type PromptBuilder struct {
+	mu       sync.RWMutex // guards the content, which SetContent swaps
 	resume   *content.Resume
 	projects *content.Projects
 	bio      string
+	version  string // of the content, "" if unknown
+	clock    *availability.Clock
+	now      func() time.Time
 }
 
 // NewPromptBuilder creates a prompt builder from loaded content.
@@ -20,17 +27,49 @@ func NewPromptBuilder(resume *content.Resume, projects *content.Projects, bio st
 		resume:   resume,
 		projects: projects,
 		bio:      bio,
+		now:      time.Now,
 	}
 }
 
+// WithClock adds Mohak's local time to every prompt, so scheduling
+// questions are answered in his timezone. A nil clock leaves it out.
+func (b *PromptBuilder) WithClock(clock *availability.Clock) *PromptBuilder {
+	b.clock = clock
+	return b
+}
+
+// WithVersion names the content's version, the content.Digest's, which
+// ChatStream tags requests with and compares with the session's
+func (b *PromptBuilder) WithVersion(version string) *PromptBuilder {
+	b.version = version
+	return b
+}
+
+// SetContent swaps in reloaded content, and its version, for the prompts
+// built after it
+func (b *PromptBuilder) SetContent(resume *content.Resume, projects *content.Projects, bio, version string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resume, b.projects, b.bio, b.version = resume, projects, bio, version
+}
+
 // BuildSystemPrompt returns a context-aware system prompt.
 func (b *PromptBuilder) BuildSystemPrompt(userMessage string) string {
+	prompt, _ := b.build(userMessage)
+	return prompt
+}
+
+// build is BuildSystemPrompt, and the version of the content it was
+// built from
+func (b *PromptBuilder) build(userMessage string) (prompt, version string) {
 	intent := IntentGeneral
 	if userMessage != "" {
 		intent = DetectQueryIntent(userMessage)
 	}
 
-	context := b.buildContextForIntent(intent)
+	b.mu.RLock()
+	context, version := b.buildContextForIntent(intent), b.version
+	b.mu.RUnlock()
 	return fmt.Sprintf(`You are NEURAL, Mohak's AI assistant embedded in an SSH-accessible TUI portfolio (ssh bmohak.xyz).
 
 ## PERSONA
@@ -70,7 +109,7 @@ You are helpful, concise, and technically knowledgeable. You have a subtle cyber
 
 ---
 
-Remember: You represent Mohak's professional portfolio. Be helpful, accurate, and keep responses optimized for terminal display.`, context)
+Remember: You represent Mohak's professional portfolio. Be helpful, accurate, and keep responses optimized for terminal display.`, context), version
 }
 
 // GenerateFollowUps returns intent-aware suggestion prompts.
@@ -95,6 +134,9 @@ func (b *PromptBuilder) buildContextForIntent(intent QueryIntent) string {
 	sections := []string{
 		fmt.Sprintf("# MOHAK BAJAJ\n%s\n\"%s\"\n\n%s", b.resume.Title, b.resume.Tagline, b.resume.Summary),
 	}
+	if b.clock != nil {
+		sections = append(sections, buildLocalTimeSection(b.clock.At(b.now())))
+	}
 
 	switch intent {
 	case IntentGreeting, IntentAbout:
@@ -155,22 +197,31 @@ func buildExperienceSection(resume *content.Resume) string {
 }
 
 func buildSkillsSection(resume *content.Resume) string {
-	return fmt.Sprintf(`# TECHNICAL SKILLS
-• **Languages:** %s
-• **Frontend:** %s
-• **Backend:** %s
-• **Databases:** %s
-• **DevOps:** %s
-• **Tools:** %s
-• **Mobile:** %s`,
-		strings.Join(resume.Skills.Languages, ", "),
-		strings.Join(resume.Skills.Frontend, ", "),
-		strings.Join(resume.Skills.Backend, ", "),
-		strings.Join(resume.Skills.Databases, ", "),
-		strings.Join(resume.Skills.DevOps, ", "),
-		strings.Join(resume.Skills.Tools, ", "),
-		strings.Join(resume.Skills.Mobile, ", "),
-	)
+	lines := []string{"# TECHNICAL SKILLS"}
+	for _, g := range resume.Skills.Groups() {
+		skills := make([]string, len(g.Skills))
+		for i, skill := range g.Skills {
+			skills[i] = describeSkill(skill)
+		}
+		lines = append(lines, fmt.Sprintf("• **%s:** %s", g.Name, strings.Join(skills, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeSkill is a skill's name with its level and years, when given,
+// as in "Go (level 4/5, 5 years)"
+func describeSkill(skill content.Skill) string {
+	var about []string
+	if skill.Level > 0 {
+		about = append(about, fmt.Sprintf("level %d/%d", skill.Level, content.MaxSkillLevel))
+	}
+	if skill.Years > 0 {
+		about = append(about, fmt.Sprintf("%d years", skill.Years))
+	}
+	if len(about) == 0 {
+		return skill.Name
+	}
+	return skill.Name + " (" + strings.Join(about, ", ") + ")"
 }
 
 func buildProjectsSection(projects *content.Projects) string {
@@ -210,7 +261,17 @@ func buildEducationSection(resume *content.Resume) string {
 }
 
 func buildAchievementsSection(resume *content.Resume) string {
-	return "# ACHIEVEMENTS\n" + bulletLines(resume.Achievements)
+	items := make([]string, len(resume.Achievements))
+	for i, a := range resume.Achievements {
+		items[i] = a.Text
+		if a.Date != "" {
+			items[i] += " (" + a.Date + ")"
+		}
+		if a.URL != "" {
+			items[i] += " - " + a.URL
+		}
+	}
+	return "# ACHIEVEMENTS\n" + bulletLines(items)
 }
 
 func buildContactSection(resume *content.Resume) string {
@@ -228,6 +289,13 @@ func buildContactSection(resume *content.Resume) string {
 	)
 }
 
+func buildLocalTimeSection(s availability.Status) string {
+	return fmt.Sprintf(`# LOCAL TIME
+It is %s on %s for Mohak (%s, %s): %s.
+He usually works 09:00-18:00 his time, Monday to Friday. Use this for questions about his availability or about scheduling a call, and convert times when the visitor gives their timezone; never guess theirs.`,
+		s.Clock(), s.Local.Format("Monday, 2 January"), s.Local.Location(), s.Zone(), s.Note())
+}
+
 func bulletLines(items []string) string {
 	lines := make([]string, 0, len(items))
 	for _, item := range items {
@@ -0,0 +1,159 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+const (
+	openAIBaseURL     = "https://api.openai.com/v1"
+	openRouterBaseURL = "https://openrouter.ai/api/v1"
+)
+
+// defaultProviderModels are used for direct providers listed without a
+// model, since the gateway's model names don't carry over
+var defaultProviderModels = map[string]string{
+	"openai":    "gpt-4o-mini",
+	"anthropic": "claude-3-5-haiku-latest",
+}
+
+// ProviderSpec names a backend and the model to ask it for. An empty Model
+// uses the service's default model.
+type ProviderSpec struct {
+	Name  string
+	Model string
+}
+
+// ProviderKeys holds credentials for the direct providers.
+type ProviderKeys struct {
+	OpenAI     string
+	Anthropic  string
+	OpenRouter string
+}
+
+// ParseProviderSpecs reads a fallback order like
+// "gateway,anthropic:claude-3-5-haiku-latest,openai:gpt-4o-mini".
+func ParseProviderSpecs(spec string) ([]ProviderSpec, error) {
+	var specs []ProviderSpec
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, model, _ := strings.Cut(part, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "gateway", "openai", "anthropic", "openrouter":
+		default:
+			return nil, fmt.Errorf("unknown AI provider %q", name)
+		}
+		model = strings.TrimSpace(model)
+		if model == "" {
+			model = defaultProviderModels[name]
+		}
+		specs = append(specs, ProviderSpec{Name: name, Model: model})
+	}
+	if len(specs) == 0 {
+		specs = []ProviderSpec{{Name: "gateway"}}
+	}
+	return specs, nil
+}
+
+// NewProviderChain builds the providers in specs, in order. More than one
+// spec yields a FallbackProvider.
+func NewProviderChain(specs []ProviderSpec, gateway GatewayConfig, keys ProviderKeys, logger *telemetry.Logger) (Provider, error) {
+	chain := make([]fallbackEntry, 0, len(specs))
+	for _, spec := range specs {
+		var provider Provider
+		switch spec.Name {
+		case "gateway":
+			var err error
+			if strings.HasPrefix(gateway.BaseURL, "ws://") || strings.HasPrefix(gateway.BaseURL, "wss://") {
+				provider, err = NewWebSocketProvider(gateway)
+			} else {
+				provider, err = NewGatewayProvider(gateway)
+			}
+			if err != nil {
+				return nil, err
+			}
+		case "openai":
+			provider = newOpenAICompatibleProvider(openAIBaseURL, keys.OpenAI, "OPENAI_API_KEY")
+		case "openrouter":
+			provider = newOpenAICompatibleProvider(openRouterBaseURL, keys.OpenRouter, "OPENROUTER_API_KEY")
+		case "anthropic":
+			provider = NewAnthropicProvider(keys.Anthropic)
+		default:
+			return nil, fmt.Errorf("unknown AI provider %q", spec.Name)
+		}
+		chain = append(chain, fallbackEntry{spec: spec, provider: provider})
+	}
+
+	if len(chain) == 1 && chain[0].spec.Model == "" {
+		return chain[0].provider, nil
+	}
+	return &FallbackProvider{chain: chain, logger: logger}, nil
+}
+
+// newOpenAICompatibleProvider talks to an OpenAI-style chat completions API
+func newOpenAICompatibleProvider(baseURL, apiKey, keyName string) *VercelGatewayProvider {
+	provider := NewVercelGatewayProvider(apiKey)
+	provider.baseURL = baseURL
+	provider.keyName = keyName
+	return provider
+}
+
+type fallbackEntry struct {
+	spec     ProviderSpec
+	provider Provider
+}
+
+// FallbackProvider tries providers in order, moving on when one fails
+// before it has streamed anything.
+type FallbackProvider struct {
+	chain  []fallbackEntry
+	logger *telemetry.Logger
+}
+
+// StreamChat streams from the first provider that answers.
+func (p *FallbackProvider) StreamChat(ctx context.Context, request CompletionRequest, callback StreamCallback) error {
+	var errs []error
+	for _, entry := range p.chain {
+		attempt := request
+		if entry.spec.Model != "" {
+			attempt.Model = entry.spec.Model
+		}
+
+		streamed := false
+		err := entry.provider.StreamChat(ctx, attempt, func(chunk string) error {
+			streamed = true
+			if callback == nil {
+				return nil
+			}
+			return callback(chunk)
+		})
+		// Once text has reached the visitor a retry elsewhere would repeat it
+		if err == nil || streamed || ctx.Err() != nil || errors.Is(err, ErrStopStream) {
+			return err
+		}
+		// Another model would likely be stopped by its own filter too
+		var streamErr *StreamError
+		if errors.As(err, &streamErr) && streamErr.Kind == ErrorContentFiltered {
+			return err
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", entry.spec.Name, err))
+		if p.logger != nil {
+			p.logger.Warn("AI provider failed, trying next", telemetry.Ctx(
+				"session_hash", request.SessionID,
+				"provider", entry.spec.Name,
+				"model", attempt.Model,
+				"error", err.Error(),
+			))
+		}
+	}
+	return errors.Join(errs...)
+}
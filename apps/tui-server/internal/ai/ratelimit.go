@@ -0,0 +1,29 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter counts requests in fixed windows shared between servers, such
+// as a store backend. Without one, each server keeps its own counts.
+type Limiter interface {
+	Hit(key string, window time.Duration) (int, error)
+}
+
+type rateLimitKey struct{}
+
+// WithRateLimitKey counts ChatStream requests under key, such as the
+// visitor's key hash, instead of the session, so reconnecting or landing
+// on another replica doesn't start a fresh allowance
+func WithRateLimitKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, rateLimitKey{}, key)
+}
+
+// rateLimitKeyFrom is the context's rate-limit key, or sessionID
+func rateLimitKeyFrom(ctx context.Context, sessionID string) string {
+	if key, _ := ctx.Value(rateLimitKey{}).(string); key != "" {
+		return key
+	}
+	return sessionID
+}
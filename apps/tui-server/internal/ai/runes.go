@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// runeSafe wraps callback so it only ever sees complete UTF-8 text. A
+// multi-byte character split across provider chunks is held back until
+// its remaining bytes arrive; flush passes on whatever is left at the end
+// of the stream, with invalid bytes replaced.
+func runeSafe(callback StreamCallback) (wrapped StreamCallback, flush func() error) {
+	if callback == nil {
+		return func(string) error { return nil }, func() error { return nil }
+	}
+	var pending []byte
+
+	wrapped = func(chunk string) error {
+		data := append(pending, chunk...)
+		cut := len(data)
+		// A rune is at most 4 bytes, so only the last 3 can be incomplete
+		for i := len(data) - 1; i >= 0 && i >= len(data)-3; i-- {
+			if utf8.RuneStart(data[i]) {
+				if !utf8.FullRune(data[i:]) {
+					cut = i
+				}
+				break
+			}
+		}
+		pending = append([]byte(nil), data[cut:]...)
+		if cut == 0 {
+			return nil
+		}
+		return callback(strings.ToValidUTF8(string(data[:cut]), "�"))
+	}
+
+	flush = func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		rest := strings.ToValidUTF8(string(pending), "�")
+		pending = nil
+		return callback(rest)
+	}
+	return wrapped, flush
+}
@@ -3,7 +3,6 @@ package ai
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 	"time"
 
@@ -12,12 +11,15 @@ import (
 
 const maxMessageLength = 2000
 
+var errRateLimited = &StreamError{Kind: ErrorRateLimited, Message: "rate limit exceeded - please wait before sending more messages"}
+
 // Analytics captures AI-specific telemetry without coupling to a concrete implementation.
 type Analytics interface {
 	TrackAIRequest(sessionID string, messageLength int, historyLength int, model string)
 	TrackAIResponse(sessionID string, durationMs int64, model string, success bool)
 	TrackAIError(sessionID string, errorMsg string, errorType string)
 	TrackAIRateLimit(sessionID string, remaining int)
+	TrackAIFiltered(sessionID string, rule string, action string)
 }
 
 // Config configures the in-process AI chat service.
@@ -35,6 +37,9 @@ type Config struct {
 	MaxHistoryLength int
 	RateLimitMax     int
 	RateLimitWindow  time.Duration
+	Limiter          Limiter // optional, shares rate limits between servers
+	Filter           FilterConfig
+	Conversations    bool // the gateway keeps each conversation's history
 }
 
 // Service orchestrates validation, prompting, rate limiting, and provider calls.
@@ -43,6 +48,7 @@ type Service struct {
 	logger    *telemetry.Logger
 	analytics Analytics
 	prompts   *PromptBuilder
+	filter    *Filter
 
 	model            string
 	maxTokens        int
@@ -53,9 +59,12 @@ type Service struct {
 	maxHistoryLength int
 	rateLimitMax     int
 	rateLimitWindow  time.Duration
+	limiter          Limiter
+	conversations    bool
 
 	mu        sync.Mutex
 	rateLimit map[string]rateLimitEntry
+	summaries map[string]historySummary
 }
 
 type rateLimitEntry struct {
@@ -70,6 +79,7 @@ func NewService(cfg Config) *Service {
 		logger:           cfg.Logger,
 		analytics:        cfg.Analytics,
 		prompts:          cfg.PromptBuilder,
+		filter:           NewFilter(cfg.Filter),
 		model:            cfg.Model,
 		maxTokens:        cfg.MaxTokens,
 		temperature:      cfg.Temperature,
@@ -79,7 +89,10 @@ func NewService(cfg Config) *Service {
 		maxHistoryLength: cfg.MaxHistoryLength,
 		rateLimitMax:     cfg.RateLimitMax,
 		rateLimitWindow:  cfg.RateLimitWindow,
+		limiter:          cfg.Limiter,
+		conversations:    cfg.Conversations,
 		rateLimit:        make(map[string]rateLimitEntry),
+		summaries:        make(map[string]historySummary),
 	}
 }
 
@@ -96,13 +109,31 @@ func (s *Service) ChatStream(
 	if message == "" {
 		return errors.New("message is required")
 	}
-	if len(message) > maxMessageLength {
-		return fmt.Errorf("message too long (max %d characters)", maxMessageLength)
+
+	filtered := s.filter.Check(sessionID, message)
+	if filtered.Rule != "" {
+		s.logger.Warn("AI message filtered", telemetry.Ctx(
+			"session_hash", sessionID,
+			"rule", string(filtered.Rule),
+			"action", string(filtered.Action),
+		))
+		if s.analytics != nil {
+			s.analytics.TrackAIFiltered(sessionID, string(filtered.Rule), string(filtered.Action))
+		}
 	}
+	switch filtered.Action {
+	case ActionBlock:
+		return errors.New(blockedMessage(filtered.Rule, s.filter.maxLength))
+	case ActionShadow:
+		// Indistinguishable from the real rate limit on purpose
+		return errRateLimited
+	}
+	message = filtered.Message
+	history = s.filter.History(history)
 
 	processedMessage := PreprocessMessage(message)
 	intent := DetectQueryIntent(processedMessage)
-	trimmedHistory := trimHistory(history, s.maxHistoryLength)
+	trimmedHistory := history[foldPoint(len(history), s.maxHistoryLength):]
 
 	if s.analytics != nil {
 		s.analytics.TrackAIRequest(sessionID, len(processedMessage), len(trimmedHistory), s.model)
@@ -116,21 +147,43 @@ func (s *Service) ChatStream(
 		"model", s.model,
 	))
 
-	remaining, allowed := s.checkRateLimit(sessionID)
+	remaining, allowed := s.checkRateLimit(rateLimitKeyFrom(ctx, sessionID))
 	if !allowed {
 		s.logger.Warn("AI rate limit exceeded", telemetry.Ctx("session_hash", sessionID))
 		if s.analytics != nil {
 			s.analytics.TrackAIRateLimit(sessionID, 0)
 			s.analytics.TrackAIError(sessionID, "rate limit exceeded", "rate_limit")
 		}
-		return errors.New("rate limit exceeded - please wait before sending more messages")
+		return errRateLimited
+	}
+
+	// Older turns are folded into a summary rather than dropped, unless
+	// the gateway keeps the conversation and so has them already
+	conversation, fresh := conversationFrom(ctx)
+	summary := ""
+	if !s.conversations || conversation == "" || fresh {
+		summary, trimmedHistory = s.condenseHistory(ctx, sessionID, history)
 	}
 
-	messages := make([]CompletionMessage, 0, len(trimmedHistory)+2)
+	// The prompt is built from the latest content, reloaded or not
+	systemPrompt, contentVersion := s.prompts.build(processedMessage)
+	messages := make([]CompletionMessage, 0, len(trimmedHistory)+4)
 	messages = append(messages, CompletionMessage{
 		Role:    "system",
-		Content: s.prompts.BuildSystemPrompt(processedMessage),
+		Content: systemPrompt,
 	})
+	if note := contentUpdateNote(ctx, contentVersion); note != "" {
+		messages = append(messages, CompletionMessage{Role: "system", Content: note})
+	}
+	if summary != "" {
+		messages = append(messages, CompletionMessage{
+			Role:    "system",
+			Content: "Summary of the earlier conversation: " + summary,
+		})
+	}
+	if instruction := replyLanguageInstruction(ctx); instruction != "" {
+		messages = append(messages, CompletionMessage{Role: "system", Content: instruction})
+	}
 	for _, historyMessage := range trimmedHistory {
 		messages = append(messages, CompletionMessage{
 			Role:    historyMessage.Role,
@@ -142,8 +195,13 @@ func (s *Service) ChatStream(
 		Content: processedMessage,
 	})
 
+	// Providers may split a multi-byte character across chunks
+	emit, flush := runeSafe(callback)
 	err := s.provider.StreamChat(ctx, CompletionRequest{
 		SessionID:        sessionID,
+		ContentVersion:   contentVersion,
+		Conversation:     conversation,
+		NewConversation:  fresh,
 		Model:            s.model,
 		Messages:         messages,
 		MaxTokens:        s.maxTokens,
@@ -151,11 +209,20 @@ func (s *Service) ChatStream(
 		TopP:             s.topP,
 		FrequencyPenalty: s.frequencyPenalty,
 		PresencePenalty:  s.presencePenalty,
-	}, callback)
+	}, emit)
+	stopped := errors.Is(err, ErrStopStream)
+	if stopped {
+		err = nil
+	} else if err == nil {
+		err = flush()
+	}
 	if err != nil {
 		errorType := "provider_error"
+		var streamErr *StreamError
 		if errors.Is(err, context.Canceled) {
 			errorType = "cancelled"
+		} else if errors.As(err, &streamErr) {
+			errorType = string(streamErr.Kind)
 		}
 		s.logger.Error("AI response failed", telemetry.Ctx(
 			"session_hash", sessionID,
@@ -180,12 +247,24 @@ func (s *Service) ChatStream(
 		"rate_limit_remaining", remaining,
 		"intent", string(intent),
 		"model", s.model,
+		"content_version", contentVersion,
+		"stopped_early", stopped,
 	))
 
 	return nil
 }
 
-func (s *Service) checkRateLimit(sessionID string) (remaining int, allowed bool) {
+func (s *Service) checkRateLimit(key string) (remaining int, allowed bool) {
+	if s.limiter != nil {
+		count, err := s.limiter.Hit("ai:"+key, s.rateLimitWindow)
+		if err != nil {
+			// A store outage shouldn't take the chat down with it
+			s.logger.Warn("AI rate limit check failed", telemetry.Ctx("error", err.Error()))
+			return s.rateLimitMax, true
+		}
+		return max(s.rateLimitMax-count, 0), count <= s.rateLimitMax
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -196,9 +275,9 @@ func (s *Service) checkRateLimit(sessionID string) (remaining int, allowed bool)
 		}
 	}
 
-	entry, ok := s.rateLimit[sessionID]
+	entry, ok := s.rateLimit[key]
 	if !ok || now.After(entry.resetAt) {
-		s.rateLimit[sessionID] = rateLimitEntry{
+		s.rateLimit[key] = rateLimitEntry{
 			count:   1,
 			resetAt: now.Add(s.rateLimitWindow),
 		}
@@ -210,14 +289,6 @@ func (s *Service) checkRateLimit(sessionID string) (remaining int, allowed bool)
 	}
 
 	entry.count++
-	s.rateLimit[sessionID] = entry
+	s.rateLimit[key] = entry
 	return s.rateLimitMax - entry.count, true
 }
-
-func trimHistory(history []Message, maxHistoryLength int) []Message {
-	if maxHistoryLength <= 0 || len(history) <= maxHistoryLength {
-		return history
-	}
-
-	return history[len(history)-maxHistoryLength:]
-}
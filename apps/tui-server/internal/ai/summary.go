@@ -0,0 +1,167 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+const (
+	// summaryStep is how many messages are folded into the summary at a
+	// time, so it is refreshed every few turns rather than on every one
+	summaryStep = 6
+	// summaryMaxTokens bounds the summarization request
+	summaryMaxTokens = 200
+	// localSummaryLength caps the fallback summary in characters
+	localSummaryLength = 600
+	// summaryTTL drops cached summaries of sessions that went quiet
+	summaryTTL = time.Hour
+)
+
+const summaryPrompt = `You condense chat transcripts. Summarize the conversation below between a visitor and an AI assistant on Mohak's portfolio in at most 5 short sentences. Keep the visitor's questions, facts they shared about themselves, and what was already answered. Reply with the summary only.`
+
+// historySummary is a running summary of the first covered messages of a
+// session's history; key fingerprints those messages
+type historySummary struct {
+	covered int
+	key     string
+	text    string
+	usedAt  time.Time
+}
+
+// foldPoint returns how many leading history messages should be replaced
+// by the summary: all but the last maxHistoryLength, in summaryStep blocks
+func foldPoint(historyLength, maxHistoryLength int) int {
+	if maxHistoryLength <= 0 || historyLength <= maxHistoryLength {
+		return 0
+	}
+	return (historyLength - maxHistoryLength + summaryStep - 1) / summaryStep * summaryStep
+}
+
+// historyKey fingerprints a run of messages
+func historyKey(messages []Message) string {
+	h := sha256.New()
+	for _, m := range messages {
+		fmt.Fprintf(h, "%s\x00%s\x00", m.Role, m.Content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// condenseHistory replaces older messages with a running summary once the
+// history outgrows maxHistoryLength. It returns the summary (empty when
+// nothing was folded) and the messages to send verbatim.
+func (s *Service) condenseHistory(ctx context.Context, sessionID string, history []Message) (string, []Message) {
+	n := min(foldPoint(len(history), s.maxHistoryLength), len(history))
+	if n == 0 {
+		return "", history
+	}
+	folded := history[:n]
+	key := historyKey(folded)
+
+	s.mu.Lock()
+	cached, ok := s.summaries[sessionID]
+	s.mu.Unlock()
+
+	if ok && cached.covered == n && cached.key == key {
+		s.storeSummary(sessionID, cached)
+		return cached.text, history[n:]
+	}
+
+	// Extend the previous summary when it still describes our prefix
+	previous := ""
+	from := 0
+	if ok && cached.covered < n && cached.key == historyKey(history[:cached.covered]) {
+		previous = cached.text
+		from = cached.covered
+	}
+
+	text, err := s.summarize(ctx, previous, history[from:n])
+	if err != nil {
+		s.logger.Warn("History summary failed, using local summary", telemetry.Ctx(
+			"session_hash", sessionID,
+			"error", err.Error(),
+		))
+		text = localSummary(folded)
+	}
+
+	s.storeSummary(sessionID, historySummary{covered: n, key: key, text: text})
+	return text, history[n:]
+}
+
+// storeSummary caches a session's summary and prunes stale ones
+func (s *Service) storeSummary(sessionID string, summary historySummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, entry := range s.summaries {
+		if now.Sub(entry.usedAt) > summaryTTL {
+			delete(s.summaries, id)
+		}
+	}
+	summary.usedAt = now
+	s.summaries[sessionID] = summary
+}
+
+// summarize asks the provider to fold messages into the previous summary
+func (s *Service) summarize(ctx context.Context, previous string, messages []Message) (string, error) {
+	var transcript strings.Builder
+	if previous != "" {
+		transcript.WriteString("Summary so far: " + previous + "\n\n")
+	}
+	for _, m := range messages {
+		role := "Visitor"
+		if m.Role == "assistant" {
+			role = "Assistant"
+		}
+		transcript.WriteString(role + ": " + m.Content + "\n")
+	}
+
+	var out strings.Builder
+	err := s.provider.StreamChat(ctx, CompletionRequest{
+		Model: s.model,
+		Messages: []CompletionMessage{
+			{Role: "system", Content: summaryPrompt},
+			{Role: "user", Content: transcript.String()},
+		},
+		MaxTokens:   summaryMaxTokens,
+		Temperature: 0.2,
+		TopP:        1,
+	}, func(chunk string) error {
+		out.WriteString(chunk)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.TrimSpace(out.String())
+	if text == "" {
+		return "", fmt.Errorf("empty summary")
+	}
+	return text, nil
+}
+
+// localSummary lists the visitor's earlier questions without a model call
+func localSummary(messages []Message) string {
+	var questions []string
+	for _, m := range messages {
+		if m.Role == "user" {
+			questions = append(questions, strings.Join(strings.Fields(m.Content), " "))
+		}
+	}
+	if len(questions) == 0 {
+		return ""
+	}
+
+	text := "Earlier the visitor asked: " + strings.Join(questions, "; ") + "."
+	if runes := []rune(text); len(runes) > localSummaryLength {
+		text = string(runes[:localSummaryLength-3]) + "..."
+	}
+	return text
+}
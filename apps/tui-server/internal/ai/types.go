@@ -1,6 +1,9 @@
 package ai
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // Message represents a chat message exchanged with the model.
 type Message struct {
@@ -11,6 +14,10 @@ type Message struct {
 // StreamCallback is called for each streamed content chunk.
 type StreamCallback func(chunk string) error
 
+// ErrStopStream may be returned by a StreamCallback to end the reply early
+// without it counting as a failure.
+var ErrStopStream = errors.New("stream stopped")
+
 // ChatService is the interface consumed by the Bubble Tea model.
 type ChatService interface {
 	ChatStream(ctx context.Context, sessionID, message string, history []Message, callback StreamCallback) error
@@ -30,6 +37,9 @@ type CompletionMessage struct {
 // CompletionRequest contains the provider-agnostic generation request.
 type CompletionRequest struct {
 	SessionID        string
+	ContentVersion   string // of the content the prompt was built from
+	Conversation     string // ID a gateway keeps the history under
+	NewConversation  bool   // Conversation starts here, so Messages all go
 	Model            string
 	Messages         []CompletionMessage
 	MaxTokens        int
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,17 +18,56 @@ import (
 
 const vercelGatewayBaseURL = "https://ai-gateway.vercel.sh/v1"
 
-// VercelGatewayProvider streams chat completions from the Vercel AI Gateway.
+const (
+	// contentVersionHeader carries a request's content version to the
+	// gateway
+	contentVersionHeader = "X-Content-Version"
+	// conversationHeader names the conversation a request continues, and
+	// conversationOmittedHeader counts the earlier messages left out for
+	// the gateway to fill in. One it doesn't have is started from the
+	// request, unless messages were left out; then it answers 404. Each
+	// turn and its reply are added to the conversation.
+	conversationHeader        = "X-Conversation-ID"
+	conversationOmittedHeader = "X-Conversation-Omitted"
+)
+
+// VercelGatewayProvider streams chat completions from the Vercel AI Gateway
+// or any OpenAI-compatible endpoint.
 type VercelGatewayProvider struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
+	apiKey       string
+	apiKeyHeader string
+	keyName      string // names the key in "is required" errors
+	baseURL      string
+	clientCert   bool
+	// conversations sends a request with a conversation ID only its
+	// latest turn; the gateway keeps the rest
+	conversations bool
+	httpClient    *http.Client
+}
+
+// GatewayConfig configures how the provider reaches and authenticates
+// with the gateway.
+type GatewayConfig struct {
+	APIKey string
+	// APIKeyHeader carries the key as-is; empty sends it as a bearer token
+	// in Authorization
+	APIKeyHeader string
+	// BaseURL defaults to the Vercel AI Gateway
+	BaseURL string
+	// TLS adds a custom CA and an optional client certificate for mTLS
+	TLS network.TLSFiles
+	// OnEvent receives notifications pushed by a ws:// or wss:// gateway
+	OnEvent func(sessionID string, event GatewayEvent)
+	// Conversations is set for a gateway that keeps each conversation's
+	// history under its ID, so requests carry only the new message
+	Conversations bool
 }
 
 // NewVercelGatewayProvider creates a Vercel AI Gateway provider.
 func NewVercelGatewayProvider(apiKey string) *VercelGatewayProvider {
 	return &VercelGatewayProvider{
 		apiKey:  apiKey,
+		keyName: "AI_GATEWAY_API_KEY",
 		baseURL: vercelGatewayBaseURL,
 		httpClient: &http.Client{
 			Timeout:   120 * time.Second,
@@ -36,19 +76,69 @@ func NewVercelGatewayProvider(apiKey string) *VercelGatewayProvider {
 	}
 }
 
+// NewGatewayProvider creates a provider for a self-hosted or private
+// gateway, failing if the TLS files can't be loaded.
+func NewGatewayProvider(cfg GatewayConfig) (*VercelGatewayProvider, error) {
+	transport, err := network.NewHTTPTransportWithTLS(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = vercelGatewayBaseURL
+	}
+
+	return &VercelGatewayProvider{
+		apiKey:        cfg.APIKey,
+		apiKeyHeader:  cfg.APIKeyHeader,
+		keyName:       "AI_GATEWAY_API_KEY",
+		baseURL:       baseURL,
+		clientCert:    cfg.TLS.CertFile != "",
+		conversations: cfg.Conversations,
+		httpClient: &http.Client{
+			Timeout:   120 * time.Second,
+			Transport: transport,
+		},
+	}, nil
+}
+
 // StreamChat sends a streaming chat completion request and emits content deltas.
 func (p *VercelGatewayProvider) StreamChat(
 	ctx context.Context,
 	request CompletionRequest,
 	callback StreamCallback,
 ) error {
-	if strings.TrimSpace(p.apiKey) == "" {
-		return errors.New("AI_GATEWAY_API_KEY is required")
+	// A client certificate is enough to authenticate on its own
+	if strings.TrimSpace(p.apiKey) == "" && !p.clientCert {
+		return errors.New(p.keyName + " is required")
 	}
 
+	if !p.conversations || request.Conversation == "" {
+		return p.stream(ctx, request, request.Messages, false, callback)
+	}
+	if request.NewConversation {
+		return p.stream(ctx, request, request.Messages, true, callback)
+	}
+	err := p.stream(ctx, request, request.latestTurn(), true, callback)
+	if errors.Is(err, ErrUnknownConversation) {
+		err = p.stream(ctx, request, request.Messages, true, callback)
+	}
+	return err
+}
+
+// stream posts messages for request, with the conversation ID if
+// conversation is set
+func (p *VercelGatewayProvider) stream(
+	ctx context.Context,
+	request CompletionRequest,
+	messages []CompletionMessage,
+	conversation bool,
+	callback StreamCallback,
+) error {
 	body, err := json.Marshal(openAIChatRequest{
 		Model:            request.Model,
-		Messages:         request.Messages,
+		Messages:         messages,
 		Stream:           true,
 		MaxTokens:        request.MaxTokens,
 		Temperature:      request.Temperature,
@@ -70,8 +160,24 @@ func (p *VercelGatewayProvider) StreamChat(
 		return fmt.Errorf("failed to create provider request: %w", err)
 	}
 
-	httpRequest.Header.Set("Authorization", "Bearer "+p.apiKey)
+	switch {
+	case p.apiKey == "":
+	case p.apiKeyHeader != "":
+		httpRequest.Header.Set(p.apiKeyHeader, p.apiKey)
+	default:
+		httpRequest.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
 	httpRequest.Header.Set("Content-Type", "application/json")
+	// Lets the gateway tell, say in its logs or cache keys, which
+	// version of the content an answer came from
+	if request.ContentVersion != "" {
+		httpRequest.Header.Set(contentVersionHeader, request.ContentVersion)
+	}
+	omitted := len(request.Messages) - len(messages)
+	if conversation {
+		httpRequest.Header.Set(conversationHeader, request.Conversation)
+		httpRequest.Header.Set(conversationOmittedHeader, strconv.Itoa(omitted))
+	}
 
 	response, err := p.httpClient.Do(httpRequest)
 	if err != nil {
@@ -79,8 +185,8 @@ func (p *VercelGatewayProvider) StreamChat(
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusTooManyRequests {
-		return errors.New("rate limit exceeded - please wait before sending more messages")
+	if conversation && omitted > 0 && response.StatusCode == http.StatusNotFound {
+		return ErrUnknownConversation
 	}
 	if response.StatusCode != http.StatusOK {
 		return readProviderError(response)
@@ -107,23 +213,40 @@ type openAIStreamChunk struct {
 		} `json:"delta"`
 		FinishReason *string `json:"finish_reason"`
 	} `json:"choices"`
+	// Error is sent by gateways that fail after the stream began
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+	} `json:"error"`
 }
 
 type providerErrorResponse struct {
 	Error struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
+		Code    string `json:"code"`
 	} `json:"error"`
 }
 
+// readProviderError is the error a failed response describes: a
+// StreamError if its type, code or status is one of a known kind
 func readProviderError(response *http.Response) error {
 	body, _ := io.ReadAll(response.Body)
+	message := strings.TrimSpace(string(body))
 	parsed := providerErrorResponse{}
 	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
-		return fmt.Errorf("AI provider error (status %d): %s", response.StatusCode, parsed.Error.Message)
+		message = parsed.Error.Message
 	}
-
-	return fmt.Errorf("AI provider error (status %d): %s", response.StatusCode, strings.TrimSpace(string(body)))
+	for _, name := range []string{parsed.Error.Code, parsed.Error.Type} {
+		if err := streamError(name, message, retryAfterHeader(response)); err != nil {
+			return err
+		}
+	}
+	if err := statusError(response); err != nil {
+		return err
+	}
+	return fmt.Errorf("AI provider error (status %d): %s", response.StatusCode, message)
 }
 
 func streamOpenAIChunks(ctx context.Context, body io.Reader, callback StreamCallback) error {
@@ -152,7 +275,19 @@ func streamOpenAIChunks(ctx context.Context, body io.Reader, callback StreamCall
 			return fmt.Errorf("failed to parse provider stream: %w", err)
 		}
 
+		if e := chunk.Error; e != nil {
+			for _, name := range []string{e.Code, e.Type} {
+				if err := streamError(name, e.Message, 0); err != nil {
+					return err
+				}
+			}
+			return fmt.Errorf("AI provider error: %s", e.Message)
+		}
+
 		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil && *choice.FinishReason == "content_filter" {
+				return &StreamError{Kind: ErrorContentFiltered, Message: "reply stopped by the content filter"}
+			}
 			if choice.Delta.Content == "" {
 				continue
 			}
@@ -0,0 +1,24 @@
+package ai
+
+import "context"
+
+type contentVersionKey struct{}
+
+// WithContentVersion tells ChatStream which version of the content the
+// visitor's screens show. Sessions keep the content they opened with, so
+// after a reload it can be older than what the prompt is built from.
+func WithContentVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, contentVersionKey{}, version)
+}
+
+// contentUpdateNote is the system message telling the model the content
+// changed since the visitor's session began, or "" if it hasn't or
+// either version is unknown. Its own earlier answers, and what the
+// visitor has on screen, may be out of date.
+func contentUpdateNote(ctx context.Context, current string) string {
+	seen, _ := ctx.Value(contentVersionKey{}).(string)
+	if seen == "" || current == "" || seen == current {
+		return ""
+	}
+	return "Mohak's portfolio was updated after this visitor connected. The context above is the latest: where it disagrees with your earlier answers or with what the visitor quotes from the screens, go by the context and say it was updated. If they ask, reconnecting shows them the new version, and /whatsnew then lists what changed."
+}
@@ -0,0 +1,342 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+)
+
+// wsIdleTimeout closes a session's connection once it goes unused this long
+const wsIdleTimeout = 5 * time.Minute
+
+// GatewayEvent is a notification the gateway pushes outside of a reply,
+// such as "content_updated".
+type GatewayEvent struct {
+	Name string
+	Data json.RawMessage
+}
+
+// wsFrame is one JSON message on the gateway socket. The client sends
+// "chat" and "cancel"; the gateway answers a chat's ID with "delta" frames
+// and a final "done" or "error", and may send "event" at any time.
+type wsFrame struct {
+	Type    string             `json:"type"`
+	ID      int                `json:"id,omitempty"`
+	Request *openAIChatRequest `json:"request,omitempty"`
+	Content string             `json:"content,omitempty"`
+	Message string             `json:"message,omitempty"`
+	Event   string             `json:"event,omitempty"`
+	Data    json.RawMessage    `json:"data,omitempty"`
+	// ContentVersion, Conversation and Omitted go with a chat's request,
+	// as their X- headers do over HTTP
+	ContentVersion string `json:"content_version,omitempty"`
+	Conversation   string `json:"conversation,omitempty"`
+	Omitted        int    `json:"omitted,omitempty"`
+	// Code is an error's kind: "rate_limited", "model_overloaded" or
+	// "content_filtered", which RetryAfter may go with in seconds, or
+	// "unknown_conversation" for a chat whose omitted messages the
+	// gateway doesn't have
+	Code       string `json:"code,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"`
+}
+
+// WebSocketProvider streams chat completions from a gateway over one
+// persistent WebSocket per session, saving a TCP and TLS handshake on
+// every message.
+type WebSocketProvider struct {
+	dial          func(ctx context.Context) (*websocket.Conn, error)
+	onEvent       func(sessionID string, event GatewayEvent)
+	conversations bool
+
+	mu       sync.Mutex
+	sessions map[string]*wsSession
+}
+
+// wsSession is one session's connection and its in-flight replies
+type wsSession struct {
+	conn   *websocket.Conn
+	idle   *time.Timer
+	closed chan struct{}
+	err    error
+
+	sendMu  sync.Mutex
+	mu      sync.Mutex
+	nextID  int
+	streams map[int]*wsStream
+}
+
+// wsStream routes one reply's frames; gone closes once it is released
+type wsStream struct {
+	frames chan wsFrame
+	gone   chan struct{}
+}
+
+// NewWebSocketProvider creates a provider for a ws:// or wss:// gateway
+// URL, failing if the TLS files can't be loaded.
+func NewWebSocketProvider(cfg GatewayConfig) (*WebSocketProvider, error) {
+	transport, err := network.NewHTTPTransportWithTLS(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &WebSocketProvider{
+		onEvent:       cfg.OnEvent,
+		conversations: cfg.Conversations,
+		sessions:      make(map[string]*wsSession),
+	}
+	p.dial = func(ctx context.Context) (*websocket.Conn, error) {
+		wsConfig, err := websocket.NewConfig(cfg.BaseURL, "http://localhost/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway URL: %w", err)
+		}
+		wsConfig.TlsConfig = transport.TLSClientConfig
+		wsConfig.Dialer = &net.Dialer{Timeout: 30 * time.Second}
+		switch {
+		case cfg.APIKey == "":
+		case cfg.APIKeyHeader != "":
+			wsConfig.Header.Set(cfg.APIKeyHeader, cfg.APIKey)
+		default:
+			wsConfig.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+		}
+		return wsConfig.DialContext(ctx)
+	}
+	return p, nil
+}
+
+// StreamChat sends the request over the session's socket, dialing it
+// first if needed, and emits content deltas.
+func (p *WebSocketProvider) StreamChat(
+	ctx context.Context,
+	request CompletionRequest,
+	callback StreamCallback,
+) error {
+	if !p.conversations || request.Conversation == "" {
+		return p.stream(ctx, request, request.Messages, false, callback)
+	}
+	if request.NewConversation {
+		return p.stream(ctx, request, request.Messages, true, callback)
+	}
+	err := p.stream(ctx, request, request.latestTurn(), true, callback)
+	if errors.Is(err, ErrUnknownConversation) {
+		err = p.stream(ctx, request, request.Messages, true, callback)
+	}
+	return err
+}
+
+// stream sends messages for request, with the conversation ID if
+// conversation is set
+func (p *WebSocketProvider) stream(
+	ctx context.Context,
+	request CompletionRequest,
+	messages []CompletionMessage,
+	conversation bool,
+	callback StreamCallback,
+) error {
+	chat := &openAIChatRequest{
+		Model:            request.Model,
+		Messages:         messages,
+		Stream:           true,
+		MaxTokens:        request.MaxTokens,
+		Temperature:      request.Temperature,
+		TopP:             request.TopP,
+		FrequencyPenalty: request.FrequencyPenalty,
+		PresencePenalty:  request.PresencePenalty,
+	}
+
+	// A kept-alive socket may have died quietly; redial once if so
+	var session *wsSession
+	var id int
+	var frames <-chan wsFrame
+	for attempt := 0; ; attempt++ {
+		var err error
+		session, err = p.session(ctx, request.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gateway: %w", err)
+		}
+		id, frames = session.open()
+		frame := wsFrame{Type: "chat", ID: id, Request: chat, ContentVersion: request.ContentVersion}
+		if conversation {
+			frame.Conversation, frame.Omitted = request.Conversation, len(request.Messages)-len(messages)
+		}
+		err = session.send(frame)
+		if err == nil {
+			break
+		}
+		session.release(id)
+		p.drop(request.SessionID, session, err)
+		if attempt > 0 {
+			return fmt.Errorf("failed to send provider request: %w", err)
+		}
+	}
+	defer session.release(id)
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = session.send(wsFrame{Type: "cancel", ID: id})
+			return ctx.Err()
+		case <-session.closed:
+			return fmt.Errorf("gateway connection lost: %w", session.err)
+		case frame := <-frames:
+			switch frame.Type {
+			case "delta":
+				if frame.Content == "" || callback == nil {
+					continue
+				}
+				if err := callback(frame.Content); err != nil {
+					_ = session.send(wsFrame{Type: "cancel", ID: id})
+					return err
+				}
+			case "done":
+				return nil
+			case "error":
+				if frame.Code == "unknown_conversation" && conversation {
+					return ErrUnknownConversation
+				}
+				if err := streamError(frame.Code, frame.Message, time.Duration(frame.RetryAfter)*time.Second); err != nil {
+					return err
+				}
+				return fmt.Errorf("AI provider error: %s", frame.Message)
+			}
+		}
+	}
+}
+
+// session returns the live connection for sessionID, dialing a new one
+func (p *WebSocketProvider) session(ctx context.Context, sessionID string) (*wsSession, error) {
+	if s := p.live(sessionID); s != nil {
+		return s, nil
+	}
+
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.sessions[sessionID]; ok && s.alive() {
+		// Another reply dialed first; share its connection
+		_ = conn.Close()
+		s.idle.Reset(wsIdleTimeout)
+		return s, nil
+	}
+	s := &wsSession{
+		conn:    conn,
+		closed:  make(chan struct{}),
+		streams: make(map[int]*wsStream),
+	}
+	s.idle = time.AfterFunc(wsIdleTimeout, func() {
+		p.drop(sessionID, s, errors.New("idle"))
+	})
+	p.sessions[sessionID] = s
+	go p.read(sessionID, s)
+	return s, nil
+}
+
+// live returns sessionID's open connection, if any, and marks it used
+func (p *WebSocketProvider) live(sessionID string) *wsSession {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.sessions[sessionID]; ok && s.alive() {
+		s.idle.Reset(wsIdleTimeout)
+		return s
+	}
+	return nil
+}
+
+// read routes incoming frames to their streams until the socket fails
+func (p *WebSocketProvider) read(sessionID string, s *wsSession) {
+	for {
+		var frame wsFrame
+		if err := websocket.JSON.Receive(s.conn, &frame); err != nil {
+			p.drop(sessionID, s, err)
+			return
+		}
+
+		if frame.Type == "event" {
+			if p.onEvent != nil {
+				p.onEvent(sessionID, GatewayEvent{Name: frame.Event, Data: frame.Data})
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		stream, ok := s.streams[frame.ID]
+		s.mu.Unlock()
+		if !ok {
+			// The reply was cancelled; drop what was already in flight
+			continue
+		}
+		select {
+		case stream.frames <- frame:
+		case <-stream.gone:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// drop closes a session's connection and forgets it
+func (p *WebSocketProvider) drop(sessionID string, s *wsSession, err error) {
+	p.mu.Lock()
+	if p.sessions[sessionID] == s {
+		delete(p.sessions, sessionID)
+	}
+	p.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.alive() {
+		return
+	}
+	s.err = err
+	s.idle.Stop()
+	close(s.closed)
+	_ = s.conn.Close()
+}
+
+// alive reports whether the connection is still open
+func (s *wsSession) alive() bool {
+	select {
+	case <-s.closed:
+		return false
+	default:
+		return true
+	}
+}
+
+// open registers a new reply on the connection
+func (s *wsSession) open() (int, <-chan wsFrame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	stream := &wsStream{frames: make(chan wsFrame, 64), gone: make(chan struct{})}
+	s.streams[s.nextID] = stream
+	return s.nextID, stream.frames
+}
+
+// release stops routing frames to a finished reply
+func (s *wsSession) release(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream, ok := s.streams[id]; ok {
+		close(stream.gone)
+		delete(s.streams, id)
+	}
+}
+
+// send writes one frame; writes from concurrent replies are serialized
+func (s *wsSession) send(frame wsFrame) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return websocket.JSON.Send(s.conn, frame)
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// viewTitle is the spoken name of each view in accessibility mode
+func viewTitle(v View) string {
+	switch v {
+	case ViewAbout:
+		return "About"
+	case ViewProjects:
+		return "Projects"
+	case ViewProjectDetail:
+		return "Project details"
+	case ViewResume:
+		return "Resume"
+	case ViewExperience:
+		return "Experience"
+	case ViewSnake:
+		return "Snake"
+	case ViewQuiz:
+		return "Quiz"
+	case ViewStats:
+		return "Server statistics"
+	case ViewCard:
+		return "Contact card"
+	case ViewActivity:
+		return "GitHub activity"
+	case ViewUses:
+		return "Uses"
+	case ViewTalks:
+		return "Talks and publications"
+	case ViewCerts:
+		return "Certifications"
+	case ViewWhatsNew:
+		return "What's new"
+	case ViewFind:
+		return "Search results"
+	case ViewBookmarks:
+		return "Bookmarks"
+	case ViewFeedback:
+		return "Feedback"
+	case ViewAchievements:
+		return "Achievements"
+	default:
+		return "Chat"
+	}
+}
+
+// toggleAccessible switches accessibility mode and re-renders
+func (m Model) toggleAccessible(on bool) Model {
+	m.themeManager.SetAccessible(on)
+	if on {
+		m.statusMessage = "Accessibility mode on"
+	} else {
+		m.statusMessage = "Accessibility mode off"
+	}
+	m.updateViewport()
+	return m
+}
+
+// renderAccessible draws the screen as plain, linear text: no frames,
+// every state spelled out in words. Row count matches the framed layout.
+func (m Model) renderAccessible(styles theme.Styles) string {
+	var b strings.Builder
+
+	status := "online"
+	switch {
+	case m.startupPhase < 2:
+		status = "connecting"
+	case m.isStreaming:
+		status = "assistant is responding"
+	}
+	if m.recorder != nil {
+		status += ", recording"
+	}
+	b.WriteString(styles.Neon.Bold(true).Render("bmohak.xyz terminal portfolio.") +
+		" View: " + viewTitle(m.view) + ". Status: " + status + ".\n")
+	online := ""
+	if m.live > 1 {
+		online = fmt.Sprintf(" %d visitors online.", m.live)
+	}
+	if m.announcing() {
+		b.WriteString("Announcement: " + m.announcement.text + "\n")
+	}
+	b.WriteString("Time " + m.now.Format("15:04") + ". Session length " + formatClock(m.now.Sub(m.sessionStart)) + "." + online + "\n")
+
+	tabs := make([]string, len(tabViews))
+	for i, v := range tabViews {
+		tabs[i] = viewTitle(v)
+		if i == m.activeTab() {
+			tabs[i] += " (current)"
+		}
+	}
+	b.WriteString("Views: " + strings.Join(tabs, ", ") + ".\n\n")
+
+	content := m.viewport.View()
+	switch {
+	case m.palette.open:
+		content = m.renderPalette(styles)
+	case m.helpOpen:
+		content = ui.Help(styles, m.viewport.Width, m.viewport.Height, helpCommands())
+	}
+	lines := strings.Split(strings.Trim(content, "\n"), "\n")
+	for i := 0; i < m.viewport.Height; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.idleWarningShown():
+		b.WriteString(m.idleMessage())
+	case m.errorMessage != "":
+		b.WriteString(ui.Error(styles, m.errorMessage))
+	case m.statusMessage != "":
+		b.WriteString(ui.Success(styles, m.statusMessage))
+	case m.edit.active:
+		b.WriteString("Editing your last message. Enter resends it and replaces the old answer, Escape cancels.")
+	case m.search.active:
+		fmt.Fprintf(&b, "Search %q: match %d of %d. Press n for next, Shift+N for previous, Escape to close.",
+			m.search.term, m.search.current+1, len(m.search.matches))
+	case m.helpOpen:
+		b.WriteString("Help is open. Press Escape to close it.")
+	case m.palette.open:
+		b.WriteString("Command palette is open. Type to search, arrows to select, Enter to run, Escape to close.")
+	case m.isStreaming:
+		b.WriteString("Assistant is responding. Press Escape to stop.")
+	case m.view == ViewQuiz && m.quiz.done:
+		b.WriteString("Quiz finished. Press R to play again, Escape to leave.")
+	case m.view == ViewQuiz && m.quiz.picked < 0:
+		b.WriteString("Quiz: press the number of your answer.")
+	case m.view == ViewQuiz:
+		b.WriteString("Quiz: press Enter for the next question.")
+	case m.view == ViewActivity && len(m.activity.calendar.Weeks) > 0:
+		b.WriteString("Activity: press Left or Right to move between weeks.")
+	case m.view == ViewProjects && m.projects != nil && len(m.projects.Projects) > 0:
+		b.WriteString("Projects: press a number to open one, Left or Right to pick a filter, Enter to toggle it.")
+	case m.view == ViewFind && len(m.find.hits) > 0:
+		fmt.Fprintf(&b, "Result %d of %d selected. Press Up or Down to move, Enter to open.", m.find.selected+1, len(m.find.hits))
+	case m.view == ViewChat && m.ratedAnswer() >= 0:
+		b.WriteString("Press plus if the last answer helped, minus if it didn't.")
+	case m.view == ViewProjectDetail:
+		b.WriteString("Project: press B to bookmark it, or to remove the bookmark.")
+	case m.view == ViewFeedback && m.feedback.sent < maxFeedbackPerSession:
+		b.WriteString("Feedback: press 1 to 5 to rate, then type a comment if you like and press Enter to send.")
+	case m.view == ViewBookmarks && len(m.bookmarks) > 0:
+		b.WriteString("Bookmarks: press a number to open one.")
+	case m.view == ViewAchievements && m.achievementPages() > 1:
+		fmt.Fprintf(&b, "Achievements page %d of %d. Press Left or Right to turn the page.", m.achievementPage+1, m.achievementPages())
+	}
+	b.WriteString("\n")
+	b.WriteString("Input: " + m.input.View() + "\n")
+	b.WriteString(styles.Muted.Render("Keys: Ctrl+H help, Ctrl+K palette, Escape back, Ctrl+Q quit."))
+
+	// Keep every row on one terminal line so the layout never scrolls
+	rows := strings.Split(b.String(), "\n")
+	for i, row := range rows {
+		rows[i] = ansi.Truncate(row, m.width, "")
+	}
+	return strings.Join(rows, "\n")
+}
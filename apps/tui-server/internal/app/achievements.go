@@ -0,0 +1,56 @@
+package app
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// achievements are the resume's achievements, or none without a resume
+func (m Model) achievements() []content.Achievement {
+	if m.resume == nil {
+		return nil
+	}
+	return m.resume.Achievements
+}
+
+// achievementPages is how many pages /achievements has
+func (m Model) achievementPages() int {
+	return ui.AchievementPages(len(m.achievements()))
+}
+
+// handleAchievements runs /achievements: the first page, or the page
+// numbered
+func (m Model) handleAchievements(args []string) (tea.Model, tea.Cmd) {
+	page := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > m.achievementPages() {
+			m.errorMessage = "Usage: /achievements [1-" + strconv.Itoa(m.achievementPages()) + "]"
+			m.updateViewport()
+			return m, nil
+		}
+		page = n
+	}
+	m.achievementPage = page - 1
+	return m.showView(ViewAchievements)
+}
+
+// updateAchievements turns the pages with the arrow keys. Keys it
+// doesn't use fall through to the input.
+func (m Model) updateAchievements(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, keys.PagePrev) && m.achievementPage > 0:
+		m.achievementPage--
+	case key.Matches(msg, keys.PageNext) && m.achievementPage < m.achievementPages()-1:
+		m.achievementPage++
+	default:
+		return m, nil, false
+	}
+	m.updateViewport()
+	m.viewport.GotoTop()
+	return m, nil, true
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/github"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// Activity supplies the GitHub contribution calendar /activity draws,
+// usually a *github.Client shared by every session
+type Activity interface {
+	Calendar(ctx context.Context) (github.Calendar, error)
+}
+
+// activityState is the /activity view: the calendar once fetched and
+// the week whose details are shown
+type activityState struct {
+	loading  bool
+	calendar github.Calendar
+	err      string
+	selected int
+}
+
+// ActivityMsg delivers a fetched calendar to /activity
+type ActivityMsg struct {
+	Calendar github.Calendar
+	Err      error
+}
+
+// openActivity shows the heatmap, fetching the calendar in the
+// background; one already fetched this session shows meanwhile
+func (m Model) openActivity() (tea.Model, tea.Cmd) {
+	if m.activitySource == nil {
+		m.errorMessage = "GitHub activity isn't set up on this server"
+		m.updateViewport()
+		return m, nil
+	}
+	m.activity.loading = len(m.activity.calendar.Weeks) == 0
+	m.activity.err = ""
+	model, _ := m.showView(ViewActivity)
+	source, ctx := m.activitySource, m.ctx
+	return model, func() tea.Msg {
+		cal, err := source.Calendar(ctx)
+		return ActivityMsg{Calendar: cal, Err: err}
+	}
+}
+
+// receiveActivity stores a fetched calendar, selecting the latest week
+func (m Model) receiveActivity(msg ActivityMsg) (Model, tea.Cmd) {
+	m.activity.loading = false
+	switch {
+	case msg.Err != nil && len(m.activity.calendar.Weeks) == 0:
+		m.activity.err = "GitHub activity isn't available right now"
+	case msg.Err == nil:
+		m.activity.calendar = msg.Calendar
+		m.activity.selected = len(msg.Calendar.Weeks) - 1
+	}
+	if m.view == ViewActivity {
+		m.updateViewport()
+	}
+	return m, nil
+}
+
+// updateActivity moves the selected week with the arrow keys. Keys it
+// doesn't use fall through to the input.
+func (m Model) updateActivity(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	weeks := len(m.activity.calendar.Weeks)
+	if weeks == 0 {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, keys.WeekPrev):
+		m.activity.selected = max(m.activity.selected-1, 0)
+	case key.Matches(msg, keys.WeekNext):
+		m.activity.selected = min(m.activity.selected+1, weeks-1)
+	default:
+		return m, nil, false
+	}
+	m.updateViewport()
+	return m, nil, true
+}
+
+// activityData is the view as the ui package draws it
+func (m Model) activityData() ui.ActivityData {
+	return ui.ActivityData{
+		Calendar: m.activity.calendar,
+		Selected: m.activity.selected,
+		Loading:  m.activity.loading,
+		Err:      m.activity.err,
+	}
+}
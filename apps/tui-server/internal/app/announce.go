@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// announcementFor is how long an announcement stays up in place of the
+// tab bar
+const announcementFor = time.Minute
+
+// AnnouncementMsg is the operator's announcement to every session
+type AnnouncementMsg struct{ Text string }
+
+// announcement is the last one received and when it comes down
+type announcement struct {
+	text  string
+	until time.Time
+}
+
+// announcing reports whether an announcement is up
+func (m Model) announcing() bool {
+	return m.announcement.text != "" && m.now.Before(m.announcement.until)
+}
+
+// handleBroadcast runs /broadcast, which only the operator's sessions
+// have; to everyone else it's as unknown as any other typo
+func (m Model) handleBroadcast(args []string) (tea.Model, tea.Cmd) {
+	if m.broadcast == nil {
+		m.errorMessage = "Unknown command: /broadcast"
+		m.updateViewport()
+		return m, nil
+	}
+	if len(args) == 0 {
+		m.errorMessage = "Usage: /broadcast <message>"
+		m.updateViewport()
+		return m, nil
+	}
+	n := m.broadcast(strings.Join(args, " "))
+	noun := "sessions"
+	if n == 1 {
+		noun = "session"
+	}
+	m.statusMessage = fmt.Sprintf("Announced to %d %s", n, noun)
+	return m, clearStatusAfter(3 * time.Second)
+}
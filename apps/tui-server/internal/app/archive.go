@@ -0,0 +1,107 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+const (
+	// maxShownMessages is how many of the latest messages the chat
+	// renders; earlier ones are archived until scrolled back to
+	maxShownMessages = 100
+	// earlierPage is how many archived messages one scroll-up loads
+	earlierPage = 25
+	// maxHistoryMessages bounds the history a session keeps live
+	maxHistoryMessages = 500
+	// maxTrimmedMessages bounds the messages trimmed past it and kept
+	// for scrolling back to. Each message is capped in length, so the two
+	// cap a session's memory.
+	maxTrimmedMessages = 500
+	// forgetChunk is how many messages past the bound are trimmed at
+	// once, so the cache shifts rarely rather than on every message
+	forgetChunk = 50
+)
+
+// archiveState tracks which part of the history the chat renders. Messages
+// before start are archived: kept, but not rendered until loaded.
+type archiveState struct {
+	start     int           // history index of the first rendered message
+	seen      int           // history length when last bounded
+	trimmed   []ChatMessage // archived messages moved out of the history, oldest first
+	forgotten int           // messages dropped from trimmed for good
+}
+
+// boundHistory archives all but the latest messages when new ones arrive
+// and clears the oldest once the history passes its bound
+func (m *Model) boundHistory() {
+	a := &m.archive
+	n := len(m.chatHistory)
+	if n < a.seen {
+		// Cleared or cut back by an edit
+		a.start = min(a.start, n)
+		if n == 0 {
+			a.trimmed, a.forgotten = nil, 0
+		}
+	}
+	if n > a.seen && n-a.start > maxShownMessages {
+		a.start = n - maxShownMessages
+	}
+
+	// Only archived messages are trimmed, and an edit holds an index into
+	// the history, so trimming waits for it
+	if over := n - maxHistoryMessages; over > 0 && !m.edit.active {
+		drop := min((over+forgetChunk-1)/forgetChunk*forgetChunk, a.start)
+		a.trimmed = append(a.trimmed, m.chatHistory[:drop]...)
+		m.chatHistory = append([]ChatMessage(nil), m.chatHistory[drop:]...)
+		m.renderCache.shift(drop)
+		a.start -= drop
+		n = len(m.chatHistory)
+	}
+	if over := len(a.trimmed) - maxTrimmedMessages; over > 0 {
+		a.trimmed = append([]ChatMessage(nil), a.trimmed[over:]...)
+		a.forgotten += over
+	}
+	a.seen = n
+}
+
+// visitorSent reports whether the visitor added a message since the
+// history was last bounded, which takes the chat back to its end
+func (m Model) visitorSent() bool {
+	for _, msg := range m.chatHistory[min(m.archive.seen, len(m.chatHistory)):] {
+		if msg.Role == "user" {
+			return true
+		}
+	}
+	return false
+}
+
+// loadEarlier renders another page of archived messages, keeping the
+// message at the top of the screen where it was. Once every archived
+// message in the history shows, the page comes back from the trimmed ones.
+func (m *Model) loadEarlier() bool {
+	a := &m.archive
+	if a.start == 0 && len(a.trimmed) == 0 {
+		return false
+	}
+	before := len(m.viewLines)
+	if a.start > 0 {
+		a.start = max(a.start-earlierPage, 0)
+	} else {
+		k := len(a.trimmed) - min(earlierPage, len(a.trimmed))
+		m.chatHistory = append(a.trimmed[k:len(a.trimmed):len(a.trimmed)], m.chatHistory...)
+		m.renderCache.shift(k - len(a.trimmed))
+		a.trimmed = a.trimmed[:k]
+		a.seen = len(m.chatHistory)
+	}
+	m.updateViewport()
+	m.viewport.SetYOffset(len(m.viewLines) - before)
+	return true
+}
+
+// scrollsUp reports whether msg asks to scroll up: the wheel or PgUp
+func scrollsUp(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return msg.Button == tea.MouseButtonWheelUp
+	case tea.KeyMsg:
+		return msg.Type == tea.KeyPgUp
+	}
+	return false
+}
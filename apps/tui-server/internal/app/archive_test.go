@@ -0,0 +1,33 @@
+package app
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestTrimmedMessagesLoadBack(t *testing.T) {
+	m := NewModel(Config{ThemeManager: theme.NewManager(80, 24, nil), Projects: &content.Projects{}})
+	m.showWelcome = false
+	for i := range 600 {
+		m.chatHistory = append(m.chatHistory, ChatMessage{Role: "assistant", Content: "message " + strconv.Itoa(i), Time: time.Now()})
+	}
+	m.updateViewport()
+	if len(m.chatHistory) > maxHistoryMessages || len(m.archive.trimmed) == 0 {
+		t.Fatalf("history %d, trimmed %d", len(m.chatHistory), len(m.archive.trimmed))
+	}
+
+	for m.loadEarlier() {
+		offset := m.viewport.YOffset
+		m.updateViewport()
+		if m.viewport.YOffset != offset {
+			t.Fatalf("loaded messages scrolled from line %d to %d", offset, m.viewport.YOffset)
+		}
+	}
+	if len(m.chatHistory) != 600 || m.chatHistory[0].Content != "message 0" {
+		t.Errorf("history after loading back starts %q, %d messages", m.chatHistory[0].Content, len(m.chatHistory))
+	}
+}
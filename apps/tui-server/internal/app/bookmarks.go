@@ -0,0 +1,119 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// loadBookmarks switches to the bookmarks saved under the visitor's key
+func (m *Model) loadBookmarks() {
+	ids, err := m.prefs.store.Bookmarks(m.prefs.key)
+	if err != nil {
+		m.errorMessage = "Couldn't load bookmarks"
+		return
+	}
+	m.bookmarks = ids
+}
+
+// bookmarksSaved reports whether bookmarks outlive the session
+func (m Model) bookmarksSaved() bool {
+	return m.prefs.store != nil && m.prefs.key != "" && !m.readOnly
+}
+
+// saveBookmarks writes the bookmarks in the background
+func (m Model) saveBookmarks() tea.Cmd {
+	if !m.bookmarksSaved() {
+		return nil
+	}
+	s, key, ids := m.prefs.store, m.prefs.key, slices.Clone(m.bookmarks)
+	return func() tea.Msg {
+		if err := s.SaveBookmarks(key, ids); err != nil {
+			return PrefsErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// toggleBookmark bookmarks the project on screen, or takes it off the
+// bookmarks when it's there already
+func (m Model) toggleBookmark() (tea.Model, tea.Cmd) {
+	if m.projects == nil {
+		return m, nil
+	}
+	project := m.projects.GetProjectByID(m.selectedProj)
+	if project == nil {
+		return m, nil
+	}
+	if i := slices.Index(m.bookmarks, project.ID); i >= 0 {
+		m.bookmarks = slices.Delete(slices.Clone(m.bookmarks), i, i+1)
+		m.statusMessage = "Removed " + project.Name + " from bookmarks"
+	} else {
+		m.bookmarks = append(slices.Clone(m.bookmarks), project.ID)
+		m.statusMessage = "Bookmarked " + project.Name + " - /bookmarks lists them"
+		if !m.bookmarksSaved() {
+			m.statusMessage = "Bookmarked " + project.Name + " for this session - connect with an SSH key or /login to keep it"
+		}
+	}
+	m.updateViewport()
+	return m, tea.Batch(m.saveBookmarks(), clearStatusAfter(3*time.Second))
+}
+
+// handleBookmarks runs /bookmarks: list the bookmarked projects, or
+// clear them
+func (m Model) handleBookmarks(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 0 && strings.ToLower(args[0]) == "clear" {
+		m.bookmarks = nil
+		m.statusMessage = "Bookmarks cleared"
+		m.updateViewport()
+		return m, tea.Batch(m.saveBookmarks(), clearStatusAfter(3*time.Second))
+	}
+	return m.showView(ViewBookmarks)
+}
+
+// mergeBookmarks adds the IDs in more that ids is missing to its end
+func mergeBookmarks(ids, more []string) []string {
+	merged := slices.Clone(ids)
+	for _, id := range more {
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+// bookmarkedProjects are the bookmarked projects, in the order they were
+// added; ones no longer in the content are left out
+func (m Model) bookmarkedProjects() []content.Project {
+	if m.projects == nil {
+		return nil
+	}
+	var shown []content.Project
+	for _, id := range m.bookmarks {
+		if p := m.projects.GetProjectByID(id); p != nil {
+			shown = append(shown, *p)
+		}
+	}
+	return shown
+}
+
+// numberedProjects are the projects the number keys and clicks open in
+// the view on screen
+func (m Model) numberedProjects() []content.Project {
+	switch m.view {
+	case ViewProjects:
+		return m.shownProjects()
+	case ViewBookmarks:
+		return m.bookmarkedProjects()
+	}
+	return nil
+}
+
+// bookmarksData is the view as the ui package draws it
+func (m Model) bookmarksData() ui.BookmarksData {
+	return ui.BookmarksData{Projects: m.bookmarkedProjects(), Saved: m.bookmarksSaved()}
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/export"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// cardData is the contact card /card shows, taken from the resume. The
+// .vcf download is offered only where scp is, so not in --local.
+func (m Model) cardData() ui.CardData {
+	if m.resume == nil {
+		return ui.CardData{}
+	}
+	r := m.resume
+	data := ui.CardData{
+		Name:    r.Name,
+		Title:   r.Title,
+		Email:   r.Contact.Email,
+		Website: export.WebURL(r.Contact.Website),
+		VCard:   string(export.ShortVCard(r)),
+
+		LocalTime: m.localTime(),
+	}
+	if m.scpPrefix != "" {
+		data.Download = m.scpPrefix + "contact.vcf ."
+	}
+	return data
+}
+
+// localTime is the line saying what time it is for Mohak and what that
+// means for replies; "" without a clock
+func (m Model) localTime() string {
+	if m.clock == nil {
+		return ""
+	}
+	return m.clock.At(m.now).Line()
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestClockTickKeepsChatScroll(t *testing.T) {
+	m := NewModel(Config{ThemeManager: theme.NewManager(80, 24, nil), Projects: &content.Projects{}})
+	start := m.sessionStart.Truncate(time.Minute).Add(time.Minute + 30*time.Second)
+	m.timestamps = "relative"
+	m.showWelcome = false
+	m.now = start
+	for i := range 30 {
+		m.chatHistory = append(m.chatHistory, ChatMessage{Role: "user", Content: strings.Repeat("question ", 20), Time: start.Add(-time.Duration(i) * time.Minute)})
+	}
+	m.updateViewport()
+	m.viewport.SetYOffset(3)
+
+	for _, tick := range []time.Time{start.Add(time.Second), start.Add(time.Minute)} {
+		model, _ := m.Update(ClockTickMsg{Time: tick})
+		m = model.(Model)
+		if m.viewport.YOffset != 3 {
+			t.Fatalf("tick at %s scrolled the chat to line %d, want 3", tick.Format(time.TimeOnly), m.viewport.YOffset)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// chunkFlushInterval is the longest a streamed chunk waits before redraw
+	chunkFlushInterval = 50 * time.Millisecond
+	// chunkFlushBytes flushes early once this much text is pending
+	chunkFlushBytes = 512
+)
+
+// chunkMerger batches streamed chunks for the UI. Chunks are merged into
+// one pending string rather than queued, so while a slow client is still
+// drawing the last batch, everything that arrives meanwhile goes out as
+// the next one. The provider is never held up by the terminal, and a
+// session holds at most one batch beyond the reply itself.
+type chunkMerger struct {
+	mu      sync.Mutex
+	pending strings.Builder
+	closed  bool
+	wake    chan struct{} // flush now; holds at most one signal
+}
+
+func newChunkMerger() *chunkMerger {
+	return &chunkMerger{wake: make(chan struct{}, 1)}
+}
+
+// Add merges chunk into the pending batch; it never blocks
+func (c *chunkMerger) Add(chunk string) {
+	c.mu.Lock()
+	c.pending.WriteString(chunk)
+	full := c.pending.Len() >= chunkFlushBytes
+	c.mu.Unlock()
+	if full {
+		c.signal()
+	}
+}
+
+// Close ends the stream; Run flushes what's pending and returns. Nothing
+// may be added after.
+func (c *chunkMerger) Close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.signal()
+}
+
+func (c *chunkMerger) signal() {
+	select {
+	case c.wake <- struct{}{}:
+	default: // a flush is already due
+	}
+}
+
+// take empties the pending batch
+func (c *chunkMerger) take() (batch string, closed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	batch = c.pending.String()
+	c.pending.Reset()
+	return batch, c.closed
+}
+
+// Run hands the pending batch to emit every chunkFlushInterval, or sooner
+// once chunkFlushBytes are pending, so a byte-at-a-time stream causes a
+// few redraws per second rather than one per byte. emit may block; chunks
+// keep merging until it returns. Run returns after Close and a final flush.
+func (c *chunkMerger) Run(emit func(string)) {
+	ticker := time.NewTicker(chunkFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.wake:
+		case <-ticker.C:
+		}
+		batch, closed := c.take()
+		if batch != "" {
+			emit(batch)
+		}
+		if closed {
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkMergerMergesWhileEmitBlocks(t *testing.T) {
+	chunks := newChunkMerger()
+	release := make(chan struct{})
+	var batches []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		chunks.Run(func(batch string) {
+			batches = append(batches, batch)
+			if len(batches) == 1 {
+				<-release // a slow client still drawing the first batch
+			}
+		})
+	}()
+
+	first := strings.Repeat("a", chunkFlushBytes)
+	chunks.Add(first)
+	for i := 0; i < 1000; i++ {
+		chunks.Add("b")
+	}
+	close(release)
+	chunks.Close()
+	<-done
+
+	if got := strings.Join(batches, ""); got != first+strings.Repeat("b", 1000) {
+		t.Fatalf("batches lost or reordered text: %d bytes", len(got))
+	}
+	if len(batches) > 3 {
+		t.Errorf("got %d batches for chunks added while emit blocked, want them merged", len(batches))
+	}
+}
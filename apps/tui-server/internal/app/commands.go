@@ -0,0 +1,275 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
+)
+
+// Command is a slash command. Handlers get the model and the words typed
+// after the command, and return like Update does.
+type Command struct {
+	Name    string   // with the slash, like "/open"
+	Aliases []string // other names it answers to
+	Args    string   // argument spec shown in usage, like "<id>"
+	MinArgs int      // fewer prints the usage instead of running
+	Help    string   // one line for /help
+	Palette bool     // list it in the command palette
+	Hidden  bool     // leave it out of /help
+	Run     func(m Model, args []string) (tea.Model, tea.Cmd)
+}
+
+// usage is the command as typed, with its argument spec
+func (c Command) usage() string {
+	return strings.TrimSpace(c.Name + " " + c.Args)
+}
+
+var (
+	commandList  []Command
+	commandIndex = map[string]int{}
+)
+
+// RegisterCommand adds a slash command alongside the built-in ones, so a
+// fork can add its own without touching the dispatcher. Call it at
+// startup, before any session starts.
+func RegisterCommand(c Command) error {
+	names := append([]string{c.Name}, c.Aliases...)
+	for _, name := range names {
+		if !strings.HasPrefix(name, "/") || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("command %q must be a slash and one word", name)
+		}
+		if _, taken := commandIndex[strings.ToLower(name)]; taken {
+			return fmt.Errorf("command %s is already registered", name)
+		}
+	}
+	if c.Run == nil {
+		return fmt.Errorf("command %s has no handler", c.Name)
+	}
+	for _, name := range names {
+		commandIndex[strings.ToLower(name)] = len(commandList)
+	}
+	commandList = append(commandList, c)
+	return nil
+}
+
+func init() {
+	for _, c := range append(builtinCommands(), eggCommands()...) {
+		if err := RegisterCommand(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// lookupCommand finds a command by name or alias
+func lookupCommand(name string) (Command, bool) {
+	i, ok := commandIndex[strings.ToLower(name)]
+	if !ok {
+		return Command{}, false
+	}
+	return commandList[i], true
+}
+
+// helpCommands lists the commands /help shows, in registration order
+func helpCommands() []ui.HelpCommand {
+	var out []ui.HelpCommand
+	for _, c := range commandList {
+		if !c.Hidden {
+			out = append(out, ui.HelpCommand{Usage: c.usage(), Desc: c.Help})
+		}
+	}
+	return out
+}
+
+func (m Model) handleSlashCommand(input string) (tea.Model, tea.Cmd) {
+	parts := strings.Fields(input)
+	command := strings.ToLower(parts[0])
+	args := parts[1:]
+
+	// Track command execution
+	if m.analytics != nil {
+		m.analytics.TrackCommandExecuted(m.sessionID, command)
+	}
+
+	c, ok := lookupCommand(command)
+	switch {
+	case !ok:
+		m.errorMessage = "Unknown command: " + command
+	case len(args) < c.MinArgs:
+		m.errorMessage = "Usage: " + c.usage()
+	default:
+		oldView := m.view
+		model, cmd := c.Run(m, args)
+		next, ok := model.(Model)
+		if !ok {
+			return model, cmd
+		}
+		// Track view change
+		if next.view != oldView && next.analytics != nil {
+			next.analytics.TrackViewChanged(next.sessionID, viewName(oldView), viewName(next.view))
+		}
+		return next, cmd
+	}
+	m.updateViewport()
+	return m, nil
+}
+
+// showView switches to a content view
+func (m Model) showView(v View) (tea.Model, tea.Cmd) {
+	m.view = v
+	m.showWelcome = false
+	m.updateViewport()
+	return m, nil
+}
+
+// builtinCommands are the commands every session has
+func builtinCommands() []Command {
+	return []Command{
+		{Name: "/help", Aliases: []string{"/h", "/?"}, Help: "show help",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+				m.helpOpen = true
+				m.updateViewport()
+				return m, nil
+			}},
+		{Name: "/about", Aliases: []string{"/bio"}, Help: "profile",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewAbout) }},
+		{Name: "/projects", Aliases: []string{"/p"}, Args: projectsUsage, Help: "list, filtered and sorted",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.filterProjects(args) }},
+		{Name: "/bookmarks", Aliases: []string{"/saved"}, Args: "[clear]", Help: "bookmarked projects",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleBookmarks(args) }},
+		{Name: "/open", Aliases: []string{"/o"}, Args: "<project-id>", MinArgs: 1, Help: "view",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				m.selectedProj = args[0]
+				if m.projects.GetProjectByID(m.selectedProj) == nil {
+					m.errorMessage = "Project not found: " + m.selectedProj
+					m.updateViewport()
+					return m, nil
+				}
+				return m.showView(ViewProjectDetail)
+			}},
+		{Name: "/resume", Aliases: []string{"/cv", "/r"}, Help: "credentials",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewResume) }},
+		{Name: "/exp", Aliases: []string{"/experience", "/work"}, Help: "work history",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewExperience) }},
+		{Name: "/uses", Aliases: []string{"/setup"}, Help: "hardware and tools",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewUses) }},
+		{Name: "/talks", Aliases: []string{"/publications", "/writing"}, Help: "talks and writing",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewTalks) }},
+		{Name: "/certs", Aliases: []string{"/certifications"}, Help: "certifications",
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewCerts) }},
+		{Name: "/achievements", Aliases: []string{"/awards"}, Args: "[page]", Help: "achievements in full",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleAchievements(args) }},
+		{Name: "/link", Args: "<n>", MinArgs: 1, Help: "copy a listed link",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleLink(args) }},
+		{Name: "/copycode", Aliases: []string{"/cc"}, Args: "[n]", Help: "copy code from the last answer",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleCopyCode(args) }},
+		{Name: "/search", Args: "<term>", MinArgs: 1, Help: "find in chat",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				m.startSearch(strings.Join(args, " "))
+				return m, nil
+			}},
+		{Name: "/find", Args: "<term>", MinArgs: 1, Help: "search the site",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.startFind(strings.Join(args, " ")) }},
+		{Name: "/clear", Aliases: []string{"/cls"}, Help: "reset chat", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.clearChat() }},
+		{Name: "/retry", Help: "resend last message", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.retryLast() }},
+		{Name: "/regen", Aliases: []string{"/regenerate"}, Help: "new answer", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.regenerateLast() }},
+		{Name: "/continue", Help: "finish a cut-off reply", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.continueLast() }},
+		{Name: "/theme", Args: "<name>", Help: "colors",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				switch {
+				case len(args) == 0:
+					m.errorMessage = "Usage: /theme <" + strings.Join(paletteNames(), "|") + ">"
+				case m.themeManager.SetPalette(strings.ToLower(args[0])):
+					m.statusMessage = "Theme: " + m.themeManager.Palette().Name
+					m.updateViewport()
+					return m, tea.Batch(m.rememberSetting("theme", m.themeManager.Palette().Name), clearStatusAfter(2*time.Second))
+				default:
+					m.errorMessage = "Unknown theme: " + args[0]
+				}
+				m.updateViewport()
+				return m, nil
+			}},
+		{Name: "/set", Args: "<key> <value>", Help: "options",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				if len(args) < 2 {
+					m.errorMessage = "Usage: /set <key> <value> (keys: " + strings.Join(settingKeys(), ", ") + ")"
+					m.updateViewport()
+					return m, nil
+				}
+				status, err := m.applySetting(strings.ToLower(args[0]), strings.ToLower(args[1]))
+				if err != nil {
+					m.errorMessage = err.Error()
+					m.updateViewport()
+					return m, nil
+				}
+				m.statusMessage = status
+				m.updateViewport()
+				save := m.rememberSetting(strings.ToLower(args[0]), strings.ToLower(args[1]))
+				return m, tea.Batch(save, m.mouseCmd(), clearStatusAfter(2*time.Second))
+			}},
+		{Name: "/prefs", Args: "[reset]", Help: "saved options",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handlePrefs(args) }},
+		{Name: "/login", Args: "<handle> <passphrase>", Help: "sign in",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleLogin(args) }},
+		{Name: "/accessible", Aliases: []string{"/a11y"}, Args: "[on|off]", Help: "screen reader", Palette: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				on := !m.themeManager.Accessible()
+				if len(args) > 0 {
+					on = args[0] != "off"
+				}
+				m = m.toggleAccessible(on)
+				value := "off"
+				if on {
+					value = "on"
+				}
+				return m, tea.Batch(m.rememberSetting("accessible", value), clearStatusAfter(2*time.Second))
+			}},
+		{Name: "/tour", Help: "guided walkthrough", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.startTour() }},
+		{Name: "/record", Args: "[stop]", Help: "record a cast", Palette: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleRecord(args) }},
+		{Name: "/whatsnew", Aliases: []string{"/changes", "/changelog"}, Help: "changes since your last visit", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewWhatsNew) }},
+		{Name: "/card", Aliases: []string{"/vcard", "/qr"}, Help: "scan my contact card", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.showView(ViewCard) }},
+		{Name: "/activity", Aliases: []string{"/github"}, Help: "GitHub contributions", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.openActivity() }},
+		{Name: "/feedback", Args: "[1-5] [text]", Help: "rate the site", Palette: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleFeedback(args) }},
+		{Name: "/stats", Help: "server stats", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.openStats() }},
+		{Name: "/quiz", Help: "test yourself", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.startQuiz() }},
+		{Name: "/snake", Help: "play snake", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.startSnake() }},
+		{Name: "/version", Aliases: []string{"/v"}, Help: "build and release", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+				m.statusMessage = "bmohak.xyz " + version.String()
+				return m, clearStatusAfter(5 * time.Second)
+			}},
+		{Name: "/share", Args: "[all]", Help: "share the chat as a link", Palette: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleShare(args) }},
+		{Name: "/conversation", Args: "[id]", Help: "copy this chat's ID, or continue another's",
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleConversation(args) }},
+		{Name: "/broadcast", Args: "<message>", Help: "announce to every session", Hidden: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) { return m.handleBroadcast(args) }},
+		{Name: "/back", Aliases: []string{"/b"}, Help: "back to chat", Hidden: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+				m.view = ViewChat
+				m.updateViewport()
+				return m, nil
+			}},
+		{Name: "/exit", Aliases: []string{"/quit", "/q"}, Help: "quit", Palette: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+				m.quitting = true
+				return m, quitAfter(1500 * time.Millisecond)
+			}},
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestCommandsRunWithAnalyticsOff(t *testing.T) {
+	// How a suspected bot's session had analytics turned off: a nil
+	// client that still gets past the model's nil checks
+	var off *telemetry.Analytics
+	m := NewModel(Config{
+		ThemeManager: theme.NewManager(80, 24, nil),
+		Analytics:    off,
+	})
+
+	model, _ := m.handleSlashCommand("/projects")
+	if got := model.(Model).view; got != ViewProjects {
+		t.Errorf("view = %v, want projects", got)
+	}
+}
+
+func TestClearStartsNewConversation(t *testing.T) {
+	m := NewModel(Config{ThemeManager: theme.NewManager(80, 24, nil), Projects: &content.Projects{}})
+	m.chatHistory = []ChatMessage{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+
+	// Ctrl+L and /clear both forget the gateway's conversation
+	before := m.conversation
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = model.(Model)
+	if len(m.chatHistory) != 0 || m.conversation == before {
+		t.Fatalf("Ctrl+L kept %d messages and conversation %q", len(m.chatHistory), m.conversation)
+	}
+
+	before = m.conversation
+	model, _ = m.handleSlashCommand("/clear")
+	if model.(Model).conversation == before {
+		t.Fatal("/clear kept the conversation")
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+)
+
+// handleConversation runs /conversation. Bare, it copies the chat's
+// conversation ID; given one from another session, the chat continues
+// that conversation, whose history the gateway keeps. The earlier
+// messages stay on the other screen, but the AI remembers them.
+func (m Model) handleConversation(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.readOnly:
+		m.errorMessage = readOnlyNotice
+		m.updateViewport()
+		return m, nil
+	case !m.conversations:
+		m.errorMessage = "Conversations aren't kept on this server"
+		m.updateViewport()
+		return m, nil
+	case len(args) == 0:
+		m.clipboard = m.conversation
+		m.statusMessage = "Copied conversation " + m.conversation + " - continue it elsewhere with /conversation <id>"
+		return m, clearStatusAfter(4 * time.Second)
+	}
+
+	id, ok := ai.ParseConversationID(args[0])
+	if !ok {
+		m.errorMessage = "That isn't a conversation ID; /conversation copies this chat's"
+		m.updateViewport()
+		return m, nil
+	}
+	if m.isStreaming {
+		m.errorMessage = "Wait for the reply to finish first"
+		m.updateViewport()
+		return m, nil
+	}
+	m.conversation = id
+	m.restarted = false
+	m.chatHistory = nil
+	m.followUps = followUpState{}
+	m.errorMessage = ""
+	m.statusMessage = "Continuing the conversation - the AI remembers what was said there"
+	m.goTo(location{view: ViewChat})
+	m.updateViewport()
+	return m, clearStatusAfter(4 * time.Second)
+}
+
+// restartConversation moves the chat to a new conversation once its
+// history no longer matches the one the gateway kept, as after /regen or
+// an edit. The next request starts it with the history as it stands.
+func (m *Model) restartConversation() {
+	m.conversation = ai.NewConversationID()
+	m.restarted = true
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// answerCode is the code blocks in the last answer
+func (m Model) answerCode() []string {
+	for i := len(m.chatHistory) - 1; i >= 0; i-- {
+		if msg := m.chatHistory[i]; msg.Role == "assistant" && !msg.Superseded {
+			return ui.CodeBlocks(msg.Content)
+		}
+	}
+	return nil
+}
+
+// handleCopyCode runs /copycode: put the numbered code block of the last
+// answer, or its first, on the visitor's clipboard with OSC 52
+func (m Model) handleCopyCode(args []string) (tea.Model, tea.Cmd) {
+	blocks := m.answerCode()
+	if len(blocks) == 0 {
+		m.errorMessage = "No code in the last answer"
+		m.updateViewport()
+		return m, nil
+	}
+	n := 1
+	if len(args) > 0 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 || n > len(blocks) {
+			m.errorMessage = "Usage: /copycode [1-" + strconv.Itoa(len(blocks)) + "]"
+			m.updateViewport()
+			return m, nil
+		}
+	}
+
+	code := blocks[n-1]
+	m.clipboard = code
+	lines, noun := strings.Count(code, "\n")+1, "lines"
+	if lines == 1 {
+		noun = "line"
+	}
+	m.statusMessage = fmt.Sprintf("Copied code block %d of %d (%d %s)", n, len(blocks), lines, noun)
+	return m, clearStatusAfter(2 * time.Second)
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editState tracks a user message loaded back into the input with ↑
+type editState struct {
+	active bool
+	index  int // position of the message being edited in chatHistory
+}
+
+// startEdit loads the last user message into the input for editing
+func (m Model) startEdit() (Model, bool) {
+	i := m.lastUserMessage()
+	if i < 0 {
+		return m, false
+	}
+	m.edit = editState{active: true, index: i}
+	m.input.SetValue(m.chatHistory[i].Content)
+	m.input.CursorEnd()
+	return m, true
+}
+
+// submitEdit replaces the edited message, and everything after it, with
+// the new text and asks again
+func (m Model) submitEdit(text string) (tea.Model, tea.Cmd) {
+	i := m.edit.index
+	m.edit = editState{}
+	if i >= len(m.chatHistory) || m.chatHistory[i].Role != "user" {
+		// History was cleared while editing; treat it as a new message
+		return m.sendChatMessage(text)
+	}
+	if !m.aiReady() {
+		return m, nil
+	}
+	m.chatHistory = m.chatHistory[:i]
+	m.restartConversation()
+	return m.sendChatMessage(text)
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+const (
+	// matrixInterval is the frame time of the /matrix rain
+	matrixInterval = 80 * time.Millisecond
+	// matrixFrames is how long the rain runs, about five seconds
+	matrixFrames = 60
+	// hackInterval is the pause between lines of the /hack sequence
+	hackInterval = 450 * time.Millisecond
+)
+
+// eggState is a running /matrix or /hack animation. id invalidates ticks
+// of one that has ended.
+type eggState struct {
+	kind  string // "matrix" or "hack"; empty when none is running
+	id    int
+	frame int
+}
+
+// EggTickMsg advances the easter egg animation with the given ID
+type EggTickMsg struct{ ID int }
+
+func eggTick(id int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return EggTickMsg{ID: id} })
+}
+
+// eggCommands are the hidden commands. They stay out of /help and the
+// palette; analytics records who finds them.
+func eggCommands() []Command {
+	return []Command{
+		{Name: "/matrix", Help: "digital rain", Hidden: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.startEgg("matrix") }},
+		{Name: "/hack", Help: "hack the mainframe", Hidden: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) { return m.startEgg("hack") }},
+		{Name: "/coffee", Help: "brew coffee", Hidden: true,
+			Run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+				m.foundEgg("coffee")
+				m.errorMessage = "418 I'm a teapot: this server only brews tea"
+				return m, nil
+			}},
+		{Name: "/cowsay", Args: "<text>", Help: "moo", Hidden: true,
+			Run: func(m Model, args []string) (tea.Model, tea.Cmd) {
+				m.foundEgg("cowsay")
+				text := strings.Join(args, " ")
+				if text == "" {
+					text = "moo"
+				}
+				m.view = ViewChat
+				m.showWelcome = false
+				m.chatHistory = append(m.chatHistory, ChatMessage{
+					Role:    "assistant",
+					Content: "```\n" + ui.Cowsay(text, min(m.columnWidth()-16, 48)) + "\n```",
+					Time:    time.Now(),
+				})
+				m.updateViewport()
+				return m, nil
+			}},
+	}
+}
+
+// foundEgg records a visitor finding a hidden command
+func (m Model) foundEgg(name string) {
+	if m.analytics != nil {
+		m.analytics.TrackEasterEgg(m.sessionID, name)
+	}
+}
+
+// startEgg plays an animated easter egg. With motion reduced it settles
+// for a line of text instead.
+func (m Model) startEgg(kind string) (tea.Model, tea.Cmd) {
+	m.foundEgg(kind)
+	if m.reducedMotion || m.themeManager.Accessible() {
+		if kind == "matrix" {
+			m.statusMessage = "Wake up, Neo... follow the white rabbit"
+		} else {
+			m.statusMessage = "ACCESS GRANTED (to a portfolio)"
+		}
+		return m, clearStatusAfter(3 * time.Second)
+	}
+	m.egg = eggState{kind: kind, id: m.egg.id + 1}
+	m.helpOpen = false
+	interval := matrixInterval
+	if kind == "hack" {
+		interval = hackInterval
+	}
+	return m, eggTick(m.egg.id, interval)
+}
+
+// stepEgg advances the running animation, ending it after its last frame
+func (m Model) stepEgg(msg EggTickMsg) (Model, tea.Cmd) {
+	if m.egg.kind == "" || msg.ID != m.egg.id {
+		return m, nil
+	}
+	m.egg.frame++
+	switch m.egg.kind {
+	case "matrix":
+		if m.egg.frame >= matrixFrames {
+			m.egg.kind = ""
+			return m, nil
+		}
+		return m, eggTick(m.egg.id, matrixInterval)
+	default:
+		if m.egg.frame > ui.HackSteps+3 {
+			// ACCESS GRANTED holds for a few steps before closing
+			m.egg.kind = ""
+			return m, nil
+		}
+		return m, eggTick(m.egg.id, hackInterval)
+	}
+}
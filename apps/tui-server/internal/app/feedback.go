@@ -0,0 +1,103 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// maxFeedbackPerSession is how many times one session can send /feedback
+const maxFeedbackPerSession = 3
+
+// maxFeedbackText caps a comment, in runes
+const maxFeedbackText = 500
+
+// FeedbackSink takes /feedback submissions, keeping them and telling the
+// owner. Submit is called in the background.
+type FeedbackSink interface {
+	Submit(f store.Feedback) error
+}
+
+// FeedbackSentMsg reports a submission that reached the sink, or Err
+type FeedbackSentMsg struct{ Err error }
+
+// feedbackState is the /feedback form's rating, 0 until one is picked,
+// and how many submissions this session sent
+type feedbackState struct {
+	rating int
+	sent   int
+}
+
+// handleFeedback runs /feedback: the form, or with a rating and comment,
+// as in /feedback 5 lovely site, a submission straight away
+func (m Model) handleFeedback(args []string) (tea.Model, tea.Cmd) {
+	if m.feedbackSink == nil {
+		m.errorMessage = "Feedback isn't set up on this server"
+		m.updateViewport()
+		return m, nil
+	}
+	if len(args) == 0 {
+		return m.showView(ViewFeedback)
+	}
+	rating, err := strconv.Atoi(args[0])
+	if err != nil || rating < 1 || rating > 5 {
+		m.errorMessage = "Usage: /feedback [1-5] [text]"
+		m.updateViewport()
+		return m, nil
+	}
+	m.feedback.rating = rating
+	model, _ := m.showView(ViewFeedback)
+	return model.(Model).submitFeedback(strings.Join(args[1:], " "))
+}
+
+// updateFeedback picks the rating with the number keys. Keys it doesn't
+// use fall through to the input.
+func (m Model) updateFeedback(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "1", "2", "3", "4", "5":
+		if m.feedback.sent >= maxFeedbackPerSession {
+			return m, nil, false
+		}
+		m.feedback.rating = int(msg.String()[0] - '0')
+		m.updateViewport()
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// submitFeedback sends the picked rating with comment, which may be
+// empty, in the background
+func (m Model) submitFeedback(comment string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.feedback.sent >= maxFeedbackPerSession:
+		m.errorMessage = "That's all the feedback one session can send - thank you!"
+	case m.feedback.rating == 0:
+		m.errorMessage = "Pick a rating from 1 to 5 first"
+	}
+	if m.errorMessage != "" {
+		m.updateViewport()
+		return m, nil
+	}
+
+	if runes := []rune(comment); len(runes) > maxFeedbackText {
+		comment = string(runes[:maxFeedbackText])
+	}
+	f := store.Feedback{Rating: m.feedback.rating, Text: comment, Visitor: m.prefs.key, At: time.Now()}
+	m.feedback = feedbackState{sent: m.feedback.sent + 1}
+	if m.analytics != nil {
+		m.analytics.TrackCommandExecuted(m.sessionID, "feedback:"+strconv.Itoa(f.Rating))
+	}
+	m.updateViewport()
+
+	sink := m.feedbackSink
+	return m, func() tea.Msg { return FeedbackSentMsg{Err: sink.Submit(f)} }
+}
+
+// feedbackData is the form as the ui package draws it
+func (m Model) feedbackData() ui.FeedbackData {
+	return ui.FeedbackData{Rating: m.feedback.rating, Sent: m.feedback.sent, Limit: maxFeedbackPerSession}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// findState is the last /find: its term, the ranked hits and the one
+// selected
+type findState struct {
+	term     string
+	hits     []content.Hit
+	selected int
+}
+
+// startFind runs /find term over the content and lists the hits
+func (m Model) startFind(term string) (tea.Model, tea.Cmd) {
+	m.find = findState{term: term, hits: m.index.Search(term)}
+	return m.showView(ViewFind)
+}
+
+// updateFind moves the selected hit with the arrow keys and opens it
+// with Enter. Keys it doesn't use fall through to the input.
+func (m Model) updateFind(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	hits := len(m.find.hits)
+	if hits == 0 {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, keys.HitPrev):
+		m.find.selected = max(m.find.selected-1, 0)
+	case key.Matches(msg, keys.HitNext):
+		m.find.selected = min(m.find.selected+1, hits-1)
+	case key.Matches(msg, keys.OpenHit):
+		m.openHit(m.find.hits[m.find.selected])
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+	m.updateViewport()
+	m.scrollToHit()
+	return m, nil, true
+}
+
+// scrollToHit keeps the selected hit on screen
+func (m *Model) scrollToHit() {
+	line := ui.FindHitLine(m.find.selected)
+	switch {
+	case line < m.viewport.YOffset:
+		m.viewport.SetYOffset(line)
+	case line+1 >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(line + 2 - m.viewport.Height)
+	}
+}
+
+// openHit shows the page a hit is on with its match highlighted and in
+// view, as after /search, so n and N step through the rest
+func (m *Model) openHit(hit content.Hit) {
+	name, id, _ := strings.Cut(hit.Route, "/")
+	view := routes[name]
+	if view == ViewProjects && id != "" {
+		m.selectedProj = id
+		view = ViewProjectDetail
+	}
+	m.view = view
+	m.showWelcome = false
+
+	m.search = searchState{term: hit.Match, view: view}
+	m.updateViewport()
+	if len(m.search.matches) == 0 {
+		// Cut off where the page truncates it; show the page anyway
+		m.search = searchState{}
+		m.updateViewport()
+		m.viewport.GotoTop()
+		return
+	}
+	m.search.active = true
+	m.search.current = matchNear(m.viewLines, m.search.matches, hit.Snippet)
+	m.updateViewport()
+}
+
+// matchNear picks the match whose line reads like the hit's snippet, as
+// the term may turn up elsewhere on the page too, or else the first
+func matchNear(lines []string, matches []searchMatch, snippet string) int {
+	want := strings.ToLower(strings.TrimPrefix(snippet, "…"))
+	for i, mt := range matches {
+		after := ansi.Strip(ansi.Cut(lines[mt.line], mt.start, mt.start+24))
+		after = strings.ToLower(strings.TrimSpace(strings.TrimRight(after, " │…")))
+		if after != "" && strings.Contains(want, after) {
+			return i
+		}
+	}
+	return 0
+}
+
+// findData is the view as the ui package draws it
+func (m Model) findData() ui.FindData {
+	return ui.FindData{Term: m.find.term, Hits: m.find.hits, Selected: m.find.selected}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+)
+
+// maxFollowUps caps the suggestion chips shown after an answer
+const maxFollowUps = 3
+
+// followUpState holds the suggested questions offered after an answer.
+// selected is the chip Tab has moved to, or -1.
+type followUpState struct {
+	items    []string
+	selected int
+}
+
+// suggestFollowUps picks questions related to the last user message,
+// skipping any the visitor has already asked
+func suggestFollowUps(history []ChatMessage) []string {
+	asked := make(map[string]bool)
+	last := ""
+	for _, msg := range history {
+		if msg.Role == "user" {
+			asked[strings.ToLower(msg.Content)] = true
+			last = msg.Content
+		}
+	}
+	if last == "" {
+		return nil
+	}
+
+	var items []string
+	for _, q := range ai.GenerateFollowUps(ai.DetectQueryIntent(last)) {
+		if asked[strings.ToLower(q)] {
+			continue
+		}
+		items = append(items, q)
+		if len(items) == maxFollowUps {
+			break
+		}
+	}
+	return items
+}
+
+// cycleFollowUp moves the chip selection forward, wrapping around
+func (m Model) cycleFollowUp() Model {
+	m.followUps.selected = (m.followUps.selected + 1) % len(m.followUps.items)
+	m.updateViewport()
+	return m
+}
+
+// sendFollowUp asks suggestion i as if it had been typed
+func (m Model) sendFollowUp(i int) (tea.Model, tea.Cmd) {
+	question := m.followUps.items[i]
+	m.followUps = followUpState{}
+	m.input.SetValue("")
+	return m.sendChatMessage(question)
+}
+
+// followUpsShown reports whether chips are on screen and can take keys
+func (m Model) followUpsShown() bool {
+	return m.view == ViewChat && !m.isStreaming && len(m.followUps.items) > 0 && m.input.Value() == ""
+}
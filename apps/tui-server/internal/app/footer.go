@@ -0,0 +1,258 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/layout"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// hint is a footer entry for a binding. Footers too narrow for every hint
+// drop the lowest priority ones first.
+type hint struct {
+	binding  key.Binding
+	color    func(theme.Styles) lipgloss.Style
+	priority int
+}
+
+func yellow(s theme.Styles) lipgloss.Style { return s.Yellow }
+func green(s theme.Styles) lipgloss.Style  { return s.Green }
+func orange(s theme.Styles) lipgloss.Style { return s.Orange }
+func neon(s theme.Styles) lipgloss.Style   { return s.Neon }
+func purple(s theme.Styles) lipgloss.Style { return s.Purple }
+func cyan(s theme.Styles) lipgloss.Style   { return s.Cyan }
+func red(s theme.Styles) lipgloss.Style    { return s.Red }
+
+// chatHints are shown in the chat view with nothing else going on
+var chatHints = []hint{
+	{keys.About, green, 7},
+	{keys.Projects, yellow, 7},
+	{keys.Experience, orange, 5},
+	{keys.Resume, neon, 6},
+	{keys.Help, purple, 9},
+	{keys.Palette, cyan, 8},
+	{keys.Back, cyan, 2},
+	{keys.Clear, cyan, 3},
+	{keys.Mouse, cyan, 1},
+	{keys.Quit, red, 2},
+}
+
+// viewHints are shown in the content views
+var viewHints = []hint{
+	{keys.Leave, yellow, 9},
+	{keys.Home, cyan, 7},
+	{keys.Help, purple, 8},
+	{keys.Palette, cyan, 6},
+	{keys.Back, cyan, 3},
+	{keys.Forward, cyan, 2},
+	{keys.Mouse, cyan, 1},
+}
+
+// projectHints are shown on a project's page
+var projectHints = []hint{
+	{keys.Leave, yellow, 9},
+	{keys.Bookmark, yellow, 7},
+	{keys.Home, cyan, 7},
+	{keys.Help, purple, 8},
+	{keys.Palette, cyan, 6},
+	{keys.Back, cyan, 3},
+	{keys.Forward, cyan, 2},
+	{keys.Mouse, cyan, 1},
+}
+
+// twoRowFooterHeight is the shortest terminal that gets a second footer
+// row when the hints don't fit on one
+const twoRowFooterHeight = 32
+
+// footerRows is how many rows the footer takes. It only depends on the
+// terminal size, so the viewport doesn't jump as the hints change.
+func (m Model) footerRows() int {
+	if m.height < twoRowFooterHeight {
+		return 1
+	}
+	width := layout.InnerWidth(m.columnWidth())
+	if hintsWidth(chatHints) <= width {
+		return 1
+	}
+	return 2
+}
+
+// renderFooter renders the footer's rows: an error or status, and the keys
+// that apply right now, trimmed to fit
+func (m Model) renderFooter(styles theme.Styles) []string {
+	rows := m.footerRows()
+	width := layout.InnerWidth(m.columnWidth())
+	prefix, hints := m.contextHints(styles)
+
+	var status string
+	if m.idleWarningShown() {
+		status = styles.Orange.Bold(true).Render("⏻ " + m.idleMessage())
+	} else if m.notice.kind != "" && m.errorMessage == m.notice.String() {
+		status = m.notice.render(styles)
+	} else if m.errorMessage != "" {
+		status = styles.Red.Bold(true).Render("⚠ ERR: " + m.errorMessage)
+	} else if m.statusMessage != "" {
+		status = styles.Green.Bold(true).Render("✓ " + m.statusMessage)
+	}
+	if status != "" {
+		if rows == 1 {
+			return []string{status}
+		}
+		return append([]string{status}, layoutHints(styles, prefix, hints, width, 1)...)
+	}
+	lines := layoutHints(styles, prefix, hints, width, rows)
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// contextHints picks the footer's hints for what's on screen, with an
+// optional label that's always shown before them
+func (m Model) contextHints(styles theme.Styles) (string, []hint) {
+	switch {
+	case m.egg.kind != "":
+		return styles.Green.Render(strings.ToUpper(m.egg.kind)) + styles.Dim.Render(" any key to stop"), nil
+	case m.paste.text != "":
+		return styles.Orange.Render("PASTE") + styles.Dim.Render(" "+m.pasteSummary()),
+			[]hint{{keys.SendParts, yellow, 2}, {keys.Trim, yellow, 1}}
+	case m.edit.active:
+		return styles.Cyan.Render("EDITING") + styles.Dim.Render(" last message"),
+			[]hint{{keys.Resend, yellow, 2}, {keys.Cancel, yellow, 1}}
+	case m.search.active:
+		return styles.Cyan.Render("SEARCH ") + styles.Highlight.Render(textutil.Truncate(m.search.term, 20)) +
+				styles.Dim.Render(" "+m.search.counter()),
+			[]hint{{keys.NextMatch, yellow, 3}, {keys.PrevMatch, yellow, 2}, {keys.Close, yellow, 1}}
+	case m.helpOpen:
+		return styles.Purple.Render("HELP"), []hint{{keys.Close, yellow, 1}}
+	case m.palette.open:
+		return styles.Cyan.Render("^K") + styles.Dim.Render(" command palette"), []hint{{keys.Close, yellow, 1}}
+	case m.isStreaming:
+		return ui.Spinner(styles, m.themeManager.Palette().Spinner, m.anim()) + " " + ui.Shimmer(styles, "streaming", m.anim()),
+			[]hint{{keys.Abort, yellow, 1}}
+	case m.view == ViewSnake:
+		if m.snake.over {
+			return styles.Red.Render("GAME OVER"), []hint{{keys.Restart, yellow, 2}, {keys.Leave, yellow, 1}}
+		}
+		return styles.Green.Render("SNAKE"), []hint{{keys.Steer, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewQuiz && len(m.quiz.questions) > 0:
+		q := m.quiz
+		switch {
+		case q.done:
+			return styles.Purple.Render("QUIZ") + styles.Dim.Render(fmt.Sprintf(" %d/%d", q.score, len(q.questions))),
+				[]hint{{keys.Restart, yellow, 2}, {keys.Leave, yellow, 1}}
+		case q.picked < 0:
+			return styles.Purple.Render("QUIZ") + styles.Dim.Render(fmt.Sprintf(" %d of %d", q.current+1, len(q.questions))),
+				[]hint{{keys.Answer, yellow, 2}, {keys.Leave, yellow, 1}}
+		}
+		return styles.Purple.Render("QUIZ") + styles.Dim.Render(fmt.Sprintf(" %d of %d", q.current+1, len(q.questions))),
+			[]hint{{keys.NextQuestion, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewActivity && len(m.activity.calendar.Weeks) > 0:
+		return styles.Green.Render("ACTIVITY"), []hint{{keys.Week, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewProjects && m.projectList.cursor >= 0:
+		return styles.Yellow.Render("FILTER"), []hint{{keys.Chips, yellow, 3}, {keys.ToggleChip, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewFind && len(m.find.hits) > 0:
+		return styles.Cyan.Render("FIND"), []hint{{keys.Hits, yellow, 3}, {keys.OpenHit, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewFeedback && m.feedback.sent < maxFeedbackPerSession:
+		return styles.Green.Render("FEEDBACK"), []hint{{keys.Stars, yellow, 3}, {keys.SendFeedback, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewAchievements && m.achievementPages() > 1:
+		return styles.Green.Render("ACHIEVEMENTS") + styles.Dim.Render(fmt.Sprintf(" %d/%d", m.achievementPage+1, m.achievementPages())),
+			[]hint{{keys.Pages, yellow, 2}, {keys.Leave, yellow, 1}}
+	case m.view == ViewProjectDetail:
+		return "", m.withLinkHint(projectHints)
+	case m.view != ViewChat:
+		return "", m.withLinkHint(viewHints)
+	}
+	return "", chatHints
+}
+
+var hintSeparator = " │ "
+
+// renderHint renders a binding as "KEY desc"
+func renderHint(styles theme.Styles, h hint) string {
+	help := h.binding.Help()
+	return h.color(styles).Render(help.Key) + styles.Dim.Render(" "+help.Desc)
+}
+
+// hintsWidth is the width of hints laid out on a single row
+func hintsWidth(hints []hint) int {
+	width := 0
+	for i, h := range hints {
+		if i > 0 {
+			width += lipgloss.Width(hintSeparator)
+		}
+		help := h.binding.Help()
+		width += lipgloss.Width(help.Key) + 1 + lipgloss.Width(help.Desc)
+	}
+	return width
+}
+
+// layoutHints flows the prefix and hints over up to rows rows of width,
+// dropping the least important hints until the rest fit
+func layoutHints(styles theme.Styles, prefix string, hints []hint, width, rows int) []string {
+	kept := append([]hint(nil), hints...)
+	for {
+		if lines, ok := flowHints(styles, prefix, kept, width, rows); ok || len(kept) == 0 {
+			return lines
+		}
+		kept = dropLeastImportant(kept)
+	}
+}
+
+// flowHints fills rows left to right, reporting whether every hint fit
+func flowHints(styles theme.Styles, prefix string, hints []hint, width, rows int) ([]string, bool) {
+	sep := styles.Dim.Render(hintSeparator)
+	var lines []string
+	var line strings.Builder
+	used := 0
+	if prefix != "" {
+		line.WriteString(prefix)
+		used = lipgloss.Width(prefix)
+	}
+	for _, h := range hints {
+		item := renderHint(styles, h)
+		itemWidth := lipgloss.Width(item)
+		if used > 0 && used+lipgloss.Width(hintSeparator)+itemWidth > width {
+			lines = append(lines, line.String())
+			if len(lines) == rows {
+				return lines, false
+			}
+			line.Reset()
+			used = 0
+		}
+		if used > 0 {
+			line.WriteString(sep)
+			used += lipgloss.Width(hintSeparator)
+		}
+		line.WriteString(item)
+		used += itemWidth
+	}
+	lines = append(lines, line.String())
+	return lines, used <= width
+}
+
+// dropLeastImportant removes the lowest priority hint, the last one on
+// ties, keeping the others in order
+func dropLeastImportant(hints []hint) []hint {
+	order := make([]int, len(hints))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return hints[order[a]].priority < hints[order[b]].priority
+	})
+	drop := order[0]
+	for _, i := range order {
+		if hints[i].priority != hints[drop].priority {
+			break
+		}
+		drop = i
+	}
+	return append(hints[:drop:drop], hints[drop+1:]...)
+}
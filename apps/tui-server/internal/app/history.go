@@ -0,0 +1,176 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// maxNavHistory bounds the back and forward stacks
+const maxNavHistory = 50
+
+// location is a place the visitor can navigate to; project is set only
+// for ViewProjectDetail
+type location struct {
+	view    View
+	project string
+}
+
+// navHistory is the browser-style back/forward stack. Esc and Alt+← go
+// back, Alt+→ goes forward, and any other view change clears forward.
+type navHistory struct {
+	back    []location
+	forward []location
+	// moving is set while back/forward change the view, so the change
+	// isn't recorded as a new visit
+	moving bool
+}
+
+// here is the current location
+func (m Model) here() location {
+	if m.view == ViewProjectDetail {
+		return location{view: m.view, project: m.selectedProj}
+	}
+	return location{view: m.view}
+}
+
+// recordNavigation pushes prev onto the back stack if the view changed
+// since, unless the change was a back or forward step
+func (m *Model) recordNavigation(prev location) {
+	if m.nav.moving {
+		m.nav.moving = false
+		return
+	}
+	if m.here() == prev {
+		return
+	}
+	m.nav.back = pushLocation(m.nav.back, prev)
+	m.nav.forward = nil
+}
+
+// goBack returns to the previous view, or to chat when there is none
+func (m *Model) goBack() {
+	if len(m.nav.back) == 0 {
+		if m.view != ViewChat {
+			m.nav.forward = pushLocation(m.nav.forward, m.here())
+			m.goTo(location{view: ViewChat})
+		}
+		return
+	}
+	prev := m.nav.back[len(m.nav.back)-1]
+	m.nav.back = m.nav.back[:len(m.nav.back)-1]
+	m.nav.forward = pushLocation(m.nav.forward, m.here())
+	m.goTo(prev)
+}
+
+// goForward redoes the last goBack
+func (m *Model) goForward() {
+	if len(m.nav.forward) == 0 {
+		return
+	}
+	next := m.nav.forward[len(m.nav.forward)-1]
+	m.nav.forward = m.nav.forward[:len(m.nav.forward)-1]
+	m.nav.back = pushLocation(m.nav.back, m.here())
+	m.goTo(next)
+}
+
+// goTo shows loc without recording it as a new visit
+func (m *Model) goTo(loc location) {
+	if loc.view == ViewProjectDetail && (m.projects == nil || m.projects.GetProjectByID(loc.project) == nil) {
+		loc = location{view: ViewProjects}
+	}
+
+	oldView := m.view
+	m.nav.moving = true
+	m.view = loc.view
+	m.selectedProj = loc.project
+	m.showWelcome = loc.view == ViewChat && len(m.chatHistory) == 0
+
+	if m.view != oldView && m.analytics != nil {
+		m.analytics.TrackViewChanged(m.sessionID, viewName(oldView), viewName(m.view))
+	}
+
+	m.updateViewport()
+}
+
+// pushLocation appends loc, dropping the oldest entry past maxNavHistory
+// and collapsing immediate repeats
+func pushLocation(stack []location, loc location) []location {
+	if n := len(stack); n > 0 && stack[n-1] == loc {
+		return stack
+	}
+	stack = append(stack, loc)
+	if len(stack) > maxNavHistory {
+		stack = stack[len(stack)-maxNavHistory:]
+	}
+	return stack
+}
+
+// locationLabel is the header name and color of a location
+func (m Model) locationLabel(styles theme.Styles, loc location) (string, lipgloss.Style) {
+	switch loc.view {
+	case ViewChat:
+		return "NEURAL_LINK", styles.Green
+	case ViewAbout:
+		return "PROFILE", styles.Cyan
+	case ViewProjects:
+		return "PROJECTS", styles.Yellow
+	case ViewProjectDetail:
+		if m.projects != nil {
+			if project := m.projects.GetProjectByID(loc.project); project != nil {
+				return textutil.Truncate(strings.ToUpper(project.Name), 16), styles.Yellow
+			}
+		}
+		return "PROJECT", styles.Yellow
+	case ViewResume:
+		return "CREDENTIALS", styles.Neon
+	case ViewExperience:
+		return "EXPERIENCE", styles.Orange
+	case ViewSnake:
+		return "SNAKE", styles.Green
+	case ViewQuiz:
+		return "QUIZ", styles.Purple
+	case ViewStats:
+		return "STATS", styles.Cyan
+	case ViewCard:
+		return "CARD", styles.Neon
+	case ViewActivity:
+		return "ACTIVITY", styles.Green
+	case ViewUses:
+		return "USES", styles.Purple
+	case ViewTalks:
+		return "TALKS", styles.Cyan
+	case ViewCerts:
+		return "CERTS", styles.Green
+	case ViewWhatsNew:
+		return "WHAT'S NEW", styles.Yellow
+	case ViewFind:
+		return "FIND", styles.Cyan
+	case ViewBookmarks:
+		return "BOOKMARKS", styles.Yellow
+	case ViewFeedback:
+		return "FEEDBACK", styles.Green
+	case ViewAchievements:
+		return "ACHIEVEMENTS", styles.Green
+	}
+	return "", styles.Muted
+}
+
+// breadcrumb renders the header's "[PROJECTS › ECHO]" trail: up to depth
+// previous locations, then the current one in bold
+func (m Model) breadcrumb(styles theme.Styles, depth int) string {
+	trail := m.nav.back[max(len(m.nav.back)-depth, 0):]
+
+	var b strings.Builder
+	b.WriteString(styles.Yellow.Render("["))
+	for _, loc := range trail {
+		label, _ := m.locationLabel(styles, loc)
+		b.WriteString(styles.Dim.Render(label + " › "))
+	}
+	label, style := m.locationLabel(styles, m.here())
+	b.WriteString(style.Bold(true).Render(label))
+	b.WriteString(styles.Yellow.Render("]"))
+	return b.String()
+}
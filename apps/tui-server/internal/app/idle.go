@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleWarning is how long before an idle disconnect the countdown shows
+const idleWarning = 60 * time.Second
+
+// noteActivity restarts the idle countdown on any key press or mouse use
+func (m *Model) noteActivity(msg tea.Msg) {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		m.lastInput = time.Now()
+	}
+}
+
+// idleRemaining is the time left before an idle disconnect
+func (m Model) idleRemaining() time.Duration {
+	return m.idleTimeout - m.now.Sub(m.lastInput)
+}
+
+// idleWarningShown reports whether the disconnect countdown is showing
+func (m Model) idleWarningShown() bool {
+	return m.idleTimeout > 0 && !m.quitting && m.idleRemaining() <= idleWarning
+}
+
+// idleSeconds is the countdown in whole seconds, never below zero
+func (m Model) idleSeconds() int {
+	return max(int((m.idleRemaining()+time.Second-1)/time.Second), 0)
+}
+
+// checkIdle runs on each clock tick, closing the session once it has been
+// idle for idleTimeout. A streaming reply counts as activity.
+func (m Model) checkIdle() (Model, tea.Cmd) {
+	if m.idleTimeout <= 0 || m.quitting {
+		return m, nil
+	}
+	if m.isStreaming {
+		m.lastInput = m.now
+	}
+	if m.idleRemaining() > 0 {
+		return m, nil
+	}
+	m.quitting = true
+	m.idleQuit = true
+	return m, quitAfter(1500 * time.Millisecond)
+}
+
+// idleMessage is the countdown toast text
+func (m Model) idleMessage() string {
+	if m.themeManager.Accessible() {
+		return fmt.Sprintf("Idle: disconnecting in %d seconds. Press any key to stay connected.", m.idleSeconds())
+	}
+	return fmt.Sprintf("disconnecting in %ds — press any key", m.idleSeconds())
+}
@@ -0,0 +1,26 @@
+package app
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// handleVimKey applies the vim keymap in content views while the input is
+// empty; keys it handles are not typed into the input
+func (m Model) handleVimKey(msg tea.KeyMsg) (Model, bool) {
+	if m.keymap != "vim" || m.view == ViewChat || m.input.Value() != "" {
+		return m, false
+	}
+	switch msg.String() {
+	case "j":
+		m.viewport.ScrollDown(1)
+	case "k":
+		m.viewport.ScrollUp(1)
+	case "g":
+		m.viewport.GotoTop()
+	case "G":
+		m.viewport.GotoBottom()
+	case "h":
+		m.goBack()
+	default:
+		return m, false
+	}
+	return m, true
+}
@@ -0,0 +1,152 @@
+package app
+
+import "github.com/charmbracelet/bubbles/key"
+
+// keyMap lists the keyboard shortcuts. Update matches keys against it and
+// the footer builds its hints from it, so the two can't drift apart.
+type keyMap struct {
+	// Global shortcuts, active whenever no overlay is open
+	Palette    key.Binding
+	Back       key.Binding
+	Forward    key.Binding
+	PrevTab    key.Binding
+	NextTab    key.Binding
+	Mouse      key.Binding
+	Help       key.Binding
+	About      key.Binding
+	Projects   key.Binding
+	Resume     key.Binding
+	Experience key.Binding
+	Home       key.Binding
+	Clear      key.Binding
+	Quit       key.Binding
+
+	// The welcome screen, while the input is empty
+	OpenFeatured key.Binding
+
+	// The last answer in the chat, while the input is empty
+	RateUp   key.Binding
+	RateDown key.Binding
+
+	// Keys whose meaning depends on what's on screen
+	Leave     key.Binding // esc out of a view
+	Abort     key.Binding // esc while a reply streams
+	Close     key.Binding // esc out of an overlay or search
+	Cancel    key.Binding // esc out of an edit
+	Resend    key.Binding // enter while editing
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	SendParts key.Binding // enter with a long paste held
+	Trim      key.Binding // esc with a long paste held
+
+	// The /snake game, while the input is empty
+	SnakeUp    key.Binding
+	SnakeDown  key.Binding
+	SnakeLeft  key.Binding
+	SnakeRight key.Binding
+	Steer      key.Binding // all four, for the footer hint
+	Restart    key.Binding
+
+	// The /quiz round, while the input is empty
+	Answer       key.Binding // the number keys, for the footer hint
+	NextQuestion key.Binding
+
+	// The /activity heatmap, while the input is empty
+	WeekPrev key.Binding
+	WeekNext key.Binding
+	Week     key.Binding // both, for the footer hint
+
+	// A project's page, while the input is empty
+	Bookmark key.Binding
+
+	// The links listed under a view, while the input is empty
+	CopyLink key.Binding // the number keys
+
+	// The /achievements pages, while the input is empty
+	PagePrev key.Binding
+	PageNext key.Binding
+	Pages    key.Binding // both, for the footer hint
+
+	// The /feedback form; Stars while the input is empty
+	Stars        key.Binding // the number keys, for the footer hint
+	SendFeedback key.Binding
+
+	// The /find results, while the input is empty
+	HitPrev key.Binding
+	HitNext key.Binding
+	Hits    key.Binding // both, for the footer hint
+	OpenHit key.Binding
+
+	// The projects list's filter chips, while the input is empty
+	ChipPrev   key.Binding
+	ChipNext   key.Binding
+	Chips      key.Binding // both, for the footer hint
+	ToggleChip key.Binding
+}
+
+var keys = keyMap{
+	Palette:    key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("^K", "palette")),
+	Back:       key.NewBinding(key.WithKeys("alt+left"), key.WithHelp("alt+←", "back")),
+	Forward:    key.NewBinding(key.WithKeys("alt+right"), key.WithHelp("alt+→", "forward")),
+	PrevTab:    key.NewBinding(key.WithKeys("ctrl+pgup"), key.WithHelp("^PgUp", "prev tab")),
+	NextTab:    key.NewBinding(key.WithKeys("ctrl+pgdown"), key.WithHelp("^PgDn", "next tab")),
+	Mouse:      key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("^S", "mouse")),
+	Help:       key.NewBinding(key.WithKeys("ctrl+h", "ctrl+/"), key.WithHelp("^H", "help")),
+	About:      key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("^A", "about")),
+	Projects:   key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("^P", "projects")),
+	Resume:     key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("^R", "resume")),
+	Experience: key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("^E", "exp")),
+	Home:       key.NewBinding(key.WithKeys("ctrl+w"), key.WithHelp("^W", "home")),
+	Clear:      key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("^L", "clear")),
+	Quit:       key.NewBinding(key.WithKeys("ctrl+q"), key.WithHelp("^Q", "quit")),
+
+	OpenFeatured: key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "open featured")),
+
+	RateUp:   key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "helpful")),
+	RateDown: key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "not helpful")),
+
+	Leave:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("ESC", "back")),
+	Abort:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("ESC", "abort")),
+	Close:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("ESC", "close")),
+	Cancel:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("ESC", "cancel")),
+	Resend:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "resend")),
+	NextMatch: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next")),
+	PrevMatch: key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "prev")),
+	SendParts: key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "send in parts")),
+	Trim:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("ESC", "trim")),
+
+	SnakeUp:    key.NewBinding(key.WithKeys("up", "w", "k")),
+	SnakeDown:  key.NewBinding(key.WithKeys("down", "s", "j")),
+	SnakeLeft:  key.NewBinding(key.WithKeys("left", "a", "h")),
+	SnakeRight: key.NewBinding(key.WithKeys("right", "d", "l")),
+	Steer:      key.NewBinding(key.WithKeys("up", "down", "left", "right"), key.WithHelp("←↑↓→", "steer")),
+	Restart:    key.NewBinding(key.WithKeys("r", " "), key.WithHelp("r", "restart")),
+
+	Answer:       key.NewBinding(key.WithKeys("1", "2", "3", "4"), key.WithHelp("1-4", "answer")),
+	NextQuestion: key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "next")),
+
+	WeekPrev: key.NewBinding(key.WithKeys("left")),
+	WeekNext: key.NewBinding(key.WithKeys("right")),
+	Week:     key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "week")),
+
+	Bookmark: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "bookmark")),
+
+	CopyLink: key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "copy link")),
+
+	PagePrev: key.NewBinding(key.WithKeys("left")),
+	PageNext: key.NewBinding(key.WithKeys("right")),
+	Pages:    key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "page")),
+
+	Stars:        key.NewBinding(key.WithKeys("1", "2", "3", "4", "5"), key.WithHelp("1-5", "rate")),
+	SendFeedback: key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "send")),
+
+	HitPrev: key.NewBinding(key.WithKeys("up")),
+	HitNext: key.NewBinding(key.WithKeys("down")),
+	Hits:    key.NewBinding(key.WithKeys("up", "down"), key.WithHelp("↑/↓", "select")),
+	OpenHit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "open")),
+
+	ChipPrev:   key.NewBinding(key.WithKeys("left")),
+	ChipNext:   key.NewBinding(key.WithKeys("right")),
+	Chips:      key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "filter")),
+	ToggleChip: key.NewBinding(key.WithKeys("enter"), key.WithHelp("ENTER", "toggle")),
+}
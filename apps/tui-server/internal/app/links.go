@@ -0,0 +1,93 @@
+package app
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// linkState is the links listed under the content on screen, and the
+// one last copied, which the list writes out in full
+type linkState struct {
+	urls   []string
+	copied string
+}
+
+// answerLinks are the links in the last answer, once it has finished
+func (m Model) answerLinks() []string {
+	last := len(m.chatHistory) - 1
+	if m.isStreaming || last < 0 {
+		return nil
+	}
+	if msg := m.chatHistory[last]; msg.Role == "assistant" && !msg.Superseded {
+		return ui.MarkdownLinks(msg.Content)
+	}
+	return nil
+}
+
+// pageLinks are the OSC 8 hyperlinks in a view's content, each once, in
+// order
+func pageLinks(content string) []string {
+	var links []string
+	for _, match := range hyperlinkPattern.FindAllStringSubmatch(content, -1) {
+		if link := match[1]; link != "" && !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// linkKeys reports whether the number keys copy links: in views that
+// don't take them for something else, while the input is empty
+func (m Model) linkKeys() bool {
+	if len(m.links.urls) == 0 || m.input.Value() != "" {
+		return false
+	}
+	switch m.view {
+	case ViewChat, ViewProjects, ViewBookmarks, ViewQuiz, ViewFeedback, ViewSnake:
+		return false
+	}
+	return true
+}
+
+// withLinkHint adds the number keys to hints when they copy links
+func (m Model) withLinkHint(hints []hint) []hint {
+	if !m.linkKeys() {
+		return hints
+	}
+	return append(slices.Clone(hints), hint{keys.CopyLink, yellow, 4})
+}
+
+// linkList is the numbered list of links to put under the content
+func (m Model) linkList() string {
+	return ui.LinkList(m.themeManager.Styles(), m.links.urls, m.links.copied, m.linkKeys(), m.viewport.Width)
+}
+
+// handleLink runs /link: copy the link numbered in the list
+func (m Model) handleLink(args []string) (tea.Model, tea.Cmd) {
+	n, err := strconv.Atoi(args[0])
+	switch {
+	case len(m.links.urls) == 0:
+		m.errorMessage = "No links on screen"
+	case err != nil || n < 1 || n > len(m.links.urls):
+		m.errorMessage = "Usage: /link [1-" + strconv.Itoa(len(m.links.urls)) + "]"
+	default:
+		return m.copyLink(n - 1)
+	}
+	m.updateViewport()
+	return m, nil
+}
+
+// copyLink puts link i on the visitor's clipboard with OSC 52, and writes
+// it out in full in the list for terminals that don't support that
+func (m Model) copyLink(i int) (tea.Model, tea.Cmd) {
+	link := m.links.urls[i]
+	m.clipboard = link
+	m.links.copied = link
+	m.statusMessage = "Copied " + link
+	m.updateViewport()
+	return m, clearStatusAfter(2 * time.Second)
+}
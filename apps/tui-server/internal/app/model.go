@@ -2,19 +2,27 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/availability"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/layout"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/status"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
 )
 
 // View represents an overlay view (when not chatting)
@@ -22,18 +30,46 @@ type View int
 
 const (
 	ViewChat View = iota
-	ViewHelp
 	ViewAbout
 	ViewProjects
 	ViewProjectDetail
 	ViewResume
 	ViewExperience
+	ViewSnake
+	ViewQuiz
+	ViewStats
+	ViewCard
+	ViewActivity
+	ViewUses
+	ViewTalks
+	ViewCerts
+	ViewWhatsNew
+	ViewFind
+	ViewBookmarks
+	ViewFeedback
+	ViewAchievements
 )
 
+// chromeHeight is the number of rows used by the frame around the viewport
+// with a one-row footer: header (3) + tab bar (1) + footer (5)
+const chromeHeight = 9
+
 // ChatMessage represents a message in the chat history
 type ChatMessage struct {
 	Role    string
 	Content string
+	Time    time.Time
+	// Superseded marks an answer replaced by /regen; it is shown
+	// collapsed and no longer sent to the AI as history
+	Superseded bool
+	// FAQ marks an answer served from the local FAQ instead of the AI
+	FAQ bool
+	// Truncated marks an answer cut off at the length cap
+	Truncated bool
+	// Part numbers a message sent as one of several, like "2/3"
+	Part string
+	// Rating is "up" or "down" once the visitor rated the answer
+	Rating string
 }
 
 // Model is the main Bubble Tea model
@@ -46,40 +82,129 @@ type Model struct {
 	resume   *content.Resume
 	projects *content.Projects
 	bio      string
+	uses     string
+	talks    *content.Talks
+	certs    *content.Certifications
+	faq      *content.FAQ
+	digest   content.Digest
+	whatsNew whatsNewState
+	index    content.Index
+	find     findState
+	feedback feedbackState
+
+	contentVersion string // digest's Version, hashed once for the session
+
+	projectList     projectsState
+	bookmarks       []string // project IDs, in the order bookmarked
+	achievementPage int      // the /achievements page on screen, from 0
 
 	view          View
 	selectedProj  string
 	errorMessage  string
+	notice        errorNotice // how errorMessage is shown, while it's the notice's
 	statusMessage string
 
 	input    textinput.Model
 	viewport viewport.Model
 
-	aiService    ai.ChatService
-	chatHistory  []ChatMessage
-	chatResponse *strings.Builder
-	isStreaming  bool
-	sessionID    string
-	showWelcome  bool
-	streamCtx    context.Context
-	streamCancel context.CancelFunc
-	streamMu     *sync.Mutex
-	chunkChan    chan string
-	errChan      chan error
-
-	mouseEnabled bool
-	quitting     bool
-	startupPhase int // 0=connecting, 1=syncing, 2=online
-	analytics    Analytics
+	aiService      ai.ChatService
+	chatHistory    []ChatMessage
+	chatResponse   *strings.Builder
+	md             *ui.MarkdownRenderer // finished messages, reused across renders
+	streamMD       *ui.MarkdownRenderer // keeps its block cache across chunks
+	glamour        *ui.GlamourRenderer  // created on first use of /set renderer glamour
+	stylesGen      int                  // theme generation the renderers were given
+	renderCache    *chatRenderCache
+	router         router // per-view scroll and render memory
+	mdBackend      string // "builtin" or "glamour" for finished messages
+	timestamps     string // "on", "relative" or "off"
+	keymap         string // "default" or "vim"
+	language       string // reply language code, see ai.ReplyLanguages
+	conversation   string // ID the gateway keeps the chat under, see /conversation
+	conversations  bool   // whether the gateway keeps them
+	restarted      bool   // the next request starts conversation afresh
+	isStreaming    bool
+	sessionID      string
+	showWelcome    bool
+	greeting       string // welcome-back line for returning visitors
+	visitorNum     int
+	recordingsDir  string
+	scpPrefix      string
+	readOnly       bool
+	liveSessions   func() int
+	counters       Counters
+	activitySource Activity
+	statusSource   StatusSource
+	feedbackSink   FeedbackSink
+	sharer         Sharer
+	shares         int // transcripts this session shared
+	broadcast      func(text string) int
+	announcement   announcement
+	graphics       graphics.Protocol
+	screenshots    map[string][]*graphics.Picture
+	ownerStatus    status.Status // for the welcome screen, once fetched
+	clock          *availability.Clock
+	live           int // refreshed by ClockTickMsg
+	streamCancel   context.CancelFunc
+	streamMu       *sync.Mutex
+	streamID       int // identifies the current stream in Stream*Msg and AnimTickMsg
+	send           func(tea.Msg)
+	ctx            context.Context
+
+	maxResponseLength int // characters; see Config.MaxResponseLength
+
+	prefs     prefsState
+	snap      snapshotState
+	palette   paletteState
+	nav       navHistory
+	viewLines []string // viewport content as last rendered, for hit-testing
+	hover     hotspot  // hotspot under the pointer, while hovering
+	hovering  bool
+	clipboard string        // sent to the terminal via OSC 52 until the status clears
+	links     linkState     // links listed under the content, for /link
+	recorder  *castRecorder // set while /record captures frames
+	search    searchState
+	edit      editState
+	paste     pasteState
+	egg       eggState
+	snake     snakeState
+	quiz      quizState
+	stats     statsState
+	activity  activityState
+	archive   archiveState
+	followUps followUpState
+	tour      tourState
+	helpOpen  bool
+
+	mouseEnabled  bool
+	reducedMotion bool
+	background    bool // paint the palette background on every cell
+	maxWidth      int  // widest the layout column gets; 0 for no limit
+	animFrame     int  // advanced by AnimTickMsg while streaming
+	introFrame    int  // banner glitch intro progress, up to ui.IntroFrames
+	quitting      bool
+	resizing      bool // a resize is settling; View shows a placeholder
+	resizeID      int
+	startupPhase  int // 0=connecting, 1=syncing, 2=online
+	analytics     Analytics
+
+	now          time.Time // refreshed by ClockTickMsg
+	lastInput    time.Time // last key or mouse event, for the idle timeout
+	idleTimeout  time.Duration
+	idleQuit     bool // the session is closing for inactivity
+	sessionStart time.Time
+	serverStart  time.Time
 }
 
 // Analytics interface for tracking events
 type Analytics interface {
 	TrackViewChanged(sessionID string, fromView, toView string)
 	TrackCommandExecuted(sessionID string, command string)
+	TrackEasterEgg(sessionID string, egg string)
 	TrackChatSent(sessionID string, messageLength int)
 	TrackChatReceived(sessionID string, responseLength int, durationMs int64)
 	TrackChatError(sessionID string, errorMsg string)
+	TrackAnswerRated(sessionID string, question, answer, rating string, faq bool)
 }
 
 // Config holds initialization options
@@ -88,11 +213,94 @@ type Config struct {
 	Resume       *content.Resume
 	Projects     *content.Projects
 	Bio          string
-	AIService    ai.ChatService
-	SessionID    string
-	Width        int
-	Height       int
-	Analytics    Analytics
+	Uses         string
+	Talks        *content.Talks
+	Certs        *content.Certifications
+	FAQ          *content.FAQ
+	// Digest describes the content for /whatsnew, which compares it with
+	// the version a returning visitor saw; empty turns it off
+	Digest      content.Digest
+	AIService   ai.ChatService
+	SessionID   string
+	Width       int
+	Height      int
+	Analytics   Analytics
+	ServerStart time.Time
+
+	// Send delivers messages to the running program from background
+	// goroutines; AI replies stream through it
+	Send func(tea.Msg)
+	// Context is the session context; in-flight streams stop when it ends
+	Context context.Context
+
+	// ReducedMotion disables spinners and other animations
+	ReducedMotion bool
+	// Accessible starts the session in linear, screen-reader friendly mode
+	Accessible bool
+	// ASCII renders with the ASCII glyph set for non-UTF-8 terminals
+	ASCII bool
+	// Graphics is how the terminal draws pictures; see graphics.Detect
+	Graphics graphics.Protocol
+	// MaxResponseLength caps AI replies in characters; longer ones are
+	// cut off and can be resumed with /continue
+	MaxResponseLength int
+	// InitialRoute opens a view on connect, e.g. "resume" or
+	// "projects/mohak-tui"; see openRoute
+	InitialRoute string
+	// Store keeps /set choices and visit counts between sessions under
+	// VisitorKey, usually PublicKeyVisitorKey; visitors without a key
+	// can /login to save preferences
+	Store      Store
+	VisitorKey string
+	// VisitorNumber is this connection's place in the all-time visit
+	// count; zero hides it
+	VisitorNumber int
+	// LiveSessions counts sessions open across every server process;
+	// nil hides the count
+	LiveSessions func() int
+	// Counters keeps the all-time totals /stats shows; nil leaves them
+	// at zero
+	Counters Counters
+	// Activity supplies the GitHub calendar /activity draws; nil
+	// disables the command
+	Activity Activity
+	// Status supplies the now-playing or status line on the welcome
+	// screen, fetched as the session starts; nil leaves it out
+	Status StatusSource
+	// Clock tells Mohak's local time for the welcome and contact card
+	// screens; nil leaves it out
+	Clock *availability.Clock
+	// RecordingsDir is where /record writes asciinema casts; empty
+	// disables recording
+	RecordingsDir string
+	// SCPPrefix is the download command a cast's file name is appended
+	// to, e.g. "scp -P 2222 bmohak.xyz:"
+	SCPPrefix string
+	// IdleTimeout disconnects sessions without key or mouse input for
+	// this long, after a one-minute countdown; zero disables it
+	IdleTimeout time.Duration
+	// MaxWidth caps the layout column; wider terminals get it centered.
+	// Zero uses the full width.
+	MaxWidth int
+	// ResumeWindow restores the view, scroll position and chat of the
+	// visitor's last session under VisitorKey if it dropped within this
+	// long; zero disables resuming
+	ResumeWindow time.Duration
+	// ReadOnly limits the session to browsing, for visitors outside the
+	// allowlist: no AI chat, /record or /login, and settings aren't saved
+	ReadOnly bool
+	// Feedback takes /feedback submissions; nil disables the command
+	Feedback FeedbackSink
+	// Share publishes /share transcripts; nil disables the command
+	Share Sharer
+	// Broadcast announces text to every session and returns how many
+	// there are. Only the operator's sessions get it, for /broadcast.
+	Broadcast func(text string) int
+	// Conversations is set when the AI gateway keeps chat histories, so
+	// /conversation can continue a chat from another session
+	Conversations bool
+	// Screenshots are the projects' pictures by project ID
+	Screenshots map[string][]*graphics.Picture
 }
 
 // NewModel creates a new app model
@@ -102,51 +310,159 @@ func NewModel(cfg Config) Model {
 
 	input := textinput.New()
 	input.Prompt = ""
-	input.Placeholder = "enter command or chat..."
+	input.Placeholder = defaultPlaceholder
 	input.Focus()
-	input.CharLimit = 1000
-	input.Width = max(width-8, 20)
+	input.CharLimit = inputLimit
+	input.Width = max(layout.InnerWidth(width)-4, 20)
 
-	// Header (3) + Footer (5) = 8 lines reserved
-	vp := viewport.New(max(width-4, 20), max(height-8, 8))
+	vp := viewport.New(0, 0)
 	vp.Style = lipgloss.NewStyle()
 
-	return Model{
+	now := time.Now()
+	serverStart := cfg.ServerStart
+	if serverStart.IsZero() {
+		serverStart = now
+	}
+
+	if cfg.Accessible {
+		cfg.ThemeManager.SetAccessible(true)
+	}
+	if cfg.ASCII {
+		cfg.ThemeManager.SetGlyphs(theme.ASCIIGlyphs)
+	}
+
+	ctx := cfg.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	maxResponseLength := cfg.MaxResponseLength
+	if maxResponseLength <= 0 {
+		maxResponseLength = defaultMaxResponseLength
+	}
+
+	introFrame := 0
+	if cfg.ReducedMotion {
+		introFrame = ui.IntroFrames
+	}
+
+	m := Model{
 		width:        width,
 		height:       height,
 		themeManager: cfg.ThemeManager,
 		resume:       cfg.Resume,
 		projects:     cfg.Projects,
 		bio:          cfg.Bio,
+		uses:         cfg.Uses,
+		talks:        cfg.Talks,
+		certs:        cfg.Certs,
+		digest:       cfg.Digest,
+		index:        content.NewIndex(cfg.Resume, cfg.Projects, cfg.Bio, cfg.Uses, cfg.Talks, cfg.Certs),
+		faq:          cfg.FAQ,
 		view:         ViewChat,
 		input:        input,
 		viewport:     vp,
+		palette:      paletteState{input: newPaletteInput()},
+		projectList:  projectsState{cursor: -1},
 		aiService:    cfg.AIService,
 		chatHistory:  make([]ChatMessage, 0),
 		chatResponse: &strings.Builder{},
+		md:           ui.NewMarkdownRenderer(cfg.ThemeManager.Styles()),
+		streamMD:     ui.NewMarkdownRenderer(cfg.ThemeManager.Styles()),
+		stylesGen:    cfg.ThemeManager.Generation(),
+		mdBackend:    "builtin",
+		timestamps:   "on",
+		keymap:       "default",
+		language:     "en",
+		renderCache:  &chatRenderCache{},
+		router:       newRouter(),
 		streamMu:     &sync.Mutex{},
 		sessionID:    cfg.SessionID,
 		showWelcome:  true,
 		mouseEnabled: true,
 		analytics:    cfg.Analytics,
+		send:         cfg.Send,
+		ctx:          ctx,
+
+		maxResponseLength: maxResponseLength,
+
+		reducedMotion:  cfg.ReducedMotion,
+		introFrame:     introFrame,
+		now:            now,
+		sessionStart:   now,
+		serverStart:    serverStart,
+		lastInput:      now,
+		idleTimeout:    cfg.IdleTimeout,
+		maxWidth:       cfg.MaxWidth,
+		prefs:          prefsState{store: cfg.Store},
+		visitorNum:     cfg.VisitorNumber,
+		liveSessions:   cfg.LiveSessions,
+		counters:       cfg.Counters,
+		activitySource: cfg.Activity,
+		contentVersion: cfg.Digest.Version(),
+		statusSource:   cfg.Status,
+		feedbackSink:   cfg.Feedback,
+		sharer:         cfg.Share,
+		broadcast:      cfg.Broadcast,
+		conversation:   ai.NewConversationID(),
+		conversations:  cfg.Conversations,
+		graphics:       cfg.Graphics,
+		screenshots:    cfg.Screenshots,
+		clock:          cfg.Clock,
+		recordingsDir:  cfg.RecordingsDir,
+		scpPrefix:      cfg.SCPPrefix,
+		readOnly:       cfg.ReadOnly,
+	}
+	// The footer's height depends on the column, known only now
+	m.viewport.Width = max(layout.InnerWidth(m.columnWidth()), 20)
+	m.viewport.Height = max(height-m.chromeRows(), 8)
+	m.refreshLive()
+	m.loadPrefs(cfg.VisitorKey, "your SSH key")
+	m.greeting = m.recordVisit(now)
+	if cfg.ResumeWindow > 0 && cfg.Store != nil && cfg.VisitorKey != "" && !cfg.ReadOnly && claimSnapshot(ctx, cfg.VisitorKey) {
+		m.snap = snapshotState{key: cfg.VisitorKey, window: cfg.ResumeWindow}
+		if m.restoreSnapshot() {
+			m.statusMessage = "Resumed where you left off"
+		}
 	}
+	m.openRoute(cfg.InitialRoute)
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		textinput.Blink,
 		tea.EnableBracketedPaste,
-		func() tea.Msg { return tea.EnableMouseCellMotion() },
+		m.mouseCmd(),
 		startupTick(), // Start the connection animation
-	)
+		clockTick(),
+	}
+	if !m.reducedMotion {
+		cmds = append(cmds, introTick())
+	}
+	if m.statusSource != nil {
+		cmds = append(cmds, m.fetchStatus())
+	}
+	if m.tour.active {
+		// Started by `ssh host tour`; begins once the intro has played
+		cmds = append(cmds, tourTick(m.tour.id, 1500*time.Millisecond))
+	}
+	return tea.Batch(cmds...)
 }
 
+// StreamChunkMsg carries a batch of streamed reply text. ID is the
+// stream it belongs to; chunks of an aborted stream are dropped.
 type StreamChunkMsg struct {
+	ID    int
 	Chunk string
 }
 
+// StreamDoneMsg ends the stream with the given ID. Truncated is set when
+// the reply was cut off at the length cap.
 type StreamDoneMsg struct {
-	Error error
+	ID        int
+	Error     error
+	Truncated bool
 }
 
 type ClearStatusMsg struct{}
@@ -155,6 +471,32 @@ type QuitMsg struct{}
 
 type StartupTickMsg struct{}
 
+// ClockTickMsg refreshes the header clock and timers once per second
+type ClockTickMsg struct {
+	Time time.Time
+}
+
+// ResizeSettledMsg applies a terminal resize once no newer
+// WindowSizeMsg has arrived for resizeDebounce
+type ResizeSettledMsg struct {
+	ID int
+}
+
+// resizeDebounce is how long the size must hold before re-rendering
+const resizeDebounce = 100 * time.Millisecond
+
+// IntroTickMsg advances the welcome banner glitch intro
+type IntroTickMsg struct{}
+
+// AnimTickMsg advances streaming animations. ID ties the tick to the
+// stream that started it so an aborted stream's loop dies out.
+type AnimTickMsg struct {
+	ID int
+}
+
+// animInterval is the frame time of streaming animations
+const animInterval = 100 * time.Millisecond
+
 func clearStatusAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return ClearStatusMsg{}
@@ -173,31 +515,147 @@ func startupTick() tea.Cmd {
 	})
 }
 
-func listenForChunks(ch <-chan string, errCh <-chan error) tea.Cmd {
-	return func() tea.Msg {
-		chunk, ok := <-ch
-		if !ok {
-			select {
-			case err := <-errCh:
-				return StreamDoneMsg{Error: err}
-			default:
-				return StreamDoneMsg{}
-			}
-		}
-		return StreamChunkMsg{Chunk: chunk}
-	}
+func clockTick() tea.Cmd {
+	return tea.Every(time.Second, func(t time.Time) tea.Msg {
+		return ClockTickMsg{Time: t}
+	})
+}
+
+func introTick() tea.Cmd {
+	// IntroFrames frames over roughly one second
+	return tea.Tick(60*time.Millisecond, func(t time.Time) tea.Msg {
+		return IntroTickMsg{}
+	})
+}
+
+func resizeSettle(id int) tea.Cmd {
+	return tea.Tick(resizeDebounce, func(t time.Time) tea.Msg {
+		return ResizeSettledMsg{ID: id}
+	})
 }
 
+func animTick(id int) tea.Cmd {
+	return tea.Tick(animInterval, func(t time.Time) tea.Msg {
+		return AnimTickMsg{ID: id}
+	})
+}
+
+// anim returns the animation state handed to renderers
+func (m Model) anim() ui.Anim {
+	return ui.Anim{Frame: m.animFrame, Reduced: m.reducedMotion || m.themeManager.Accessible()}
+}
+
+// Update handles msg and records any view change in the back stack
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev := m.here()
+	m.noteActivity(msg)
+	model, cmd := m.update(msg)
+	next := model.(Model)
+	next.recordNavigation(prev)
+	next.countProjectView(prev)
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Any key skips the intro and is then handled normally
+		if m.introFrame < ui.IntroFrames {
+			m.introFrame = ui.IntroFrames
+			m.updateViewport()
+		}
+		// Any key ends a running /matrix or /hack
+		if m.egg.kind != "" {
+			m.egg.kind = ""
+			if msg.Type == tea.KeyEsc {
+				return m, nil
+			}
+		}
+		// Any key also hands a running tour over to the visitor
+		if m.tour.active {
+			m = m.stopTour()
+			if msg.Type == tea.KeyEsc {
+				return m, nil
+			}
+		}
+		// Command palette captures all keys while open
+		if m.palette.open && msg.Type != tea.KeyCtrlC {
+			return m.updatePalette(msg)
+		}
+		// Help overlay is modal; dismiss it without touching the view below
+		if m.helpOpen && msg.Type != tea.KeyCtrlC {
+			switch msg.String() {
+			case "esc", "enter", "q", "ctrl+h", "ctrl+/":
+				m.helpOpen = false
+			}
+			return m, nil
+		}
+		// An active search takes n/N; any other key ends it
+		if m.search.active && msg.Type != tea.KeyCtrlC {
+			switch {
+			case key.Matches(msg, keys.NextMatch):
+				m.search.step(1)
+				m.updateViewport()
+				return m, nil
+			case key.Matches(msg, keys.PrevMatch):
+				m.search.step(-1)
+				m.updateViewport()
+				return m, nil
+			case key.Matches(msg, keys.Close):
+				m.endSearch()
+				return m, nil
+			}
+			m.endSearch()
+		}
+		// A held long paste takes ENTER and ESC; any other key drops it
+		if m.paste.text != "" && msg.Type != tea.KeyCtrlC {
+			model, cmd, handled := m.updatePaste(msg)
+			if handled {
+				return model, cmd
+			}
+			m = model
+		}
 		// Handle paste events - pass directly to input
 		if msg.Paste {
-			var inputCmd tea.Cmd
-			m.input, inputCmd = m.input.Update(msg)
-			return m, inputCmd
+			return m.handlePaste(msg)
+		}
+		// The snake game steers with keys the input would otherwise take
+		if m.view == ViewSnake && m.input.Value() == "" {
+			if model, cmd, handled := m.updateSnake(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewQuiz && m.input.Value() == "" {
+			if model, cmd, handled := m.updateQuiz(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewActivity && m.input.Value() == "" {
+			if model, cmd, handled := m.updateActivity(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewProjects && m.input.Value() == "" {
+			if model, cmd, handled := m.updateProjects(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewFind && m.input.Value() == "" {
+			if model, cmd, handled := m.updateFind(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewFeedback && m.input.Value() == "" {
+			if model, cmd, handled := m.updateFeedback(msg); handled {
+				return model, cmd
+			}
+		}
+		if m.view == ViewAchievements && m.input.Value() == "" {
+			if model, cmd, handled := m.updateAchievements(msg); handled {
+				return model, cmd
+			}
 		}
 		switch msg.Type {
 		case tea.KeyCtrlC:
@@ -215,12 +673,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.input.SetValue("")
 			m.errorMessage = ""
 			m.statusMessage = ""
+			if m.edit.active && input != "" && !strings.HasPrefix(input, "/") {
+				return m.submitEdit(input)
+			}
+			// On the feedback form, what's typed is the comment
+			if m.view == ViewFeedback && !strings.HasPrefix(input, "/") {
+				return m.submitFeedback(input)
+			}
+			if input == "" && m.followUpsShown() && m.followUps.selected >= 0 {
+				return m.sendFollowUp(m.followUps.selected)
+			}
+			m.edit = editState{}
 			if input == "" {
 				return m, nil
 			}
 			return m.handleInput(input)
 
+		case tea.KeyTab:
+			if m.followUpsShown() {
+				return m.cycleFollowUp(), nil
+			}
+
+		case tea.KeyUp:
+			// ↑ on an empty input edits the last message, like chat apps
+			if m.view == ViewChat && !m.isStreaming && !m.edit.active && m.input.Value() == "" {
+				if edited, ok := m.startEdit(); ok {
+					return edited, nil
+				}
+			}
+
 		case tea.KeyEsc:
+			if m.edit.active {
+				m.edit = editState{}
+				m.input.SetValue("")
+				return m, nil
+			}
 			if m.isStreaming && m.streamCancel != nil {
 				m.streamCancel()
 				m.isStreaming = false
@@ -229,6 +716,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.chatHistory = append(m.chatHistory, ChatMessage{
 						Role:    "assistant",
 						Content: m.chatResponse.String(),
+						Time:    time.Now(),
 					})
 					m.chatResponse.Reset()
 				}
@@ -237,87 +725,168 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.view != ViewChat {
-				m.view = ViewChat
-				// Show welcome if no chat history
-				if len(m.chatHistory) == 0 {
-					m.showWelcome = true
-				}
-				m.updateViewport()
+				m.goBack()
 			}
 
 		default:
 			// Keyboard shortcuts (work anytime)
-			switch msg.String() {
-			case "ctrl+s":
-				m.mouseEnabled = !m.mouseEnabled
+			switch {
+			case key.Matches(msg, keys.Palette):
+				m.helpOpen = false
+				return m.openPalette()
+			case key.Matches(msg, keys.Back):
+				m.goBack()
+				return m, nil
+			case key.Matches(msg, keys.Forward):
+				m.goForward()
+				return m, nil
+			case key.Matches(msg, keys.PrevTab):
+				return m.cycleTab(-1)
+			case key.Matches(msg, keys.NextTab):
+				return m.cycleTab(1)
+			case key.Matches(msg, keys.Mouse):
+				value := "on"
 				if m.mouseEnabled {
-					m.statusMessage = "Mouse ON (scroll mode)"
-					return m, tea.Batch(
-						func() tea.Msg { return tea.EnableMouseCellMotion() },
-						clearStatusAfter(2*time.Second),
-					)
-				} else {
-					m.statusMessage = "Mouse OFF (select mode)"
-					return m, func() tea.Msg { return tea.DisableMouse() }
+					value = "off"
 				}
-			case "ctrl+h", "ctrl+/":
-				m.view = ViewHelp
-				m.updateViewport()
+				m.statusMessage = settings["mouse"].apply(&m, value)
+				return m, tea.Batch(m.mouseCmd(), m.rememberSetting("mouse", value), clearStatusAfter(2*time.Second))
+			case key.Matches(msg, keys.Help):
+				m.helpOpen = true
 				return m, nil
-			case "ctrl+a":
+			case key.Matches(msg, keys.About):
 				m.view = ViewAbout
 				m.updateViewport()
 				return m, nil
-			case "ctrl+p":
+			case key.Matches(msg, keys.Projects):
 				m.view = ViewProjects
 				m.selectedProj = ""
 				m.updateViewport()
 				return m, nil
-			case "ctrl+r":
+			case key.Matches(msg, keys.Resume):
 				m.view = ViewResume
 				m.updateViewport()
 				return m, nil
-			case "ctrl+e":
+			case key.Matches(msg, keys.Experience):
 				m.view = ViewExperience
 				m.updateViewport()
 				return m, nil
-			case "ctrl+w":
+			case key.Matches(msg, keys.Home):
 				// Go home/welcome
 				m.view = ViewChat
 				m.showWelcome = len(m.chatHistory) == 0
 				m.updateViewport()
 				return m, nil
-			case "ctrl+l":
-				// Clear chat
-				m.chatHistory = nil
-				m.showWelcome = true
-				m.view = ViewChat
-				m.errorMessage = ""
-				m.statusMessage = ""
-				m.updateViewport()
-				return m, nil
-			case "ctrl+q":
+			case key.Matches(msg, keys.Clear):
+				return m.clearChat()
+			case key.Matches(msg, keys.Quit):
 				m.quitting = true
 				return m, quitAfter(1500 * time.Millisecond)
 			}
 
-			// Number keys for project selection (only in projects view with empty input)
-			if m.view == ViewProjects && m.input.Value() == "" {
+			if vim, ok := m.handleVimKey(msg); ok {
+				return vim, nil
+			}
+
+			// O opens the welcome screen's featured project (empty input)
+			if m.view == ViewChat && m.showWelcome && len(m.chatHistory) == 0 && m.input.Value() == "" && key.Matches(msg, keys.OpenFeatured) {
+				if featured := m.projects.Spotlight(); featured != nil {
+					m.selectedProj = featured.ID
+					return m.showView(ViewProjectDetail)
+				}
+			}
+
+			// b bookmarks the project on screen (empty input)
+			if m.view == ViewProjectDetail && m.input.Value() == "" && key.Matches(msg, keys.Bookmark) {
+				return m.toggleBookmark()
+			}
+
+			// + and - rate the last answer (chat view, empty input)
+			if rating, ok := ratingKey(msg); ok && m.ratedAnswer() >= 0 {
+				return m.rateAnswer(rating)
+			}
+
+			// Number keys send a suggested follow-up (chat view, empty input)
+			if m.followUpsShown() {
+				switch msg.String() {
+				case "1", "2", "3":
+					if idx := int(msg.String()[0] - '1'); idx < len(m.followUps.items) {
+						return m.sendFollowUp(idx)
+					}
+				}
+			}
+
+			// Number keys for project selection (projects and bookmarks views, empty input)
+			if (m.view == ViewProjects || m.view == ViewBookmarks) && m.input.Value() == "" {
 				switch msg.String() {
 				case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 					idx := int(msg.String()[0] - '1')
-					if idx >= 0 && idx < len(m.projects.Projects) {
-						m.selectedProj = m.projects.Projects[idx].ID
+					if shown := m.numberedProjects(); idx >= 0 && idx < len(shown) {
+						m.selectedProj = shown[idx].ID
 						m.view = ViewProjectDetail
 						m.updateViewport()
 						return m, nil
 					}
 				}
 			}
+
+			// Number keys copy a link from the list under the view (empty input)
+			if m.linkKeys() && key.Matches(msg, keys.CopyLink) {
+				if idx := int(msg.String()[0] - '1'); idx < len(m.links.urls) {
+					return m.copyLink(idx)
+				}
+			}
+		}
+
+	case tea.MouseMsg:
+		// Coordinates relative to the centered column
+		msg.X -= m.columnMargin()
+		if !m.palette.open && !m.helpOpen && !m.themeManager.Accessible() {
+			if model, cmd, handled := m.handleTabClick(msg); handled {
+				return model, cmd
+			}
+			if model, cmd, handled := m.handleMouse(msg); handled {
+				return model, cmd
+			}
+		}
+
+	case PrefsErrorMsg:
+		m.errorMessage = "Couldn't save preferences"
+
+	case AnnouncementMsg:
+		m.announcement = announcement{text: msg.Text, until: m.now.Add(announcementFor)}
+
+	case FeedbackSentMsg:
+		if msg.Err != nil {
+			m.errorMessage = "Couldn't send feedback - try again later"
+			m.feedback.sent--
+		} else {
+			m.statusMessage = "Feedback sent - thank you!"
+			cmds = append(cmds, clearStatusAfter(3*time.Second))
+		}
+		m.updateViewport()
+
+	case SharedMsg:
+		if msg.Err != nil {
+			m.statusMessage = ""
+			m.errorMessage = "Couldn't share - try again later"
+			m.shares--
+		} else {
+			m.clipboard = msg.URL
+			m.statusMessage = "Shared: " + msg.URL + " (copied)"
+			cmds = append(cmds, clearStatusAfter(8*time.Second))
+		}
+		m.updateViewport()
+
+	case TourTickMsg:
+		if !m.tour.active || msg.ID != m.tour.id {
+			return m, nil
 		}
+		return m.advanceTour()
 
 	case ClearStatusMsg:
 		m.statusMessage = ""
+		m.clipboard = ""
 
 	case StartupTickMsg:
 		// Animate: CONNECTING (0) → SYNCING (1) → ONLINE (2)
@@ -327,53 +896,159 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Animation complete, stay at ONLINE
 
+	case ClockTickMsg:
+		// Relative times, uptime and the owner's clock change by the
+		// minute, so only a new minute or a new live count redraws
+		newMinute := !msg.Time.Truncate(time.Minute).Equal(m.now.Truncate(time.Minute))
+		m.now = msg.Time
+		m.rotatePlaceholder()
+		liveChanged := m.refreshLive()
+		switch {
+		case liveChanged && (m.showWelcome || m.view == ViewStats),
+			newMinute && ((m.timestamps == "relative" && m.view == ViewChat) || m.view == ViewStats),
+			newMinute && m.clock != nil && (m.showWelcome || m.view == ViewCard):
+			m.updateViewport()
+		}
+		if m.recorder != nil && m.recorder.full() {
+			model, cmd := m.stopRecording()
+			m = model.(Model)
+			return m, tea.Batch(clockTick(), cmd)
+		}
+		var idleCmd tea.Cmd
+		m, idleCmd = m.checkIdle()
+		return m, tea.Batch(clockTick(), idleCmd, m.saveSnapshot())
+
+	case EggTickMsg:
+		return m.stepEgg(msg)
+
+	case SnakeTickMsg:
+		return m.stepSnake(msg)
+
+	case StatsTickMsg:
+		return m.stepStats(msg)
+
+	case ActivityMsg:
+		return m.receiveActivity(msg)
+
+	case OwnerStatusMsg:
+		m.ownerStatus = msg.Status
+		if m.showWelcome {
+			m.updateViewport()
+		}
+		return m, nil
+
+	case IntroTickMsg:
+		if m.introFrame >= ui.IntroFrames {
+			return m, nil
+		}
+		m.introFrame++
+		m.updateViewport()
+		if m.introFrame < ui.IntroFrames {
+			return m, introTick()
+		}
+		return m, nil
+
+	case AnimTickMsg:
+		// The tick loop ends with the stream; sendChatMessage restarts it
+		if !m.isStreaming || m.reducedMotion || msg.ID != m.streamID {
+			return m, nil
+		}
+		m.animFrame++
+		m.updateViewport()
+		return m, animTick(m.streamID)
+
 	case QuitMsg:
-		return m, tea.Quit
+		// Quitting on purpose isn't a dropped connection to resume
+		return m, tea.Sequence(m.dropSnapshot(), tea.Quit)
 
 	case tea.WindowSizeMsg:
+		// Dragging a window corner sends a burst of sizes; only record
+		// the latest and rebuild once it has held for resizeDebounce
+		if !m.resizing && msg.Width == m.width && msg.Height == m.height {
+			return m, nil
+		}
 		m.width = msg.Width
 		m.height = msg.Height
-		m.themeManager.SetSize(msg.Width, msg.Height)
-		m.input.Width = msg.Width - 8
-		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - 8
+		m.resizing = true
+		m.resizeID++
+		return m, resizeSettle(m.resizeID)
+
+	case ResizeSettledMsg:
+		if !m.resizing || msg.ID != m.resizeID {
+			return m, nil
+		}
+		m.resizing = false
+		m.themeManager.SetSize(m.columnWidth(), m.height)
+		m.fitInput()
+		m.viewport.Width = layout.InnerWidth(m.columnWidth())
+		m.viewport.Height = m.height - m.chromeRows()
 		m.updateViewport()
 
 	case StreamChunkMsg:
+		if !m.isStreaming || msg.ID != m.streamID {
+			return m, nil
+		}
 		m.streamMu.Lock()
 		m.chatResponse.WriteString(msg.Chunk)
 		m.streamMu.Unlock()
 		m.updateViewport()
-		if m.chunkChan != nil {
-			return m, listenForChunks(m.chunkChan, m.errChan)
+
+	case StreamRetryMsg:
+		if !m.isStreaming || msg.ID != m.streamID {
+			return m, nil
 		}
+		m.streamMu.Lock()
+		m.chatResponse.Reset()
+		m.streamMu.Unlock()
+		m.statusMessage = "Response stalled, retrying..."
+		m.updateViewport()
+		return m, clearStatusAfter(3 * time.Second)
 
 	case StreamDoneMsg:
+		if !m.isStreaming || msg.ID != m.streamID {
+			return m, nil
+		}
 		m.isStreaming = false
 		m.streamMu.Lock()
 		response := m.chatResponse.String()
 		m.streamMu.Unlock()
-		if msg.Error != nil {
+		if notice, ok := noticeFor(msg.Error); ok {
+			m.notice = notice
+			m.errorMessage = notice.String()
+		} else if msg.Error != nil {
 			m.errorMessage = msg.Error.Error()
 		} else if response != "" {
 			m.chatHistory = append(m.chatHistory, ChatMessage{
-				Role:    "assistant",
-				Content: response,
+				Role:      "assistant",
+				Content:   response,
+				Time:      time.Now(),
+				Truncated: msg.Truncated,
 			})
+			m.followUps = followUpState{items: suggestFollowUps(m.chatHistory), selected: -1}
 		}
 		m.chatResponse.Reset()
-		m.chunkChan = nil
-		m.errChan = nil
 		m.updateViewport()
 	}
 
 	var inputCmd tea.Cmd
 	m.input, inputCmd = m.input.Update(msg)
+	m.fitInput()
 	cmds = append(cmds, inputCmd)
 
+	if m.palette.open {
+		var paletteCmd tea.Cmd
+		m.palette.input, paletteCmd = m.palette.input.Update(msg)
+		cmds = append(cmds, paletteCmd)
+	}
+
+	// Scrolling up past the top of the chat loads archived messages
+	atTop := m.viewport.AtTop()
 	var vpCmd tea.Cmd
 	m.viewport, vpCmd = m.viewport.Update(msg)
 	cmds = append(cmds, vpCmd)
+	if m.view == ViewChat && atTop && scrollsUp(msg) {
+		m.loadEarlier()
+	}
 
 	return m, tea.Batch(cmds...)
 }
@@ -385,76 +1060,10 @@ func (m Model) handleInput(input string) (tea.Model, tea.Cmd) {
 	return m.sendChatMessage(input)
 }
 
-func (m Model) handleSlashCommand(input string) (tea.Model, tea.Cmd) {
-	parts := strings.Fields(input)
-	command := strings.ToLower(parts[0])
-	args := parts[1:]
-
-	// Track command execution
-	if m.analytics != nil {
-		m.analytics.TrackCommandExecuted(m.sessionID, command)
-	}
-
-	oldView := m.view
-
-	switch command {
-	case "/help", "/h", "/?":
-		m.view = ViewHelp
-		m.showWelcome = false
-	case "/about", "/bio":
-		m.view = ViewAbout
-		m.showWelcome = false
-	case "/projects", "/p":
-		m.view = ViewProjects
-		m.showWelcome = false
-	case "/open", "/o":
-		if len(args) == 0 {
-			m.errorMessage = "Usage: /open <project-id>"
-		} else {
-			m.selectedProj = args[0]
-			if m.projects.GetProjectByID(m.selectedProj) == nil {
-				m.errorMessage = "Project not found: " + m.selectedProj
-			} else {
-				m.view = ViewProjectDetail
-				m.showWelcome = false
-			}
-		}
-	case "/resume", "/cv", "/r":
-		m.view = ViewResume
-		m.showWelcome = false
-	case "/exp", "/experience", "/work":
-		m.view = ViewExperience
-		m.showWelcome = false
-	case "/clear", "/cls":
-		m.view = ViewChat
-		m.chatHistory = nil
-		m.showWelcome = true
-		m.errorMessage = ""
-		m.statusMessage = ""
-	case "/exit", "/quit", "/q":
-		m.quitting = true
-		return m, quitAfter(1500 * time.Millisecond)
-	case "/back", "/b":
-		m.view = ViewChat
-	default:
-		m.errorMessage = "Unknown command: " + command
-	}
-
-	// Track view change
-	if m.view != oldView && m.analytics != nil {
-		m.analytics.TrackViewChanged(m.sessionID, viewName(oldView), viewName(m.view))
-	}
-
-	m.updateViewport()
-	return m, nil
-}
-
 func viewName(v View) string {
 	switch v {
 	case ViewChat:
 		return "chat"
-	case ViewHelp:
-		return "help"
 	case ViewAbout:
 		return "about"
 	case ViewProjects:
@@ -465,19 +1074,106 @@ func viewName(v View) string {
 		return "resume"
 	case ViewExperience:
 		return "experience"
+	case ViewSnake:
+		return "snake"
+	case ViewQuiz:
+		return "quiz"
+	case ViewStats:
+		return "stats"
+	case ViewCard:
+		return "card"
+	case ViewActivity:
+		return "activity"
+	case ViewUses:
+		return "uses"
+	case ViewTalks:
+		return "talks"
+	case ViewCerts:
+		return "certs"
+	case ViewWhatsNew:
+		return "whatsnew"
+	case ViewFind:
+		return "find"
+	case ViewBookmarks:
+		return "bookmarks"
+	case ViewFeedback:
+		return "feedback"
+	case ViewAchievements:
+		return "achievements"
 	default:
 		return "unknown"
 	}
 }
 
-func (m Model) sendChatMessage(message string) (tea.Model, tea.Cmd) {
-	if m.aiService == nil {
+// readOnlyNotice is what a ReadOnly session gets for chat, /record and
+// saved settings; browsing and canned FAQ answers still work
+const readOnlyNotice = "Read-only preview: chat, /record and saved settings are open to invited keys"
+
+// aiReady reports whether a reply can be streamed, flagging the error if not
+func (m *Model) aiReady() bool {
+	if m.readOnly {
+		m.errorMessage = readOnlyNotice
+		return false
+	}
+	if m.aiService == nil || m.send == nil {
 		m.errorMessage = "AI not available"
 		if m.analytics != nil {
 			m.analytics.TrackChatError(m.sessionID, "AI not available")
 		}
+		return false
+	}
+	return true
+}
+
+func (m Model) sendChatMessage(message string) (tea.Model, tea.Cmd) {
+	if entry, ok := ai.MatchFAQ(m.faq, message); ok {
+		return m.answerFromFAQ(message, entry), nil
+	}
+	if !m.aiReady() {
 		return m, nil
 	}
+	m.chatHistory = append(m.chatHistory, ChatMessage{Role: "user", Content: message, Time: time.Now()})
+	return m.streamReply(len(m.chatHistory) - 1)
+}
+
+// clearChat empties the chat for /clear and Ctrl+L. It starts a new
+// conversation too, so a gateway that keeps them forgets the old chat.
+func (m Model) clearChat() (tea.Model, tea.Cmd) {
+	m.view = ViewChat
+	m.chatHistory = nil
+	m.conversation = ai.NewConversationID()
+	m.followUps = followUpState{}
+	m.showWelcome = true
+	m.errorMessage = ""
+	m.statusMessage = ""
+	m.updateViewport()
+	return m, nil
+}
+
+// answerFromFAQ replies instantly with a canned FAQ answer, skipping the AI
+func (m Model) answerFromFAQ(message string, entry *content.FAQEntry) Model {
+	if m.analytics != nil {
+		m.analytics.TrackChatSent(m.sessionID, len(message))
+		m.analytics.TrackCommandExecuted(m.sessionID, "faq:"+entry.ID)
+	}
+
+	now := time.Now()
+	m.view = ViewChat
+	m.showWelcome = false
+	m.chatHistory = append(m.chatHistory,
+		ChatMessage{Role: "user", Content: message, Time: now},
+		ChatMessage{Role: "assistant", Content: entry.Answer, Time: now, FAQ: true},
+	)
+	m.followUps = followUpState{items: suggestFollowUps(m.chatHistory), selected: -1}
+	m.updateViewport()
+	return m
+}
+
+// streamReply asks the AI to answer the user message at index prompt.
+// Earlier messages form the conversation history; superseded answers
+// are left out.
+func (m Model) streamReply(prompt int) (tea.Model, tea.Cmd) {
+	message := m.chatHistory[prompt].Content
 
 	// Track chat sent
 	if m.analytics != nil {
@@ -486,57 +1182,122 @@ func (m Model) sendChatMessage(message string) (tea.Model, tea.Cmd) {
 
 	m.view = ViewChat
 	m.showWelcome = false
-	m.chatHistory = append(m.chatHistory, ChatMessage{Role: "user", Content: message})
 	m.isStreaming = true
+	m.followUps = followUpState{}
 	m.chatResponse.Reset()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	m.streamCtx = ctx
+	// The AI answers from the latest content, which may be newer than
+	// this session's
+	ctx := ai.WithContentVersion(ai.WithReplyLanguage(m.ctx, m.language), m.contentVersion)
+	if m.restarted {
+		ctx = ai.WithNewConversation(ctx, m.conversation)
+	} else {
+		ctx = ai.WithConversation(ctx, m.conversation)
+	}
+	m.restarted = false
+	ctx, cancel := context.WithCancel(ctx)
 	m.streamCancel = cancel
-
-	chunkChan := make(chan string, 1000)
-	errChan := make(chan error, 1)
-	m.chunkChan = chunkChan
-	m.errChan = errChan
+	m.streamID++
 	m.updateViewport()
 
-	history := make([]ai.Message, 0, len(m.chatHistory)-1)
-	for _, msg := range m.chatHistory[:len(m.chatHistory)-1] {
+	history := make([]ai.Message, 0, prompt)
+	for _, msg := range m.chatHistory[:prompt] {
+		if msg.Superseded {
+			continue
+		}
 		history = append(history, ai.Message{Role: msg.Role, Content: msg.Content})
 	}
 
 	aiService := m.aiService
 	sessionID := m.sessionID
 	analytics := m.analytics
+	counters := m.counters
+	send := m.send
+	id := m.streamID
+	maxResponseLength := m.maxResponseLength
 	startTime := time.Now()
 
+	// ctx ends with the session, so every send below and the provider
+	// call return once the client is gone and nothing reads anymore
+	activeStreams.Add(1)
 	go func() {
-		defer close(chunkChan)
-		defer close(errChan)
+		defer activeStreams.Add(-1)
+		defer cancel()
+
 		var totalResponse strings.Builder
-		err := aiService.ChatStream(ctx, sessionID, message, history, func(chunk string) error {
-			totalResponse.WriteString(chunk)
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case chunkChan <- chunk:
+		var length int
+		var truncated bool
+		err := watchStream(ctx, func(ctx context.Context, touch func()) error {
+			totalResponse.Reset()
+			length = 0
+
+			// Provider chunks are batched before reaching the UI
+			chunks := newChunkMerger()
+			flushed := make(chan struct{})
+			go func() {
+				defer close(flushed)
+				chunks.Run(func(batch string) {
+					send(StreamChunkMsg{ID: id, Chunk: batch})
+				})
+			}()
+
+			err := aiService.ChatStream(ctx, sessionID, message, history, func(chunk string) error {
+				touch()
+				// Stop runaway replies once they pass the length cap
+				runes := []rune(chunk)
+				if length+len(runes) > maxResponseLength {
+					chunk = string(runes[:maxResponseLength-length])
+					truncated = true
+				}
+				length += len(runes)
+				totalResponse.WriteString(chunk)
+				chunks.Add(chunk)
+				if truncated {
+					return ai.ErrStopStream
+				}
 				return nil
-			}
+			})
+			chunks.Close()
+			<-flushed
+			return err
+		}, func() {
+			send(StreamRetryMsg{ID: id})
 		})
+		if errors.Is(err, ai.ErrStopStream) {
+			err = nil
+		}
+
 		if err != nil {
-			errChan <- err
 			if analytics != nil {
 				analytics.TrackChatError(sessionID, err.Error())
 			}
-		} else if analytics != nil {
-			analytics.TrackChatReceived(sessionID, totalResponse.Len(), time.Since(startTime).Milliseconds())
+		} else {
+			if analytics != nil {
+				analytics.TrackChatReceived(sessionID, totalResponse.Len(), time.Since(startTime).Milliseconds())
+			}
+			if counters != nil {
+				_, _ = counters.Count(questionsCounter)
+			}
 		}
+		send(StreamDoneMsg{ID: id, Error: err, Truncated: truncated})
 	}()
 
-	return m, listenForChunks(chunkChan, errChan)
+	if m.reducedMotion {
+		return m, nil
+	}
+	return m, animTick(m.streamID)
 }
 
 func (m *Model) updateViewport() {
+	// A view change since the last update: keep where the view left was
+	// scrolled to, and go back to where the one shown now was
+	here := m.here()
+	entering := here != m.router.shown
+	if entering {
+		m.router.leave(m.viewport)
+		m.router.shown = here
+	}
+
 	if m.width <= 0 {
 		m.width = 80
 	}
@@ -544,163 +1305,229 @@ func (m *Model) updateViewport() {
 		m.height = 24
 	}
 
-	m.input.Width = max(m.width-8, 20)
-	m.viewport.Width = max(m.width-4, 20)
-	m.viewport.Height = max(m.height-8, 8)
+	// The chat follows its end unless scrolled back from it
+	follow := m.viewport.AtBottom() || m.visitorSent()
+	m.fitInput()
+	m.boundHistory()
+	m.viewport.Width = max(layout.InnerWidth(m.columnWidth()), 20)
+	m.viewport.Height = max(m.height-m.chromeRows(), 8)
 
 	styles := m.themeManager.Styles()
-	mdRenderer := ui.NewMarkdownRenderer(styles)
+	content := m.renderView(styles)
+	if m.view != ViewChat {
+		// The chat lists the last answer's links under it instead
+		m.links.urls = pageLinks(content)
+		if list := m.linkList(); list != "" {
+			content += "\n" + list
+		}
+	}
 
-	var content string
-	switch m.view {
-	case ViewChat:
-		content = m.buildChatView(styles, mdRenderer)
-	case ViewHelp:
-		content = ui.Help(styles, m.width)
-	case ViewAbout:
-		content = ui.About(styles, m.bio, m.width)
-	case ViewProjects:
-		content = ui.ProjectsList(styles, m.projects, m.width)
-	case ViewProjectDetail:
-		content = ui.ProjectDetail(styles, m.projects.GetProjectByID(m.selectedProj), m.width)
-	case ViewResume:
-		content = ui.Resume(styles, m.resume, m.width)
-	case ViewExperience:
-		content = ui.Experience(styles, m.resume, m.width)
+	searching := m.search.term != "" && m.view == m.search.view
+	if searching {
+		m.search.matches = findMatches(content, m.search.term)
+		m.search.current = min(m.search.current, max(len(m.search.matches)-1, 0))
+		content = highlightMatches(styles, content, m.search.matches, m.search.current)
 	}
 
-	m.viewport.SetContent(content)
-	if m.view == ViewChat {
+	m.viewLines = strings.Split(content, "\n")
+	m.applyHover()
+	switch {
+	case searching && len(m.search.matches) > 0:
+		m.scrollToMatch()
+	case entering:
+		m.router.state(here).restore(&m.viewport)
+	case m.view == ViewChat && follow:
 		m.viewport.GotoBottom()
 	}
 }
 
-func (m Model) buildChatView(styles theme.Styles, mdRenderer *ui.MarkdownRenderer) string {
+// messageRenderer returns the markdown backend for finished messages.
+// Accessibility mode always uses the built-in linear renderer. The
+// session's renderers are handed new styles only when the theme rebuilt
+// them.
+func (m *Model) messageRenderer(styles theme.Styles) ui.MessageRenderer {
+	if gen := m.themeManager.Generation(); gen != m.stylesGen {
+		m.md.SetStyles(styles)
+		m.streamMD.SetStyles(styles)
+		m.stylesGen = gen
+	}
+	if m.mdBackend != "glamour" || styles.Accessible {
+		return m.md
+	}
+	if m.glamour == nil {
+		m.glamour = ui.NewGlamourRenderer(m.themeManager.Palette(), m.themeManager.ColorProfile(), m.md)
+	}
+	m.glamour.SetTheme(m.themeManager.Palette(), m.md)
+	return m.glamour
+}
+
+func (m Model) buildChatView(styles theme.Styles, mdRenderer ui.MessageRenderer) string {
 	var b strings.Builder
 
 	if m.showWelcome && len(m.chatHistory) == 0 {
-		b.WriteString(ui.WelcomeMessage(styles, m.width))
+		b.WriteString(ui.WelcomeMessage(styles, m.columnWidth(), ui.Anim{Frame: m.introFrame, Reduced: m.reducedMotion}, ui.Visitor{
+			Greeting: m.greeting,
+			Number:   m.visitorNum,
+			Live:     m.live,
+
+			Status:    m.ownerStatus.Text,
+			Listening: m.ownerStatus.Listening,
+			LocalTime: m.localTime(),
+		}, m.projects.Spotlight()))
 	}
 
-	for _, msg := range m.chatHistory {
-		b.WriteString(ui.ChatMessage(styles, msg.Role, msg.Content, m.width, mdRenderer))
+	if archived := m.archive.start + len(m.archive.trimmed); archived > 0 || m.archive.forgotten > 0 {
+		b.WriteString(ui.EarlierNote(styles, archived, m.archive.forgotten, m.columnWidth()))
+	}
+	m.renderCache.window(m.renderKey(), m.archive.start)
+	for i := m.archive.start; i < len(m.chatHistory); i++ {
+		b.WriteString(m.renderHistoryMessage(styles, i, mdRenderer))
 		b.WriteString("\n")
 	}
+	b.WriteString(m.linkList())
+
+	if i := m.ratedAnswer(); i >= 0 && m.chatHistory[i].Rating == "" {
+		b.WriteString(ui.RatePrompt(styles))
+	}
+	if !m.isStreaming && len(m.followUps.items) > 0 {
+		b.WriteString(ui.FollowUps(styles, m.followUps.items, m.followUps.selected, m.columnWidth()))
+	}
 
 	if m.isStreaming {
 		m.streamMu.Lock()
 		currentResponse := m.chatResponse.String()
 		m.streamMu.Unlock()
-		b.WriteString(ui.StreamingMessage(styles, currentResponse, m.width, mdRenderer))
+		b.WriteString(ui.StreamingMessage(styles, currentResponse, m.columnWidth(), m.streamMD, m.themeManager.Palette().Spinner, m.anim()))
 	}
 
 	return b.String()
 }
 
+// View renders the screen in the session's glyph set
 func (m Model) View() string {
+	view := m.themeManager.Glyphs().Render(m.render())
+	if m.background && !m.themeManager.Accessible() {
+		view = ui.FillBackground(view, m.width, m.height, m.themeManager.BackgroundSequence())
+	}
+	if m.recorder != nil {
+		m.recorder.capture(view, m.width, m.height)
+	}
+	if m.clipboard != "" {
+		view = ansi.SetSystemClipboard(m.clipboard) + view
+	}
+	return view
+}
+
+func (m Model) render() string {
 	if m.quitting {
 		return m.renderQuitScreen()
 	}
 
 	styles := m.themeManager.Styles()
-	var b strings.Builder
+	if m.resizing {
+		return m.renderResizing(styles)
+	}
+	if styles.Accessible {
+		return m.renderAccessible(styles)
+	}
 
-	// ╔══════════════════════════════════════════════════════════════════╗
-	// ║                           HEADER                                 ║
-	// ╠══════════════════════════════════════════════════════════════════╣
-	b.WriteString(m.renderHeader(styles))
-	b.WriteString("\n")
-
-	// ║                          CONTENT                                 ║
-	content := m.viewport.View()
-	// Pad content to fill width
-	lines := strings.Split(content, "\n")
-	for i, line := range lines {
-		lineWidth := lipgloss.Width(line)
-		padding := m.width - 4 - lineWidth
-		if padding > 0 {
-			lines[i] = styles.Dim.Render("║ ") + line + strings.Repeat(" ", padding) + styles.Dim.Render(" ║")
-		} else {
-			lines[i] = styles.Dim.Render("║ ") + line + styles.Dim.Render(" ║")
-		}
+	// Body: the viewport, under the palette or help when open
+	content := graphics.ClipOverlays(m.viewport.View())
+	if m.egg.kind != "" || m.palette.open || m.helpOpen {
+		// Pictures would be drawn over whatever is on top
+		content = graphics.StripOverlays(content)
+	}
+	switch {
+	case m.egg.kind == "matrix":
+		content = ui.MatrixRain(styles, m.viewport.Width, m.viewport.Height, m.egg.frame)
+	case m.egg.kind == "hack":
+		content = layout.Overlay(styles.Dim, content, ui.HackSequence(styles, m.viewport.Width, m.egg.frame), m.viewport.Width, m.viewport.Height)
+	case m.palette.open:
+		content = layout.Overlay(styles.Dim, content, m.renderPalette(styles), m.viewport.Width, m.viewport.Height)
+	case m.helpOpen:
+		content = layout.Overlay(styles.Dim, content, ui.Help(styles, m.viewport.Width, m.viewport.Height, helpCommands()), m.viewport.Width, m.viewport.Height)
 	}
-	b.WriteString(strings.Join(lines, "\n"))
-	b.WriteString("\n")
 
-	// ╠══════════════════════════════════════════════════════════════════╣
-	// ║                           FOOTER                                 ║
-	// ╚══════════════════════════════════════════════════════════════════╝
-	b.WriteString(m.renderFooter(styles))
+	screen := m.frame(styles).Render(version.Version,
+		layout.Section{Rows: []string{m.renderHeader(styles)}},
+		layout.Section{Rows: []string{m.renderTabBar(styles)}},
+		layout.Section{Rows: layout.Block(content), Divider: layout.None, Quiet: true},
+		layout.Section{Rows: []string{m.renderInputLine(styles)}},
+		layout.Section{Rows: m.renderFooter(styles), Divider: layout.Thin},
+	)
+	return layout.Indent(screen, m.columnMargin())
+}
 
-	return b.String()
+// chromeRows is the rows around the viewport, counting every footer row
+func (m Model) chromeRows() int {
+	return chromeHeight + m.footerRows() - 1
+}
+
+// columnWidth is the width of the layout column: the terminal's, up to
+// the configured maximum
+func (m Model) columnWidth() int {
+	if m.maxWidth > 0 && m.width > m.maxWidth {
+		return m.maxWidth
+	}
+	return m.width
+}
+
+// columnMargin is the blank space left of the centered column
+func (m Model) columnMargin() int {
+	return (m.width - m.columnWidth()) / 2
+}
+
+// frame is the border drawn around every screen
+func (m Model) frame(styles theme.Styles) layout.Frame {
+	return layout.Frame{Width: m.columnWidth(), Corner: styles.Yellow, Line: styles.Muted, Quiet: styles.Dim}
 }
 
 func (m Model) renderQuitScreen() string {
 	styles := m.themeManager.Styles()
-	var b strings.Builder
 
-	border := styles.Muted.Render(strings.Repeat("═", m.width-2))
-	b.WriteString("\n")
-	b.WriteString(styles.Yellow.Render("╔") + border + styles.Yellow.Render("╗"))
-	b.WriteString("\n")
-
-	msg := styles.Neon.Bold(true).Render("CONNECTION TERMINATED")
-	msgWidth := lipgloss.Width(msg)
-	pad := (m.width - 4 - msgWidth) / 2
-	b.WriteString(styles.Muted.Render("║ ") + strings.Repeat(" ", pad) + msg + strings.Repeat(" ", m.width-4-pad-msgWidth) + styles.Muted.Render(" ║"))
-	b.WriteString("\n")
+	if styles.Accessible {
+		text := "\nConnection closed. Session ended.\n"
+		if m.idleQuit {
+			text = "\nConnection closed after inactivity. Session ended.\n"
+		}
+		if m.feedbackSink != nil && m.feedback.sent == 0 {
+			text += "Thoughts on the site? Leave them with /feedback next time.\n"
+		}
+		return text
+	}
 
+	frame := m.frame(styles)
 	sub := styles.Yellow.Render("// session ended")
-	subWidth := lipgloss.Width(sub)
-	pad2 := (m.width - 4 - subWidth) / 2
-	b.WriteString(styles.Muted.Render("║ ") + strings.Repeat(" ", pad2) + sub + strings.Repeat(" ", m.width-4-pad2-subWidth) + styles.Muted.Render(" ║"))
-	b.WriteString("\n")
-
-	b.WriteString(styles.Yellow.Render("╚") + border + styles.Yellow.Render("╝"))
-	b.WriteString("\n")
+	if m.idleQuit {
+		sub = styles.Yellow.Render("// disconnected after inactivity")
+	}
+	rows := []string{
+		layout.Center(styles.Neon.Bold(true).Render("CONNECTION TERMINATED"), frame.InnerWidth()),
+		layout.Center(sub, frame.InnerWidth()),
+	}
+	if m.feedbackSink != nil && m.feedback.sent == 0 {
+		rows = append(rows, "", layout.Center(styles.Dim.Render("thoughts on the site? leave them with ")+
+			styles.Yellow.Render("/feedback")+styles.Dim.Render(" next time"), frame.InnerWidth()))
+	}
+	return "\n" + layout.Indent(frame.Render("", layout.Section{Rows: rows}), m.columnMargin()) + "\n"
+}
 
-	return b.String()
+// renderResizing is the cheap frame shown while a resize settles
+func (m Model) renderResizing(styles theme.Styles) string {
+	if styles.Accessible {
+		return "Resizing..."
+	}
+	return lipgloss.Place(max(m.width, 1), max(m.height, 1), lipgloss.Center, lipgloss.Center,
+		styles.Muted.Render("resizing…"))
 }
 
+// renderHeader renders the title row: logo, breadcrumb and status
 func (m Model) renderHeader(styles theme.Styles) string {
-	var b strings.Builder
-	innerWidth := m.width - 4
-
-	// Top border - Yellow corners, Muted lines (cyberpunk!)
-	topBorder := styles.Yellow.Render("╔") + styles.Muted.Render(strings.Repeat("═", innerWidth+2)) + styles.Yellow.Render("╗")
-	b.WriteString(topBorder)
-	b.WriteString("\n")
+	innerWidth := layout.InnerWidth(m.columnWidth())
 
 	// Title bar - Yellow/Neon gradient
 	logo := styles.Yellow.Bold(true).Render("▓▒░") + styles.Neon.Bold(true).Render(" BMOHAK.XYZ ") + styles.Yellow.Bold(true).Render("░▒▓")
 
-	// View indicator
-	viewName := ""
-	viewStyle := styles.Muted
-	switch m.view {
-	case ViewChat:
-		viewName = "NEURAL_LINK"
-		viewStyle = styles.Green
-	case ViewHelp:
-		viewName = "SYS_HELP"
-		viewStyle = styles.Purple
-	case ViewAbout:
-		viewName = "PROFILE"
-		viewStyle = styles.Cyan
-	case ViewProjects:
-		viewName = "PROJECTS"
-		viewStyle = styles.Yellow
-	case ViewProjectDetail:
-		viewName = "PROJECT"
-		viewStyle = styles.Yellow
-	case ViewResume:
-		viewName = "CREDENTIALS"
-		viewStyle = styles.Neon
-	case ViewExperience:
-		viewName = "EXPERIENCE"
-		viewStyle = styles.Orange
-	}
-
 	status := ""
 	if m.startupPhase == 0 {
 		status = styles.Yellow.Render("◌ CONNECTING")
@@ -711,80 +1538,87 @@ func (m Model) renderHeader(styles theme.Styles) string {
 	} else {
 		status = styles.Green.Render("◉ ONLINE")
 	}
+	if m.recorder != nil {
+		status = styles.Red.Bold(true).Render("● REC") + styles.Dim.Render(" │ ") + status
+	}
 
-	viewTag := styles.Yellow.Render("[") + viewStyle.Bold(true).Render(viewName) + styles.Yellow.Render("]")
-
-	// Calculate layout
+	// View indicator: a breadcrumb of recent views, shortened to fit
 	logoWidth := lipgloss.Width(logo)
-	viewWidth := lipgloss.Width(viewTag)
-	statusWidth := lipgloss.Width(status)
-	totalContent := logoWidth + viewWidth + statusWidth
-	spacing1 := (innerWidth-totalContent)/2 - 2
-	spacing2 := innerWidth - logoWidth - spacing1 - viewWidth - statusWidth
+	viewTag := m.breadcrumb(styles, 2)
+	for depth := 1; depth >= 0 && logoWidth+lipgloss.Width(viewTag)+lipgloss.Width(status)+8 > innerWidth; depth-- {
+		viewTag = m.breadcrumb(styles, depth)
+	}
 
-	headerLine := styles.Muted.Render("║ ") + logo + strings.Repeat(" ", max(1, spacing1)) + viewTag + strings.Repeat(" ", max(1, spacing2)) + status + styles.Muted.Render(" ║")
-	b.WriteString(headerLine)
-	b.WriteString("\n")
+	// Right block: status, then clock / session / uptime as width allows
+	viewWidth := lipgloss.Width(viewTag)
+	right := status
+	for _, meta := range m.headerMeta(styles) {
+		candidate := right + styles.Dim.Render(" │ ") + meta
+		if logoWidth+viewWidth+lipgloss.Width(candidate)+8 > innerWidth {
+			break
+		}
+		right = candidate
+	}
 
-	// Bottom border with connectors - Yellow corners
-	bottomBorder := styles.Yellow.Render("╠") + styles.Muted.Render(strings.Repeat("═", innerWidth+2)) + styles.Yellow.Render("╣")
-	b.WriteString(bottomBorder)
+	return layout.Spread(logo, viewTag, right, innerWidth)
+}
 
-	return b.String()
+// headerMeta returns the live clock, visitors online, session timer and
+// server uptime, most important first
+func (m Model) headerMeta(styles theme.Styles) []string {
+	meta := []string{styles.Cyan.Render(m.now.Format("15:04:05"))}
+	if m.live > 1 {
+		meta = append(meta, styles.Green.Render(fmt.Sprintf("%d", m.live))+styles.Dim.Render(" online"))
+	}
+	return append(meta,
+		styles.Dim.Render("session ")+styles.Yellow.Render(formatClock(m.now.Sub(m.sessionStart))),
+		styles.Dim.Render("up ")+styles.Green.Render(formatUptime(m.now.Sub(m.serverStart))),
+	)
 }
 
-func (m Model) renderFooter(styles theme.Styles) string {
-	var b strings.Builder
-	innerWidth := m.width - 4
-
-	// Top border - Yellow corners, Muted lines (consistent with header)
-	topBorder := styles.Yellow.Render("╠") + styles.Muted.Render(strings.Repeat("═", innerWidth+2)) + styles.Yellow.Render("╣")
-	b.WriteString(topBorder)
-	b.WriteString("\n")
-
-	// Input line
-	prompt := styles.Yellow.Bold(true).Render("❯ ")
-	inputView := m.input.View()
-	inputLine := prompt + inputView
-	inputWidth := lipgloss.Width(inputLine)
-	inputPad := innerWidth - inputWidth
-	b.WriteString(styles.Muted.Render("║ ") + inputLine + strings.Repeat(" ", max(0, inputPad)) + styles.Muted.Render(" ║"))
-	b.WriteString("\n")
-
-	// Separator - Yellow corners
-	sep := styles.Yellow.Render("╟") + styles.Muted.Render(strings.Repeat("─", innerWidth+2)) + styles.Yellow.Render("╢")
-	b.WriteString(sep)
-	b.WriteString("\n")
-
-	// Status/hint line
-	var hint string
-	if m.errorMessage != "" {
-		hint = styles.Red.Bold(true).Render("⚠ ERR: " + m.errorMessage)
-	} else if m.statusMessage != "" {
-		hint = styles.Green.Bold(true).Render("✓ " + m.statusMessage)
-	} else if m.isStreaming {
-		hint = styles.Neon.Render("▓▒░") + styles.Cyan.Render(" streaming ") + styles.Neon.Render("░▒▓") + styles.Dim.Render(" │ ") + styles.Yellow.Render("ESC") + styles.Dim.Render(" abort")
-	} else if m.view != ViewChat {
-		hint = styles.Yellow.Render("ESC") + styles.Dim.Render(" back │ ") +
-			styles.Cyan.Render("^W") + styles.Dim.Render(" home │ ") +
-			styles.Purple.Render("^H") + styles.Dim.Render(" help")
-	} else {
-		hint = styles.Green.Render("^A") + styles.Dim.Render(" about ") +
-			styles.Yellow.Render("^P") + styles.Dim.Render(" projects ") +
-			styles.Orange.Render("^E") + styles.Dim.Render(" exp ") +
-			styles.Neon.Render("^R") + styles.Dim.Render(" resume ") +
-			styles.Purple.Render("^H") + styles.Dim.Render(" help")
+// formatClock renders a duration as M:SS or H:MM:SS
+func formatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
 	}
-	hintWidth := lipgloss.Width(hint)
-	hintPad := innerWidth - hintWidth
-	b.WriteString(styles.Muted.Render("║ ") + hint + strings.Repeat(" ", max(0, hintPad)) + styles.Muted.Render(" ║"))
-	b.WriteString("\n")
+	d = d.Truncate(time.Second)
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	secs := int(d.Seconds()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mins, secs)
+	}
+	return fmt.Sprintf("%d:%02d", mins, secs)
+}
 
-	// Bottom border - Yellow corners, Muted lines
-	bottomBorder := styles.Yellow.Render("╚") + styles.Muted.Render(strings.Repeat("═", innerWidth+2)) + styles.Yellow.Render("╝")
-	b.WriteString(bottomBorder)
+// formatUptime renders a duration in its two most significant units
+func formatUptime(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	mins := int(d.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
 
-	return b.String()
+// renderInputLine renders the prompt and chat input, with the character
+// counter at the right once it shows
+func (m Model) renderInputLine(styles theme.Styles) string {
+	line := styles.Yellow.Bold(true).Render("❯ ") + m.input.View()
+	counter := m.inputCounter(styles)
+	if counter == "" {
+		return line
+	}
+	gap := layout.InnerWidth(m.columnWidth()) - lipgloss.Width(line) - lipgloss.Width(counter)
+	return line + strings.Repeat(" ", max(gap, 1)) + counter
 }
 
 func max(a, b int) int {
@@ -800,6 +1634,3 @@ func min(a, b int) int {
 	}
 	return b
 }
-
-// Unused but kept for potential future use
-var _ = fmt.Sprint
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/layout"
+)
+
+const (
+	// viewportTop is the screen row of the first viewport line
+	viewportTop = tabBarRow + 1
+	// viewportLeft is the screen column viewport text starts at
+	viewportLeft = layout.Inset
+)
+
+var (
+	linkPattern       = regexp.MustCompile(`https?://[^\s│)]+|[\w.+-]+@[\w-]+(\.[\w-]+)+|(github|linkedin)\.com/[\w./-]+`)
+	projectRowPattern = regexp.MustCompile(`\[(\d+)\] `)
+	hyperlinkPattern  = regexp.MustCompile("\x1b]8;[^;]*;([^\x07\x1b]*)(?:\x07|\x1b\\\\)")
+)
+
+// hotspot is a clickable span of the viewport content: a link to copy or
+// a project row to open
+type hotspot struct {
+	view    View
+	span    searchMatch
+	link    string
+	project string
+}
+
+// hotspotAt hit-tests screen cell x, y against the viewport content
+func (m Model) hotspotAt(x, y int) (hotspot, bool) {
+	row := y - viewportTop
+	if row < 0 || row >= m.viewport.Height {
+		return hotspot{}, false
+	}
+	line := m.viewport.YOffset + row
+	if line >= len(m.viewLines) {
+		return hotspot{}, false
+	}
+	col := x - viewportLeft
+	raw := m.viewLines[line]
+	if spot, ok := hyperlinkAt(raw, col); ok {
+		spot.view = m.view
+		spot.span.line = line
+		return spot, true
+	}
+
+	plain := ansi.Strip(raw)
+	for _, loc := range linkPattern.FindAllStringIndex(plain, -1) {
+		start := ansi.StringWidth(plain[:loc[0]])
+		end := ansi.StringWidth(plain[:loc[1]])
+		if col >= start && col < end {
+			return hotspot{
+				view: m.view,
+				span: searchMatch{line: line, start: start, end: end},
+				link: plain[loc[0]:loc[1]],
+			}, true
+		}
+	}
+
+	if shown := m.numberedProjects(); len(shown) > 0 {
+		// A project's block is its "[n] Name" row and the lines below it
+		for i := line; i >= 0 && i > line-5; i-- {
+			header := ansi.Strip(m.viewLines[i])
+			loc := projectRowPattern.FindStringSubmatchIndex(header)
+			if loc == nil {
+				continue
+			}
+			n, _ := strconv.Atoi(header[loc[2]:loc[3]])
+			if n < 1 || n > len(shown) {
+				break
+			}
+			// Highlight the row up to the box's right border
+			text := strings.TrimRight(strings.TrimRight(header, " "), "│")
+			return hotspot{
+				view: m.view,
+				span: searchMatch{
+					line:  i,
+					start: ansi.StringWidth(header[:loc[0]]),
+					end:   ansi.StringWidth(strings.TrimRight(text, " ")),
+				},
+				project: shown[n-1].ID,
+			}, true
+		}
+	}
+	return hotspot{}, false
+}
+
+// handleMouse opens clicked projects, copies clicked links and tracks
+// the hovered hotspot. It reports whether the event was consumed.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd, bool) {
+	spot, ok := m.hotspotAt(msg.X, msg.Y)
+
+	switch msg.Action {
+	case tea.MouseActionMotion:
+		if ok != m.hovering || spot != m.hover {
+			m.hover, m.hovering = spot, ok
+			m.applyHover()
+		}
+		return m, nil, true
+
+	case tea.MouseActionPress:
+		if msg.Button != tea.MouseButtonLeft || !ok {
+			return m, nil, false
+		}
+		m.hover, m.hovering = hotspot{}, false
+		if spot.project != "" {
+			m.selectedProj = spot.project
+			m.view = ViewProjectDetail
+			m.updateViewport()
+			return m, nil, true
+		}
+		// OSC 52 puts the link on the visitor's clipboard with the next frame
+		m.clipboard = spot.link
+		m.statusMessage = "Copied " + spot.link
+		return m, clearStatusAfter(2 * time.Second), true
+	}
+	return m, nil, false
+}
+
+// applyHover re-highlights the hovered hotspot over the last rendered
+// content, keeping the scroll position
+func (m *Model) applyHover() {
+	content := strings.Join(m.viewLines, "\n")
+	if m.hovering && m.hover.view == m.view && m.hover.span.line < len(m.viewLines) {
+		content = highlightMatches(m.themeManager.Styles(), content, []searchMatch{m.hover.span}, -1)
+	}
+	m.viewport.SetContent(content)
+}
+
+// hyperlinkAt finds the OSC 8 link covering cell col of a rendered line;
+// its target may be longer than the truncated text shown
+func hyperlinkAt(raw string, col int) (hotspot, bool) {
+	var spot hotspot
+	open := false
+	for _, loc := range hyperlinkPattern.FindAllStringSubmatchIndex(raw, -1) {
+		at := ansi.StringWidth(raw[:loc[0]])
+		url := raw[loc[2]:loc[3]]
+		if open && col >= spot.span.start && col < at {
+			spot.span.end = at
+			return spot, true
+		}
+		open = url != ""
+		spot = hotspot{link: url, span: searchMatch{start: at}}
+	}
+	return hotspot{}, false
+}
+
+// mouseCmd turns mouse reporting on or off to match mouseEnabled
+func (m Model) mouseCmd() tea.Cmd {
+	if m.mouseEnabled {
+		return func() tea.Msg { return tea.EnableMouseAllMotion() }
+	}
+	return func() tea.Msg { return tea.DisableMouse() }
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// errorNotice is a failed reply put the visitor's way: what happened, and
+// what to do about it, in place of the provider's error
+type errorNotice struct {
+	kind   ai.ErrorKind
+	text   string
+	action string
+}
+
+// String is the notice as the errorMessage it's shown as
+func (n errorNotice) String() string {
+	return n.text + " - " + n.action
+}
+
+// noticeFor is the notice for err, and whether it's of a kind the visitor
+// can do something about
+func noticeFor(err error) (errorNotice, bool) {
+	var streamErr *ai.StreamError
+	if !errors.As(err, &streamErr) {
+		return errorNotice{}, false
+	}
+	n := errorNotice{kind: streamErr.Kind}
+	switch streamErr.Kind {
+	case ai.ErrorRateLimited:
+		wait := "a minute"
+		if streamErr.RetryAfter > 0 {
+			wait = streamErr.RetryAfter.Round(time.Second).String()
+		}
+		n.text, n.action = "Too many questions at once", "wait "+wait+", then /retry"
+	case ai.ErrorModelOverloaded:
+		n.text, n.action = "The AI model is overloaded right now", "/retry in a few seconds"
+	case ai.ErrorContentFiltered:
+		n.text, n.action = "The content filter stopped that reply", "↑ to rephrase it"
+	default:
+		return errorNotice{}, false
+	}
+	return n, true
+}
+
+// render is the notice's footer line, tagged and colored by its kind
+func (n errorNotice) render(styles theme.Styles) string {
+	tag, color := "⚠ ERR", styles.Red
+	switch n.kind {
+	case ai.ErrorRateLimited:
+		tag, color = "◷ SLOW DOWN", styles.Orange
+	case ai.ErrorModelOverloaded:
+		tag, color = "☁ BUSY", styles.Yellow
+	case ai.ErrorContentFiltered:
+		tag, color = "⊘ FILTERED", styles.Purple
+	}
+	return color.Bold(true).Render(tag+": "+n.text) + styles.Dim.Render(" · ") + styles.Cyan.Render(n.action)
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/status"
+)
+
+// StatusSource supplies the owner's status line, usually a
+// *status.Source caching it for every session
+type StatusSource interface {
+	Current(ctx context.Context) status.Status
+}
+
+// OwnerStatusMsg delivers the status line to the welcome screen
+type OwnerStatusMsg struct {
+	Status status.Status
+}
+
+// fetchStatus reads the status in the background, so a slow service
+// never holds up the welcome screen
+func (m Model) fetchStatus() tea.Cmd {
+	source, ctx := m.statusSource, m.ctx
+	return func() tea.Msg {
+		return OwnerStatusMsg{Status: source.Current(ctx)}
+	}
+}
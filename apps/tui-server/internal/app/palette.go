@@ -0,0 +1,208 @@
+package app
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// paletteMaxResults is how many matches the palette shows at once; the
+// rest scroll into view
+const paletteMaxResults = 10
+
+// paletteItem is a runnable entry in the command palette
+type paletteItem struct {
+	Group   string // VIEW, COMMAND, THEME, PROJECT
+	Label   string
+	Hint    string
+	Command string // slash command executed on Enter
+}
+
+// paletteState holds the command palette overlay state
+type paletteState struct {
+	open     bool
+	input    textinput.Model
+	selected int
+}
+
+func newPaletteInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = "type to search views, commands, themes, projects..."
+	input.CharLimit = 64
+	return input
+}
+
+// paletteItems lists every view, slash command, theme and project
+func (m Model) paletteItems() []paletteItem {
+	items := []paletteItem{
+		{Group: "VIEW", Label: "Home", Hint: "welcome screen", Command: "/back"},
+		{Group: "VIEW", Label: "Help", Hint: "shortcuts and commands", Command: "/help"},
+		{Group: "VIEW", Label: "About", Hint: "profile and bio", Command: "/about"},
+		{Group: "VIEW", Label: "Projects", Hint: "project list", Command: "/projects"},
+		{Group: "VIEW", Label: "Resume", Hint: "credentials", Command: "/resume"},
+		{Group: "VIEW", Label: "Experience", Hint: "work history", Command: "/exp"},
+		{Group: "VIEW", Label: "Uses", Hint: "hardware and tools", Command: "/uses"},
+		{Group: "VIEW", Label: "Talks", Hint: "talks and writing", Command: "/talks"},
+		{Group: "VIEW", Label: "Certifications", Hint: "certifications", Command: "/certs"},
+		{Group: "VIEW", Label: "Achievements", Hint: "achievements in full", Command: "/achievements"},
+	}
+
+	for _, c := range commandList {
+		if c.Palette {
+			items = append(items, paletteItem{Group: "COMMAND", Label: c.Name, Hint: c.Help, Command: c.Name})
+		}
+	}
+
+	for _, p := range theme.Palettes {
+		items = append(items, paletteItem{
+			Group:   "THEME",
+			Label:   p.Name,
+			Hint:    "switch color theme",
+			Command: "/theme " + p.Name,
+		})
+	}
+
+	if m.projects != nil {
+		for _, p := range m.projects.Projects {
+			items = append(items, paletteItem{
+				Group:   "PROJECT",
+				Label:   p.Name,
+				Hint:    p.ID,
+				Command: "/open " + p.ID,
+			})
+		}
+	}
+
+	return items
+}
+
+// filteredPaletteItems returns items matching the query, best match first
+func (m Model) filteredPaletteItems() []paletteItem {
+	items := m.paletteItems()
+	query := strings.ToLower(strings.TrimSpace(m.palette.input.Value()))
+	if query == "" {
+		return items
+	}
+
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		haystack := strings.ToLower(item.Label + " " + item.Hint + " " + item.Group)
+		if score, ok := fuzzyScore(query, haystack); ok {
+			matches = append(matches, scored{item: item, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]paletteItem, len(matches))
+	for i, match := range matches {
+		result[i] = match.item
+	}
+	return result
+}
+
+// fuzzyScore reports whether every rune of query appears in order in text,
+// rewarding consecutive runs and matches at word starts.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(query)
+	t := []rune(text)
+	score := 0
+	qi := 0
+	lastMatch := -1
+
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if lastMatch == ti-1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2
+		}
+		if lastMatch >= 0 {
+			score -= min(ti-lastMatch-1, 3)
+		}
+		lastMatch = ti
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+func (m Model) openPalette() (Model, tea.Cmd) {
+	m.palette.open = true
+	m.palette.selected = 0
+	m.palette.input.SetValue("")
+	m.palette.input.Width = max(min(m.columnWidth()-30, 50), 10)
+	m.input.Blur()
+	return m, m.palette.input.Focus()
+}
+
+func (m Model) closePalette() Model {
+	m.palette.open = false
+	m.palette.input.Blur()
+	m.input.Focus()
+	return m
+}
+
+// updatePalette handles keys while the palette is open
+func (m Model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+k":
+		return m.closePalette(), nil
+	case "up", "ctrl+p":
+		if m.palette.selected > 0 {
+			m.palette.selected--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		items := m.filteredPaletteItems()
+		if m.palette.selected < len(items)-1 {
+			m.palette.selected++
+		}
+		return m, nil
+	case "enter":
+		items := m.filteredPaletteItems()
+		m = m.closePalette()
+		if m.palette.selected >= len(items) {
+			return m, nil
+		}
+		return m.handleInput(items[m.palette.selected].Command)
+	}
+
+	var cmd tea.Cmd
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	m.palette.selected = 0
+	return m, cmd
+}
+
+// renderPalette renders the palette box for the current query
+func (m Model) renderPalette(styles theme.Styles) string {
+	items := m.filteredPaletteItems()
+	entries := make([]ui.PaletteEntry, len(items))
+	for i, item := range items {
+		entries[i] = ui.PaletteEntry{
+			Group: item.Group,
+			Label: item.Label,
+			Hint:  item.Hint,
+		}
+	}
+	return ui.CommandPalette(styles, m.palette.input.View(), entries, m.palette.selected, paletteMaxResults, m.columnWidth())
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/layout"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// inputLimit is the most characters one chat message can hold
+const inputLimit = 1000
+
+// counterFrom is the length at which the input shows its counter
+const counterFrom = inputLimit * 3 / 4
+
+// maxPasteParts caps how many messages an oversized paste is split into
+const maxPasteParts = 4
+
+// pasteState holds a paste too long for the input while the visitor
+// decides to send it in parts or trim it
+type pasteState struct {
+	text string
+}
+
+// inputCounter renders the "742/1000" counter once the input nears the
+// limit, in red at the limit
+func (m Model) inputCounter(styles theme.Styles) string {
+	n := len([]rune(m.input.Value()))
+	if n < counterFrom {
+		return ""
+	}
+	style := styles.Dim
+	if n >= inputLimit {
+		style = styles.Red.Bold(true)
+	}
+	return style.Render(fmt.Sprintf("%d/%d", n, inputLimit))
+}
+
+// fitInput sizes the input to the column, leaving room for the counter
+func (m *Model) fitInput() {
+	width := layout.InnerWidth(m.columnWidth()) - 4
+	if counter := m.inputCounter(m.themeManager.Styles()); counter != "" {
+		width -= lipgloss.Width(counter) + 1
+	}
+	if width = max(width, 20); width != m.input.Width {
+		m.input.Width = width
+		// Re-scroll the text to the new width
+		m.input.SetCursor(m.input.Position())
+	}
+}
+
+// handlePaste inserts a paste at the cursor. One that would overflow the
+// input is held instead, for the visitor to send in parts or trim.
+func (m Model) handlePaste(msg tea.KeyMsg) (Model, tea.Cmd) {
+	value := []rune(m.input.Value())
+	pos := m.input.Position()
+	text := string(value[:pos]) + string(msg.Runes) + string(value[pos:])
+	if len([]rune(text)) <= inputLimit {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		m.fitInput()
+		return m, cmd
+	}
+	m.paste = pasteState{text: text}
+	m.input.SetValue(string([]rune(text)[:inputLimit]))
+	m.fitInput()
+	return m, nil
+}
+
+// updatePaste handles keys while a long paste waits: ENTER sends it in
+// parts, ESC keeps what fits in the input, anything else edits that
+func (m Model) updatePaste(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, keys.SendParts):
+		if m.isStreaming {
+			return m, nil, true
+		}
+		model, cmd := m.sendParts(splitParts(m.paste.text, inputLimit))
+		return model.(Model), cmd, true
+	case key.Matches(msg, keys.Trim):
+		m.paste = pasteState{}
+		m.statusMessage = fmt.Sprintf("Trimmed to %d characters", inputLimit)
+		return m, clearStatusAfter(2 * time.Second), true
+	}
+	m.paste = pasteState{}
+	return m, nil, false
+}
+
+// pasteSummary describes how the held paste would be sent
+func (m Model) pasteSummary() string {
+	parts := splitParts(m.paste.text, inputLimit)
+	total, sent := len([]rune(m.paste.text)), 0
+	for _, p := range parts {
+		sent += len([]rune(p))
+	}
+	summary := fmt.Sprintf("%d chars in %d parts", total, len(parts))
+	if total-sent > len(parts)*2 {
+		// More than the whitespace trimmed at the cuts is left out
+		summary += fmt.Sprintf(", last %d dropped", total-sent)
+	}
+	return summary
+}
+
+// sendParts sends parts as consecutive messages, then asks for one reply
+func (m Model) sendParts(parts []string) (tea.Model, tea.Cmd) {
+	if !m.aiReady() {
+		return m, nil
+	}
+	m.paste = pasteState{}
+	m.input.SetValue("")
+	m.fitInput()
+	m.errorMessage = ""
+	m.statusMessage = ""
+	m.edit = editState{}
+	now := time.Now()
+	for i, part := range parts {
+		m.chatHistory = append(m.chatHistory, ChatMessage{
+			Role:    "user",
+			Content: part,
+			Time:    now,
+			Part:    fmt.Sprintf("%d/%d", i+1, len(parts)),
+		})
+	}
+	return m.streamReply(len(m.chatHistory) - 1)
+}
+
+// splitParts cuts text into at most maxPasteParts pieces of up to limit
+// characters, breaking at whitespace where it can. Text past the last
+// part is dropped.
+func splitParts(text string, limit int) []string {
+	rest := []rune(strings.TrimSpace(text))
+	var parts []string
+	for len(rest) > 0 && len(parts) < maxPasteParts {
+		cut := min(limit, len(rest))
+		if cut < len(rest) {
+			for i := cut; i > limit/2; i-- {
+				if unicode.IsSpace(rest[i]) {
+					cut = i
+					break
+				}
+			}
+		}
+		parts = append(parts, strings.TrimSpace(string(rest[:cut])))
+		rest = []rune(strings.TrimSpace(string(rest[cut:])))
+	}
+	return parts
+}
@@ -0,0 +1,59 @@
+package app
+
+import "time"
+
+// defaultPlaceholder is the input placeholder when it isn't rotating
+const defaultPlaceholder = "enter command or chat..."
+
+// placeholderInterval is how long each rotating placeholder shows
+const placeholderInterval = 4 * time.Second
+
+// placeholders are the input hints for the current view, shown in turn
+// while the input is empty to teach the commands in passing
+func (m Model) placeholders() []string {
+	project := "ssh-portfolio"
+	if len(m.projects.Projects) > 0 {
+		project = m.projects.Projects[0].ID
+	}
+	switch m.view {
+	case ViewProjects:
+		return []string{
+			"1-9 to open a project...",
+			"/open " + project + " for the details...",
+			"ask which project to look at first...",
+		}
+	case ViewProjectDetail:
+		return []string{
+			"ask how this project was built...",
+			"/back to the project list...",
+		}
+	case ViewChat:
+		return []string{
+			defaultPlaceholder,
+			"ask about my Go work...",
+			"/projects to browse...",
+			"try /open " + project + "...",
+			"^K to search every command...",
+		}
+	}
+	return []string{
+		defaultPlaceholder,
+		"ask a follow-up about this...",
+		"^W to go home...",
+	}
+}
+
+// rotatePlaceholder shows the placeholder due at m.now. Reduced motion and
+// screen readers keep the default, since a changing prompt is noise there.
+func (m *Model) rotatePlaceholder() {
+	if m.reducedMotion || m.themeManager.Accessible() {
+		m.input.Placeholder = defaultPlaceholder
+		return
+	}
+	if m.input.Value() != "" {
+		return
+	}
+	hints := m.placeholders()
+	turn := int(m.now.Sub(m.sessionStart) / placeholderInterval)
+	m.input.Placeholder = hints[turn%len(hints)]
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+)
+
+// Store persists per-visitor data between sessions: /set preferences,
+// visit history, game high scores, bookmarks and the content version last
+// seen
+type Store interface {
+	Load(key string) (map[string]string, error)
+	Save(key string, prefs map[string]string) error
+	RecordVisit(key string, at time.Time) (store.Visits, error)
+	ForgetVisits(key string) error
+	HighScore(key, game string) (int, error)
+	RecordScore(key, game string, score int) (int, error)
+	Bookmarks(key string) ([]string, error)
+	SaveBookmarks(key string, ids []string) error
+	SaveSnapshot(key string, data []byte, at time.Time) error
+	TakeSnapshot(key string, since time.Time) ([]byte, error)
+	RecordContentSeen(key, version string) (string, error)
+	ArchivedContent(version string) ([]byte, error)
+}
+
+// PrefsErrorMsg reports a preference save that failed
+type PrefsErrorMsg struct{ Err error }
+
+// prefsState tracks whose preferences these are and which settings the
+// visitor chose; settings left at their defaults are not saved
+type prefsState struct {
+	store Store
+	key   string // "key:<hash>" or "login:<hash>"; empty when not saved
+	owner string // who /prefs says they belong to, e.g. "your SSH key"
+	saved map[string]string
+}
+
+// PublicKeyVisitorKey is the store key for a visitor's hashed public key
+func PublicKeyVisitorKey(keyHash string) string {
+	if keyHash == "" {
+		return ""
+	}
+	return "key:" + keyHash
+}
+
+// loginPrefsKey derives the store key for a /login handle and passphrase
+func loginPrefsKey(handle, passphrase string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(handle) + "\n" + passphrase))
+	return "login:" + hex.EncodeToString(sum[:16])
+}
+
+// loadPrefs switches to the preferences saved under key and applies them.
+// Values that are no longer valid are dropped.
+func (m *Model) loadPrefs(key, owner string) {
+	m.prefs.key, m.prefs.owner = key, owner
+	if m.prefs.store == nil || key == "" {
+		return
+	}
+	m.loadBookmarks()
+	saved, err := m.prefs.store.Load(key)
+	if err != nil {
+		m.errorMessage = "Couldn't load preferences"
+		return
+	}
+	if len(saved) == 0 {
+		return
+	}
+	// Applying prints each setting's status line; restoring them is silent
+	status := m.statusMessage
+	defer func() { m.statusMessage = status }()
+	m.prefs.saved = make(map[string]string, len(saved))
+	for _, name := range settingKeys() {
+		if value, ok := saved[name]; ok {
+			if _, err := m.applySetting(name, value); err == nil {
+				m.prefs.saved[name] = value
+			}
+		}
+	}
+}
+
+// rememberSetting records a setting the visitor changed and saves it
+func (m *Model) rememberSetting(name, value string) tea.Cmd {
+	if m.prefs.saved == nil {
+		m.prefs.saved = make(map[string]string)
+	}
+	m.prefs.saved[name] = value
+	return m.savePrefs()
+}
+
+// savePrefs writes the chosen settings in the background, and forgets
+// the visit history of visitors who turned it off
+func (m Model) savePrefs() tea.Cmd {
+	if m.prefs.store == nil || m.prefs.key == "" || m.readOnly {
+		return nil
+	}
+	s, key, prefs := m.prefs.store, m.prefs.key, maps.Clone(m.prefs.saved)
+	return func() tea.Msg {
+		if err := s.Save(key, prefs); err != nil {
+			return PrefsErrorMsg{Err: err}
+		}
+		if prefs["visits"] == "off" {
+			if err := s.ForgetVisits(key); err != nil {
+				return PrefsErrorMsg{Err: err}
+			}
+		}
+		return nil
+	}
+}
+
+// handlePrefs runs /prefs: list the saved preferences, or reset them
+func (m Model) handlePrefs(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 0 && strings.ToLower(args[0]) == "reset" {
+		m.prefs.saved = nil
+		m.statusMessage = "Saved preferences cleared"
+		return m, tea.Batch(m.savePrefs(), clearStatusAfter(3*time.Second))
+	}
+
+	switch {
+	case m.readOnly:
+		m.statusMessage = readOnlyNotice
+	case m.prefs.store == nil:
+		m.statusMessage = "Preferences aren't saved on this server"
+	case m.prefs.key == "":
+		m.statusMessage = "Not saved: connect with an SSH key or /login <handle> <passphrase>"
+	case len(m.prefs.saved) == 0:
+		m.statusMessage = "No saved preferences for " + m.prefs.owner + " - change one with /set"
+	default:
+		names := make([]string, 0, len(m.prefs.saved))
+		for name := range m.prefs.saved {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			names[i] = name + "=" + m.prefs.saved[name]
+		}
+		m.statusMessage = "Saved for " + m.prefs.owner + ": " + strings.Join(names, " · ")
+	}
+	return m, clearStatusAfter(5 * time.Second)
+}
+
+// handleLogin runs /login: preferences follow a handle and passphrase
+// instead of the SSH key. Choices made before logging in carry over when
+// the handle has none saved yet.
+func (m Model) handleLogin(args []string) (tea.Model, tea.Cmd) {
+	if len(args) < 2 {
+		m.errorMessage = "Usage: /login <handle> <passphrase>"
+		return m, nil
+	}
+	if m.readOnly {
+		m.errorMessage = readOnlyNotice
+		return m, nil
+	}
+	if m.prefs.store == nil {
+		m.errorMessage = "Preferences aren't saved on this server"
+		return m, nil
+	}
+
+	carried, marked := m.prefs.saved, m.bookmarks
+	m.prefs.saved = nil
+	m.loadPrefs(loginPrefsKey(args[0], strings.Join(args[1:], " ")), "@"+args[0])
+	var save, saveMarks tea.Cmd
+	if len(m.prefs.saved) == 0 && len(carried) > 0 {
+		m.prefs.saved = carried
+		save = m.savePrefs()
+	}
+	// Bookmarks made before logging in join the handle's
+	if merged := mergeBookmarks(m.bookmarks, marked); len(merged) > len(m.bookmarks) {
+		m.bookmarks = merged
+		saveMarks = m.saveBookmarks()
+	}
+
+	m.statusMessage = "Logged in as @" + args[0]
+	m.updateViewport()
+	return m, tea.Batch(save, saveMarks, m.mouseCmd(), clearStatusAfter(3*time.Second))
+}
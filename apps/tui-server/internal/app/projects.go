@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// maxChips is how many tech or tag chips the projects list offers, the
+// most used first
+const maxChips = 6
+
+// projectsUsage is the /projects argument spec
+const projectsUsage = "[--tech <name>] [--status <status>] [--tag <tag>] [--sort recent|name]"
+
+// projectsState is the projects list's filters, the sections collapsed,
+// and the chip or section header the arrow keys are on, -1 until they're
+// used
+type projectsState struct {
+	query     content.ProjectQuery
+	collapsed []string
+	cursor    int
+}
+
+// parseProjectQuery reads /projects flags, as in --tech go --sort recent.
+// A value runs to the next flag, so --tech bubble tea works; --tech=go
+// works too.
+func parseProjectQuery(args []string) (content.ProjectQuery, error) {
+	var q content.ProjectQuery
+	var field *string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			if field == nil {
+				return q, fmt.Errorf("unexpected %q", arg)
+			}
+			*field = strings.TrimSpace(*field + " " + arg)
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		switch name {
+		case "tech":
+			field = &q.Tech
+		case "status":
+			field = &q.Status
+		case "tag":
+			field = &q.Tag
+		case "sort":
+			field = &q.Sort
+		default:
+			return q, fmt.Errorf("unknown option --%s", name)
+		}
+		*field = value
+	}
+	q.Sort = strings.ToLower(q.Sort)
+	if q.Sort != "" && !slices.Contains(content.ProjectSorts, q.Sort) {
+		return q, fmt.Errorf("can't sort by %q, only %s", q.Sort, strings.Join(content.ProjectSorts, " or "))
+	}
+	return q, nil
+}
+
+// filterProjects runs /projects with flags, or shows every project
+// without any
+func (m Model) filterProjects(args []string) (tea.Model, tea.Cmd) {
+	q, err := parseProjectQuery(args)
+	if err != nil {
+		m.errorMessage = "Usage: /projects " + projectsUsage + " (" + err.Error() + ")"
+		m.updateViewport()
+		return m, nil
+	}
+	m.projectList.query = q
+	return m.showView(ViewProjects)
+}
+
+// projectSections are the filtered projects in their categories
+func (m Model) projectSections() []ui.ProjectSection {
+	return ui.ProjectSections(content.GroupProjects(m.projects.Query(m.projectList.query)), m.projectList.collapsed)
+}
+
+// shownProjects are the projects the list shows, in its order and
+// leaving out collapsed sections; its [n] numbers count these
+func (m Model) shownProjects() []content.Project {
+	var shown []content.Project
+	for _, s := range m.projectSections() {
+		if !s.Collapsed {
+			shown = append(shown, s.Projects...)
+		}
+	}
+	return shown
+}
+
+// projectChips are the filters offered above the list: each status, the
+// most used tech and tags, and the sorts. One set by /projects is offered
+// even when it's not among them.
+func (m Model) projectChips() []ui.Chip {
+	if m.projects == nil || len(m.projects.Projects) == 0 {
+		return nil
+	}
+	q := m.projectList.query
+
+	var statuses, tech, tags []string
+	for _, p := range m.projects.Projects {
+		statuses = append(statuses, p.Status)
+		tech = append(tech, p.Tech...)
+		tags = append(tags, p.Tags...)
+	}
+
+	var chips []ui.Chip
+	add := func(group, current string, labels []string) {
+		if current != "" && !slices.ContainsFunc(labels, func(l string) bool { return strings.EqualFold(l, current) }) {
+			labels = append(labels, current)
+		}
+		for _, l := range labels {
+			chips = append(chips, ui.Chip{Group: group, Label: l, On: strings.EqualFold(l, current)})
+		}
+	}
+	add("STATUS", q.Status, mostUsed(statuses, len(statuses)))
+	add("TECH", q.Tech, mostUsed(tech, maxChips))
+	add("TAG", q.Tag, mostUsed(tags, maxChips))
+	add("SORT", q.Sort, content.ProjectSorts)
+	return chips
+}
+
+// mostUsed lists the distinct values, the most frequent first and ties
+// in order, up to n
+func mostUsed(values []string, n int) []string {
+	counts := map[string]int{}
+	var distinct []string
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if counts[v] == 0 {
+			distinct = append(distinct, v)
+		}
+		counts[v]++
+	}
+	slices.SortStableFunc(distinct, func(a, b string) int { return counts[b] - counts[a] })
+	return distinct[:min(len(distinct), n)]
+}
+
+// updateProjects moves along the filter chips and on to the section
+// headers with the arrow keys, and toggles the one picked with Enter.
+// Keys it doesn't use fall through to the input.
+func (m Model) updateProjects(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	chips := m.projectChips()
+	var headers []string
+	for _, s := range m.projectSections() {
+		if s.Name != "" {
+			headers = append(headers, s.Name)
+		}
+	}
+	last := len(chips) + len(headers) - 1
+	if last < 0 {
+		return m, nil, false
+	}
+	cursor := &m.projectList.cursor
+	switch {
+	case key.Matches(msg, keys.ChipPrev):
+		*cursor = max(min(*cursor, last)-1, 0)
+	case key.Matches(msg, keys.ChipNext):
+		*cursor = min(*cursor+1, last)
+	case key.Matches(msg, keys.ToggleChip) && *cursor >= len(chips):
+		name := headers[min(*cursor, last)-len(chips)]
+		if i := slices.Index(m.projectList.collapsed, name); i >= 0 {
+			m.projectList.collapsed = slices.Delete(slices.Clone(m.projectList.collapsed), i, i+1)
+		} else {
+			m.projectList.collapsed = append(slices.Clone(m.projectList.collapsed), name)
+		}
+	case key.Matches(msg, keys.ToggleChip) && *cursor >= 0:
+		chip := chips[*cursor]
+		value := chip.Label
+		if chip.On {
+			value = ""
+		}
+		switch chip.Group {
+		case "STATUS":
+			m.projectList.query.Status = value
+		case "TECH":
+			m.projectList.query.Tech = value
+		case "TAG":
+			m.projectList.query.Tag = value
+		case "SORT":
+			m.projectList.query.Sort = value
+		}
+	default:
+		return m, nil, false
+	}
+	m.updateViewport()
+	return m, nil, true
+}
+
+// projectsData is the list as the ui package draws it
+func (m Model) projectsData() ui.ProjectsData {
+	data := ui.ProjectsData{Sections: m.projectSections(), Chips: m.projectChips(), Bookmarks: m.bookmarks, Cursor: m.projectList.cursor}
+	if m.projects != nil {
+		data.Total = len(m.projects.Projects)
+	}
+	return data
+}
@@ -0,0 +1,337 @@
+package app
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+const (
+	// quizLength is how many questions a round asks
+	quizLength = 5
+	// quizChoices is the most choices a question offers
+	quizChoices = 4
+)
+
+// quizState is a round of /quiz. picked is -1 until the current question
+// is answered; done is set after the last one.
+type quizState struct {
+	questions []ui.QuizQuestion
+	current   int
+	picked    int
+	score     int
+	done      bool
+}
+
+// card is the current question as the ui package draws it
+func (q quizState) card() ui.QuizCard {
+	return ui.QuizCard{
+		Question: q.questions[q.current],
+		Number:   q.current + 1,
+		Total:    len(q.questions),
+		Score:    q.score,
+		Picked:   q.picked,
+	}
+}
+
+// verdict is the line the results end on
+func (q quizState) verdict() string {
+	total := len(q.questions)
+	switch {
+	case q.score == total:
+		return "Flawless. You clearly read the resume - shall we talk about a role?"
+	case q.score*5 >= total*3:
+		return "Solid. You'd sail through the screening call."
+	case q.score > 0:
+		return "Not bad. A quick look at /resume and /projects would fix the rest."
+	default:
+		return "Were you even reading? /projects is a good place to start."
+	}
+}
+
+// startQuiz opens the quiz view on a new round of questions drawn from
+// the resume and projects
+func (m Model) startQuiz() (tea.Model, tea.Cmd) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	questions := quizQuestions(m.resume, m.projects, rng, quizLength)
+	if len(questions) == 0 {
+		m.errorMessage = "Not enough resume content for a quiz"
+		m.updateViewport()
+		return m, nil
+	}
+	m.quiz = quizState{questions: questions, picked: -1}
+	m.helpOpen = false
+	m.statusMessage = ""
+	m.errorMessage = ""
+	m.view = ViewQuiz
+	m.showWelcome = false
+	m.updateViewport()
+	return m, nil
+}
+
+// updateQuiz answers with the number keys and moves on with ENTER. Keys
+// it doesn't use fall through to the input.
+func (m Model) updateQuiz(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	q := &m.quiz
+	if len(q.questions) == 0 {
+		return m, nil, false
+	}
+	switch {
+	case q.done:
+		if !key.Matches(msg, keys.Restart) {
+			return m, nil, false
+		}
+		model, cmd := m.startQuiz()
+		return model.(Model), cmd, true
+	case q.picked < 0:
+		choices := q.questions[q.current].Choices
+		if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+			return m, nil, false
+		}
+		n := int(msg.Runes[0] - '1')
+		if n < 0 || n >= len(choices) {
+			return m, nil, false
+		}
+		q.picked = n
+		if n == q.questions[q.current].Answer {
+			q.score++
+		}
+	case key.Matches(msg, keys.NextQuestion):
+		q.current++
+		q.picked = -1
+		if q.current == len(q.questions) {
+			q.current--
+			q.done = true
+		}
+	default:
+		return m, nil, false
+	}
+	m.updateViewport()
+	return m, nil, true
+}
+
+// renderQuiz is the quiz view's content
+func (m Model) renderQuiz(styles theme.Styles) string {
+	if m.quiz.done {
+		return ui.QuizResult(styles, m.quiz.score, len(m.quiz.questions), m.quiz.verdict(), m.columnWidth())
+	}
+	return ui.Quiz(styles, m.quiz.card(), m.columnWidth())
+}
+
+// quizQuestions draws up to n questions, taking each kind in turn so a
+// round mixes work history, projects and skills
+func quizQuestions(resume *content.Resume, projects *content.Projects, rng *rand.Rand, n int) []ui.QuizQuestion {
+	var kinds [][]ui.QuizQuestion
+	if resume != nil {
+		kinds = append(kinds, roleQuestions(resume), periodQuestions(resume), skillQuestions(resume))
+	}
+	if projects != nil {
+		kinds = append(kinds, descriptionQuestions(projects), techQuestions(projects))
+	}
+	for _, pool := range kinds {
+		rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	}
+	rng.Shuffle(len(kinds), func(i, j int) { kinds[i], kinds[j] = kinds[j], kinds[i] })
+
+	var out []ui.QuizQuestion
+	for round := 0; len(out) < n; round++ {
+		added := false
+		for _, pool := range kinds {
+			if round < len(pool) && len(out) < n {
+				out = append(out, shuffleChoices(pool[round], rng))
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return out
+}
+
+// question builds a question from its answer and the wrong choices,
+// keeping a few distinct ones. It fails without at least one.
+func question(prompt, answer string, wrong []string) (ui.QuizQuestion, bool) {
+	choices := []string{answer}
+	seen := map[string]bool{strings.ToLower(answer): true}
+	for _, w := range wrong {
+		if len(choices) == quizChoices {
+			break
+		}
+		if !seen[strings.ToLower(w)] {
+			seen[strings.ToLower(w)] = true
+			choices = append(choices, w)
+		}
+	}
+	if len(choices) < 2 {
+		return ui.QuizQuestion{}, false
+	}
+	return ui.QuizQuestion{Prompt: prompt, Choices: choices, Answer: 0}, true
+}
+
+// shuffleChoices moves the answer somewhere other than always first
+func shuffleChoices(q ui.QuizQuestion, rng *rand.Rand) ui.QuizQuestion {
+	choices := append([]string(nil), q.Choices...)
+	answer := choices[q.Answer]
+	rng.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	for i, c := range choices {
+		if c == answer {
+			q.Answer = i
+		}
+	}
+	q.Choices = choices
+	return q
+}
+
+// firstName is who the questions ask about
+func firstName(resume *content.Resume) string {
+	if fields := strings.Fields(resume.Name); len(fields) > 0 {
+		return fields[0]
+	}
+	return "the author"
+}
+
+// roleQuestions ask where each role was held. Companies where the same
+// role was also held are left out of the wrong choices.
+func roleQuestions(resume *content.Resume) []ui.QuizQuestion {
+	var out []ui.QuizQuestion
+	for _, exp := range resume.Experience {
+		var wrong []string
+		for _, other := range resume.Experience {
+			if other.Company != exp.Company && !sameRoleAt(resume, exp.Role, other.Company) {
+				wrong = append(wrong, other.Company)
+			}
+		}
+		prompt := fmt.Sprintf("Where did %s work as %s?", firstName(resume), exp.Role)
+		if q, ok := question(prompt, exp.Company, wrong); ok {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+func sameRoleAt(resume *content.Resume, role, company string) bool {
+	for _, exp := range resume.Experience {
+		if exp.Role == role && exp.Company == company {
+			return true
+		}
+	}
+	return false
+}
+
+// periodQuestions ask when each role was held
+func periodQuestions(resume *content.Resume) []ui.QuizQuestion {
+	var out []ui.QuizQuestion
+	for _, exp := range resume.Experience {
+		var wrong []string
+		for _, other := range resume.Experience {
+			wrong = append(wrong, other.Period)
+		}
+		prompt := fmt.Sprintf("When was %s %s at %s?", firstName(resume), exp.Role, exp.Company)
+		if q, ok := question(prompt, exp.Period, wrong); ok {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// skillQuestions ask which skill belongs to a category, with wrong
+// choices from the other categories
+func skillQuestions(resume *content.Resume) []ui.QuizQuestion {
+	s := resume.Skills
+	categories := []struct {
+		name   string
+		skills []string
+	}{
+		{"languages", content.SkillNames(s.Languages)}, {"frontend", content.SkillNames(s.Frontend)},
+		{"backend", content.SkillNames(s.Backend)}, {"databases", content.SkillNames(s.Databases)},
+		{"DevOps", content.SkillNames(s.DevOps)}, {"tools", content.SkillNames(s.Tools)},
+		{"mobile", content.SkillNames(s.Mobile)},
+	}
+	var out []ui.QuizQuestion
+	for i, c := range categories {
+		if len(c.skills) == 0 {
+			continue
+		}
+		var wrong []string
+		for j, other := range categories {
+			if j != i && len(other.skills) > 0 {
+				wrong = append(wrong, other.skills[(i+j)%len(other.skills)])
+			}
+		}
+		prompt := fmt.Sprintf("Which of these does %s list under %s skills?", firstName(resume), c.name)
+		if q, ok := question(prompt, c.skills[0], wrong); ok {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// descriptionQuestions ask which project a description belongs to, with
+// the project's own name blanked out of it
+func descriptionQuestions(projects *content.Projects) []ui.QuizQuestion {
+	var out []ui.QuizQuestion
+	for _, p := range projects.Projects {
+		desc := firstSentence(p.Description)
+		if desc == "" {
+			continue
+		}
+		desc = regexp.MustCompile(`(?i)`+regexp.QuoteMeta(p.Name)).ReplaceAllString(desc, "___")
+		prompt := fmt.Sprintf("Which project is this? %q", desc)
+		if q, ok := question(prompt, p.Name, otherProjects(projects, p.ID)); ok {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// techQuestions ask which project uses a technology only it uses
+func techQuestions(projects *content.Projects) []ui.QuizQuestion {
+	uses := map[string]int{}
+	for _, p := range projects.Projects {
+		for _, t := range p.Tech {
+			uses[strings.ToLower(t)]++
+		}
+	}
+	var out []ui.QuizQuestion
+	for _, p := range projects.Projects {
+		for _, t := range p.Tech {
+			if uses[strings.ToLower(t)] != 1 {
+				continue
+			}
+			prompt := fmt.Sprintf("Which project is built with %s?", t)
+			if q, ok := question(prompt, p.Name, otherProjects(projects, p.ID)); ok {
+				out = append(out, q)
+			}
+			break
+		}
+	}
+	return out
+}
+
+func otherProjects(projects *content.Projects, id string) []string {
+	var names []string
+	for _, p := range projects.Projects {
+		if p.ID != id {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// firstSentence cuts a description at its first full stop
+func firstSentence(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
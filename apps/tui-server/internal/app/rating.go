@@ -0,0 +1,61 @@
+package app
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Ratings a visitor can give an answer
+const (
+	ratingUp   = "up"
+	ratingDown = "down"
+)
+
+// ratingKey reports the rating a key gives, if it's one of + and -
+func ratingKey(msg tea.KeyMsg) (string, bool) {
+	switch {
+	case key.Matches(msg, keys.RateUp):
+		return ratingUp, true
+	case key.Matches(msg, keys.RateDown):
+		return ratingDown, true
+	}
+	return "", false
+}
+
+// ratedAnswer is the index of the answer + and - rate: the last message,
+// once it's finished and on screen with the input empty; -1 otherwise
+func (m Model) ratedAnswer() int {
+	last := len(m.chatHistory) - 1
+	if m.view != ViewChat || m.isStreaming || m.input.Value() != "" || last < 0 {
+		return -1
+	}
+	if msg := m.chatHistory[last]; msg.Role != "assistant" || msg.Superseded {
+		return -1
+	}
+	return last
+}
+
+// rateAnswer rates the last answer and reports it to analytics with the
+// question it answered. Rating it again changes the rating.
+func (m Model) rateAnswer(rating string) (tea.Model, tea.Cmd) {
+	i := m.ratedAnswer()
+	answer := m.chatHistory[i]
+	if answer.Rating == rating {
+		return m, nil
+	}
+	question := ""
+	if q := m.lastUserMessage(); q >= 0 {
+		question = m.chatHistory[q].Content
+	}
+
+	m.chatHistory[i].Rating = rating
+	if m.analytics != nil {
+		m.analytics.TrackAnswerRated(m.sessionID, question, answer.Content, rating, answer.FAQ)
+	}
+	m.statusMessage = "Thanks - that helps improve the answers"
+	m.updateViewport()
+	m.viewport.GotoBottom()
+	return m, clearStatusAfter(2 * time.Second)
+}
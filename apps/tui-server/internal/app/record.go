@@ -0,0 +1,191 @@
+package app
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// maxRecording stops a recording that runs this long
+	maxRecording = 15 * time.Minute
+	// maxRecordingBytes stops a recording whose cast grows this large
+	maxRecordingBytes = 20 << 20
+	// recordingRetention is how long finished casts stay downloadable
+	recordingRetention = 24 * time.Hour
+)
+
+// castRecorder writes the session's rendered frames to an asciinema v2
+// cast file. Frames are captured from View, so it records exactly what
+// the visitor saw.
+type castRecorder struct {
+	name string // "<token>.cast"; the token is the only way to fetch it
+
+	mu      sync.Mutex
+	file    *os.File
+	w       *bufio.Writer
+	start   time.Time
+	width   int
+	height  int
+	last    string
+	written int
+	done    bool
+}
+
+// startCast creates a cast in dir named with a random token, so nobody
+// can guess another visitor's recording
+func startCast(dir string, width, height int) (*castRecorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	pruneCasts(dir, time.Now().Add(-recordingRetention))
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	name := hex.EncodeToString(token) + ".cast"
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &castRecorder{
+		name:   name,
+		file:   file,
+		w:      bufio.NewWriter(file),
+		start:  time.Now(),
+		width:  width,
+		height: height,
+	}
+	header, _ := json.Marshal(map[string]any{
+		"version":   2,
+		"width":     width,
+		"height":    height,
+		"timestamp": r.start.Unix(),
+		"title":     "bmohak.xyz",
+		"env":       map[string]string{"TERM": "xterm-256color"},
+	})
+	r.writeLine(header)
+	return r, nil
+}
+
+// capture records a frame if it differs from the last one. The whole
+// screen is redrawn each time, so any frame can be seeked to on playback.
+func (r *castRecorder) capture(frame string, width, height int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done || frame == r.last {
+		return
+	}
+	elapsed := time.Since(r.start)
+	if width != r.width || height != r.height {
+		r.width, r.height = width, height
+		r.event(elapsed, "r", fmt.Sprintf("%dx%d", width, height))
+	}
+	r.last = frame
+	r.event(elapsed, "o", "\x1b[H\x1b[2J"+strings.ReplaceAll(frame, "\n", "\r\n"))
+}
+
+// full reports whether the recording hit its duration or size limit
+func (r *castRecorder) full() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Since(r.start) > maxRecording || r.written > maxRecordingBytes
+}
+
+// stop flushes and closes the cast; later calls do nothing
+func (r *castRecorder) stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done {
+		return nil
+	}
+	r.done = true
+	if err := r.w.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// event appends one [time, type, data] line; the caller holds mu
+func (r *castRecorder) event(at time.Duration, kind, data string) {
+	line, _ := json.Marshal([]any{at.Seconds(), kind, data})
+	r.writeLine(line)
+}
+
+func (r *castRecorder) writeLine(line []byte) {
+	n, _ := r.w.Write(line)
+	_ = r.w.WriteByte('\n')
+	r.written += n + 1
+}
+
+// pruneCasts deletes casts last written before cutoff
+func pruneCasts(dir string, cutoff time.Time) {
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && strings.HasSuffix(e.Name(), ".cast") && info.ModTime().Before(cutoff) {
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
+
+// handleRecord runs /record: start recording, or stop with "/record stop"
+func (m Model) handleRecord(args []string) (tea.Model, tea.Cmd) {
+	stopping := len(args) > 0 && strings.ToLower(args[0]) == "stop"
+	switch {
+	case m.readOnly:
+		m.errorMessage = readOnlyNotice
+		return m, nil
+	case m.recordingsDir == "":
+		m.errorMessage = "Recording isn't available on this server"
+		return m, nil
+	case stopping && m.recorder == nil:
+		m.errorMessage = "Not recording"
+		return m, nil
+	case stopping:
+		return m.stopRecording()
+	case m.recorder != nil:
+		m.errorMessage = "Already recording - /record stop to finish"
+		return m, nil
+	}
+
+	rec, err := startCast(m.recordingsDir, m.width, m.height)
+	if err != nil {
+		m.errorMessage = "Couldn't start recording"
+		return m, nil
+	}
+	m.recorder = rec
+	// A dropped connection still leaves a playable cast behind
+	go func() {
+		<-m.ctx.Done()
+		_ = rec.stop()
+	}()
+	m.statusMessage = "Recording to " + rec.name + " - /record stop to finish"
+	return m, clearStatusAfter(5 * time.Second)
+}
+
+// stopRecording finishes the cast and hands the visitor the scp command
+// to download it, copied to their clipboard
+func (m Model) stopRecording() (tea.Model, tea.Cmd) {
+	rec := m.recorder
+	m.recorder = nil
+	if err := rec.stop(); err != nil {
+		m.errorMessage = "Recording failed to save"
+		return m, nil
+	}
+	download := m.scpPrefix + rec.name + " ."
+	m.clipboard = download
+	m.statusMessage = "Saved, copied: " + download
+	return m, clearStatusAfter(15 * time.Second)
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// renderedMessage is a chat message together with its rendered form
+type renderedMessage struct {
+	msg      ChatMessage
+	stamp    string
+	rendered string
+}
+
+// chatRenderCache memoizes rendered chat history. Entries are reused while
+// the render key (width, theme, glyphs, backend, timestamps) is unchanged and
+// the message at that position and its timestamp text are the same. It
+// covers the rendered messages only, from history index start on.
+type chatRenderCache struct {
+	key     string
+	start   int
+	entries []renderedMessage
+}
+
+// window moves the cache to cover messages from history index from on:
+// archived entries are released and newly loaded ones left as gaps
+func (c *chatRenderCache) window(key string, from int) {
+	if c.key != key {
+		c.key = key
+		c.start = from
+		c.entries = c.entries[:0]
+		return
+	}
+	switch {
+	case from > c.start:
+		n := min(from-c.start, len(c.entries))
+		c.entries = append([]renderedMessage(nil), c.entries[n:]...)
+	case from < c.start:
+		c.entries = append(make([]renderedMessage, c.start-from), c.entries...)
+	}
+	c.start = from
+}
+
+// shift follows the history dropping n messages from its front
+func (c *chatRenderCache) shift(n int) {
+	c.start -= n
+	if c.start < 0 {
+		c.entries = c.entries[min(-c.start, len(c.entries)):]
+		c.start = 0
+	}
+}
+
+// renderKey captures every setting that changes how a message renders.
+// The styles' generation covers anything else they're rebuilt for.
+func (m Model) renderKey() string {
+	tm := m.themeManager
+	return fmt.Sprintf("%d|%s|%s|%t|%s|%s|%d", m.columnWidth(), tm.Palette().Name, m.mdBackend, tm.Accessible(),
+		tm.Glyphs().Name, m.timestamps, tm.Generation())
+}
+
+// renderMessage returns the rendered message at history index i, rendering
+// it only on a cache miss. window must have been called for this render.
+func (c *chatRenderCache) renderMessage(i int, msg ChatMessage, stamp string, render func() string) string {
+	k := i - c.start
+	if k >= 0 && k < len(c.entries) && c.entries[k].rendered != "" &&
+		c.entries[k].msg == msg && c.entries[k].stamp == stamp {
+		return c.entries[k].rendered
+	}
+
+	rendered := render()
+	entry := renderedMessage{msg: msg, stamp: stamp, rendered: rendered}
+	switch {
+	case k < 0:
+	case k < len(c.entries) && (c.entries[k].msg == msg || c.entries[k].rendered == ""):
+		// Only the relative time moved on, or a gap left by loading
+		// earlier messages; later entries are still valid
+		c.entries[k] = entry
+	case k < len(c.entries):
+		// History changed under us (cleared or edited); drop everything after
+		c.entries = append(c.entries[:k], entry)
+	case k == len(c.entries):
+		c.entries = append(c.entries, entry)
+	}
+	return rendered
+}
+
+// renderHistoryMessage renders one history entry through the cache
+func (m Model) renderHistoryMessage(styles theme.Styles, i int, mdRenderer ui.MessageRenderer) string {
+	msg := m.chatHistory[i]
+	stamp := m.messageStamp(msg.Time)
+	if msg.Part != "" {
+		stamp = strings.TrimSuffix("part "+msg.Part+" · "+stamp, " · ")
+	}
+	return m.renderCache.renderMessage(i, msg, stamp, func() string {
+		if msg.Superseded {
+			return ui.SupersededMessage(styles, msg.Content, m.columnWidth())
+		}
+		role := msg.Role
+		if msg.FAQ {
+			role = "faq"
+		}
+		rendered := ui.ChatMessage(styles, role, msg.Content, stamp, m.columnWidth(), mdRenderer)
+		if msg.Truncated {
+			rendered += ui.TruncatedNote(styles)
+		}
+		if msg.Rating != "" {
+			rendered += ui.RatedNote(styles, msg.Rating == ratingUp)
+		}
+		return rendered
+	})
+}
+
+// messageStamp formats a message time per the timestamps setting
+func (m Model) messageStamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch m.timestamps {
+	case "on":
+		return t.Format("15:04")
+	case "relative":
+		return relativeTime(m.now.Sub(t))
+	}
+	return ""
+}
+
+// relativeTime renders an age as "just now", "5m ago" or "2h ago"
+func relativeTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultMaxResponseLength caps AI replies when Config leaves it unset
+const defaultMaxResponseLength = 4000
+
+// continuePrompt is sent by /continue to resume a truncated answer
+const continuePrompt = "Continue your previous answer exactly where it was cut off."
+
+// lastUserMessage returns the index of the most recent user message, or -1
+func (m Model) lastUserMessage() int {
+	for i := len(m.chatHistory) - 1; i >= 0; i-- {
+		if m.chatHistory[i].Role == "user" {
+			return i
+		}
+	}
+	return -1
+}
+
+// retryLast resends the last user message. A message left unanswered by
+// an error or abort is retried in place; otherwise it is asked again as
+// a new turn.
+func (m Model) retryLast() (tea.Model, tea.Cmd) {
+	i := m.lastUserMessage()
+	if i < 0 {
+		m.errorMessage = "Nothing to retry yet"
+		return m, nil
+	}
+	if !m.aiReady() {
+		return m, nil
+	}
+	m.errorMessage = ""
+	if i == len(m.chatHistory)-1 {
+		// The gateway may have kept the failed turn
+		m.restartConversation()
+		return m.streamReply(i)
+	}
+	return m.sendChatMessage(m.chatHistory[i].Content)
+}
+
+// regenerateLast asks for a new answer to the last user message. The
+// previous answers to it are kept, collapsed, but dropped from history.
+func (m Model) regenerateLast() (tea.Model, tea.Cmd) {
+	i := m.lastUserMessage()
+	if i < 0 || i == len(m.chatHistory)-1 {
+		m.errorMessage = "No answer to regenerate"
+		return m, nil
+	}
+	if !m.aiReady() {
+		return m, nil
+	}
+	m.errorMessage = ""
+	for j := i + 1; j < len(m.chatHistory); j++ {
+		m.chatHistory[j].Superseded = true
+	}
+	m.restartConversation()
+	return m.streamReply(i)
+}
+
+// continueLast asks for the rest of an answer cut off at the length cap,
+// as a new turn
+func (m Model) continueLast() (tea.Model, tea.Cmd) {
+	last := len(m.chatHistory) - 1
+	if last < 0 || !m.chatHistory[last].Truncated {
+		m.errorMessage = "Nothing to continue"
+		return m, nil
+	}
+	if !m.aiReady() {
+		return m, nil
+	}
+	m.errorMessage = ""
+	m.chatHistory = append(m.chatHistory, ChatMessage{Role: "user", Content: continuePrompt, Time: time.Now()})
+	return m.streamReply(len(m.chatHistory) - 1)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+)
+
+// routes maps deep-link names, as in `ssh -t host resume`, to views
+var routes = map[string]View{
+	"chat":         ViewChat,
+	"about":        ViewAbout,
+	"bio":          ViewAbout,
+	"projects":     ViewProjects,
+	"resume":       ViewResume,
+	"cv":           ViewResume,
+	"experience":   ViewExperience,
+	"exp":          ViewExperience,
+	"work":         ViewExperience,
+	"card":         ViewCard,
+	"uses":         ViewUses,
+	"setup":        ViewUses,
+	"talks":        ViewTalks,
+	"certs":        ViewCerts,
+	"whatsnew":     ViewWhatsNew,
+	"bookmarks":    ViewBookmarks,
+	"feedback":     ViewFeedback,
+	"achievements": ViewAchievements,
+}
+
+// openRoute shows the view a deep link names: one of routes, or
+// projects/<id> for a single project. "tour" starts the walkthrough. Unknown links leave the model on
+// the chat view with an error.
+func (m *Model) openRoute(route string) {
+	route = strings.ToLower(strings.Trim(strings.TrimSpace(route), "/"))
+	if route == "" {
+		return
+	}
+	if route == "tour" {
+		*m, _ = m.startTour()
+		return
+	}
+
+	name, id, _ := strings.Cut(route, "/")
+	view, ok := routes[name]
+	switch {
+	case !ok:
+		m.errorMessage = "Unknown link: " + route
+		return
+	case view == ViewProjects && id != "":
+		if m.projects == nil || m.projects.GetProjectByID(id) == nil {
+			m.errorMessage = "Project not found: " + id
+			view = ViewProjects
+		} else {
+			m.selectedProj = id
+			view = ViewProjectDetail
+			// Esc from a linked project goes to the list, as if browsed
+			m.nav.back = []location{{view: ViewChat}, {view: ViewProjects}}
+		}
+	case view == ViewAchievements && id != "":
+		// achievements/2 opens on page 2
+		if n, err := strconv.Atoi(id); err == nil && n >= 1 && n <= m.achievementPages() {
+			m.achievementPage = n - 1
+		}
+	}
+
+	m.view = view
+	m.showWelcome = view == ViewChat
+	m.updateViewport()
+}
+
+// PreviewPage names the ui.Preview page and argument a deep link shows,
+// so it can be rendered without a session. Chat and the tour have none.
+func PreviewPage(route string) (page, arg string, ok bool) {
+	route = strings.ToLower(strings.Trim(strings.TrimSpace(route), "/"))
+	name, id, _ := strings.Cut(route, "/")
+	switch view := routes[name]; {
+	case view == ViewAbout:
+		return "about", "", true
+	case view == ViewProjects && id != "":
+		return "project", id, true
+	case view == ViewProjects:
+		return "projects", "", true
+	case view == ViewResume:
+		return "resume", "", true
+	case view == ViewExperience:
+		return "experience", "", true
+	case view == ViewUses:
+		return "uses", "", true
+	case view == ViewTalks:
+		return "talks", "", true
+	case view == ViewCerts:
+		return "certs", "", true
+	case view == ViewAchievements:
+		return "achievements", id, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// component draws a view. A static one depends only on the content, the
+// theme and the width, so its last render is kept until one of them
+// changes.
+type component struct {
+	render func(m *Model, styles theme.Styles) string
+	static bool
+}
+
+// components are the views' components. Set in init: they render through
+// the model, whose updateViewport looks them up.
+var components map[View]component
+
+func init() {
+	components = map[View]component{
+		ViewChat: {render: func(m *Model, styles theme.Styles) string {
+			m.links.urls = m.answerLinks()
+			return m.buildChatView(styles, m.messageRenderer(styles))
+		}},
+		ViewAbout: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.About(styles, m.bio, m.columnWidth())
+		}},
+		ViewProjects: {render: func(m *Model, styles theme.Styles) string {
+			return ui.ProjectsList(styles, m.projectsData(), m.columnWidth())
+		}},
+		ViewProjectDetail: {render: func(m *Model, styles theme.Styles) string {
+			return ui.ProjectDetail(styles, m.projects.GetProjectByID(m.selectedProj), slices.Contains(m.bookmarks, m.selectedProj), m.projectShots(m.selectedProj), m.columnWidth())
+		}},
+		ViewResume: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.Resume(styles, m.resume, m.talks, m.certs, m.columnWidth())
+		}},
+		ViewExperience: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.Experience(styles, m.resume, m.columnWidth())
+		}},
+		ViewSnake: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Snake(styles, m.snake.board(), m.columnWidth())
+		}},
+		ViewQuiz: {render: func(m *Model, styles theme.Styles) string {
+			return m.renderQuiz(styles)
+		}},
+		ViewStats: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Stats(styles, m.statsData(), m.columnWidth())
+		}},
+		ViewCard: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Card(styles, m.cardData(), m.columnWidth(), m.viewport.Height)
+		}},
+		ViewActivity: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Activity(styles, m.activityData(), m.viewport.Width)
+		}},
+		ViewUses: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.Uses(styles, m.uses, m.columnWidth())
+		}},
+		ViewTalks: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.Talks(styles, m.talks, m.columnWidth())
+		}},
+		ViewCerts: {static: true, render: func(m *Model, styles theme.Styles) string {
+			return ui.Certifications(styles, m.certs, m.columnWidth())
+		}},
+		ViewAchievements: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Achievements(styles, m.achievements(), m.achievementPage, m.columnWidth())
+		}},
+		ViewWhatsNew: {render: func(m *Model, styles theme.Styles) string {
+			return ui.WhatsNew(styles, m.whatsNewData(), m.columnWidth())
+		}},
+		ViewFind: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Find(styles, m.findData(), m.columnWidth())
+		}},
+		ViewBookmarks: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Bookmarks(styles, m.bookmarksData(), m.columnWidth())
+		}},
+		ViewFeedback: {render: func(m *Model, styles theme.Styles) string {
+			return ui.Feedback(styles, m.feedbackData(), m.columnWidth())
+		}},
+	}
+}
+
+// viewState is what a location keeps while the visitor is elsewhere, so
+// coming back finds it as it was left. Selections live with the view's
+// own state, like projectsState's cursor, which outlasts a visit too.
+type viewState struct {
+	offset   int  // first line in view
+	atBottom bool // following the end, as the chat does until scrolled up
+	// cache is a static view's last render, made under cacheKey
+	cache    string
+	cacheKey string
+}
+
+// router keeps each location's viewState. It notices the view changing
+// when updateViewport next runs, however it was changed, so every way
+// of getting somewhere restores it.
+type router struct {
+	shown  location // whose content the viewport holds
+	states map[location]*viewState
+}
+
+func newRouter() router {
+	return router{states: map[location]*viewState{}}
+}
+
+// state is loc's viewState, made on the first visit: the top of the
+// page, or for the chat, its end
+func (r router) state(loc location) *viewState {
+	s, ok := r.states[loc]
+	if !ok {
+		s = &viewState{atBottom: loc.view == ViewChat}
+		r.states[loc] = s
+	}
+	return s
+}
+
+// leave keeps where the shown location is scrolled to
+func (r router) leave(vp viewport.Model) {
+	s := r.state(r.shown)
+	s.offset, s.atBottom = vp.YOffset, vp.AtBottom()
+}
+
+// restore scrolls vp, holding the location's content, to where it was left
+func (s *viewState) restore(vp *viewport.Model) {
+	if s.atBottom {
+		vp.GotoBottom()
+		return
+	}
+	vp.SetYOffset(s.offset)
+}
+
+// renderView draws the current view with its component, from the cache
+// for a static one whose width, theme and content haven't changed
+func (m *Model) renderView(styles theme.Styles) string {
+	c, ok := components[m.view]
+	if !ok {
+		return ""
+	}
+	if !c.static {
+		return c.render(m, styles)
+	}
+	s := m.router.state(m.here())
+	key := strconv.Itoa(m.columnWidth()) + "/" + strconv.Itoa(m.themeManager.Generation()) + "/" + m.contentVersion
+	if s.cacheKey != key {
+		s.cache, s.cacheKey = c.render(m, styles), key
+	}
+	return s.cache
+}
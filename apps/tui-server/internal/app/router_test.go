@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestViewsKeepTheirScroll(t *testing.T) {
+	m := NewModel(Config{
+		ThemeManager: theme.NewManager(80, 24, nil),
+		Bio:          strings.Repeat("A paragraph about the work.\n\n", 60),
+		Uses:         strings.Repeat("- a tool\n", 60),
+	})
+	show := func(v View) {
+		model, _ := m.showView(v)
+		m = model.(Model)
+	}
+
+	show(ViewAbout)
+	m.viewport.SetYOffset(20)
+	show(ViewUses)
+	if m.viewport.YOffset != 0 {
+		t.Fatalf("first visit to uses at line %d, want the top", m.viewport.YOffset)
+	}
+	m.viewport.SetYOffset(7)
+
+	show(ViewAbout)
+	if m.viewport.YOffset != 20 {
+		t.Errorf("back on about at line %d, want 20", m.viewport.YOffset)
+	}
+	m.goTo(location{view: ViewUses})
+	if m.viewport.YOffset != 7 {
+		t.Errorf("back on uses at line %d, want 7", m.viewport.YOffset)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// projectShots are the project's screenshots, drawn the way the terminal
+// can. Screen readers get none, and ASCII terminals get ASCII art.
+func (m Model) projectShots(id string) []ui.Screenshot {
+	pictures := m.screenshots[id]
+	if len(pictures) == 0 || m.themeManager.Accessible() {
+		return nil
+	}
+	o := graphics.Options{
+		Protocol: m.graphics,
+		Profile:  m.themeManager.ColorProfile(),
+		ASCII:    m.themeManager.Glyphs().ASCII,
+	}
+	if o.ASCII {
+		o.Protocol = graphics.Text
+	}
+
+	shots := make([]ui.Screenshot, len(pictures))
+	for i, p := range pictures {
+		shots[i] = func(cols, rows int) []string { return p.Render(o, cols, rows) }
+	}
+	return shots
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// searchMatch is one hit in the rendered view, in screen cells
+type searchMatch struct {
+	line       int
+	start, end int
+}
+
+// searchState holds a search of one view's text: an in-chat /search, or
+// the match of a /find hit on its page. While active, n and N jump
+// between matches and Esc ends the search.
+type searchState struct {
+	active  bool
+	term    string
+	view    View
+	matches []searchMatch
+	current int
+}
+
+// findMatches locates every case-insensitive occurrence of term in the
+// rendered content, ignoring styling
+func findMatches(content, term string) []searchMatch {
+	term = strings.ToLower(term)
+	if term == "" {
+		return nil
+	}
+
+	var matches []searchMatch
+	for i, line := range strings.Split(content, "\n") {
+		plain := strings.ToLower(ansi.Strip(line))
+		offset := 0
+		for {
+			idx := strings.Index(plain[offset:], term)
+			if idx < 0 {
+				break
+			}
+			idx += offset
+			start := ansi.StringWidth(plain[:idx])
+			matches = append(matches, searchMatch{
+				line:  i,
+				start: start,
+				end:   start + ansi.StringWidth(term),
+			})
+			offset = idx + len(term)
+		}
+	}
+	return matches
+}
+
+// highlightMatches restyles each match in content, marking the current
+// one more strongly than the rest
+func highlightMatches(styles theme.Styles, content string, matches []searchMatch, current int) string {
+	if len(matches) == 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	// Walk backwards so earlier cuts on a line keep their columns
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		line := lines[match.line]
+		style := styles.Highlight.Reverse(true)
+		if i == current {
+			style = styles.Neon.Reverse(true).Bold(true)
+		}
+		text := ansi.Strip(ansi.Cut(line, match.start, match.end))
+		lines[match.line] = ansi.Cut(line, 0, match.start) + style.Render(text) +
+			ansi.Cut(line, match.end, ansi.StringWidth(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// step moves the current match by delta, wrapping at either end
+func (s *searchState) step(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + delta + len(s.matches)) % len(s.matches)
+}
+
+// counter is the "2/5" position shown in the footer
+func (s searchState) counter() string {
+	return fmt.Sprintf("%d/%d", s.current+1, len(s.matches))
+}
+
+// startSearch runs /search term over the chat history
+func (m *Model) startSearch(term string) {
+	m.view = ViewChat
+	m.search = searchState{term: term, view: ViewChat}
+	m.updateViewport()
+	if len(m.search.matches) == 0 {
+		m.search = searchState{}
+		m.errorMessage = "No matches for \"" + term + "\""
+		m.updateViewport()
+		return
+	}
+	// Start from the most recent match, nearest the bottom of the chat
+	m.search.active = true
+	m.search.current = len(m.search.matches) - 1
+	m.updateViewport()
+}
+
+// endSearch clears the search and its highlights
+func (m *Model) endSearch() {
+	m.search = searchState{}
+	m.updateViewport()
+}
+
+// scrollToMatch centers the current match in the viewport
+func (m *Model) scrollToMatch() {
+	if len(m.search.matches) == 0 {
+		return
+	}
+	line := m.search.matches[m.search.current].line
+	m.viewport.SetYOffset(max(line-m.viewport.Height/2, 0))
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// setting is a session option adjustable with /set <key> <value>
+type setting struct {
+	values []string
+	// list stands in for values only known at run time, like loaded themes
+	list  func() []string
+	apply func(m *Model, value string) string
+}
+
+// choices returns the values the setting accepts
+func (s setting) choices() []string {
+	if s.list != nil {
+		return s.list()
+	}
+	return s.values
+}
+
+// settings lists every /set key
+var settings = map[string]setting{
+	"motion": {
+		values: []string{"on", "off"},
+		apply: func(m *Model, value string) string {
+			m.reducedMotion = value == "off"
+			m.introFrame = ui.IntroFrames
+			if m.reducedMotion {
+				return "Reduced motion: animations off"
+			}
+			return "Animations on"
+		},
+	},
+	"renderer": {
+		values: []string{"builtin", "glamour"},
+		apply: func(m *Model, value string) string {
+			m.mdBackend = value
+			return "Renderer: " + value
+		},
+	},
+	"timestamps": {
+		values: []string{"on", "relative", "off"},
+		apply: func(m *Model, value string) string {
+			m.timestamps = value
+			return "Timestamps: " + value
+		},
+	},
+	"theme": {
+		list: paletteNames,
+		apply: func(m *Model, value string) string {
+			m.themeManager.SetPalette(value)
+			return "Theme: " + value
+		},
+	},
+	"background": {
+		values: []string{"on", "off"},
+		apply: func(m *Model, value string) string {
+			m.background = value == "on"
+			if m.background {
+				return "Background: painted with the theme's color"
+			}
+			return "Background: your terminal's"
+		},
+	},
+	"mouse": {
+		values: []string{"on", "off"},
+		apply: func(m *Model, value string) string {
+			m.mouseEnabled = value == "on"
+			if m.mouseEnabled {
+				return "Mouse ON (scroll mode)"
+			}
+			return "Mouse OFF (select mode)"
+		},
+	},
+	"accessible": {
+		values: []string{"on", "off"},
+		apply: func(m *Model, value string) string {
+			*m = m.toggleAccessible(value == "on")
+			return m.statusMessage
+		},
+	},
+	"keymap": {
+		values: []string{"default", "vim"},
+		apply: func(m *Model, value string) string {
+			m.keymap = value
+			if value == "vim" {
+				return "Keymap: vim (j/k scroll, g/G top/bottom, h back in views)"
+			}
+			return "Keymap: default"
+		},
+	},
+	"language": {
+		values: ai.ReplyLanguages,
+		apply: func(m *Model, value string) string {
+			m.language = value
+			return "Reply language: " + value
+		},
+	},
+	"visits": {
+		values: []string{"on", "off"},
+		apply: func(m *Model, value string) string {
+			if value == "off" {
+				m.greeting = ""
+				return "Visits: not counted, history forgotten"
+			}
+			return "Visits: counted from your next connection"
+		},
+	},
+	"glyphs": {
+		values: []string{theme.UnicodeGlyphs.Name, theme.ASCIIGlyphs.Name},
+		apply: func(m *Model, value string) string {
+			g, _ := theme.GlyphSetByName(value)
+			m.themeManager.SetGlyphs(g)
+			return "Glyphs: " + g.Name
+		},
+	},
+}
+
+// settingKeys returns the /set keys in alphabetical order
+func settingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applySetting validates and applies a /set command, returning a status line
+func (m *Model) applySetting(key, value string) (string, error) {
+	s, ok := settings[key]
+	if !ok {
+		return "", fmt.Errorf("Unknown setting: %s", key)
+	}
+	for _, v := range s.choices() {
+		if v == value {
+			return s.apply(m, value), nil
+		}
+	}
+	return "", fmt.Errorf("Usage: /set %s <%s>", key, strings.Join(s.choices(), "|"))
+}
+
+// paletteNames lists the /theme and /set theme values
+func paletteNames() []string {
+	names := make([]string, 0, len(theme.Palettes))
+	for _, p := range theme.Palettes {
+		names = append(names, p.Name)
+	}
+	return names
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/share"
+)
+
+// maxSharesPerSession is how many times one session can /share
+const maxSharesPerSession = 5
+
+// Sharer publishes a /share transcript and returns its URL. Share is
+// called in the background.
+type Sharer interface {
+	Share(ctx context.Context, transcript string) (string, error)
+}
+
+// SharedMsg reports a shared transcript's URL, or Err
+type SharedMsg struct {
+	URL string
+	Err error
+}
+
+// handleShare runs /share: the last question and its answer, or with
+// "all" the whole chat, published as a redacted transcript
+func (m Model) handleShare(args []string) (tea.Model, tea.Cmd) {
+	all := len(args) > 0 && strings.EqualFold(args[0], "all")
+	switch {
+	case m.readOnly:
+		m.errorMessage = readOnlyNotice
+	case m.sharer == nil:
+		m.errorMessage = "Sharing isn't set up on this server"
+	case len(args) > 0 && !all:
+		m.errorMessage = "Usage: /share [all]"
+	case m.isStreaming:
+		m.errorMessage = "Wait for the reply to finish first"
+	case m.shares >= maxSharesPerSession:
+		m.errorMessage = "That's all one session can share"
+	}
+	if m.errorMessage != "" {
+		m.updateViewport()
+		return m, nil
+	}
+
+	messages := m.shareMessages(all)
+	if len(messages) == 0 {
+		m.errorMessage = "Nothing to share yet - ask something first"
+		m.updateViewport()
+		return m, nil
+	}
+	title := "A chat with NEURAL, the AI assistant of a terminal portfolio"
+	if m.resume != nil {
+		title = "A chat with NEURAL, the AI assistant on " + m.resume.Name + "'s terminal portfolio"
+	}
+	transcript := share.Transcript(title, time.Now(), messages)
+	m.shares++
+	m.statusMessage = "Sharing..."
+	if m.analytics != nil {
+		m.analytics.TrackCommandExecuted(m.sessionID, "share")
+	}
+
+	sharer, ctx := m.sharer, m.ctx
+	return m, func() tea.Msg {
+		url, err := sharer.Share(ctx, transcript)
+		return SharedMsg{URL: url, Err: err}
+	}
+}
+
+// shareMessages is the chat to share without superseded answers: all of
+// it, or the last answer and the question before it
+func (m Model) shareMessages(all bool) []share.Message {
+	var messages []share.Message
+	for _, msg := range m.chatHistory {
+		if !msg.Superseded {
+			messages = append(messages, share.Message{Role: msg.Role, Content: msg.Content})
+		}
+	}
+	if all {
+		return messages
+	}
+	for i := len(messages) - 1; i > 0; i-- {
+		if messages[i].Role == "assistant" && messages[i-1].Role == "user" {
+			return messages[i-1 : i+1]
+		}
+	}
+	return nil
+}
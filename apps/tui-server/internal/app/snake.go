@@ -0,0 +1,252 @@
+package app
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+const (
+	// snakeInterval is the time between moves at the start of a game
+	snakeInterval = 130 * time.Millisecond
+	// snakeFastest caps how fast the snake gets as the score grows
+	snakeFastest = 60 * time.Millisecond
+	// snakeGame is the store's name for the game's high scores
+	snakeGame = "snake"
+)
+
+// Board size limits, in cells. The board shrinks to fit smaller
+// terminals but below the minimum there's no room to play.
+const (
+	snakeMaxWidth  = 24
+	snakeMaxHeight = 14
+	snakeMinWidth  = 10
+	snakeMinHeight = 6
+)
+
+// snakeState is a game of /snake. id invalidates ticks of earlier games;
+// running is false once the tick loop has stopped, so a game left for
+// another view and returned to can pick up again.
+type snakeState struct {
+	id      int
+	running bool
+	width   int
+	height  int
+	body    []ui.Cell // head first
+	dir     ui.Cell   // direction of the last move
+	next    ui.Cell   // direction of the next move
+	food    ui.Cell
+	score   int
+	best    int
+	over    bool
+	rng     *rand.Rand
+}
+
+// SnakeTickMsg moves the snake of the game with the given ID
+type SnakeTickMsg struct{ ID int }
+
+func snakeTick(id int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return SnakeTickMsg{ID: id} })
+}
+
+// board is the game as the ui package draws it
+func (s snakeState) board() ui.SnakeBoard {
+	return ui.SnakeBoard{
+		Width:  s.width,
+		Height: s.height,
+		Body:   s.body,
+		Food:   s.food,
+		Score:  s.score,
+		Best:   s.best,
+		Over:   s.over,
+	}
+}
+
+// interval speeds the game up a little with every point
+func (s snakeState) interval() time.Duration {
+	d := snakeInterval - time.Duration(s.score)*3*time.Millisecond
+	if d < snakeFastest {
+		return snakeFastest
+	}
+	return d
+}
+
+// startSnake opens the snake view on a new game sized to the viewport
+func (m Model) startSnake() (tea.Model, tea.Cmd) {
+	if m.themeManager.Accessible() {
+		m.errorMessage = "Snake needs the visual display; turn off /accessible to play"
+		m.updateViewport()
+		return m, nil
+	}
+	width := min((m.columnWidth()-2)/2, snakeMaxWidth)
+	height := min(m.viewport.Height-5, snakeMaxHeight)
+	if width < snakeMinWidth || height < snakeMinHeight {
+		m.errorMessage = "Make the window bigger to play snake"
+		m.updateViewport()
+		return m, nil
+	}
+
+	best := m.snake.best
+	if m.prefs.store != nil && m.prefs.key != "" {
+		if saved, err := m.prefs.store.HighScore(m.prefs.key, snakeGame); err == nil {
+			best = max(best, saved)
+		}
+	}
+	rng := m.snake.rng
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	y := height / 2
+	m.snake = snakeState{
+		id:      m.snake.id + 1,
+		running: true,
+		width:   width,
+		height:  height,
+		body:    []ui.Cell{{X: 4, Y: y}, {X: 3, Y: y}, {X: 2, Y: y}},
+		dir:     ui.Cell{X: 1},
+		next:    ui.Cell{X: 1},
+		best:    best,
+		rng:     rng,
+	}
+	m.snake.placeFood()
+	m.helpOpen = false
+	m.statusMessage = ""
+	m.errorMessage = ""
+	m.view = ViewSnake
+	m.showWelcome = false
+	m.updateViewport()
+	return m, snakeTick(m.snake.id, m.snake.interval())
+}
+
+// placeFood drops food on a random free cell
+func (s *snakeState) placeFood() {
+	taken := make(map[ui.Cell]bool, len(s.body))
+	for _, c := range s.body {
+		taken[c] = true
+	}
+	free := make([]ui.Cell, 0, s.width*s.height-len(s.body))
+	for y := 0; y < s.height; y++ {
+		for x := 0; x < s.width; x++ {
+			if c := (ui.Cell{X: x, Y: y}); !taken[c] {
+				free = append(free, c)
+			}
+		}
+	}
+	if len(free) == 0 {
+		// The snake fills the board; nothing left to eat
+		s.over = true
+		return
+	}
+	s.food = free[s.rng.Intn(len(free))]
+}
+
+// stepSnake moves the snake one cell. The loop stops while the game is
+// out of sight and pauses under an overlay.
+func (m Model) stepSnake(msg SnakeTickMsg) (Model, tea.Cmd) {
+	if msg.ID != m.snake.id || m.snake.over {
+		return m, nil
+	}
+	if m.view != ViewSnake {
+		m.snake.running = false
+		return m, nil
+	}
+	if m.helpOpen || m.palette.open {
+		return m, snakeTick(m.snake.id, m.snake.interval())
+	}
+
+	s := &m.snake
+	s.dir = s.next
+	head := ui.Cell{X: s.body[0].X + s.dir.X, Y: s.body[0].Y + s.dir.Y}
+	eating := head == s.food
+	// The tail moves out of the way unless the snake is growing
+	body := s.body
+	if !eating {
+		body = body[:len(body)-1]
+	}
+	crashed := head.X < 0 || head.Y < 0 || head.X >= s.width || head.Y >= s.height
+	for _, c := range body {
+		crashed = crashed || c == head
+	}
+	if crashed {
+		return m.endSnake()
+	}
+
+	s.body = append([]ui.Cell{head}, body...)
+	if eating {
+		s.score++
+		s.placeFood()
+		if s.over {
+			return m.endSnake()
+		}
+	}
+	m.updateViewport()
+	return m, snakeTick(s.id, s.interval())
+}
+
+// endSnake finishes the game and saves the score if it's a new best
+func (m Model) endSnake() (Model, tea.Cmd) {
+	m.snake.over = true
+	m.snake.running = false
+	var cmd tea.Cmd
+	if m.snake.score > m.snake.best {
+		m.snake.best = m.snake.score
+		m.statusMessage = "New high score!"
+		cmd = tea.Batch(m.saveScore(snakeGame, m.snake.score), clearStatusAfter(3*time.Second))
+	}
+	m.updateViewport()
+	return m, cmd
+}
+
+// saveScore records a score against the visitor's key in the background
+func (m Model) saveScore(game string, score int) tea.Cmd {
+	store, key := m.prefs.store, m.prefs.key
+	if store == nil || key == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, err := store.RecordScore(key, game, score); err != nil {
+			return PrefsErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// updateSnake steers the snake and restarts a finished game. Keys it
+// doesn't use fall through to the input.
+func (m Model) updateSnake(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if len(m.snake.body) == 0 {
+		return m, nil, false
+	}
+	var dir ui.Cell
+	switch {
+	case key.Matches(msg, keys.SnakeUp):
+		dir = ui.Cell{Y: -1}
+	case key.Matches(msg, keys.SnakeDown):
+		dir = ui.Cell{Y: 1}
+	case key.Matches(msg, keys.SnakeLeft):
+		dir = ui.Cell{X: -1}
+	case key.Matches(msg, keys.SnakeRight):
+		dir = ui.Cell{X: 1}
+	case key.Matches(msg, keys.Restart) && m.snake.over:
+		model, cmd := m.startSnake()
+		return model.(Model), cmd, true
+	default:
+		return m, nil, false
+	}
+	if m.snake.over {
+		return m, nil, true
+	}
+	// Turning back on itself would be instant death; ignore it
+	if dir.X != -m.snake.dir.X || dir.Y != -m.snake.dir.Y {
+		m.snake.next = dir
+	}
+	if !m.snake.running {
+		m.snake.running = true
+		return m, snakeTick(m.snake.id, m.snake.interval()), true
+	}
+	return m, nil, true
+}
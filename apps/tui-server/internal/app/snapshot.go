@@ -0,0 +1,141 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snapshotInterval is how often a changed session is saved for resuming
+const snapshotInterval = 10 * time.Second
+
+// snapshot is what a dropped session resumes with
+type snapshot struct {
+	View    View          `json:"view"`
+	Project string        `json:"project,omitempty"`
+	Offset  int           `json:"offset,omitempty"`
+	Input   string        `json:"input,omitempty"`
+	Chat    []ChatMessage `json:"chat,omitempty"`
+	// Conversation keeps the gateway's history of the chat
+	Conversation string `json:"conversation,omitempty"`
+}
+
+// snapshotState tracks the saved snapshot of this session
+type snapshotState struct {
+	key    string        // visitor key; empty when sessions aren't resumed
+	window time.Duration // how long after a drop the state is kept
+	last   []byte        // state as last saved; unchanged, it isn't saved again
+	due    time.Time     // next save, at most one per snapshotInterval
+}
+
+// snapshotOwners maps visitor keys to the session in this process that
+// saves their snapshot, by its context. Another session of the same
+// visitor opened meanwhile neither resumes the snapshot nor saves over it.
+var snapshotOwners = struct {
+	sync.Mutex
+	sessions map[string]context.Context
+}{sessions: map[string]context.Context{}}
+
+// claimSnapshot makes the session of ctx the owner of key's snapshot until
+// ctx ends, and reports false when another live session owns it
+func claimSnapshot(ctx context.Context, key string) bool {
+	snapshotOwners.Lock()
+	defer snapshotOwners.Unlock()
+	if owner, ok := snapshotOwners.sessions[key]; ok && owner.Err() == nil {
+		return false
+	}
+	snapshotOwners.sessions[key] = ctx
+	context.AfterFunc(ctx, func() {
+		snapshotOwners.Lock()
+		defer snapshotOwners.Unlock()
+		if snapshotOwners.sessions[key] == ctx {
+			delete(snapshotOwners.sessions, key)
+		}
+	})
+	return true
+}
+
+// snapshot captures where the visitor is. A reply still streaming is
+// kept as a cut-off answer that /continue finishes after reconnecting.
+// Games, /stats and /activity aren't resumed; they reopen on chat.
+func (m Model) snapshot() snapshot {
+	s := snapshot{View: m.view, Offset: m.viewport.YOffset, Input: m.input.Value(), Conversation: m.conversation}
+	switch m.view {
+	case ViewSnake, ViewQuiz, ViewStats, ViewActivity:
+		s.View, s.Offset = ViewChat, 0
+	case ViewProjectDetail:
+		s.Project = m.selectedProj
+	}
+	s.Chat = m.chatHistory
+	m.streamMu.Lock()
+	if m.isStreaming && m.chatResponse.Len() > 0 {
+		s.Chat = append(s.Chat[:len(s.Chat):len(s.Chat)], ChatMessage{
+			Role:      "assistant",
+			Content:   m.chatResponse.String(),
+			Time:      m.now,
+			Truncated: true,
+		})
+	}
+	m.streamMu.Unlock()
+	return s
+}
+
+// saveSnapshot saves the session in the background if it changed since
+// the last save and one is due
+func (m *Model) saveSnapshot() tea.Cmd {
+	if m.snap.key == "" || m.quitting || m.now.Before(m.snap.due) {
+		return nil
+	}
+	m.snap.due = m.now.Add(snapshotInterval)
+	data, err := json.Marshal(m.snapshot())
+	if err != nil || bytes.Equal(data, m.snap.last) {
+		return nil
+	}
+	m.snap.last = data
+	s, key, at := m.prefs.store, m.snap.key, m.now
+	return func() tea.Msg {
+		if err := s.SaveSnapshot(key, data, at); err != nil {
+			return PrefsErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// dropSnapshot deletes the saved state, for a visitor who quit on purpose
+func (m Model) dropSnapshot() tea.Cmd {
+	if m.snap.key == "" || m.snap.last == nil {
+		return nil
+	}
+	s, key := m.prefs.store, m.snap.key
+	return func() tea.Msg {
+		_ = s.SaveSnapshot(key, nil, time.Time{})
+		return nil
+	}
+}
+
+// restoreSnapshot resumes a session of the same visitor that dropped
+// within the window, and reports whether there was one
+func (m *Model) restoreSnapshot() bool {
+	if m.snap.key == "" {
+		return false
+	}
+	data, err := m.prefs.store.TakeSnapshot(m.snap.key, m.now.Add(-m.snap.window))
+	var s snapshot
+	if err != nil || data == nil || json.Unmarshal(data, &s) != nil {
+		return false
+	}
+	m.chatHistory = s.Chat
+	if s.Conversation != "" {
+		m.conversation = s.Conversation
+	}
+	m.input.SetValue(s.Input)
+	m.input.CursorEnd()
+	m.goTo(location{view: s.View, project: s.Project})
+	m.nav.moving = false
+	m.viewport.SetYOffset(s.Offset)
+	return true
+}
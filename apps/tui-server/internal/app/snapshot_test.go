@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestReconnectResumesDroppedSession(t *testing.T) {
+	s, err := store.NewFileStore(filepath.Join(t.TempDir(), "store.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	connect := func() (Model, context.CancelFunc) {
+		ctx, drop := context.WithCancel(context.Background())
+		t.Cleanup(drop)
+		return NewModel(Config{
+			ThemeManager: theme.NewManager(80, 24, nil),
+			Store:        s,
+			VisitorKey:   "key:abc",
+			ResumeWindow: 15 * time.Minute,
+			Context:      ctx,
+		}), drop
+	}
+
+	dropped, drop := connect()
+	dropped.chatHistory = []ChatMessage{{Role: "user", Content: "why Go?", Time: dropped.now}}
+	dropped.isStreaming = true
+	dropped.chatResponse.WriteString("Because it")
+	dropped.goTo(location{view: ViewAbout})
+	dropped.input.SetValue("and Rust?")
+	save := dropped.saveSnapshot()
+	if save == nil {
+		t.Fatal("saveSnapshot() = nil, want a save")
+	}
+	save()
+
+	if other, _ := connect(); len(other.chatHistory) != 0 || other.snap.key != "" {
+		t.Fatal("a second session resumed, or saves over, a live session's snapshot")
+	}
+	drop()
+
+	resumed, dropResumed := connect()
+	if resumed.view != ViewAbout || resumed.input.Value() != "and Rust?" {
+		t.Errorf("resumed on view %d with input %q, want About and the draft", resumed.view, resumed.input.Value())
+	}
+	if n := len(resumed.chatHistory); n != 2 || !resumed.chatHistory[1].Truncated || resumed.chatHistory[1].Content != "Because it" {
+		t.Fatalf("resumed chat = %+v, want the question and the cut-off reply", resumed.chatHistory)
+	}
+	if resumed.conversation != dropped.conversation {
+		t.Errorf("resumed conversation %q, want %q", resumed.conversation, dropped.conversation)
+	}
+	if resumed.statusMessage == "" {
+		t.Error("resuming should say so")
+	}
+
+	dropResumed()
+	if again, _ := connect(); len(again.chatHistory) != 0 || again.view != ViewChat {
+		t.Error("a snapshot should be resumed only once")
+	}
+}
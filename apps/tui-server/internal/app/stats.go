@@ -0,0 +1,119 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+const (
+	// statsInterval is the frame time of the /stats count-up
+	statsInterval = 40 * time.Millisecond
+	// statsFrames is how long the count-up runs, under a second
+	statsFrames = 20
+	// questionsCounter totals AI replies across every session
+	questionsCounter = "questions"
+)
+
+// Counters keeps all-time totals shared by every server process, such as
+// visits and AI questions answered
+type Counters interface {
+	Count(name string) (int, error)
+	Total(name string) int
+}
+
+// projectCounter names the total of a project's detail views
+func projectCounter(id string) string {
+	return "project." + id
+}
+
+// statsState is the open /stats view: the totals read when it opened and
+// how far their count-up has got
+type statsState struct {
+	id         int
+	frame      int
+	visitors   int
+	questions  int
+	topProject string
+	topViews   int
+}
+
+// StatsTickMsg advances the /stats count-up with the given ID
+type StatsTickMsg struct{ ID int }
+
+func statsTick(id int) tea.Cmd {
+	return tea.Tick(statsInterval, func(time.Time) tea.Msg { return StatsTickMsg{ID: id} })
+}
+
+// openStats reads the totals and opens the stats view, counting up to
+// them unless motion is reduced
+func (m Model) openStats() (tea.Model, tea.Cmd) {
+	m.refreshLive()
+	s := statsState{id: m.stats.id + 1, visitors: m.visitorNum}
+	if m.counters != nil {
+		s.visitors = max(s.visitors, m.counters.Total("visits"))
+		s.questions = m.counters.Total(questionsCounter)
+		if m.projects != nil {
+			for _, p := range m.projects.Projects {
+				if views := m.counters.Total(projectCounter(p.ID)); views > s.topViews {
+					s.topProject, s.topViews = p.Name, views
+				}
+			}
+		}
+	}
+	if m.reducedMotion || m.themeManager.Accessible() {
+		s.frame = statsFrames
+	}
+	m.stats = s
+	model, _ := m.showView(ViewStats)
+	if s.frame == statsFrames {
+		return model, nil
+	}
+	return model, statsTick(s.id)
+}
+
+// stepStats advances the count-up, stopping at its last frame or when
+// the view closes
+func (m Model) stepStats(msg StatsTickMsg) (Model, tea.Cmd) {
+	if msg.ID != m.stats.id || m.stats.frame >= statsFrames {
+		return m, nil
+	}
+	if m.view != ViewStats {
+		m.stats.frame = statsFrames
+		return m, nil
+	}
+	m.stats.frame++
+	m.updateViewport()
+	if m.stats.frame == statsFrames {
+		return m, nil
+	}
+	return m, statsTick(m.stats.id)
+}
+
+// statsData is the view's numbers at the current frame. Counts ease out
+// toward their totals; online and uptime are always live.
+func (m Model) statsData() ui.StatsData {
+	t := float64(m.stats.frame) / statsFrames
+	ease := 1 - (1-t)*(1-t)*(1-t)
+	scale := func(n int) int { return int(float64(n)*ease + 0.5) }
+	return ui.StatsData{
+		Visitors:   scale(m.stats.visitors),
+		Online:     max(m.live, 1),
+		Questions:  scale(m.stats.questions),
+		TopProject: m.stats.topProject,
+		TopViews:   scale(m.stats.topViews),
+		Uptime:     formatUptime(m.now.Sub(m.serverStart)),
+	}
+}
+
+// countProjectView adds to a project's view total when its detail view
+// opens, however it was reached
+func (m Model) countProjectView(prev location) {
+	here := m.here()
+	if m.counters == nil || here.view != ViewProjectDetail || here == prev {
+		return
+	}
+	counters := m.counters
+	go func() { _, _ = counters.Count(projectCounter(here.project)) }()
+}
@@ -0,0 +1,12 @@
+package app
+
+import "sync/atomic"
+
+// activeStreams counts reply goroutines still running in this process
+var activeStreams atomic.Int64
+
+// ActiveStreams reports how many replies are streaming across sessions.
+// A count that stays up after sessions end means a stream leaked.
+func ActiveStreams() int64 {
+	return activeStreams.Load()
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// endlessService streams chunks until its context ends
+type endlessService struct{}
+
+func (endlessService) ChatStream(ctx context.Context, _, _ string, _ []ai.Message, callback ai.StreamCallback) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+		if err := callback("chunk "); err != nil {
+			return err
+		}
+	}
+}
+
+func TestStreamExitsWhenSessionDies(t *testing.T) {
+	before := runtime.NumGoroutine()
+	session, endSession := context.WithCancel(context.Background())
+	program, kill := context.WithCancel(context.Background())
+
+	// Like tea.Program.Send on a program that has stopped reading
+	hung := make(chan tea.Msg)
+	send := func(msg tea.Msg) {
+		select {
+		case <-program.Done():
+		case hung <- msg:
+		}
+	}
+
+	m := NewModel(Config{
+		ThemeManager:      theme.NewManager(80, 24, nil),
+		AIService:         endlessService{},
+		Send:              send,
+		Context:           session,
+		MaxResponseLength: 1 << 20,
+	})
+	m.sendChatMessage("hello")
+	if got := ActiveStreams(); got != 1 {
+		t.Fatalf("ActiveStreams() = %d while streaming, want 1", got)
+	}
+
+	endSession()
+	kill()
+	deadline := time.Now().Add(2 * time.Second)
+	for ActiveStreams() != 0 || runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("stream leaked: ActiveStreams() = %d, goroutines %d > %d\n%s",
+				ActiveStreams(), runtime.NumGoroutine(), before, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// recordingProvider keeps the requests it was sent
+type recordingProvider struct {
+	mu       sync.Mutex
+	requests []ai.CompletionRequest
+}
+
+func (p *recordingProvider) StreamChat(_ context.Context, request ai.CompletionRequest, callback ai.StreamCallback) error {
+	p.mu.Lock()
+	p.requests = append(p.requests, request)
+	p.mu.Unlock()
+	return callback("answer")
+}
+
+func (p *recordingProvider) last() ai.CompletionRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests[len(p.requests)-1]
+}
+
+func TestRegenStartsNewConversation(t *testing.T) {
+	loader := content.NewLoader("")
+	resume, _ := loader.LoadResume()
+	projects, _ := loader.LoadProjects()
+
+	provider := &recordingProvider{}
+	done := make(chan struct{}, 1)
+	m := NewModel(Config{
+		ThemeManager: theme.NewManager(80, 24, nil),
+		AIService: ai.NewService(ai.Config{
+			Provider:      provider,
+			Logger:        telemetry.NewLogger("test"),
+			PromptBuilder: ai.NewPromptBuilder(resume, projects, ""),
+			RateLimitMax:  10,
+			Conversations: true,
+		}),
+		Send: func(msg tea.Msg) {
+			if _, ok := msg.(StreamDoneMsg); ok {
+				done <- struct{}{}
+			}
+		},
+		Context:           context.Background(),
+		Conversations:     true,
+		MaxResponseLength: 100,
+	})
+	m.chatHistory = []ChatMessage{{Role: "user", Content: "what stack"}, {Role: "assistant", Content: "Go"}}
+	before := m.conversation
+
+	// The gateway kept the superseded answer, so /regen starts over
+	model, _ := m.regenerateLast()
+	<-done
+	regen := provider.last()
+	if regen.Conversation == before || !regen.NewConversation || len(regen.Messages) < 2 {
+		t.Fatalf("regen went to %q (new %v) with %d messages", regen.Conversation, regen.NewConversation, len(regen.Messages))
+	}
+
+	m = model.(Model)
+	m.isStreaming = false
+	m.sendChatMessage("and the database")
+	<-done
+	if next := provider.last(); next.Conversation != regen.Conversation || next.NewConversation {
+		t.Fatalf("next turn went to %q (new %v), want %q", next.Conversation, next.NewConversation, regen.Conversation)
+	}
+}
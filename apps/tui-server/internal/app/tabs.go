@@ -0,0 +1,87 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/layout"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// tabBarRow is the screen row of the tab strip (below the header's three lines)
+const tabBarRow = 3
+
+// tabViews lists the views reachable from the tab strip, in display order
+var tabViews = []View{ViewChat, ViewAbout, ViewProjects, ViewResume, ViewExperience}
+
+var tabLabels = []string{"CHAT", "ABOUT", "PROJECTS", "RESUME", "EXP"}
+
+// activeTab maps the current view to its tab index
+func (m Model) activeTab() int {
+	view := m.view
+	if view == ViewProjectDetail {
+		view = ViewProjects
+	}
+	for i, v := range tabViews {
+		if v == view {
+			return i
+		}
+	}
+	return 0
+}
+
+// selectTab switches to the view behind tab i
+func (m Model) selectTab(i int) (tea.Model, tea.Cmd) {
+	if i < 0 || i >= len(tabViews) {
+		return m, nil
+	}
+
+	oldView := m.view
+	m.view = tabViews[i]
+	if m.view == ViewChat {
+		m.showWelcome = len(m.chatHistory) == 0
+	} else {
+		m.showWelcome = false
+	}
+	if m.view == ViewProjects {
+		m.selectedProj = ""
+	}
+
+	if m.view != oldView && m.analytics != nil {
+		m.analytics.TrackViewChanged(m.sessionID, viewName(oldView), viewName(m.view))
+	}
+
+	m.updateViewport()
+	return m, nil
+}
+
+// cycleTab moves delta tabs left or right, wrapping around
+func (m Model) cycleTab(delta int) (tea.Model, tea.Cmd) {
+	n := len(tabViews)
+	return m.selectTab(((m.activeTab()+delta)%n + n) % n)
+}
+
+// handleTabClick switches tabs when the tab strip is clicked
+func (m Model) handleTabClick(msg tea.MouseMsg) (tea.Model, tea.Cmd, bool) {
+	if msg.Y != tabBarRow || msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil, false
+	}
+	if m.announcing() {
+		// Clicking an announcement takes it down, uncovering the tabs
+		m.announcement = announcement{}
+		return m, nil, true
+	}
+	idx := ui.TabAt(tabLabels, msg.X-layout.Inset)
+	if idx < 0 {
+		return m, nil, false
+	}
+	model, cmd := m.selectTab(idx)
+	return model.(Model), cmd, true
+}
+
+// renderTabBar renders the tab strip row, or an announcement over it
+func (m Model) renderTabBar(styles theme.Styles) string {
+	if m.announcing() {
+		return ui.AnnouncementBar(styles, m.announcement.text, layout.InnerWidth(m.columnWidth()))
+	}
+	return ui.TabBar(styles, tabLabels, m.activeTab(), layout.InnerWidth(m.columnWidth()))
+}
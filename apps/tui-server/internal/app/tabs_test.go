@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestCycleTabWraps(t *testing.T) {
+	m := NewModel(Config{
+		ThemeManager: theme.NewManager(80, 24, nil),
+		Projects:     &content.Projects{},
+		Resume:       &content.Resume{},
+	})
+	press := func(k tea.KeyType) {
+		model, _ := m.Update(tea.KeyMsg{Type: k})
+		m = model.(Model)
+	}
+
+	press(tea.KeyCtrlPgUp)
+	if m.view != tabViews[len(tabViews)-1] {
+		t.Fatalf("ctrl+pgup from chat opened %v, want the last tab", m.view)
+	}
+	press(tea.KeyCtrlPgDown)
+	press(tea.KeyCtrlPgDown)
+	if m.view != tabViews[1] {
+		t.Errorf("two ctrl+pgdown later on %v, want the second tab", m.view)
+	}
+}
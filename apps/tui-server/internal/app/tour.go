@@ -0,0 +1,107 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tourTypingDelay is the pause between auto-typed characters
+const tourTypingDelay = 45 * time.Millisecond
+
+// tourStep is one beat of the /tour walkthrough: type and send a
+// question, or show a view. pause is how long it stays on screen.
+type tourStep struct {
+	ask     string
+	view    View
+	project int // 1-based project to open with ViewProjectDetail
+	pause   time.Duration
+}
+
+// tourScript walks through the site and ends on the contact details.
+// The questions match FAQ entries, so they answer instantly without AI.
+var tourScript = []tourStep{
+	{view: ViewChat, pause: 2 * time.Second},
+	{ask: "What are his skills?", pause: 4 * time.Second},
+	{view: ViewAbout, pause: 3 * time.Second},
+	{view: ViewProjects, pause: 3 * time.Second},
+	{view: ViewProjectDetail, project: 1, pause: 3 * time.Second},
+	{view: ViewExperience, pause: 3 * time.Second},
+	{view: ViewResume, pause: 3 * time.Second},
+	{ask: "How can I contact him?", pause: 0},
+}
+
+// tourState tracks the running tour; id invalidates ticks of a stopped one
+type tourState struct {
+	active bool
+	id     int
+	step   int
+	typed  int // characters of the current question typed so far
+}
+
+// TourTickMsg advances the tour with the given ID
+type TourTickMsg struct{ ID int }
+
+func tourTick(id int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return TourTickMsg{ID: id} })
+}
+
+// startTour begins the walkthrough from a fresh chat
+func (m Model) startTour() (Model, tea.Cmd) {
+	m.tour = tourState{active: true, id: m.tour.id + 1}
+	m.helpOpen = false
+	m.input.SetValue("")
+	m.statusMessage = "Tour: press any key to take over"
+	return m, tourTick(m.tour.id, 500*time.Millisecond)
+}
+
+// stopTour ends the walkthrough early, leaving the visitor where it was
+func (m Model) stopTour() Model {
+	if m.tour.step < len(tourScript) && tourScript[m.tour.step].ask != "" {
+		m.input.SetValue("")
+	}
+	m.tour.active = false
+	m.statusMessage = ""
+	return m
+}
+
+// advanceTour runs the current step: one character of a question at a
+// time, or a view change, then schedules the next tick
+func (m Model) advanceTour() (tea.Model, tea.Cmd) {
+	if m.tour.step >= len(tourScript) {
+		m.tour.active = false
+		m.statusMessage = "Tour finished - ask anything"
+		return m, clearStatusAfter(5 * time.Second)
+	}
+	step := tourScript[m.tour.step]
+
+	if step.ask != "" {
+		question := []rune(step.ask)
+		if m.tour.typed < len(question) {
+			m.tour.typed++
+			m.input.SetValue(string(question[:m.tour.typed]))
+			m.input.CursorEnd()
+			return m, tourTick(m.tour.id, tourTypingDelay)
+		}
+		m.input.SetValue("")
+		m.tour.step++
+		m.tour.typed = 0
+		model, cmd := m.sendChatMessage(step.ask)
+		next := model.(Model)
+		return next, tea.Batch(cmd, tourTick(next.tour.id, step.pause))
+	}
+
+	m.view = step.view
+	m.showWelcome = step.view == ViewChat && len(m.chatHistory) == 0
+	if step.view == ViewProjectDetail {
+		if m.projects == nil || step.project > len(m.projects.Projects) {
+			m.view = ViewProjects
+		} else {
+			m.selectedProj = m.projects.Projects[step.project-1].ID
+		}
+	}
+	m.updateViewport()
+	m.viewport.GotoTop()
+	m.tour.step++
+	return m, tourTick(m.tour.id, step.pause)
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// recordVisit counts this connection under the visitor's key and returns
+// the welcome-back line for a returning visitor. First visits, visitors
+// without a key and those who turned visits off get "".
+func (m *Model) recordVisit(now time.Time) string {
+	if m.prefs.store == nil || m.prefs.key == "" || m.prefs.saved["visits"] == "off" {
+		return ""
+	}
+	prev, err := m.prefs.store.RecordVisit(m.prefs.key, now)
+	if err != nil {
+		return ""
+	}
+	m.compareContent(prev)
+	if prev.Count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("welcome back — %s visit, last seen %s", ordinal(prev.Count+1), lastSeen(now.Sub(prev.LastSeen))) + m.whatsNewHint()
+}
+
+// ordinal renders n as "2nd", "3rd", "11th"
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// lastSeen renders the time since a previous visit as "moments ago",
+// "3 hours ago", "yesterday" or "5 days ago"
+func lastSeen(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "moments ago"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute") + " ago"
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour") + " ago"
+	case d < 48*time.Hour:
+		return "yesterday"
+	default:
+		return plural(int(d/(24*time.Hour)), "day") + " ago"
+	}
+}
+
+// plural renders "1 day" or "5 days"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// refreshLive updates the live session count, reporting whether it changed
+func (m *Model) refreshLive() bool {
+	if m.liveSessions == nil {
+		return false
+	}
+	live := m.liveSessions()
+	changed := live != m.live
+	m.live = live
+	return changed
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// streamStallTimeout aborts a reply when the gateway goes quiet this long
+const streamStallTimeout = 20 * time.Second
+
+var errStreamStalled = errors.New("response stalled, try again")
+
+// StreamRetryMsg discards the partial reply of a stalled stream before it
+// is retried
+type StreamRetryMsg struct {
+	ID int
+}
+
+// watchStream runs attempt under an inactivity watchdog. attempt calls
+// touch whenever a chunk arrives; if none arrives for streamStallTimeout
+// the attempt is aborted and retried once, calling retry first. A second
+// stall ends the stream with errStreamStalled.
+func watchStream(ctx context.Context, attempt func(ctx context.Context, touch func()) error, retry func()) error {
+	for tries := 0; ; tries++ {
+		attemptCtx, cancel := context.WithCancelCause(ctx)
+		timer := time.AfterFunc(streamStallTimeout, func() { cancel(errStreamStalled) })
+		err := attempt(attemptCtx, func() { timer.Reset(streamStallTimeout) })
+		timer.Stop()
+		stalled := errors.Is(context.Cause(attemptCtx), errStreamStalled)
+		cancel(nil)
+
+		if err == nil || !stalled {
+			return err
+		}
+		if tries > 0 {
+			return errStreamStalled
+		}
+		retry()
+	}
+}
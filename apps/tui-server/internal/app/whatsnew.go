@@ -0,0 +1,79 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// whatsNewState is what changed in the content since the visitor's last
+// session, worked out when they connect
+type whatsNewState struct {
+	compared bool // the content they saw last was found and compared
+	since    time.Time
+	changes  []content.Change
+	note     string // why nothing could be compared, once known
+}
+
+// compareContent records the content version this visitor sees and, for
+// one who saw another before, works out what changed since their visit
+// prev. The caller has checked the visitor is counted.
+func (m *Model) compareContent(prev store.Visits) {
+	if len(m.digest.Facts) == 0 {
+		return
+	}
+	seen, err := m.prefs.store.RecordContentSeen(m.prefs.key, m.contentVersion)
+	switch {
+	case err != nil:
+		m.whatsNew.note = "Couldn't look up your last visit"
+		return
+	case prev.Count == 0:
+		m.whatsNew.note = "This is your first visit, so it's all new. Come back after an update to see what changed."
+		return
+	}
+	m.whatsNew.since = prev.LastSeen
+	if seen == m.contentVersion {
+		m.whatsNew.compared = true
+		return
+	}
+
+	var before content.Digest
+	data, err := m.prefs.store.ArchivedContent(seen)
+	if seen == "" || err != nil || data == nil || json.Unmarshal(data, &before) != nil {
+		m.whatsNew.note = "Your last visit was before changes were tracked. Come back after the next update to see what changed."
+		return
+	}
+	m.whatsNew.compared = true
+	m.whatsNew.changes = content.Diff(before, m.digest)
+}
+
+// whatsNewHint is the greeting's pointer to /whatsnew, "" with nothing new
+func (m Model) whatsNewHint() string {
+	if len(m.whatsNew.changes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" · %s since, see /whatsnew", plural(len(m.whatsNew.changes), "update"))
+}
+
+// whatsNewData is what the /whatsnew view shows
+func (m Model) whatsNewData() ui.WhatsNewData {
+	data := ui.WhatsNewData{Changes: m.whatsNew.changes, Note: m.whatsNew.note}
+	switch {
+	case m.whatsNew.compared:
+		data.Since = lastSeen(m.now.Sub(m.whatsNew.since))
+	case data.Note != "":
+	case m.prefs.store == nil || len(m.digest.Facts) == 0:
+		data.Note = "Changes aren't tracked on this server"
+	case m.prefs.key == "":
+		data.Note = "Connect with an SSH key to see what changed between your visits"
+	case m.prefs.saved["visits"] == "off":
+		data.Note = "Your visits aren't counted, so there's no last visit to compare with. /set visits on counts them from your next connection."
+	default:
+		data.Note = "Your visit history starts now. Come back after an update to see what changed."
+	}
+	return data
+}
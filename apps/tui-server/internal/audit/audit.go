@@ -0,0 +1,189 @@
+// Package audit writes one line per SSH connection attempt to a file of
+// its own, apart from the application logs, for fail2ban and the like.
+// Unlike the application logs it records client addresses, so it is
+// rotated daily and old days are deleted.
+package audit
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Outcome is how a connection attempt ended
+type Outcome string
+
+const (
+	// Accepted opened a session
+	Accepted Outcome = "accepted"
+	// Rejected failed the handshake or was banned
+	Rejected Outcome = "rejected"
+	// RateLimited had too many sessions open from its address
+	RateLimited Outcome = "rate-limited"
+)
+
+// maxField bounds client-supplied fields in a line
+const maxField = 64
+
+// Event is one connection attempt
+type Event struct {
+	Outcome       Outcome
+	Addr          net.Addr
+	User          string
+	KeyType       string // "" for keyboard-interactive
+	ClientVersion string
+	Reason        string // why it was rejected
+}
+
+// line formats e the way sshd reports connections, "from <ip> port <n>",
+// so stock fail2ban patterns need little change. Fields a client chooses
+// are quoted, so they can't forge the start of a line or another field.
+func (e Event) line(at time.Time) string {
+	host, port, err := net.SplitHostPort(addrString(e.Addr))
+	if err != nil {
+		host, port = addrString(e.Addr), "0"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s tui-server audit: %s from %s port %s", at.UTC().Format(time.RFC3339), e.Outcome, host, port)
+	if e.Reason != "" {
+		fmt.Fprintf(&b, " reason=%s", e.Reason)
+	}
+	if e.User != "" {
+		fmt.Fprintf(&b, " user=%s", quote(e.User))
+	}
+	keyType := e.KeyType
+	if keyType == "" && e.Outcome == Accepted {
+		keyType = "none"
+	}
+	if keyType != "" {
+		fmt.Fprintf(&b, " key_type=%s", quote(keyType))
+	}
+	if e.ClientVersion != "" {
+		fmt.Fprintf(&b, " client=%s", quote(e.ClientVersion))
+	}
+	return b.String() + "\n"
+}
+
+func quote(s string) string {
+	if len(s) > maxField {
+		s = s[:maxField]
+	}
+	return strconv.QuoteToASCII(s)
+}
+
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return "unknown"
+	}
+	return addr.String()
+}
+
+// Log appends events to a file, moving it aside each day as
+// <name>.<yyyy-mm-dd> and deleting days past the retention. A nil Log
+// records nothing.
+type Log struct {
+	path      string
+	retention int // days of rotated files kept; 0 keeps them all
+	now       func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	day  string
+}
+
+// Open starts the log at path, keeping retention days of old files
+func Open(path string, retention int) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log: %w", err)
+	}
+	l := &Log{path: path, retention: retention, now: time.Now}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	l.prune()
+	return l, nil
+}
+
+// Record writes e. Failures are dropped: an attempt must never wait on,
+// or fail because of, the audit trail.
+func (l *Log) Record(e Event) {
+	if l == nil {
+		return
+	}
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if day := now.UTC().Format(time.DateOnly); day != l.day {
+		l.rotate(day)
+	}
+	if l.file != nil {
+		_, _ = l.file.WriteString(e.line(now))
+	}
+}
+
+// Close closes the file
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// open opens the current file, dating it from its last write so a
+// restart on a later day still rotates it; the caller holds mu
+func (l *Log) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l.file = f
+	l.day = l.now().UTC().Format(time.DateOnly)
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		l.day = info.ModTime().UTC().Format(time.DateOnly)
+	}
+	return nil
+}
+
+// rotate moves the file aside under its day and starts a new one; the
+// caller holds mu
+func (l *Log) rotate(day string) {
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+		_ = os.Rename(l.path, l.path+"."+l.day)
+	}
+	if l.open() == nil {
+		l.day = day
+	}
+	l.prune()
+}
+
+// prune deletes rotated files older than the retention; the caller
+// holds mu
+func (l *Log) prune() {
+	if l.retention <= 0 {
+		return
+	}
+	cutoff := l.now().UTC().AddDate(0, 0, -l.retention).Format(time.DateOnly)
+	rotated, _ := filepath.Glob(l.path + ".*")
+	for _, name := range rotated {
+		day := strings.TrimPrefix(name, l.path+".")
+		if _, err := time.Parse(time.DateOnly, day); err == nil && day < cutoff {
+			_ = os.Remove(name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLineQuotesClientFields(t *testing.T) {
+	e := Event{
+		Outcome:       Accepted,
+		Addr:          &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 40000},
+		User:          "bob\n2026-01-01T00:00:00Z tui-server audit: rejected from 198.51.100.1 port 1",
+		ClientVersion: "SSH-2.0-OpenSSH_9.6",
+	}
+	got := e.line(time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC))
+	if !strings.HasPrefix(got, "2026-10-17T20:00:00Z tui-server audit: accepted from 203.0.113.5 port 40000 user=\"bob\\n") {
+		t.Errorf("line = %q", got)
+	}
+	if strings.Count(got, "\n") != 1 || !strings.Contains(got, ` key_type="none" client="SSH-2.0-OpenSSH_9.6"`) {
+		t.Errorf("line = %q, want one line with the key type and client", got)
+	}
+}
+
+func TestLogRotatesDailyAndPrunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	day := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.WriteFile(path+".2026-09-01", []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Open(path, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.now = func() time.Time { return day }
+	l.day = day.Format(time.DateOnly)
+
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}
+	l.Record(Event{Outcome: RateLimited, Addr: addr})
+	day = day.AddDate(0, 0, 1)
+	l.Record(Event{Outcome: Rejected, Addr: addr, Reason: "banned"})
+
+	rotated, err := os.ReadFile(path + ".2026-10-01")
+	if err != nil || !strings.Contains(string(rotated), "rate-limited from 192.0.2.1 port 22") {
+		t.Fatalf("rotated day = %q, %v", rotated, err)
+	}
+	current, _ := os.ReadFile(path)
+	if !strings.Contains(string(current), "rejected from 192.0.2.1 port 22 reason=banned") || strings.Contains(string(current), "rate-limited") {
+		t.Errorf("current day = %q", current)
+	}
+	if _, err := os.Stat(path + ".2026-09-01"); !os.IsNotExist(err) {
+		t.Error("a day past the retention was kept")
+	}
+}
@@ -0,0 +1,115 @@
+// Package availability works out the owner's local time from a home
+// timezone, and from it how soon a message is likely to be answered.
+package availability
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is roughly what the owner is doing at a given local time
+type State int
+
+const (
+	Asleep State = iota
+	Morning
+	Working
+	Weekend
+	Evening
+	Late
+)
+
+// Clock tells the time where the owner lives
+type Clock struct {
+	name string
+	loc  *time.Location
+}
+
+// New returns a clock for an IANA timezone such as "Asia/Kolkata", naming
+// the owner in Line; an empty zone returns nil, turning the feature off
+func New(zone, name string) (*Clock, error) {
+	if zone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", zone, err)
+	}
+	return &Clock{name: name, loc: loc}, nil
+}
+
+// Status is the owner's local time and what it means for replies
+type Status struct {
+	Name  string
+	Local time.Time
+	State State
+}
+
+// At is the status at now
+func (c *Clock) At(now time.Time) Status {
+	local := now.In(c.loc)
+	return Status{Name: c.name, Local: local, State: stateAt(local)}
+}
+
+// stateAt reads the local time as a typical week: nights asleep, office
+// hours on weekdays, the rest free
+func stateAt(t time.Time) State {
+	weekend := t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	switch h := t.Hour(); {
+	case h < 7:
+		return Asleep
+	case h < 9:
+		return Morning
+	case h < 18 && weekend:
+		return Weekend
+	case h < 18:
+		return Working
+	case h < 23:
+		return Evening
+	default:
+		return Late
+	}
+}
+
+// Note is what the state means for a visitor waiting on a reply
+func (s Status) Note() string {
+	switch s.State {
+	case Asleep:
+		return "probably asleep, expect replies later"
+	case Morning:
+		return "starting the day, replies soon"
+	case Working:
+		return "working hours, replies within a few hours"
+	case Weekend:
+		return "it's the weekend, replies may be slower"
+	case Evening:
+		return "off work but often around"
+	default:
+		return "winding down, expect replies tomorrow"
+	}
+}
+
+// Clock is the local time as "03:12"
+func (s Status) Clock() string {
+	return s.Local.Format("15:04")
+}
+
+// Zone is the local zone as "IST (UTC+05:30)"
+func (s Status) Zone() string {
+	abbr, offset := s.Local.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	utc := fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+	if abbr == "" || abbr[0] == '+' || abbr[0] == '-' {
+		return utc
+	}
+	return abbr + " (" + utc + ")"
+}
+
+// Line is the status as one line, "it's 03:12 for Mohak — probably
+// asleep, expect replies later"
+func (s Status) Line() string {
+	return fmt.Sprintf("it's %s for %s — %s", s.Clock(), s.Name, s.Note())
+}
@@ -0,0 +1,45 @@
+package availability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusAt(t *testing.T) {
+	clock, err := New("Asia/Kolkata", "Mohak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		utc  time.Time
+		want State
+	}{
+		{name: "night", utc: time.Date(2026, 10, 16, 21, 42, 0, 0, time.UTC), want: Asleep},
+		{name: "weekday afternoon", utc: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), want: Working},
+		{name: "saturday afternoon", utc: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), want: Weekend},
+		{name: "evening", utc: time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), want: Evening},
+		{name: "late", utc: time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), want: Late},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := clock.At(tc.utc).State; got != tc.want {
+				t.Errorf("state at %s = %d, want %d", tc.utc.In(clock.loc).Format("Mon 15:04"), got, tc.want)
+			}
+		})
+	}
+
+	s := clock.At(time.Date(2026, 10, 16, 21, 42, 0, 0, time.UTC))
+	if want := "it's 03:12 for Mohak — probably asleep, expect replies later"; s.Line() != want {
+		t.Errorf("Line() = %q, want %q", s.Line(), want)
+	}
+	if want := "IST (UTC+05:30)"; s.Zone() != want {
+		t.Errorf("Zone() = %q, want %q", s.Zone(), want)
+	}
+	if c, err := New("", "Mohak"); c != nil || err != nil {
+		t.Errorf("New with no zone = %v, %v; want nil, nil", c, err)
+	}
+	if _, err := New("Mars/Olympus", "Mohak"); err == nil {
+		t.Error("New accepted an unknown zone")
+	}
+}
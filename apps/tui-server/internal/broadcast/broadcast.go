@@ -0,0 +1,67 @@
+// Package broadcast carries the operator's announcements, such as
+// "deploying in 5 minutes", to every session this server process has
+// open.
+package broadcast
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// MaxLength is the most characters an announcement keeps; it has to fit
+// on a line
+const MaxLength = 200
+
+// Hub delivers announcements to its subscribers. The zero value is
+// ready to use.
+type Hub struct {
+	mu   sync.Mutex
+	next int
+	subs map[int]func(text string)
+}
+
+// Subscribe calls deliver with each announcement published until ctx
+// ends. deliver runs on its own goroutine, so a session slow to take it
+// holds up nobody else.
+func (h *Hub) Subscribe(ctx context.Context, deliver func(text string)) {
+	h.mu.Lock()
+	if h.subs == nil {
+		h.subs = map[int]func(string){}
+	}
+	id := h.next
+	h.next++
+	h.subs[id] = deliver
+	h.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		delete(h.subs, id)
+		h.mu.Unlock()
+	})
+}
+
+// Publish announces text, cut to MaxLength and to one line, to every
+// subscriber and returns how many there were
+func (h *Hub) Publish(text string) int {
+	text = Clean(text)
+	if text == "" {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, deliver := range h.subs {
+		go deliver(text)
+	}
+	return len(h.subs)
+}
+
+// Clean is text as it's announced: one line of at most MaxLength
+// characters
+func Clean(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > MaxLength {
+		text = string(runes[:MaxLength-1]) + "…"
+	}
+	return text
+}
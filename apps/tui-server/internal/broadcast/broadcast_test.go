@@ -0,0 +1,43 @@
+package broadcast
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublishReachesSubscribersUntilTheyLeave(t *testing.T) {
+	var h Hub
+	got := make(chan string, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	h.Subscribe(ctx, func(text string) { got <- text })
+	h.Subscribe(context.Background(), func(string) {})
+
+	if n := h.Publish("  deploying\nin 5 minutes "); n != 2 {
+		t.Errorf("Publish reached %d sessions, want 2", n)
+	}
+	select {
+	case text := <-got:
+		if text != "deploying in 5 minutes" {
+			t.Errorf("delivered %q", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("announcement not delivered")
+	}
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for h.Publish("again") != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscriber not dropped after its context ended")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := h.Publish(" \n "); n != 0 {
+		t.Errorf("blank announcement reached %d sessions", n)
+	}
+	if text := Clean(strings.Repeat("x", MaxLength+10)); len([]rune(text)) != MaxLength {
+		t.Errorf("Clean kept %d characters, want %d", len([]rune(text)), MaxLength)
+	}
+}
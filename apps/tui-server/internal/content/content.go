@@ -1,7 +1,15 @@
 package content
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // project images
+	_ "image/jpeg"
+	_ "image/png"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -20,23 +28,107 @@ type Resume struct {
 	} `json:"contact"`
 	Summary    string       `json:"summary"`
 	Experience []Experience `json:"experience"`
-	Skills     struct {
-		Languages []string `json:"languages"`
-		Frontend  []string `json:"frontend"`
-		Backend   []string `json:"backend"`
-		Databases []string `json:"databases"`
-		DevOps    []string `json:"devops"`
-		Tools     []string `json:"tools"`
-		Mobile    []string `json:"mobile"`
-	} `json:"skills"`
-	Education []struct {
+	Skills     Skills       `json:"skills"`
+	Education  []struct {
 		Institution string `json:"institution"`
 		Degree      string `json:"degree"`
 		Location    string `json:"location"`
 		Period      string `json:"period"`
 		Score       string `json:"score"`
 	} `json:"education"`
-	Achievements []string `json:"achievements"`
+	Achievements []Achievement `json:"achievements"`
+}
+
+// Skills are the resume's skills by group
+type Skills struct {
+	Languages []Skill `json:"languages"`
+	Frontend  []Skill `json:"frontend"`
+	Backend   []Skill `json:"backend"`
+	Databases []Skill `json:"databases"`
+	DevOps    []Skill `json:"devops"`
+	Tools     []Skill `json:"tools"`
+	Mobile    []Skill `json:"mobile"`
+}
+
+// MaxSkillLevel is the top of the Skill.Level scale
+const MaxSkillLevel = 5
+
+// Skill is a skill with, optionally, how well it's known: a Level from 1
+// to MaxSkillLevel and the Years it's been used. In resume.json it is
+// {"name", "level", "years"}, or just the name.
+type Skill struct {
+	Name  string `json:"name"`
+	Level int    `json:"level,omitempty"`
+	Years int    `json:"years,omitempty"`
+}
+
+// UnmarshalJSON reads a skill object, or a name on its own
+func (s *Skill) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*s = Skill{}
+		return json.Unmarshal(data, &s.Name)
+	}
+	type plain Skill
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// SkillGroup is a titled group of skills
+type SkillGroup struct {
+	Name   string
+	Skills []Skill
+}
+
+// Groups are the groups that have skills, in the order the resume
+// shows them
+func (s Skills) Groups() []SkillGroup {
+	all := []SkillGroup{
+		{"Languages", s.Languages}, {"Frontend", s.Frontend}, {"Backend", s.Backend},
+		{"Databases", s.Databases}, {"DevOps", s.DevOps}, {"Tools", s.Tools}, {"Mobile", s.Mobile},
+	}
+	var groups []SkillGroup
+	for _, g := range all {
+		if len(g.Skills) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// SkillNames are the names of skills
+func SkillNames(skills []Skill) []string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Achievement is an award, role or other milestone. Date is free text,
+// as in "Mar 2024". In resume.json it is {"text", "date", "url"}, or
+// just the text.
+type Achievement struct {
+	Text string `json:"text"`
+	Date string `json:"date,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// UnmarshalJSON reads an achievement object, or its text on its own
+func (a *Achievement) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*a = Achievement{}
+		return json.Unmarshal(data, &a.Text)
+	}
+	type plain Achievement
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// AchievementTexts are the texts of achievements
+func AchievementTexts(achievements []Achievement) []string {
+	texts := make([]string, len(achievements))
+	for i, a := range achievements {
+		texts[i] = a.Text
+	}
+	return texts
 }
 
 // Experience represents work experience
@@ -47,13 +139,23 @@ type Experience struct {
 	Highlights []string `json:"highlights"`
 }
 
-// Project represents a single project
+// Project represents a single project. Date is when it was last worked
+// on, as "2024-06" or "2024-06-30", and Year when it started; Tags are
+// free-form topics such as "ai", and Category one of ProjectCategories.
+// At most one project is Featured. Images are screenshots, as paths in
+// the content directory such as "images/site.png".
 type Project struct {
 	ID          string   `json:"id"`
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
 	Tech        []string `json:"tech"`
 	Status      string   `json:"status"`
+	Date        string   `json:"date,omitempty"`
+	Year        int      `json:"year,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Featured    bool     `json:"featured,omitempty"`
+	Images      []string `json:"images,omitempty"`
 	Links       struct {
 		Demo   string `json:"demo,omitempty"`
 		Github string `json:"github,omitempty"`
@@ -65,6 +167,50 @@ type Projects struct {
 	Projects []Project `json:"projects"`
 }
 
+// Talk is a talk, publication or other appearance. Kind is "talk",
+// "publication" or "podcast"; Date is free text, as in "Mar 2024".
+type Talk struct {
+	Title   string `json:"title"`
+	Kind    string `json:"kind"`
+	Event   string `json:"event"`
+	Date    string `json:"date"`
+	URL     string `json:"url,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// Talks container, newest first
+type Talks struct {
+	Talks []Talk `json:"talks"`
+}
+
+// Certification is a professional certification. Expires is empty for
+// ones that don't.
+type Certification struct {
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer"`
+	Date         string `json:"date"`
+	Expires      string `json:"expires,omitempty"`
+	CredentialID string `json:"credentialId,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// Certifications container, newest first
+type Certifications struct {
+	Certifications []Certification `json:"certifications"`
+}
+
+// FAQEntry is a canned answer to a set of common phrasings of a question
+type FAQEntry struct {
+	ID        string   `json:"id"`
+	Questions []string `json:"questions"`
+	Answer    string   `json:"answer"`
+}
+
+// FAQ container
+type FAQ struct {
+	FAQs []FAQEntry `json:"faqs"`
+}
+
 // Loader handles loading content from files
 type Loader struct {
 	basePath string
@@ -86,6 +232,9 @@ func (l *Loader) LoadResume() (*Resume, error) {
 	if err := json.Unmarshal(data, &resume); err != nil {
 		return nil, err
 	}
+	if err := resume.validate(); err != nil {
+		return nil, fmt.Errorf("invalid resume.json: %w", err)
+	}
 
 	return &resume, nil
 }
@@ -101,6 +250,9 @@ func (l *Loader) LoadProjects() (*Projects, error) {
 	if err := json.Unmarshal(data, &projects); err != nil {
 		return nil, err
 	}
+	if err := errors.Join(projects.validate(), l.checkImages(&projects)); err != nil {
+		return nil, fmt.Errorf("invalid projects.json: %w", err)
+	}
 
 	return &projects, nil
 }
@@ -115,6 +267,110 @@ func (l *Loader) LoadBio() (string, error) {
 	return string(data), nil
 }
 
+// LoadUses reads the uses markdown file, listing hardware and tools. The
+// page is optional, so a missing file yields "".
+func (l *Loader) LoadUses() (string, error) {
+	data, err := l.readFile("uses.md")
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// LoadFAQ reads and parses the FAQ JSON. The FAQ is optional, so a
+// missing file yields an empty FAQ.
+func (l *Loader) LoadFAQ() (*FAQ, error) {
+	data, err := l.readFile("faq.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return &FAQ{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var faq FAQ
+	if err := json.Unmarshal(data, &faq); err != nil {
+		return nil, err
+	}
+
+	return &faq, nil
+}
+
+// LoadTalks reads and parses the talks JSON. Talks are optional, so a
+// missing file yields an empty list.
+func (l *Loader) LoadTalks() (*Talks, error) {
+	data, err := l.readFile("talks.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Talks{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var talks Talks
+	if err := json.Unmarshal(data, &talks); err != nil {
+		return nil, err
+	}
+
+	return &talks, nil
+}
+
+// LoadCertifications reads and parses the certifications JSON.
+// Certifications are optional, so a missing file yields an empty list.
+func (l *Loader) LoadCertifications() (*Certifications, error) {
+	data, err := l.readFile("certifications.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Certifications{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var certs Certifications
+	if err := json.Unmarshal(data, &certs); err != nil {
+		return nil, err
+	}
+
+	return &certs, nil
+}
+
+// LoadImage reads and decodes a PNG, JPEG or GIF in the content
+func (l *Loader) LoadImage(name string) (image.Image, error) {
+	data, err := l.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return img, nil
+}
+
+// checkImages reports project images that are missing or can't be read
+// as pictures, without decoding them whole
+func (l *Loader) checkImages(p *Projects) error {
+	var errs []error
+	for _, project := range p.Projects {
+		for _, name := range project.Images {
+			data, err := l.readFile(name)
+			if err == nil {
+				_, _, err = image.DecodeConfig(bytes.NewReader(data))
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("project %s: image %s: %w", project.ID, name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GetProjectByID finds a project by its ID
 func (p *Projects) GetProjectByID(id string) *Project {
 	for _, project := range p.Projects {
@@ -0,0 +1,225 @@
+package content
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+)
+
+// Fact is one detail of the content a returning visitor might notice
+// changing, as in Projects / Echo / status / active. Fields such as a
+// project's tech repeat, one fact per value.
+type Fact struct {
+	Section string `json:"section"`
+	Item    string `json:"item,omitempty"`
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+}
+
+// Digest flattens the content into facts, so two versions of it can be
+// compared without keeping either whole
+type Digest struct {
+	Facts []Fact `json:"facts"`
+}
+
+// NewDigest describes the content; any part may be nil
+func NewDigest(resume *Resume, projects *Projects, talks *Talks, certs *Certifications) Digest {
+	var d Digest
+	add := func(section, item, field string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				d.Facts = append(d.Facts, Fact{Section: section, Item: item, Field: field, Value: v})
+			}
+		}
+	}
+
+	if resume != nil {
+		add("Profile", "", "title", resume.Title)
+		add("Profile", "", "tagline", resume.Tagline)
+		add("Profile", "", "summary", resume.Summary)
+		add("Profile", "", "email", resume.Contact.Email)
+		add("Profile", "", "website", resume.Contact.Website)
+		for _, e := range resume.Experience {
+			item := e.Role + " at " + e.Company
+			add("Experience", item, "period", e.Period)
+			add("Experience", item, "highlight", e.Highlights...)
+		}
+		for _, group := range resume.Skills.Groups() {
+			add("Skills", group.Name, "skill", SkillNames(group.Skills)...)
+		}
+		for _, e := range resume.Education {
+			add("Education", e.Degree, "institution", e.Institution)
+			add("Education", e.Degree, "period", e.Period)
+			add("Education", e.Degree, "score", e.Score)
+		}
+		add("Achievements", "", "achievement", AchievementTexts(resume.Achievements)...)
+	}
+	if projects != nil {
+		for _, p := range projects.Projects {
+			add("Projects", p.Name, "status", p.Status)
+			add("Projects", p.Name, "description", p.Description)
+			add("Projects", p.Name, "tech", p.Tech...)
+			add("Projects", p.Name, "demo", p.Links.Demo)
+			add("Projects", p.Name, "source", p.Links.Github)
+		}
+	}
+	if talks != nil {
+		for _, t := range talks.Talks {
+			add("Talks", t.Title, "event", t.Event)
+			add("Talks", t.Title, "date", t.Date)
+		}
+	}
+	if certs != nil {
+		for _, c := range certs.Certifications {
+			add("Certifications", c.Name, "issuer", c.Issuer)
+			add("Certifications", c.Name, "date", c.Date)
+			add("Certifications", c.Name, "expires", c.Expires)
+		}
+	}
+	return d
+}
+
+// Version is a short hash of the digest, the same for the same content
+func (d Digest) Version() string {
+	raw, _ := json.Marshal(d.Facts)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:6])
+}
+
+// ChangeKind says what happened to an entry
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Updated
+)
+
+// Change is what happened to one entry, such as a project, between two
+// versions. Section-wide facts like achievements have no Item and are
+// always Updated.
+type Change struct {
+	Section string
+	Item    string
+	Kind    ChangeKind
+	// Edits are the differences in an updated entry, or the values of
+	// an added one
+	Edits []Edit
+}
+
+// Edit is one changed value: Old is empty for a value added and New for
+// one removed
+type Edit struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// entry is one item's facts, its values grouped by field in order
+type entry struct {
+	section, item string
+	fields        []string
+	values        map[string][]string
+}
+
+// Diff lists what changed from prev to next, by section in next's
+// order, with entries that are gone after the rest of their section
+func Diff(prev, next Digest) []Change {
+	oldEntries, oldOrder := entries(prev)
+	newEntries, newOrder := entries(next)
+
+	var sections []string
+	for _, k := range append(slices.Clone(newOrder), oldOrder...) {
+		if !slices.Contains(sections, k[0]) {
+			sections = append(sections, k[0])
+		}
+	}
+
+	var changes []Change
+	for _, section := range sections {
+		for _, k := range newOrder {
+			n := newEntries[k]
+			if n.section != section {
+				continue
+			}
+			o, ok := oldEntries[k]
+			switch {
+			case !ok && n.item != "":
+				changes = append(changes, Change{Section: section, Item: n.item, Kind: Added, Edits: edits(entry{}, *n)})
+			case !ok:
+				changes = append(changes, Change{Section: section, Kind: Updated, Edits: edits(entry{}, *n)})
+			default:
+				if e := edits(*o, *n); len(e) > 0 {
+					changes = append(changes, Change{Section: section, Item: n.item, Kind: Updated, Edits: e})
+				}
+			}
+		}
+		for _, k := range oldOrder {
+			o := oldEntries[k]
+			if _, ok := newEntries[k]; ok || o.section != section {
+				continue
+			}
+			if o.item == "" {
+				changes = append(changes, Change{Section: section, Kind: Updated, Edits: edits(*o, entry{})})
+			} else {
+				changes = append(changes, Change{Section: section, Item: o.item, Kind: Removed})
+			}
+		}
+	}
+	return changes
+}
+
+// entries groups a digest's facts by section and item, keyed by the
+// two, in order
+func entries(d Digest) (map[[2]string]*entry, [][2]string) {
+	byKey := map[[2]string]*entry{}
+	var order [][2]string
+	for _, f := range d.Facts {
+		k := [2]string{f.Section, f.Item}
+		e, ok := byKey[k]
+		if !ok {
+			e = &entry{section: f.Section, item: f.Item, values: map[string][]string{}}
+			byKey[k] = e
+			order = append(order, k)
+		}
+		if _, ok := e.values[f.Field]; !ok {
+			e.fields = append(e.fields, f.Field)
+		}
+		e.values[f.Field] = append(e.values[f.Field], f.Value)
+	}
+	return byKey, order
+}
+
+// edits compares an entry's fields: a field with one value either side
+// is changed in place, and for repeated fields the values that came and
+// went are listed
+func edits(o, n entry) []Edit {
+	var fields []string
+	for _, f := range append(slices.Clone(n.fields), o.fields...) {
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	var out []Edit
+	for _, f := range fields {
+		before, after := o.values[f], n.values[f]
+		if len(before) == 1 && len(after) == 1 {
+			if before[0] != after[0] {
+				out = append(out, Edit{Field: f, Old: before[0], New: after[0]})
+			}
+			continue
+		}
+		for _, v := range after {
+			if !slices.Contains(before, v) {
+				out = append(out, Edit{Field: f, New: v})
+			}
+		}
+		for _, v := range before {
+			if !slices.Contains(after, v) {
+				out = append(out, Edit{Field: f, Old: v})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDescribesChanges(t *testing.T) {
+	t.Parallel()
+
+	resume := &Resume{Title: "Engineer", Achievements: []Achievement{{Text: "Won a hackathon"}}}
+	projects := &Projects{Projects: []Project{
+		{Name: "Echo", Status: "active", Tech: []string{"Go", "Redis"}},
+		{Name: "Old", Status: "completed"},
+	}}
+	before := NewDigest(resume, projects, nil, nil)
+
+	resume.Title = "Senior Engineer"
+	resume.Achievements = append(resume.Achievements, Achievement{Text: "Gave a talk"})
+	projects.Projects = []Project{
+		{Name: "Echo", Status: "completed", Tech: []string{"Go", "SQLite"}},
+		{Name: "New", Status: "active"},
+	}
+	after := NewDigest(resume, projects, nil, nil)
+
+	if before.Version() == after.Version() {
+		t.Fatal("different content has the same version")
+	}
+	if again := NewDigest(resume, projects, nil, nil); again.Version() != after.Version() {
+		t.Fatal("the same content has different versions")
+	}
+
+	want := []Change{
+		{Section: "Profile", Kind: Updated, Edits: []Edit{{Field: "title", Old: "Engineer", New: "Senior Engineer"}}},
+		{Section: "Achievements", Kind: Updated, Edits: []Edit{{Field: "achievement", New: "Gave a talk"}}},
+		{Section: "Projects", Item: "Echo", Kind: Updated, Edits: []Edit{
+			{Field: "status", Old: "active", New: "completed"},
+			{Field: "tech", New: "SQLite"},
+			{Field: "tech", Old: "Redis"},
+		}},
+		{Section: "Projects", Item: "New", Kind: Added, Edits: []Edit{{Field: "status", New: "active"}}},
+		{Section: "Projects", Item: "Old", Kind: Removed},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := Diff(after, after); len(got) != 0 {
+		t.Fatalf("Diff() of the same content = %+v, want nothing", got)
+	}
+}
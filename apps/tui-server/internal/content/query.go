@@ -0,0 +1,107 @@
+package content
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// ProjectSorts are the orders a ProjectQuery can ask for besides the
+// file's own
+var ProjectSorts = []string{"recent", "name"}
+
+// ProjectQuery narrows and orders the projects list. Tech, Status and Tag
+// match case-insensitively and empty ones match everything; Sort is one
+// of ProjectSorts, or empty for the file's order.
+type ProjectQuery struct {
+	Tech   string
+	Status string
+	Tag    string
+	Sort   string
+}
+
+// IsZero reports whether the query leaves the list as it is
+func (q ProjectQuery) IsZero() bool {
+	return q == ProjectQuery{}
+}
+
+// Query lists the projects matching q in its order. Sorting by recent
+// puts undated projects last.
+func (p *Projects) Query(q ProjectQuery) []Project {
+	if p == nil {
+		return nil
+	}
+	var out []Project
+	for _, project := range p.Projects {
+		if matchesAny(q.Tech, project.Tech) && matchesAny(q.Status, []string{project.Status}) && matchesAny(q.Tag, project.Tags) {
+			out = append(out, project)
+		}
+	}
+
+	switch q.Sort {
+	case "recent":
+		slices.SortStableFunc(out, func(a, b Project) int {
+			switch {
+			case a.Date == "" && b.Date != "":
+				return 1
+			case a.Date != "" && b.Date == "":
+				return -1
+			}
+			return cmp.Compare(b.Date, a.Date)
+		})
+	case "name":
+		slices.SortStableFunc(out, func(a, b Project) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+	return out
+}
+
+// matchesAny reports whether want is empty or one of values
+func matchesAny(want string, values []string) bool {
+	if want == "" {
+		return true
+	}
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, want) })
+}
+
+// ProjectGroup is one section of the projects list: the projects in a
+// category, or without one when Category is empty
+type ProjectGroup struct {
+	Category string
+	Projects []Project
+}
+
+// GroupProjects splits projects into their categories' sections, in the
+// order of ProjectCategories, keeping their order within each. Those
+// without one come last, as a single unnamed group when none has one.
+func GroupProjects(projects []Project) []ProjectGroup {
+	var groups []ProjectGroup
+	for _, category := range append(slices.Clone(ProjectCategories), "") {
+		var in []Project
+		for _, p := range projects {
+			if p.Category == category || category == "" && !slices.Contains(ProjectCategories, p.Category) {
+				in = append(in, p)
+			}
+		}
+		if len(in) > 0 {
+			groups = append(groups, ProjectGroup{Category: category, Projects: in})
+		}
+	}
+	return groups
+}
+
+// Spotlight is the project to show off first: the featured one, or else
+// the most recently updated, or the first listed. Nil without projects.
+func (p *Projects) Spotlight() *Project {
+	if p == nil || len(p.Projects) == 0 {
+		return nil
+	}
+	for _, project := range p.Projects {
+		if project.Featured {
+			return &project
+		}
+	}
+	recent := p.Query(ProjectQuery{Sort: "recent"})
+	return &recent[0]
+}
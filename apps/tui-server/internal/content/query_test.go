@@ -0,0 +1,151 @@
+package content
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryFiltersAndSorts(t *testing.T) {
+	t.Parallel()
+
+	projects := &Projects{Projects: []Project{
+		{ID: "echo", Name: "Echo", Tech: []string{"Go", "Redis"}, Status: "active", Date: "2023-05"},
+		{ID: "atlas", Name: "atlas", Tech: []string{"MongoDB"}, Status: "active"},
+		{ID: "zed", Name: "Zed", Tech: []string{"go"}, Status: "completed", Date: "2024-11", Tags: []string{"ai"}},
+	}}
+	ids := func(ps []Project) []string {
+		var out []string
+		for _, p := range ps {
+			out = append(out, p.ID)
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		query ProjectQuery
+		want  []string
+	}{
+		{ProjectQuery{}, []string{"echo", "atlas", "zed"}},
+		{ProjectQuery{Tech: "GO"}, []string{"echo", "zed"}},
+		{ProjectQuery{Tech: "go", Status: "active"}, []string{"echo"}},
+		{ProjectQuery{Tag: "AI"}, []string{"zed"}},
+		{ProjectQuery{Sort: "recent"}, []string{"zed", "echo", "atlas"}},
+		{ProjectQuery{Sort: "name"}, []string{"atlas", "echo", "zed"}},
+		{ProjectQuery{Status: "archived"}, nil},
+	} {
+		if got := ids(projects.Query(tc.query)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Query(%+v) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestGroupProjectsByCategory(t *testing.T) {
+	t.Parallel()
+
+	projects := []Project{
+		{ID: "site", Category: "Web"},
+		{ID: "misc"},
+		{ID: "bot", Category: "AI"},
+		{ID: "blog", Category: "Web"},
+	}
+	var got [][]string
+	for _, g := range GroupProjects(projects) {
+		group := []string{g.Category}
+		for _, p := range g.Projects {
+			group = append(group, p.ID)
+		}
+		got = append(got, group)
+	}
+	want := [][]string{{"AI", "bot"}, {"Web", "site", "blog"}, {"", "misc"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupProjects() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	ok := &Projects{Projects: []Project{
+		{ID: "echo", Name: "Echo", Category: "Web", Year: 2023, Date: "2024-03", Tags: []string{"social"}, Featured: true},
+		{ID: "atlas", Name: "Atlas", Images: []string{"images/atlas.png"}},
+	}}
+	if err := ok.validate(); err != nil {
+		t.Fatalf("valid projects: %v", err)
+	}
+
+	bad := &Projects{Projects: []Project{
+		{ID: "echo", Name: "Echo", Category: "Games", Featured: true},
+		{ID: "echo", Year: 1066, Date: "March 2024", Tags: []string{"ai", "ai", " "}, Featured: true,
+			Images: []string{"../secret.png", "images/notes.txt"}},
+	}}
+	err := bad.validate()
+	if err == nil {
+		t.Fatal("invalid projects passed")
+	}
+	for _, want := range []string{
+		`project echo: category "Games" is not one of AI, Systems, Web`,
+		"project echo: id is used twice",
+		"project echo: missing name",
+		"project echo: year 1066 is out of range",
+		`project echo: date "March 2024" is not YYYY-MM or YYYY-MM-DD`,
+		`project echo: tag "ai" is listed twice`,
+		"project echo: blank tag",
+		`project echo: image "../secret.png" is outside the content directory`,
+		`project echo: image "images/notes.txt" is not .png, .jpg, .jpeg, .gif`,
+		"only one project can be featured, not echo, echo",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error doesn't say %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestSpotlightPrefersFeatured(t *testing.T) {
+	t.Parallel()
+
+	projects := &Projects{Projects: []Project{
+		{ID: "old", Date: "2021-01"},
+		{ID: "new", Date: "2024-06"},
+		{ID: "best"},
+	}}
+	if got := projects.Spotlight(); got.ID != "new" {
+		t.Errorf("without a featured project Spotlight() = %s, want the latest", got.ID)
+	}
+	projects.Projects[2].Featured = true
+	if got := projects.Spotlight(); got.ID != "best" {
+		t.Errorf("Spotlight() = %s, want the featured one", got.ID)
+	}
+	if got := (&Projects{}).Spotlight(); got != nil {
+		t.Errorf("Spotlight() of nothing = %+v", got)
+	}
+}
+
+func TestSkillsAreNamesOrObjects(t *testing.T) {
+	t.Parallel()
+
+	var r Resume
+	raw := `{"skills": {"languages": ["Go", {"name": "Rust", "level": 3, "years": 2}], "tools": [{"name": "Go", "level": 9, "years": -1}, ""]}}`
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		t.Fatal(err)
+	}
+	want := []Skill{{Name: "Go"}, {Name: "Rust", Level: 3, Years: 2}}
+	if !reflect.DeepEqual(r.Skills.Languages, want) {
+		t.Errorf("languages = %+v, want %+v", r.Skills.Languages, want)
+	}
+
+	err := r.validate()
+	if err == nil {
+		t.Fatal("invalid skills passed")
+	}
+	for _, want := range []string{
+		`tools skill "Go": level 9 is not 1 to 5`,
+		`tools skill "Go": -1 years is out of range`,
+		"tools skill #2: missing name",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error doesn't say %q:\n%v", want, err)
+		}
+	}
+}
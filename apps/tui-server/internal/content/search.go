@@ -0,0 +1,186 @@
+package content
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxHits is how many hits a search returns at most
+const maxHits = 30
+
+// snippetLead is how much text, in runes, a snippet keeps before the match
+const snippetLead = 24
+
+// Hit is one place a search term turns up: the page showing it, as a
+// deep link such as "projects/echo", what on that page it's in, and the
+// text around the match. Match is the text to look for on the page: the
+// whole term when it appears as typed, otherwise its longest word.
+type Hit struct {
+	Route   string
+	Where   string
+	Snippet string
+	Match   string
+	score   int
+}
+
+// passage is one piece of searchable text. Weight ranks it against the
+// rest: a name outranks a line mentioning it.
+type passage struct {
+	route, where, text string
+	weight             int
+}
+
+// Index holds the content's text for searching
+type Index struct {
+	passages []passage
+}
+
+// NewIndex indexes the content, in the order the pages show it; any part
+// may be nil or empty
+func NewIndex(resume *Resume, projects *Projects, bio, uses string, talks *Talks, certs *Certifications) Index {
+	var ix Index
+	add := func(route, where string, weight int, texts ...string) {
+		for _, t := range texts {
+			if t = strings.Join(strings.Fields(t), " "); t != "" {
+				ix.passages = append(ix.passages, passage{route: route, where: where, text: t, weight: weight})
+			}
+		}
+	}
+
+	addMarkdown(bio, func(where, text string) { add("about", where, 1, text) }, "About")
+
+	if resume != nil {
+		add("resume", "Resume", 3, resume.Title)
+		add("resume", "Resume", 2, resume.Tagline)
+		add("resume", "Resume · Summary", 1, resume.Summary)
+		s := resume.Skills
+		skills := slices.Concat(s.Languages, s.Frontend, s.Backend, s.Databases, s.DevOps, s.Tools, s.Mobile)
+		add("resume", "Resume · Skills", 2, SkillNames(skills)...)
+		for _, e := range resume.Education {
+			add("resume", "Education · "+e.Degree, 3, e.Degree)
+			add("resume", "Education · "+e.Degree, 2, e.Institution)
+		}
+		add("resume", "Resume · Achievements", 1, AchievementTexts(resume.Achievements)...)
+		for _, e := range resume.Experience {
+			where := "Experience · " + e.Company
+			add("experience", where, 3, e.Role, e.Company)
+			add("experience", where, 1, e.Highlights...)
+		}
+	}
+
+	if projects != nil {
+		for _, p := range projects.Projects {
+			route, where := "projects/"+p.ID, "Projects · "+p.Name
+			add(route, where, 3, p.Name)
+			add(route, where, 1, p.Description)
+			add(route, where, 2, p.Tech...)
+		}
+	}
+
+	addMarkdown(uses, func(where, text string) { add("uses", where, 1, text) }, "Uses")
+
+	if talks != nil {
+		for _, t := range talks.Talks {
+			where := "Talks · " + t.Title
+			add("talks", where, 3, t.Title)
+			add("talks", where, 2, t.Event)
+			add("talks", where, 1, t.Summary)
+		}
+	}
+	if certs != nil {
+		for _, c := range certs.Certifications {
+			where := "Certifications · " + c.Name
+			add("certs", where, 3, c.Name)
+			add("certs", where, 2, c.Issuer)
+		}
+	}
+	return ix
+}
+
+// addMarkdown adds a markdown page line by line, each under the page's
+// name and its latest "## " heading
+func addMarkdown(text string, add func(where, text string), page string) {
+	where := page
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# "):
+		case strings.HasPrefix(line, "## "):
+			where = page + " · " + strings.TrimPrefix(line, "## ")
+		default:
+			line = strings.TrimLeft(line, "-*> ")
+			add(where, strings.ReplaceAll(line, "**", ""))
+		}
+	}
+}
+
+// Search finds the passages holding every word of term, ignoring case,
+// best first: the term as typed beats scattered words, names beat
+// descriptions, and ties keep the pages' order. Each passage gives at
+// most one hit.
+func (ix Index) Search(term string) []Hit {
+	term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	words := strings.Fields(term)
+	if len(words) == 0 {
+		return nil
+	}
+	longest := slices.MaxFunc(words, func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+
+	var hits []Hit
+	for _, p := range ix.passages {
+		lower := strings.ToLower(p.text)
+		score := 0
+		for _, w := range words {
+			n := strings.Count(lower, w)
+			if n == 0 {
+				score = 0
+				break
+			}
+			score += min(n, 3)
+		}
+		if score == 0 {
+			continue
+		}
+
+		match := longest
+		if strings.Contains(lower, term) {
+			match = term
+			score += 2 * len(words)
+		}
+		if lower == term {
+			score += 5
+		}
+		hits = append(hits, Hit{
+			Route:   p.route,
+			Where:   p.where,
+			Snippet: snippet(p.text, strings.Index(lower, match)),
+			Match:   match,
+			score:   score * p.weight,
+		})
+	}
+
+	slices.SortStableFunc(hits, func(a, b Hit) int { return cmp.Compare(b.score, a.score) })
+	return hits[:min(len(hits), maxHits)]
+}
+
+// snippet is text from a little before byte offset at onwards, with an
+// ellipsis where it was cut
+func snippet(text string, at int) string {
+	if at < 0 || at > len(text) || !utf8.RuneStart(text[min(at, len(text)-1)]) {
+		at = 0
+	}
+	start := at
+	for n := 0; n < snippetLead && start > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	if start == 0 {
+		return text
+	}
+	// Begin at a word where there's one nearby
+	if i := strings.IndexByte(text[start:at], ' '); i >= 0 {
+		start += i + 1
+	}
+	return "…" + text[start:]
+}
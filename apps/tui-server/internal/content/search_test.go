@@ -0,0 +1,56 @@
+package content
+
+import "testing"
+
+func TestSearchRanksHits(t *testing.T) {
+	t.Parallel()
+
+	resume := &Resume{
+		Summary: "Engineer who likes making distributed systems fast and reliable.",
+		Experience: []Experience{
+			{Role: "Engineer", Company: "Acme", Highlights: []string{"Rewrote the billing systems in Go", "Cut API latency in half"}},
+		},
+	}
+	projects := &Projects{Projects: []Project{
+		{ID: "echo", Name: "Echo", Description: "Distributed chat that keeps teams in sync across systems", Tech: []string{"Go"}},
+	}}
+	bio := "# Mohak\n\n## Interests\n\n- **Reading:** papers on distributed systems\n"
+	ix := NewIndex(resume, projects, bio, "", nil, nil)
+
+	hits := ix.Search("Distributed  SYSTEMS")
+	if len(hits) != 3 {
+		t.Fatalf("got %d hits, want 3: %+v", len(hits), hits)
+	}
+	if hits[0].Route != "about" || hits[0].Where != "About · Interests" {
+		t.Errorf("first hit = %+v, want the bio's interests", hits[0])
+	}
+	if hits[0].Match != "distributed systems" || hits[0].Snippet != "Reading: papers on distributed systems" {
+		t.Errorf("first hit matched %q in %q", hits[0].Match, hits[0].Snippet)
+	}
+	if hits[2].Route != "projects/echo" {
+		t.Errorf("last hit = %+v, want Echo, which has the words apart", hits[2])
+	}
+	if hits[2].Match != "distributed" {
+		t.Errorf("scattered words matched %q, want the longest word", hits[2].Match)
+	}
+
+	if hits := ix.Search("go"); len(hits) != 2 || hits[0].Route != "projects/echo" || hits[1].Route != "experience" {
+		t.Errorf("a tech name should outrank a highlight mentioning it: %+v", hits)
+	}
+	if hits := ix.Search("latency"); len(hits) != 1 || hits[0].Snippet != "Cut API latency in half" {
+		t.Errorf("latency: %+v", hits)
+	}
+	if hits := ix.Search("  "); hits != nil {
+		t.Errorf("a blank term found %+v", hits)
+	}
+}
+
+func TestSnippetStartsNearTheMatch(t *testing.T) {
+	t.Parallel()
+
+	text := "Built the first version of the ingestion pipeline that now serves millions of events"
+	got := snippet(text, len("Built the first version of the ingestion "))
+	if got != "…of the ingestion pipeline that now serves millions of events" {
+		t.Errorf("snippet = %q", got)
+	}
+}
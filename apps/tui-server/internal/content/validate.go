@@ -0,0 +1,121 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ProjectCategories are the sections the projects list is grouped into,
+// in order. Projects without a category come after them.
+var ProjectCategories = []string{"AI", "Systems", "Web"}
+
+// projectDatePattern is a Project.Date: a month, or a day
+var projectDatePattern = regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?$`)
+
+// firstProjectYear is the earliest Project.Year taken as real
+const firstProjectYear = 1990
+
+// imageTypes are the extensions a project image can have
+var imageTypes = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// validate checks what the views rely on: unique IDs and names, a known
+// category, a plausible year and date, tags without blanks or repeats,
+// images inside the content directory, and a single featured project.
+// It reports every problem at once.
+func (p *Projects) validate() error {
+	var errs []error
+	ids := map[string]bool{}
+	var featured []string
+	for i, project := range p.Projects {
+		bad := func(format string, args ...any) {
+			name := project.ID
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			errs = append(errs, fmt.Errorf("project %s: %s", name, fmt.Sprintf(format, args...)))
+		}
+
+		switch {
+		case project.ID == "":
+			bad("missing id")
+		case ids[project.ID]:
+			bad("id is used twice")
+		}
+		ids[project.ID] = true
+		if project.Name == "" {
+			bad("missing name")
+		}
+		if project.Category != "" && !slices.Contains(ProjectCategories, project.Category) {
+			bad("category %q is not one of %s", project.Category, strings.Join(ProjectCategories, ", "))
+		}
+		if y := project.Year; y != 0 && (y < firstProjectYear || y > time.Now().Year()+1) {
+			bad("year %d is out of range", y)
+		}
+		if project.Date != "" && !projectDatePattern.MatchString(project.Date) {
+			bad("date %q is not YYYY-MM or YYYY-MM-DD", project.Date)
+		}
+		for j, tag := range project.Tags {
+			switch {
+			case strings.TrimSpace(tag) == "":
+				bad("blank tag")
+			case slices.Contains(project.Tags[:j], tag):
+				bad("tag %q is listed twice", tag)
+			}
+		}
+		for _, name := range project.Images {
+			switch {
+			case !filepath.IsLocal(name):
+				bad("image %q is outside the content directory", name)
+			case !slices.Contains(imageTypes, strings.ToLower(filepath.Ext(name))):
+				bad("image %q is not %s", name, strings.Join(imageTypes, ", "))
+			}
+		}
+		if project.Featured {
+			featured = append(featured, project.ID)
+		}
+	}
+	if len(featured) > 1 {
+		errs = append(errs, fmt.Errorf("only one project can be featured, not %s", strings.Join(featured, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// maxSkillYears is the most Skill.Years taken as real
+const maxSkillYears = 50
+
+// validate checks the skills: named once each, with a level on the
+// scale and plausible years; and that achievements have text. It
+// reports every problem at once.
+func (r *Resume) validate() error {
+	var errs []error
+	for i, a := range r.Achievements {
+		if strings.TrimSpace(a.Text) == "" {
+			errs = append(errs, fmt.Errorf("achievement #%d: missing text", i+1))
+		}
+	}
+	for _, g := range r.Skills.Groups() {
+		for i, skill := range g.Skills {
+			bad := func(format string, args ...any) {
+				errs = append(errs, fmt.Errorf("%s skill %q: %s", strings.ToLower(g.Name), skill.Name, fmt.Sprintf(format, args...)))
+			}
+			switch {
+			case strings.TrimSpace(skill.Name) == "":
+				errs = append(errs, fmt.Errorf("%s skill #%d: missing name", strings.ToLower(g.Name), i+1))
+			case slices.ContainsFunc(g.Skills[:i], func(s Skill) bool { return s.Name == skill.Name }):
+				bad("listed twice")
+			}
+			if skill.Level < 0 || skill.Level > MaxSkillLevel {
+				bad("level %d is not 1 to %d", skill.Level, MaxSkillLevel)
+			}
+			if skill.Years < 0 || skill.Years > maxSkillYears {
+				bad("%d years is out of range", skill.Years)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Client calls the API of the server listening on a control socket
+type Client struct {
+	http *http.Client
+}
+
+// Dial makes a client for the socket at path. Nothing is opened until
+// the first call.
+func Dial(path string) *Client {
+	return &Client{http: &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}}
+}
+
+// Status reports the server at a glance
+func (c *Client) Status(ctx context.Context) (Status, error) {
+	var s Status
+	return s, c.call(ctx, http.MethodGet, "/status", nil, &s)
+}
+
+// Sessions lists the open sessions
+func (c *Client) Sessions(ctx context.Context) ([]Session, error) {
+	var s []Session
+	return s, c.call(ctx, http.MethodGet, "/sessions", nil, &s)
+}
+
+// Kick disconnects the session whose ID starts with id
+func (c *Client) Kick(ctx context.Context, id string) (Session, error) {
+	var s Session
+	return s, c.call(ctx, http.MethodPost, "/sessions/"+url.PathEscape(id)+"/kick", nil, &s)
+}
+
+// Broadcast announces text to every session and returns how many there
+// are
+func (c *Client) Broadcast(ctx context.Context, text string) (int, error) {
+	var r broadcastReply
+	return r.Sessions, c.call(ctx, http.MethodPost, "/broadcast", broadcastRequest{Text: text}, &r)
+}
+
+// ReloadContent rereads the content and returns its version
+func (c *Client) ReloadContent(ctx context.Context) (string, error) {
+	var r reloadReply
+	return r.Content, c.call(ctx, http.MethodPost, "/reload", nil, &r)
+}
+
+// SetMaintenance turns maintenance mode on or off
+func (c *Client) SetMaintenance(ctx context.Context, on bool) (Status, error) {
+	var s Status
+	return s, c.call(ctx, http.MethodPut, "/maintenance", maintenanceRequest{On: on}, &s)
+}
+
+// call sends body as JSON and decodes the reply into out, or the
+// server's error
+func (c *Client) call(ctx context.Context, method, path string, body, out any) error {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "http://control"+path, &payload)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("server not reachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e errorReply
+		if json.NewDecoder(resp.Body).Decode(&e) != nil || e.Error == "" {
+			return errors.New(resp.Status)
+		}
+		return errors.New(e.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
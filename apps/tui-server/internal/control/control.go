@@ -0,0 +1,179 @@
+// Package control is the operator's handle on a running server: an HTTP
+// API on a unix socket only its owner can open, and the client that
+// `tui-server ctl` calls it with. It lists and kicks sessions, sends
+// announcements, reloads content and turns maintenance mode on and off
+// without connecting over SSH.
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNoSession is returned by Kick for an ID that matches no session, or
+// more than one
+var ErrNoSession = errors.New("no such session")
+
+// Status is the server at a glance
+type Status struct {
+	Version     string    `json:"version"`
+	Started     time.Time `json:"started"`
+	Sessions    int       `json:"sessions"` // open in this process
+	Maintenance bool      `json:"maintenance"`
+	Content     string    `json:"content"` // version new sessions get
+}
+
+// Session is one open TUI session
+type Session struct {
+	ID       string    `json:"id"`
+	Started  time.Time `json:"started"`
+	Terminal string    `json:"terminal"`
+	Admin    bool      `json:"admin,omitempty"`
+	ReadOnly bool      `json:"read_only,omitempty"`
+}
+
+// Operator is the server the API controls
+type Operator interface {
+	Status() Status
+	Sessions() []Session
+	// Kick disconnects the session whose ID starts with id
+	Kick(id string) (Session, error)
+	// Broadcast announces text to every session and returns how many
+	// there are
+	Broadcast(text string) int
+	// ReloadContent rereads the content for new sessions and returns its
+	// version
+	ReloadContent() (string, error)
+	SetMaintenance(on bool)
+}
+
+// Handler serves the API for op
+func Handler(op Operator) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, op.Status())
+	})
+	mux.HandleFunc("GET /sessions", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, op.Sessions())
+	})
+	mux.HandleFunc("POST /sessions/{id}/kick", func(w http.ResponseWriter, r *http.Request) {
+		s, err := op.Kick(r.PathValue("id"))
+		if err != nil {
+			fail(w, http.StatusNotFound, err)
+			return
+		}
+		reply(w, http.StatusOK, s)
+	})
+	mux.HandleFunc("POST /broadcast", func(w http.ResponseWriter, r *http.Request) {
+		var req broadcastRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Text == "" {
+			fail(w, http.StatusBadRequest, errors.New("want {\"text\": \"...\"}"))
+			return
+		}
+		reply(w, http.StatusOK, broadcastReply{Sessions: op.Broadcast(req.Text)})
+	})
+	mux.HandleFunc("POST /reload", func(w http.ResponseWriter, r *http.Request) {
+		version, err := op.ReloadContent()
+		if err != nil {
+			fail(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		reply(w, http.StatusOK, reloadReply{Content: version})
+	})
+	mux.HandleFunc("PUT /maintenance", func(w http.ResponseWriter, r *http.Request) {
+		var req maintenanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			fail(w, http.StatusBadRequest, errors.New("want {\"on\": true|false}"))
+			return
+		}
+		op.SetMaintenance(req.On)
+		reply(w, http.StatusOK, op.Status())
+	})
+	return mux
+}
+
+type broadcastRequest struct {
+	Text string `json:"text"`
+}
+
+type broadcastReply struct {
+	Sessions int `json:"sessions"`
+}
+
+type reloadReply struct {
+	Content string `json:"content"`
+}
+
+type maintenanceRequest struct {
+	On bool `json:"on"`
+}
+
+type errorReply struct {
+	Error string `json:"error"`
+}
+
+func reply(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func fail(w http.ResponseWriter, code int, err error) {
+	reply(w, code, errorReply{Error: err.Error()})
+}
+
+// Serve serves h on a unix socket at path until ctx ends. Only the
+// socket's owner can open it, and it's removed on the way out; a stale
+// one left by a crash is replaced, a live one is an error.
+func Serve(ctx context.Context, path string, h http.Handler) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("control socket: %s is in use by another server", path)
+	}
+	_ = os.Remove(path)
+
+	// Bound in a directory only the owner can enter, the socket is never
+	// open to others, even before its mode is set; then it's moved into
+	// place
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".control-")
+	if err != nil {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	bound := filepath.Join(dir, "sock")
+	ln, err := net.Listen("unix", bound)
+	if err != nil {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	defer ln.Close()
+	if err := os.Chmod(bound, 0o600); err != nil {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	if err := os.Rename(bound, path); err != nil {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	defer os.Remove(path)
+
+	srv := &http.Server{Handler: h, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("control socket: %w", err)
+	}
+	return nil
+}
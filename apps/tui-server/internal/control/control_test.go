@@ -0,0 +1,95 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeOperator records what the API asked of it
+type fakeOperator struct {
+	maintenance bool
+	announced   string
+}
+
+func (f *fakeOperator) Status() Status {
+	return Status{Version: "test", Sessions: 1, Maintenance: f.maintenance}
+}
+
+func (f *fakeOperator) Sessions() []Session {
+	return []Session{{ID: "abcdef12", Terminal: "xterm"}}
+}
+
+func (f *fakeOperator) Kick(id string) (Session, error) {
+	if id != "abc" {
+		return Session{}, ErrNoSession
+	}
+	return Session{ID: "abcdef12"}, nil
+}
+
+func (f *fakeOperator) Broadcast(text string) int {
+	f.announced = text
+	return 1
+}
+
+func (f *fakeOperator) ReloadContent() (string, error) { return "", errors.New("resume: bad JSON") }
+
+func (f *fakeOperator) SetMaintenance(on bool) { f.maintenance = on }
+
+func TestClientCallsServerOverSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	op := &fakeOperator{}
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, path, Handler(op)) }()
+
+	c := Dial(path)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := c.Status(ctx); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("server never came up: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("socket mode = %v, want owner only", info.Mode())
+	}
+
+	if sessions, err := c.Sessions(ctx); err != nil || len(sessions) != 1 || sessions[0].ID != "abcdef12" {
+		t.Errorf("Sessions = %v, %v", sessions, err)
+	}
+	if s, err := c.Kick(ctx, "abc"); err != nil || s.ID != "abcdef12" {
+		t.Errorf("Kick = %v, %v", s, err)
+	}
+	if _, err := c.Kick(ctx, "zzz"); err == nil || err.Error() != ErrNoSession.Error() {
+		t.Errorf("Kick of a missing session = %v, want %v", err, ErrNoSession)
+	}
+	if n, err := c.Broadcast(ctx, "deploying"); err != nil || n != 1 || op.announced != "deploying" {
+		t.Errorf("Broadcast = %d, %v; announced %q", n, err, op.announced)
+	}
+	if _, err := c.ReloadContent(ctx); err == nil || err.Error() != "resume: bad JSON" {
+		t.Errorf("ReloadContent error = %v, want the operator's", err)
+	}
+	if s, err := c.SetMaintenance(ctx, true); err != nil || !s.Maintenance {
+		t.Errorf("SetMaintenance = %+v, %v", s, err)
+	}
+
+	if err := Serve(ctx, path, Handler(op)); err == nil {
+		t.Error("a second server took over a live socket")
+	}
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve = %v", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("left behind %v", entries)
+	}
+}
@@ -0,0 +1,149 @@
+// Package export renders the resume as files to download: a plain text
+// copy and a simple PDF, laid out from the same blocks so they carry the
+// same sections in the same order, and a vCard of the contact details.
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+)
+
+// File is a generated file and when it was generated
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+	ModTime     time.Time
+}
+
+// Files are the generated resume files, by name
+type Files map[string]File
+
+// Build renders resume.txt, resume.pdf and contact.vcf from resume
+func Build(resume *content.Resume, at time.Time) Files {
+	blocks := resumeBlocks(resume)
+	return Files{
+		"resume.txt":  {Name: "resume.txt", ContentType: "text/plain; charset=utf-8", Data: Text(blocks), ModTime: at},
+		"resume.pdf":  {Name: "resume.pdf", ContentType: "application/pdf", Data: PDF(blocks, resume.Name), ModTime: at},
+		"contact.vcf": {Name: "contact.vcf", ContentType: "text/vcard; charset=utf-8", Data: VCard(resume), ModTime: at},
+	}
+}
+
+type blockKind int
+
+const (
+	blockName blockKind = iota
+	blockTitle
+	blockContact
+	blockHeading
+	blockEntry  // a role or degree, with its period on the right
+	blockDetail // the company or school under an entry
+	blockParagraph
+	blockBullet
+	blockField // a label and a list, as in "Languages  Go, Rust"
+)
+
+// block is one piece of the document, before it is wrapped to a width
+type block struct {
+	kind  blockKind
+	text  string
+	right string // blockEntry's period
+	label string // blockField's label
+}
+
+// resumeBlocks lays out the resume as a sequence of blocks, skipping
+// whatever it leaves empty
+func resumeBlocks(r *content.Resume) []block {
+	blocks := []block{{kind: blockName, text: r.Name}}
+	if r.Title != "" {
+		blocks = append(blocks, block{kind: blockTitle, text: r.Title})
+	}
+	if contact := joinNonEmpty(" | ", r.Contact.Email, r.Contact.Website, r.Contact.Github,
+		r.Contact.LinkedIn, r.Contact.Twitter); contact != "" {
+		blocks = append(blocks, block{kind: blockContact, text: contact})
+	}
+
+	if r.Summary != "" {
+		blocks = append(blocks,
+			block{kind: blockHeading, text: "Summary"},
+			block{kind: blockParagraph, text: r.Summary})
+	}
+
+	if len(r.Experience) > 0 {
+		blocks = append(blocks, block{kind: blockHeading, text: "Experience"})
+		for _, exp := range r.Experience {
+			blocks = append(blocks,
+				block{kind: blockEntry, text: exp.Role, right: exp.Period},
+				block{kind: blockDetail, text: exp.Company})
+			for _, h := range exp.Highlights {
+				blocks = append(blocks, block{kind: blockBullet, text: h})
+			}
+		}
+	}
+
+	heading := false
+	for _, g := range r.Skills.Groups() {
+		if !heading {
+			blocks = append(blocks, block{kind: blockHeading, text: "Skills"})
+			heading = true
+		}
+		blocks = append(blocks, block{kind: blockField, label: g.Name, text: strings.Join(content.SkillNames(g.Skills), ", ")})
+	}
+
+	if len(r.Education) > 0 {
+		blocks = append(blocks, block{kind: blockHeading, text: "Education"})
+		for _, edu := range r.Education {
+			blocks = append(blocks,
+				block{kind: blockEntry, text: edu.Degree, right: edu.Period},
+				block{kind: blockDetail, text: joinNonEmpty(" | ",
+					joinNonEmpty(", ", edu.Institution, edu.Location), edu.Score)})
+		}
+	}
+
+	if len(r.Achievements) > 0 {
+		blocks = append(blocks, block{kind: blockHeading, text: "Achievements"})
+		for _, a := range r.Achievements {
+			text := a.Text
+			if a.Date != "" {
+				text += " (" + a.Date + ")"
+			}
+			blocks = append(blocks, block{kind: blockBullet, text: text})
+		}
+	}
+	return blocks
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := parts[:0:0]
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// wrap breaks text into lines no wider than width as measured by
+// measure, breaking at spaces; a word wider than width gets a line of its
+// own
+func wrap(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && measure(line+" "+word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line == "" {
+			line = word
+		} else {
+			line += " " + word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
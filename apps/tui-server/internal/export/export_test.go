@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+)
+
+func TestTextHasEverySectionWithinWidth(t *testing.T) {
+	resume, err := content.NewLoader("").LoadResume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(Build(resume, time.Now())["resume.txt"].Data)
+
+	for _, want := range []string{strings.ToUpper(resume.Name), "EXPERIENCE\n----------", "SKILLS", "EDUCATION",
+		resume.Experience[0].Period + "\n" + resume.Experience[0].Company} {
+		if !strings.Contains(text, want) {
+			t.Errorf("resume.txt is missing %q:\n%s", want, text)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if n := len([]rune(line)); n > textWidth || strings.HasSuffix(line, " ") {
+			t.Errorf("line of %d columns or with trailing space: %q", n, line)
+		}
+	}
+}
+
+func TestPDFIsWellFormedAndPaginates(t *testing.T) {
+	resume := &content.Resume{Name: "Ada (Countess) Lovelace", Title: "Analyst"}
+	for i := range 60 {
+		resume.Achievements = append(resume.Achievements, content.Achievement{Text: fmt.Sprintf("Achievement %d, long enough to wrap onto a second line of the page when set in ten point Helvetica", i)})
+	}
+	pdf := PDF(resumeBlocks(resume), resume.Name)
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	// Every xref entry must point at the start of its object
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if !bytes.HasPrefix(pdf[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Fatalf("xref entry %d points at %q", i+1, pdf[off:off+10])
+		}
+	}
+	if !bytes.Contains(pdf, []byte("/Count 2 ")) {
+		t.Error("60 achievements should take two pages")
+	}
+
+	// The first page's content is the object after its dictionary
+	start := bytes.Index(pdf, []byte("stream\n")) + len("stream\n")
+	r, err := zlib.NewReader(bytes.NewReader(pdf[start:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(r)
+	if !bytes.Contains(page, []byte(`(Ada \(Countess\) Lovelace) Tj`)) || !bytes.Contains(page, []byte("(ACHIEVEMENTS) Tj")) {
+		t.Errorf("first page is missing the escaped name or the heading:\n%s", page)
+	}
+}
+
+func TestVCardEscapesAndSplitsName(t *testing.T) {
+	resume := &content.Resume{Name: "Ada King Lovelace", Title: "Analyst, Engines; Ltd"}
+	resume.Contact.Email = "ada@example.com"
+	resume.Contact.Website = "example.com"
+	resume.Contact.Github = "github.com/ada"
+
+	full := string(VCard(resume))
+	for _, want := range []string{"BEGIN:VCARD\r\n", "N:Lovelace;Ada King;;;\r\n", `TITLE:Analyst\, Engines\; Ltd` + "\r\n",
+		"URL:https://example.com\r\n", "URL;TYPE=GitHub:https://github.com/ada\r\n", "END:VCARD\r\n"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("vCard is missing %q:\n%s", want, full)
+		}
+	}
+	if short := string(ShortVCard(resume)); strings.Contains(short, "GitHub") || !strings.Contains(short, "EMAIL:ada@example.com") {
+		t.Errorf("short vCard should keep the email and drop profiles:\n%s", short)
+	}
+}
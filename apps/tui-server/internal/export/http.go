@@ -0,0 +1,24 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// Handler serves each file at its name, as in /resume.pdf, with caching
+// and range requests handled by http.ServeContent. files is asked on
+// every request, so reloaded content is served as soon as it's there.
+func Handler(files func() Files) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		f, ok := files()[name]
+		if !ok || r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", f.ContentType)
+		w.Header().Set("Content-Disposition", `inline; filename="`+name+`"`)
+		http.ServeContent(w, r, name, f.ModTime, bytes.NewReader(f.Data))
+	})
+}
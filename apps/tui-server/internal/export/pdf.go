@@ -0,0 +1,269 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Page geometry in points: A4 with a 3/4 inch margin
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	pageMargin   = 54.0
+	contentWidth = pageWidth - 2*pageMargin
+	// fieldIndent is where skill lists start, after their labels
+	fieldIndent = 72.0
+	// bulletIndent is where bullet text starts, after the bullet
+	bulletIndent = 14.0
+)
+
+// pdfFont is one of the standard fonts every PDF reader has, so nothing
+// needs embedding. Widths are the font's advance widths for the printable
+// ASCII characters, in thousandths of the font size.
+type pdfFont struct {
+	resource string
+	widths   [95]int16
+}
+
+var helvetica = &pdfFont{resource: "F1", widths: [95]int16{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}}
+
+var helveticaBold = &pdfFont{resource: "F2", widths: [95]int16{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}}
+
+// measure returns the width of s set at size points
+func (f *pdfFont) measure(s string, size float64) float64 {
+	w := 0
+	for _, c := range winAnsi(s) {
+		switch {
+		case c >= 32 && c <= 126:
+			w += int(f.widths[c-32])
+		case c == 0x95: // bullet
+			w += 350
+		default:
+			w += 556
+		}
+	}
+	return float64(w) * size / 1000
+}
+
+// winAnsiSpecials are the characters WinAnsiEncoding places in 0x80-0x9F
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// winAnsi encodes s for the standard fonts; anything they can't show
+// becomes "?"
+func winAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch b, ok := winAnsiSpecials[r]; {
+		case ok:
+			out = append(out, b)
+		case r < 0x80 || r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// pdfLayout places text on pages from the top down
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	y     float64 // baseline of the last line placed
+}
+
+// line moves down to the next line of the given height, starting a new
+// page when it wouldn't fit, and returns its baseline
+func (l *pdfLayout) line(height float64) float64 {
+	if len(l.pages) == 0 || l.y-height < pageMargin {
+		l.pages = append(l.pages, &bytes.Buffer{})
+		l.y = pageHeight - pageMargin
+	}
+	l.y -= height
+	return l.y
+}
+
+// keep starts a new page unless height fits on this one, so a heading
+// isn't left at the foot of a page without what follows it
+func (l *pdfLayout) keep(height float64) {
+	if len(l.pages) > 0 && l.y-height < pageMargin {
+		l.y = 0
+	}
+}
+
+// space leaves a gap before the next line, unless a page just began
+func (l *pdfLayout) space(height float64) {
+	if len(l.pages) > 0 && l.y < pageHeight-pageMargin {
+		l.y -= height
+	}
+}
+
+// text draws s with its baseline at y, in gray from 0 (black) to 1
+func (l *pdfLayout) text(f *pdfFont, size, x, y, gray float64, s string) {
+	fmt.Fprintf(l.pages[len(l.pages)-1], "BT %s g /%s %s Tf %s %s Td %s Tj ET\n",
+		num(gray), f.resource, num(size), num(x), num(y), pdfString(winAnsi(s)))
+}
+
+// rule draws a hairline across the page at y
+func (l *pdfLayout) rule(y float64) {
+	fmt.Fprintf(l.pages[len(l.pages)-1], "0.6 G 0.5 w %s %s m %s %s l S\n",
+		num(pageMargin), num(y), num(pageWidth-pageMargin), num(y))
+}
+
+// paragraph draws text wrapped to width from x, one line per height
+func (l *pdfLayout) paragraph(f *pdfFont, size, height, x, width, gray float64, text string) {
+	measure := func(s string) float64 { return f.measure(s, size) }
+	for _, s := range wrap(text, width, measure) {
+		l.text(f, size, x, l.line(height), gray, s)
+	}
+}
+
+// PDF renders blocks as an A4 PDF titled after name
+func PDF(blocks []block, name string) []byte {
+	l := &pdfLayout{}
+	for i, blk := range blocks {
+		switch blk.kind {
+		case blockName:
+			l.text(helveticaBold, 22, pageMargin, l.line(26), 0, blk.text)
+		case blockTitle:
+			l.text(helvetica, 12, pageMargin, l.line(17), 0.2, blk.text)
+		case blockContact:
+			l.space(2)
+			l.paragraph(helvetica, 9, 12, pageMargin, contentWidth, 0.35, blk.text)
+		case blockHeading:
+			l.keep(14 + 15 + 6 + 2*14)
+			l.space(14)
+			y := l.line(15)
+			l.text(helveticaBold, 11, pageMargin, y, 0, strings.ToUpper(blk.text))
+			l.rule(y - 4)
+			l.space(6)
+		case blockEntry:
+			if i > 0 && blocks[i-1].kind != blockHeading {
+				l.keep(6 + 14 + 13 + 14)
+				l.space(6)
+			}
+			rightWidth := helvetica.measure(blk.right, 10)
+			measure := func(s string) float64 { return helveticaBold.measure(s, 10.5) }
+			for j, s := range wrap(blk.text, contentWidth-rightWidth-12, measure) {
+				y := l.line(14)
+				l.text(helveticaBold, 10.5, pageMargin, y, 0, s)
+				if j == 0 {
+					l.text(helvetica, 10, pageWidth-pageMargin-rightWidth, y, 0.35, blk.right)
+				}
+			}
+		case blockDetail:
+			l.paragraph(helvetica, 10, 13, pageMargin, contentWidth, 0.35, blk.text)
+		case blockParagraph:
+			l.paragraph(helvetica, 10, 14, pageMargin, contentWidth, 0, blk.text)
+		case blockBullet:
+			measure := func(s string) float64 { return helvetica.measure(s, 10) }
+			for j, s := range wrap(blk.text, contentWidth-bulletIndent, measure) {
+				y := l.line(14)
+				if j == 0 {
+					l.text(helvetica, 10, pageMargin+4, y, 0, "•")
+				}
+				l.text(helvetica, 10, pageMargin+bulletIndent, y, 0, s)
+			}
+		case blockField:
+			measure := func(s string) float64 { return helvetica.measure(s, 10) }
+			for j, s := range wrap(blk.text, contentWidth-fieldIndent, measure) {
+				y := l.line(14)
+				if j == 0 {
+					l.text(helveticaBold, 10, pageMargin, y, 0, blk.label)
+				}
+				l.text(helvetica, 10, pageMargin+fieldIndent, y, 0, s)
+			}
+		}
+	}
+	if len(l.pages) == 0 {
+		l.line(0)
+	}
+	return writePDF(l.pages, name)
+}
+
+// writePDF assembles page content streams into a PDF file. Objects 1-5
+// are the catalog, page tree, the two fonts and the document info; each
+// page then takes two, its dictionary and its content.
+func writePDF(pages []*bytes.Buffer, name string) []byte {
+	var b bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	title := "Resume"
+	if name != "" {
+		title = name + " - Resume"
+	}
+
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title %s /Author %s /Producer (mohak.tui) >>",
+		pdfString(winAnsi(title)), pdfString(winAnsi(name))))
+	for i, page := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(pageWidth), num(pageHeight), 7+2*i))
+		var z bytes.Buffer
+		w := zlib.NewWriter(&z)
+		_, _ = w.Write(page.Bytes())
+		_ = w.Close()
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.Bytes()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return b.Bytes()
+}
+
+// pdfString is s as a PDF literal string
+func pdfString(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range s {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// num formats a coordinate to two places, without trailing zeros
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// textWidth is the column resume.txt wraps at
+	textWidth = 80
+	// textLabelWidth is the column skill lists start at
+	textLabelWidth = 12
+)
+
+// Text renders blocks as plain text wrapped at textWidth columns
+func Text(blocks []block) []byte {
+	var b strings.Builder
+	runes := func(s string) float64 { return float64(utf8.RuneCountInString(s)) }
+	lines := func(indent, hanging string, text string) {
+		for i, line := range wrap(text, float64(textWidth-len(indent)), runes) {
+			if i > 0 {
+				indent = hanging
+			}
+			b.WriteString(indent + line + "\n")
+		}
+	}
+
+	for i, blk := range blocks {
+		switch blk.kind {
+		case blockName:
+			b.WriteString(strings.ToUpper(blk.text) + "\n")
+		case blockTitle, blockContact, blockDetail, blockParagraph:
+			lines("", "", blk.text)
+		case blockHeading:
+			b.WriteString("\n" + strings.ToUpper(blk.text) + "\n")
+			b.WriteString(strings.Repeat("-", utf8.RuneCountInString(blk.text)) + "\n")
+		case blockEntry:
+			if i > 0 && blocks[i-1].kind != blockHeading {
+				b.WriteString("\n")
+			}
+			gap := textWidth - utf8.RuneCountInString(blk.text) - utf8.RuneCountInString(blk.right)
+			if gap < 2 {
+				lines("", "", blk.text)
+				b.WriteString(blk.right + "\n")
+				continue
+			}
+			b.WriteString(blk.text + strings.Repeat(" ", gap) + blk.right + "\n")
+		case blockBullet:
+			lines("  - ", "    ", blk.text)
+		case blockField:
+			label := blk.label + strings.Repeat(" ", max(textLabelWidth-utf8.RuneCountInString(blk.label), 1))
+			lines(label, strings.Repeat(" ", utf8.RuneCountInString(label)), blk.text)
+		}
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+)
+
+// VCard renders the resume's name, title and contact details as a vCard
+// 3.0, which phones import from a QR code or a .vcf file alike
+func VCard(r *content.Resume) []byte {
+	return vcard(r, true)
+}
+
+// ShortVCard is VCard without the profile links, for QR codes, where
+// every byte adds to the size
+func ShortVCard(r *content.Resume) []byte {
+	return vcard(r, false)
+}
+
+func vcard(r *content.Resume, profiles bool) []byte {
+	var b strings.Builder
+	line := func(prop, value string) {
+		if value != "" {
+			b.WriteString(prop + ":" + value + "\r\n")
+		}
+	}
+	line("BEGIN", "VCARD")
+	line("VERSION", "3.0")
+	given, family := splitName(r.Name)
+	line("N", vcardEscape(family)+";"+vcardEscape(given)+";;;")
+	line("FN", vcardEscape(r.Name))
+	line("TITLE", vcardEscape(r.Title))
+	line("EMAIL", vcardEscape(r.Contact.Email))
+	line("URL", vcardEscape(WebURL(r.Contact.Website)))
+	if profiles {
+		line("URL;TYPE=GitHub", vcardEscape(WebURL(r.Contact.Github)))
+		line("URL;TYPE=LinkedIn", vcardEscape(WebURL(r.Contact.LinkedIn)))
+	}
+	line("END", "VCARD")
+	return []byte(b.String())
+}
+
+// WebURL makes a bare domain such as "example.com/me" a link; "" stays ""
+func WebURL(s string) string {
+	if s == "" || strings.Contains(s, "://") {
+		return s
+	}
+	return "https://" + s
+}
+
+// splitName takes the last word as the family name
+func splitName(name string) (given, family string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+
+func vcardEscape(s string) string {
+	return vcardEscaper.Replace(s)
+}
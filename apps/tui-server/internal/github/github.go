@@ -0,0 +1,237 @@
+// Package github fetches the owner's contribution calendar from GitHub's
+// GraphQL API. One cached copy serves every session, so visitors opening
+// /activity don't each cost a request against the token's rate limit.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+)
+
+const (
+	defaultEndpoint = "https://api.github.com/graphql"
+	// defaultTTL is how long a fetched calendar is served before it's
+	// fetched again; GitHub itself only updates it every few minutes
+	defaultTTL = time.Hour
+	// retryAfter is how long a failed fetch is remembered before the
+	// next attempt, so an outage doesn't turn every visit into a request
+	retryAfter = time.Minute
+	// fetchTimeout bounds one request
+	fetchTimeout = 10 * time.Second
+)
+
+// Config says whose calendar to fetch. The GraphQL API needs a token even
+// for public data; any token works, no scopes are required.
+type Config struct {
+	Token string
+	Login string // user name, as in github.com/<login>
+	// Endpoint overrides the GraphQL URL, for tests
+	Endpoint string
+	// TTL is how long a calendar is cached; zero means an hour
+	TTL time.Duration
+}
+
+// Day is one square of the calendar
+type Day struct {
+	Date  time.Time
+	Count int
+	// Level is GitHub's shading, 0 for none up to 4 for the busiest
+	// quarter of days
+	Level int
+}
+
+// Week is up to seven days from Sunday; the first and last week of the
+// year are usually partial
+type Week []Day
+
+// Total is the week's contribution count
+func (w Week) Total() int {
+	total := 0
+	for _, d := range w {
+		total += d.Count
+	}
+	return total
+}
+
+// Calendar is a year of contributions, oldest week first
+type Calendar struct {
+	Login   string
+	Total   int
+	Weeks   []Week
+	Fetched time.Time
+}
+
+// Client fetches and caches the calendar of one user
+type Client struct {
+	cfg    Config
+	client *http.Client
+
+	mu       sync.Mutex
+	cached   Calendar
+	err      error
+	failedAt time.Time
+}
+
+// New returns a client for cfg, or nil without a token or login
+func New(cfg Config) *Client {
+	if cfg.Token == "" || cfg.Login == "" {
+		return nil
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultEndpoint
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	return &Client{
+		cfg:    cfg,
+		client: &http.Client{Transport: network.NewHTTPTransport(), Timeout: fetchTimeout},
+	}
+}
+
+// LoginFromURL takes the user name out of a profile link such as
+// "github.com/mohak-bajaj"; "" when there isn't one
+func LoginFromURL(profile string) string {
+	profile = strings.TrimPrefix(strings.TrimPrefix(profile, "https://"), "http://")
+	rest, ok := strings.CutPrefix(profile, "github.com/")
+	if !ok {
+		return ""
+	}
+	login, _, _ := strings.Cut(strings.Trim(rest, "/"), "/")
+	return login
+}
+
+// Calendar returns the cached calendar, fetching it when it's older than
+// the TTL. A failed refresh keeps serving the last good copy; with none
+// yet, the error is returned, and repeated for a minute without retrying.
+// Concurrent callers wait for a single fetch.
+func (c *Client) Calendar(ctx context.Context) (Calendar, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.cached.Fetched.IsZero() && time.Since(c.cached.Fetched) < c.cfg.TTL {
+		return c.cached, nil
+	}
+	if c.err != nil && time.Since(c.failedAt) < retryAfter {
+		return c.cached, c.staleOr(c.err)
+	}
+
+	cal, err := c.fetch(ctx)
+	if err != nil {
+		c.err, c.failedAt = err, time.Now()
+		return c.cached, c.staleOr(err)
+	}
+	c.cached, c.err = cal, nil
+	return cal, nil
+}
+
+// staleOr is nil when there's a cached calendar to fall back on, else err
+func (c *Client) staleOr(err error) error {
+	if c.cached.Fetched.IsZero() {
+		return err
+	}
+	return nil
+}
+
+const calendarQuery = `query($login: String!) {
+  user(login: $login) {
+    contributionsCollection {
+      contributionCalendar {
+        totalContributions
+        weeks { contributionDays { date contributionCount contributionLevel } }
+      }
+    }
+  }
+}`
+
+// calendarResponse is the part of the GraphQL reply the client reads
+type calendarResponse struct {
+	Data struct {
+		User *struct {
+			ContributionsCollection struct {
+				ContributionCalendar struct {
+					TotalContributions int `json:"totalContributions"`
+					Weeks              []struct {
+						ContributionDays []struct {
+							Date              string `json:"date"`
+							ContributionCount int    `json:"contributionCount"`
+							ContributionLevel string `json:"contributionLevel"`
+						} `json:"contributionDays"`
+					} `json:"weeks"`
+				} `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// levels maps GitHub's ContributionLevel names to shades
+var levels = map[string]int{
+	"NONE":            0,
+	"FIRST_QUARTILE":  1,
+	"SECOND_QUARTILE": 2,
+	"THIRD_QUARTILE":  3,
+	"FOURTH_QUARTILE": 4,
+}
+
+func (c *Client) fetch(ctx context.Context) (Calendar, error) {
+	body, err := json.Marshal(map[string]any{
+		"query":     calendarQuery,
+		"variables": map[string]string{"login": c.cfg.Login},
+	})
+	if err != nil {
+		return Calendar{}, fmt.Errorf("github: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return Calendar{}, fmt.Errorf("github: invalid request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("github: request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Calendar{}, fmt.Errorf("github: %s", resp.Status)
+	}
+	var reply calendarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return Calendar{}, fmt.Errorf("github: invalid reply: %w", err)
+	}
+	if len(reply.Errors) > 0 {
+		return Calendar{}, fmt.Errorf("github: %s", reply.Errors[0].Message)
+	}
+	if reply.Data.User == nil {
+		return Calendar{}, errors.New("github: no such user " + c.cfg.Login)
+	}
+
+	raw := reply.Data.User.ContributionsCollection.ContributionCalendar
+	cal := Calendar{Login: c.cfg.Login, Total: raw.TotalContributions, Fetched: time.Now()}
+	for _, w := range raw.Weeks {
+		week := make(Week, 0, len(w.ContributionDays))
+		for _, d := range w.ContributionDays {
+			date, err := time.Parse(time.DateOnly, d.Date)
+			if err != nil {
+				return Calendar{}, fmt.Errorf("github: invalid date %q", d.Date)
+			}
+			week = append(week, Day{Date: date, Count: d.ContributionCount, Level: levels[d.ContributionLevel]})
+		}
+		cal.Weeks = append(cal.Weeks, week)
+	}
+	return cal, nil
+}
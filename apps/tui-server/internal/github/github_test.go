@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const calendarReply = `{"data":{"user":{"contributionsCollection":{"contributionCalendar":{
+  "totalContributions":7,
+  "weeks":[
+    {"contributionDays":[{"date":"2026-01-03","contributionCount":2,"contributionLevel":"SECOND_QUARTILE"}]},
+    {"contributionDays":[
+      {"date":"2026-01-04","contributionCount":0,"contributionLevel":"NONE"},
+      {"date":"2026-01-05","contributionCount":5,"contributionLevel":"FOURTH_QUARTILE"}]}
+  ]}}}}}`
+
+func TestCalendarIsParsedAndCached(t *testing.T) {
+	var requests atomic.Int32
+	failing := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		if failing.Load() {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(calendarReply))
+	}))
+	defer srv.Close()
+
+	c := New(Config{Token: "token", Login: "ada", Endpoint: srv.URL})
+	cal, err := c.Calendar(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.Total != 7 || len(cal.Weeks) != 2 || cal.Weeks[1].Total() != 5 || cal.Weeks[1][1].Level != 4 {
+		t.Fatalf("calendar = %+v", cal)
+	}
+	if _, err := c.Calendar(context.Background()); err != nil || requests.Load() != 1 {
+		t.Fatalf("second call made %d requests (err %v), want the cached copy", requests.Load(), err)
+	}
+
+	// An expired copy is still served while GitHub is down
+	c.cached.Fetched = time.Now().Add(-2 * defaultTTL)
+	failing.Store(true)
+	if cal, err := c.Calendar(context.Background()); err != nil || cal.Total != 7 {
+		t.Fatalf("stale calendar = %+v, %v; want the last good copy", cal, err)
+	}
+}
+
+func TestCalendarErrorsWithoutCopy(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"user":null},"errors":[{"message":"Could not resolve to a User"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(Config{Token: "token", Login: "nobody", Endpoint: srv.URL})
+	for range 2 {
+		if _, err := c.Calendar(context.Background()); err == nil {
+			t.Fatal("Calendar() succeeded for a missing user")
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("%d requests, want the failure remembered", requests.Load())
+	}
+}
+
+func TestLoginFromURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"github.com/mohak-bajaj":   "mohak-bajaj",
+		"https://github.com/ada/":  "ada",
+		"github.com/ada/some-repo": "ada",
+		"gitlab.com/ada":           "",
+		"":                         "",
+	} {
+		if got := LoginFromURL(in); got != want {
+			t.Errorf("LoginFromURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
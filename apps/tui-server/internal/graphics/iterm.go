@@ -0,0 +1,32 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"strings"
+)
+
+// iterm draws the picture with iTerm2's inline image sequence, sized in
+// cells, over blank rows
+func (p *Picture) iterm(cols, rows int) []string {
+	var data bytes.Buffer
+	if err := png.Encode(&data, resize(p.img, cols*sentCell.w, rows*sentCell.h)); err != nil {
+		return p.text(Options{}, cols, rows)
+	}
+	picture := fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0;doNotMoveCursor=1:%s\a",
+		data.Len(), cols, rows, base64.StdEncoding.EncodeToString(data.Bytes()))
+	return blankRows(cols, rows, picture)
+}
+
+// blankRows are rows blank cells for an overlay picture to cover, and
+// the line after them that draws it
+func blankRows(cols, rows int, picture string) []string {
+	lines := make([]string, rows+1)
+	for i := range rows {
+		lines[i] = strings.Repeat(" ", cols)
+	}
+	lines[rows] = overlay(picture, rows)
+	return lines
+}
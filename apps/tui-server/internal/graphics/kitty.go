@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"strings"
+)
+
+// kittyChunk is the most base64 a kitty graphics command carries
+const kittyChunk = 4096
+
+// placeholder is the character kitty draws a cell of a picture in
+const placeholder = "\U0010EEEE"
+
+// diacritics number the rows and columns of placeholders, in the order
+// kitty's rowcolumn-diacritics table gives them
+var diacritics = [...]rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+	0x035B, 0x0363, 0x0364, 0x0365, 0x0366, 0x0367, 0x0368, 0x0369,
+	0x036A, 0x036B, 0x036C, 0x036D, 0x036E, 0x036F,
+}
+
+// kitty sends the picture as a virtual placement and draws it with
+// Unicode placeholders: text cells colored with the image ID, so the
+// picture moves and clears with the text like anything else on screen.
+// The first row carries the image, which is sent again each time that
+// row is redrawn.
+func (p *Picture) kitty(cols, rows int) []string {
+	var data bytes.Buffer
+	if err := png.Encode(&data, resize(p.img, cols*sentCell.w, rows*sentCell.h)); err != nil {
+		return p.text(Options{}, cols, rows)
+	}
+	payload := base64.StdEncoding.EncodeToString(data.Bytes())
+
+	var send strings.Builder
+	for i := 0; i < len(payload); i += kittyChunk {
+		chunk := payload[i:min(i+kittyChunk, len(payload))]
+		more := 0
+		if i+kittyChunk < len(payload) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&send, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", p.id, cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&send, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+
+	color := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", p.id>>16&0xff, p.id>>8&0xff, p.id&0xff)
+	lines := make([]string, rows)
+	for r := range rows {
+		// Cells after the first take the next column on their own
+		lines[r] = color + placeholder + string(diacritics[r]) + string(diacritics[0]) +
+			strings.Repeat(placeholder, cols-1) + "\x1b[39m"
+	}
+	lines[0] = send.String() + lines[0]
+	return lines
+}
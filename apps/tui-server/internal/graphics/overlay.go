@@ -0,0 +1,49 @@
+package graphics
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// overlayPattern matches a picture drawn over the rows above its line:
+// the cursor is saved, moved up, and restored after the picture
+var overlayPattern = regexp.MustCompile(`\x1b7\x1b\[(\d+)A.*?\x1b8`)
+
+// overlay draws picture, a protocol's escape sequence, over the rows
+// lines above the line it's written on. iTerm2 and sixel pictures are
+// drawn where the cursor is and pixels under later text are lost, so
+// they go on the line after the blank rows they cover, which the
+// terminal has already written.
+func overlay(picture string, rows int) string {
+	return "\x1b7\x1b[" + strconv.Itoa(rows) + "A" + picture + "\x1b8"
+}
+
+// ClipOverlays drops the iTerm2 and sixel pictures in screen, one line a
+// row, whose rows aren't all on it: a picture drawn from a line off
+// the top would land on whatever is above the screen.
+func ClipOverlays(screen string) string {
+	if !strings.Contains(screen, "\x1b7") {
+		return screen
+	}
+	lines := strings.Split(screen, "\n")
+	for i, line := range lines {
+		lines[i] = overlayPattern.ReplaceAllStringFunc(line, func(o string) string {
+			rows, _ := strconv.Atoi(overlayPattern.FindStringSubmatch(o)[1])
+			if rows > i {
+				return ""
+			}
+			return o
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StripOverlays drops every iTerm2 and sixel picture in s, for when
+// something is drawn on top of it
+func StripOverlays(s string) string {
+	if !strings.Contains(s, "\x1b7") {
+		return s
+	}
+	return overlayPattern.ReplaceAllString(s, "")
+}
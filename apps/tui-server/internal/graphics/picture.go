@@ -0,0 +1,155 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/muesli/termenv"
+)
+
+// maxPixels bounds the larger side of a Picture's image; terminals never
+// show screenshots bigger
+const maxPixels = 960
+
+// maxRows is the most rows a picture takes, the rows kitty placeholders
+// can number
+const maxRows = len(diacritics)
+
+// maxCached is how many renderings a Picture keeps, one per size and
+// terminal kind seen lately
+const maxCached = 16
+
+// sentCell is the pixels a cell is taken to be when a picture is scaled
+// down before it's sent to kitty or iTerm2, which fit it to the cells
+var sentCell = struct{ w, h int }{10, 20}
+
+// Options says how a terminal can draw a picture
+type Options struct {
+	Protocol Protocol
+	Profile  termenv.Profile // colors for text art
+	ASCII    bool            // text art drawn with ASCII only
+}
+
+// Picture is a screenshot ready to draw. It is shared by every session
+// and caches what it draws.
+type Picture struct {
+	img image.Image
+	id  uint32 // kitty image ID
+
+	mu    sync.Mutex
+	drawn map[drawing][]string
+}
+
+// drawing is one way a Picture was drawn
+type drawing struct {
+	Options
+	cols, rows int
+}
+
+// nextID numbers the pictures for kitty
+var nextID struct {
+	sync.Mutex
+	n uint32
+}
+
+// New makes img a Picture, shrunk to at most maxPixels a side
+func New(img image.Image) *Picture {
+	b := img.Bounds()
+	if w, h := b.Dx(), b.Dy(); w > maxPixels || h > maxPixels {
+		scale := float64(maxPixels) / float64(max(w, h))
+		img = resize(img, max(1, int(float64(w)*scale)), max(1, int(float64(h)*scale)))
+	}
+
+	nextID.Lock()
+	nextID.n++
+	id := nextID.n
+	nextID.Unlock()
+	return &Picture{img: img, id: id, drawn: map[drawing][]string{}}
+}
+
+// Size is the cells the picture takes drawn at most cols wide and rows
+// high, keeping its shape. A cell is taken to be twice as high as wide.
+func (p *Picture) Size(cols, rows int) (int, int) {
+	rows = min(rows, maxRows)
+	if cols < 1 || rows < 1 {
+		return 0, 0
+	}
+	b := p.img.Bounds()
+	aspect := float64(b.Dy()) / float64(b.Dx())
+	c, r := cols, int(math.Round(float64(cols)*aspect/2))
+	if r > rows {
+		r = rows
+		c = min(cols, int(math.Round(float64(rows)*2/aspect)))
+	}
+	return max(c, 1), max(r, 1)
+}
+
+// Render draws the picture in at most cols by rows cells, one string per
+// row. Every row is as wide as the picture, but for the row iTerm2 and
+// sixel pictures add after them, which holds the picture itself and
+// takes no columns; see ClipOverlays.
+func (p *Picture) Render(o Options, cols, rows int) []string {
+	cols, rows = p.Size(cols, rows)
+	if cols == 0 {
+		return nil
+	}
+	if o.Protocol != Text {
+		o.Profile, o.ASCII = 0, false
+	}
+	key := drawing{Options: o, cols: cols, rows: rows}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if lines, ok := p.drawn[key]; ok {
+		return lines
+	}
+
+	var lines []string
+	switch o.Protocol {
+	case Kitty:
+		lines = p.kitty(cols, rows)
+	case ITerm:
+		lines = p.iterm(cols, rows)
+	case Sixel:
+		lines = p.sixel(cols, rows)
+	default:
+		lines = p.text(o, cols, rows)
+	}
+	if len(p.drawn) >= maxCached {
+		clear(p.drawn)
+	}
+	p.drawn[key] = lines
+	return lines
+}
+
+// resize scales img to w by h, averaging the pixels each new one covers
+func resize(img image.Image, w, h int) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(b.Min.Y+(y+1)*b.Dy()/h, y0+1)
+		for x := range w {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(b.Min.X+(x+1)*b.Dx()/w, x0+1)
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+pr, g+pg, bl+pb, a+pa, n+1
+				}
+			}
+			out.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(bl / n >> 8), A: uint8(a / n >> 8)})
+		}
+	}
+	return out
+}
+
+// opaque is c over black, as the terminal shows it
+func opaque(c color.Color) color.RGBA {
+	r, g, b, _ := c.RGBA()
+	return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}
+}
@@ -0,0 +1,62 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// gradient is a w by h test picture
+func gradient(w, h int) *Picture {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return New(img)
+}
+
+func TestSizeKeepsTheShape(t *testing.T) {
+	p := gradient(200, 100)
+	if c, r := p.Size(40, 20); c != 40 || r != 10 {
+		t.Errorf("Size(40, 20) = %d, %d, want 40, 10", c, r)
+	}
+	if c, r := p.Size(40, 5); c != 20 || r != 5 {
+		t.Errorf("Size(40, 5) = %d, %d, want 20, 5", c, r)
+	}
+}
+
+func TestRenderRowsAreThePictureWide(t *testing.T) {
+	p := gradient(200, 100)
+	for _, proto := range []Protocol{Text, Kitty, ITerm, Sixel} {
+		lines := p.Render(Options{Protocol: proto, Profile: termenv.TrueColor}, 20, 10)
+		rows := 5
+		if proto == ITerm || proto == Sixel {
+			rows++ // the line drawing the picture
+		}
+		if len(lines) != rows {
+			t.Fatalf("%v: %d lines, want %d", proto, len(lines), rows)
+		}
+		for i, line := range lines[:5] {
+			if w := ansi.StringWidth(line); w != 20 {
+				t.Errorf("%v: row %d is %d wide, want 20", proto, i, w)
+			}
+		}
+	}
+}
+
+func TestClipOverlaysDropsPicturesOffTheTop(t *testing.T) {
+	lines := gradient(200, 100).Render(Options{Protocol: Sixel}, 20, 10)
+	whole := strings.Join(lines, "\n")
+	if got := ClipOverlays(whole); got != whole {
+		t.Error("a picture on screen was dropped")
+	}
+	if got := ClipOverlays(strings.Join(lines[1:], "\n")); strings.Contains(got, "\x1bP") {
+		t.Error("a picture cut off at the top was kept")
+	}
+}
@@ -0,0 +1,82 @@
+// Package graphics draws project screenshots in the terminal: as real
+// pictures with the kitty, iTerm2 or sixel protocols where the terminal
+// has one, and as half-block text art everywhere else.
+package graphics
+
+import "strings"
+
+// Protocol is how a terminal draws pictures
+type Protocol int
+
+const (
+	// Text is half-block art, which any terminal shows
+	Text Protocol = iota
+	// Kitty is the kitty graphics protocol, placed with Unicode placeholders
+	Kitty
+	// ITerm is iTerm2's inline images
+	ITerm
+	// Sixel is DEC sixel graphics
+	Sixel
+)
+
+// String is the protocol's name, as GRAPHICS takes it
+func (p Protocol) String() string {
+	switch p {
+	case Kitty:
+		return "kitty"
+	case ITerm:
+		return "iterm"
+	case Sixel:
+		return "sixel"
+	}
+	return "text"
+}
+
+// ParseProtocol reads a GRAPHICS value: a protocol name, or "none" or
+// "off" for text art
+func ParseProtocol(name string) (Protocol, bool) {
+	switch strings.ToLower(name) {
+	case "kitty":
+		return Kitty, true
+	case "iterm", "iterm2":
+		return ITerm, true
+	case "sixel":
+		return Sixel, true
+	case "text", "none", "off":
+		return Text, true
+	}
+	return Text, false
+}
+
+// Detect works out the protocol a terminal supports from its TERM and
+// the environment getenv reads. Over SSH only TERM and the variables
+// the client forwards are seen: iTerm2 is known by LC_TERMINAL, which
+// ssh forwards with the locale, and GRAPHICS names a protocol outright.
+// Terminals behind tmux or screen get text, since neither passes the
+// pictures through.
+func Detect(term string, getenv func(string) string) Protocol {
+	if p, ok := ParseProtocol(getenv("GRAPHICS")); ok {
+		return p
+	}
+
+	term = strings.ToLower(term)
+	if strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux") {
+		return Text
+	}
+	switch {
+	case term == "xterm-kitty", term == "xterm-ghostty", getenv("KITTY_WINDOW_ID") != "":
+		return Kitty
+	case term == "wezterm", getenv("LC_TERMINAL") == "iTerm2":
+		return ITerm
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), term == "contour", term == "yaft-256color":
+		return Sixel
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "ghostty":
+		return Kitty
+	case "iTerm.app", "WezTerm":
+		return ITerm
+	}
+	return Text
+}
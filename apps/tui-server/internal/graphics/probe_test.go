@@ -0,0 +1,26 @@
+package graphics
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		term string
+		env  map[string]string
+		want Protocol
+	}{
+		{"xterm-kitty", nil, Kitty},
+		{"xterm-256color", map[string]string{"LC_TERMINAL": "iTerm2"}, ITerm},
+		{"foot", nil, Sixel},
+		{"xterm-256color", nil, Text},
+		{"xterm-256color", map[string]string{"TERM_PROGRAM": "WezTerm"}, ITerm},
+		{"tmux-256color", map[string]string{"LC_TERMINAL": "iTerm2"}, Text},
+		{"xterm-256color", map[string]string{"GRAPHICS": "sixel"}, Sixel},
+		{"xterm-kitty", map[string]string{"GRAPHICS": "off"}, Text},
+	}
+	for _, tt := range tests {
+		got := Detect(tt.term, func(key string) string { return tt.env[key] })
+		if got != tt.want {
+			t.Errorf("Detect(%q, %v) = %v, want %v", tt.term, tt.env, got, tt.want)
+		}
+	}
+}
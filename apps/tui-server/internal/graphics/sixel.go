@@ -0,0 +1,93 @@
+package graphics
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// sixelCell is the pixels a cell is taken to be for sixel, which draws
+// in pixels. It's on the small side, as a picture a little short of its
+// rows looks better than one spilling over the text below.
+var sixelCell = struct{ w, h int }{8, 16}
+
+// sixel draws the picture in DEC sixels, over blank rows, with colors
+// from a 6x6x6 cube
+func (p *Picture) sixel(cols, rows int) []string {
+	w, h := cols*sixelCell.w, rows*sixelCell.h
+	img := resize(p.img, w, h)
+
+	indexes := make([]int, w*h)
+	used := map[int]bool{}
+	for y := range h {
+		for x := range w {
+			i := cubeIndex(opaque(img.At(x, y)))
+			indexes[y*w+x] = i
+			used[i] = true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for i := range 216 {
+		if used[i] {
+			fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+		}
+	}
+	for top := 0; top < h; top += 6 {
+		// One pass over the band for each color in it, returning to the
+		// band's start between them
+		first := true
+		for c := range 216 {
+			if !used[c] {
+				continue
+			}
+			band := make([]byte, w)
+			inked := false
+			for x := range w {
+				var bits byte
+				for dy := 0; dy < 6 && top+dy < h; dy++ {
+					if indexes[(top+dy)*w+x] == c {
+						bits |= 1 << dy
+					}
+				}
+				band[x] = '?' + bits
+				inked = inked || bits != 0
+			}
+			if !inked {
+				continue
+			}
+			if !first {
+				b.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&b, "#%d", c)
+			writeRuns(&b, band)
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return blankRows(cols, rows, b.String())
+}
+
+// writeRuns writes sixels with runs of four or more repeated as !n
+func writeRuns(b *strings.Builder, band []byte) {
+	for i := 0; i < len(band); {
+		j := i
+		for j < len(band) && band[j] == band[i] {
+			j++
+		}
+		if n := j - i; n >= 4 {
+			fmt.Fprintf(b, "!%d%c", n, band[i])
+		} else {
+			b.Write(band[i:j])
+		}
+		i = j
+	}
+}
+
+// cubeIndex is the nearest of the 6x6x6 colors to c
+func cubeIndex(c color.RGBA) int {
+	level := func(v uint8) int { return (int(v)*5 + 127) / 255 }
+	return level(c.R)*36 + level(c.G)*6 + level(c.B)
+}
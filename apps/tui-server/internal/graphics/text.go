@@ -0,0 +1,54 @@
+package graphics
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// ramp is the ASCII art shades, darkest first
+const ramp = " .:-=+*#%@"
+
+// text draws the picture as half blocks, two pixels a cell, or with
+// ASCII or without color as one shade character a cell
+func (p *Picture) text(o Options, cols, rows int) []string {
+	halves := !o.ASCII && o.Profile != termenv.Ascii
+	h := rows
+	if halves {
+		h = rows * 2
+	}
+	img := resize(p.img, cols, h)
+
+	lines := make([]string, rows)
+	for y := range rows {
+		var b strings.Builder
+		for x := range cols {
+			if halves {
+				top, bottom := opaque(img.At(x, 2*y)), opaque(img.At(x, 2*y+1))
+				b.WriteString(o.Profile.String("▀").Foreground(o.Profile.Color(hex(top))).Background(o.Profile.Color(hex(bottom))).String())
+				continue
+			}
+			c := opaque(img.At(x, y))
+			shade := string(ramp[luma(c)*(len(ramp)-1)/255])
+			if o.Profile == termenv.Ascii {
+				b.WriteString(shade)
+				continue
+			}
+			b.WriteString(o.Profile.String(shade).Foreground(o.Profile.Color(hex(c))).String())
+		}
+		lines[y] = b.String()
+	}
+	return lines
+}
+
+// hex is c as #rrggbb
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// luma is c's brightness, 0 to 255
+func luma(c color.RGBA) int {
+	return (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+}
@@ -0,0 +1,302 @@
+// Package guard spots SSH scanners and bots: clients that never finish
+// the handshake, authenticate without opening a session, or reconnect in
+// tight loops. Offenders are kept out of analytics and, if configured,
+// banned for a while or held in a tarpit.
+package guard
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+// Mode is what happens to a banned client
+type Mode string
+
+const (
+	// ModeLog only classifies clients, for analytics and the logs
+	ModeLog Mode = "log"
+	// ModeBan closes connections from banned clients at once
+	ModeBan Mode = "ban"
+	// ModeTarpit holds banned clients with a slow drip of banner lines
+	ModeTarpit Mode = "tarpit"
+)
+
+// ParseMode reads a mode name; "off" is ModeLog
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case ModeLog, "off", "":
+		return ModeLog, nil
+	case ModeBan, ModeTarpit:
+		return Mode(s), nil
+	}
+	return "", fmt.Errorf("unknown guard mode %q (want log, ban or tarpit)", s)
+}
+
+// Kind is a reason a connection counts against its client
+type Kind string
+
+const (
+	// KindGarbage never completed the SSH handshake
+	KindGarbage Kind = "garbage"
+	// KindNoSession authenticated and left without opening a session
+	KindNoSession Kind = "no-session"
+	// KindLoop reconnected faster than a person would
+	KindLoop Kind = "reconnect-loop"
+)
+
+const (
+	// suspectStrikes marks a client as a bot
+	suspectStrikes = 3
+	// banStrikes bans a client, outside ModeLog
+	banStrikes = 6
+	// strikeMemory is how long strikes count; a quiet client starts over
+	strikeMemory = time.Hour
+	// loopConns connections within loopWindow make a reconnect loop
+	loopConns  = 10
+	loopWindow = time.Minute
+	// tarpitDrip is the gap between banner lines in the tarpit
+	tarpitDrip = 10 * time.Second
+	// tarpitHold is the longest one client is held
+	tarpitHold = 10 * time.Minute
+	// maxTarpitted bounds the connections held at once; banned clients
+	// past it are closed instead
+	maxTarpitted = 64
+)
+
+// Config sets up a Guard
+type Config struct {
+	Mode   Mode
+	BanFor time.Duration // how long a ban lasts
+	// OnBlock, if set, is told of each connection from a banned client
+	// before it's refused or tarpitted
+	OnBlock func(addr net.Addr)
+}
+
+// client is what's known about one remote address
+type client struct {
+	conns       []time.Time // recent connection times, within loopWindow
+	strikes     int
+	lastStrike  time.Time
+	bannedUntil time.Time
+}
+
+// Guard tracks clients by address. Addresses stay in memory only; logs
+// carry their hash.
+type Guard struct {
+	cfg    Config
+	logger *telemetry.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*client
+	tarpitted int
+
+	blocked atomic.Int64
+}
+
+// New returns a guard
+func New(cfg Config, logger *telemetry.Logger) *Guard {
+	return &Guard{cfg: cfg, logger: logger, now: time.Now, clients: map[string]*client{}}
+}
+
+// sessionKey marks a connection's context once it opens a session
+type sessionKey struct{}
+
+// conn reports how its connection ended when it closes
+type conn struct {
+	net.Conn
+	ctx   ssh.Context
+	guard *Guard
+	host  string
+	once  sync.Once
+}
+
+func (c *conn) Close() error {
+	c.once.Do(func() {
+		switch {
+		case c.ctx.Value(ssh.ContextKeySessionID) == nil:
+			c.guard.strike(c.host, KindGarbage)
+		case c.ctx.Value(sessionKey{}) == nil:
+			c.guard.strike(c.host, KindNoSession)
+		}
+	})
+	return c.Conn.Close()
+}
+
+// ConnCallback is the server's ssh.ConnCallback. It notes each
+// connection, and turns away or tarpits banned clients. Loopback is left
+// alone, so health checks such as `nc -z` never count.
+func (g *Guard) ConnCallback(ctx ssh.Context, nc net.Conn) net.Conn {
+	host := hostOf(nc.RemoteAddr())
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nc
+	}
+	now := g.now()
+
+	g.mu.Lock()
+	c := g.client(host)
+	banned := now.Before(c.bannedUntil)
+	if !banned {
+		c.conns = append(recent(c.conns, now), now)
+	}
+	loop := len(c.conns) > loopConns
+	tarpit := banned && g.cfg.Mode == ModeTarpit && g.tarpitted < maxTarpitted
+	if tarpit {
+		g.tarpitted++
+	}
+	g.mu.Unlock()
+
+	if banned {
+		g.blocked.Add(1)
+		if g.cfg.OnBlock != nil {
+			g.cfg.OnBlock(nc.RemoteAddr())
+		}
+		if tarpit {
+			g.drip(nc)
+			g.mu.Lock()
+			g.tarpitted--
+			g.mu.Unlock()
+		}
+		return nil
+	}
+	if loop {
+		g.strike(host, KindLoop)
+	}
+	return &conn{Conn: nc, ctx: ctx, guard: g, host: host}
+}
+
+// Middleware marks a connection as having opened a session
+func (g *Guard) Middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			s.Context().SetValue(sessionKey{}, true)
+			next(s)
+		}
+	}
+}
+
+// Suspect reports whether addr is banned or has struck often enough to
+// be a bot
+func (g *Guard) Suspect(addr net.Addr) bool {
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c, ok := g.clients[hostOf(addr)]
+	if !ok {
+		return false
+	}
+	return now.Before(c.bannedUntil) || c.strikes >= suspectStrikes && now.Sub(c.lastStrike) < strikeMemory
+}
+
+// Blocked is how many connections from banned clients were refused or
+// tarpitted since startup
+func (g *Guard) Blocked() int64 {
+	return g.blocked.Load()
+}
+
+// Run forgets quiet clients every minute until ctx ends, so the table
+// holds only recent ones
+func (g *Guard) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.sweep()
+		}
+	}
+}
+
+func (g *Guard) sweep() {
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for host, c := range g.clients {
+		c.conns = recent(c.conns, now)
+		if len(c.conns) == 0 && now.Sub(c.lastStrike) >= strikeMemory && now.After(c.bannedUntil) {
+			delete(g.clients, host)
+		}
+	}
+}
+
+// strike counts kind against host, banning it past banStrikes
+func (g *Guard) strike(host string, kind Kind) {
+	now := g.now()
+	g.mu.Lock()
+	c := g.client(host)
+	if now.Sub(c.lastStrike) >= strikeMemory {
+		c.strikes = 0
+	}
+	c.strikes++
+	c.lastStrike = now
+	strikes := c.strikes
+	ban := g.cfg.Mode != ModeLog && strikes >= banStrikes && !now.Before(c.bannedUntil)
+	if ban {
+		c.bannedUntil = now.Add(g.cfg.BanFor)
+		c.strikes = 0
+	}
+	g.mu.Unlock()
+
+	ctx := telemetry.Ctx("ip_hash", telemetry.ShortHash(host), "kind", string(kind), "strikes", strikes)
+	if ban {
+		ctx["ban_for"] = g.cfg.BanFor.String()
+		g.logger.Warn("Client banned", ctx)
+		return
+	}
+	g.logger.Debug("Client strike", ctx)
+}
+
+// client returns host's record, creating it; the caller holds mu
+func (g *Guard) client(host string) *client {
+	c, ok := g.clients[host]
+	if !ok {
+		c = &client{}
+		g.clients[host] = c
+	}
+	return c
+}
+
+// drip is the tarpit: random lines that may precede an SSH version
+// banner, one per tarpitDrip, until the client gives up or tarpitHold
+// passes. The banner itself never comes.
+func (g *Guard) drip(nc net.Conn) {
+	deadline := time.Now().Add(tarpitHold)
+	for time.Now().Before(deadline) {
+		_ = nc.SetWriteDeadline(time.Now().Add(tarpitDrip))
+		if _, err := fmt.Fprintf(nc, "%x\r\n", rand.Uint32()); err != nil {
+			return
+		}
+		time.Sleep(tarpitDrip)
+	}
+}
+
+// recent drops connection times older than loopWindow
+func recent(times []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= loopWindow {
+		i++
+	}
+	return times[i:]
+}
+
+func hostOf(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
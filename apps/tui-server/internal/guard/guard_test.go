@@ -0,0 +1,117 @@
+package guard
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+// fakeContext holds values like a connection's ssh.Context
+type fakeContext struct {
+	ssh.Context
+	values map[interface{}]interface{}
+}
+
+func newFakeContext() *fakeContext {
+	return &fakeContext{values: map[interface{}]interface{}{}}
+}
+
+func (c *fakeContext) Value(key interface{}) interface{} { return c.values[key] }
+func (c *fakeContext) SetValue(key, value interface{})   { c.values[key] = value }
+
+// addrConn is a connection from a given address
+type addrConn struct {
+	net.Conn
+	addr net.Addr
+}
+
+func (c addrConn) RemoteAddr() net.Addr { return c.addr }
+func (c addrConn) Close() error         { return nil }
+
+func dial(g *Guard, ip string) (net.Conn, *fakeContext) {
+	ctx := newFakeContext()
+	return g.ConnCallback(ctx, addrConn{addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}}), ctx
+}
+
+func TestGuardBansClientsThatNeverOpenASession(t *testing.T) {
+	logger := telemetry.NewLogger("test")
+	logger.SetOutput(io.Discard)
+	g := New(Config{Mode: ModeBan, BanFor: time.Hour}, logger)
+	bot := &net.TCPAddr{IP: net.ParseIP("203.0.113.5")}
+
+	for i := 0; i < banStrikes; i++ {
+		conn, ctx := dial(g, "203.0.113.5")
+		if conn == nil {
+			t.Fatalf("connection %d refused before the ban", i+1)
+		}
+		if i%2 == 1 {
+			ctx.SetValue(ssh.ContextKeySessionID, "id") // authenticated, then left
+		}
+		conn.Close()
+		if got, want := g.Suspect(bot), i+1 >= suspectStrikes; got != want {
+			t.Errorf("after %d strikes Suspect() = %v, want %v", i+1, got, want)
+		}
+	}
+
+	if conn, _ := dial(g, "203.0.113.5"); conn != nil {
+		t.Fatal("banned client was let in")
+	}
+	if got := g.Blocked(); got != 1 {
+		t.Errorf("Blocked() = %d, want 1", got)
+	}
+
+	g.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if conn, _ := dial(g, "203.0.113.5"); conn == nil {
+		t.Fatal("client still refused after the ban ran out")
+	}
+}
+
+func TestGuardLeavesVisitorsAndLoopbackAlone(t *testing.T) {
+	logger := telemetry.NewLogger("test")
+	logger.SetOutput(io.Discard)
+	g := New(Config{Mode: ModeBan, BanFor: time.Hour}, logger)
+
+	for i := 0; i < loopConns; i++ {
+		conn, ctx := dial(g, "198.51.100.7")
+		ctx.SetValue(ssh.ContextKeySessionID, "id")
+		ctx.SetValue(sessionKey{}, true)
+		conn.Close()
+	}
+	if g.Suspect(&net.TCPAddr{IP: net.ParseIP("198.51.100.7")}) {
+		t.Error("a visitor opening sessions was marked a bot")
+	}
+
+	for i := 0; i < 2*banStrikes; i++ {
+		conn, _ := dial(g, "127.0.0.1")
+		if conn == nil {
+			t.Fatal("loopback health check was refused")
+		}
+		conn.Close()
+	}
+	if len(g.clients) != 1 {
+		t.Errorf("tracking %d clients, want loopback left out", len(g.clients))
+	}
+}
+
+func TestGuardStrikesReconnectLoops(t *testing.T) {
+	logger := telemetry.NewLogger("test")
+	logger.SetOutput(io.Discard)
+	g := New(Config{Mode: ModeLog}, logger)
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.9")}
+
+	for i := 0; i < loopConns+suspectStrikes; i++ {
+		_, ctx := dial(g, "192.0.2.9")
+		ctx.SetValue(ssh.ContextKeySessionID, "id")
+		ctx.SetValue(sessionKey{}, true)
+	}
+	if !g.Suspect(addr) {
+		t.Error("client reconnecting in a tight loop wasn't marked a bot")
+	}
+	if conn, _ := dial(g, "192.0.2.9"); conn == nil {
+		t.Error("log mode refused a connection")
+	}
+}
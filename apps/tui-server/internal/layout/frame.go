@@ -0,0 +1,139 @@
+// Package layout draws the TUI's double-line frame and composites its
+// sections, so views only render what goes inside it. It owns the width
+// math: every row comes out exactly as wide as the frame, whatever its
+// content.
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Inset is the columns each side of the frame takes: the border and a
+// space of padding. Content starts at screen column Inset.
+const Inset = 2
+
+// InnerWidth is the content width of a frame width columns wide
+func InnerWidth(width int) int {
+	return max(width-2*Inset, 0)
+}
+
+// Divider is the rule drawn above a section
+type Divider int
+
+const (
+	// Double is the ╠═══╣ rule between major sections
+	Double Divider = iota
+	// Thin is the ╟───╢ rule within a section group
+	Thin
+	// None joins a section directly to the one above
+	None
+)
+
+// Section is a run of rows inside the frame
+type Section struct {
+	Rows    []string
+	Divider Divider // rule above the section; ignored for the first
+	Quiet   bool    // draw the side borders in Frame.Quiet
+}
+
+// Frame is a box of double lines around stacked sections
+type Frame struct {
+	Width  int
+	Corner lipgloss.Style // corners and rule ends
+	Line   lipgloss.Style // rules and side borders
+	Quiet  lipgloss.Style // the top label and side borders of Quiet sections
+}
+
+// InnerWidth is the content width inside the frame
+func (f Frame) InnerWidth() int {
+	return InnerWidth(f.Width)
+}
+
+// Render draws the frame around sections, with label set into the top
+// rule's right end when it fits
+func (f Frame) Render(label string, sections ...Section) string {
+	rows := []string{f.Top(label)}
+	for i, s := range sections {
+		if i > 0 {
+			switch s.Divider {
+			case Double:
+				rows = append(rows, f.rule("╠", "═", "╣"))
+			case Thin:
+				rows = append(rows, f.rule("╟", "─", "╢"))
+			}
+		}
+		side := f.Line
+		if s.Quiet {
+			side = f.Quiet
+		}
+		for _, row := range s.Rows {
+			rows = append(rows, f.row(side, row))
+		}
+	}
+	rows = append(rows, f.rule("╚", "═", "╝"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// Top is the frame's top rule, with label near its right end when the
+// frame is wide enough to spare it
+func (f Frame) Top(label string) string {
+	ruleWidth := f.InnerWidth() + 2
+	if label == "" || f.InnerWidth() < 40+lipgloss.Width(label) {
+		return f.rule("╔", "═", "╗")
+	}
+	tag := " " + label + " "
+	return f.Corner.Render("╔") +
+		f.Line.Render(strings.Repeat("═", ruleWidth-lipgloss.Width(tag)-2)) +
+		f.Quiet.Render(tag) +
+		f.Line.Render("══") +
+		f.Corner.Render("╗")
+}
+
+// Row fits content to the frame's inner width between side borders
+func (f Frame) Row(content string) string {
+	return f.row(f.Line, content)
+}
+
+func (f Frame) row(side lipgloss.Style, content string) string {
+	return side.Render("║ ") + Fit(content, f.InnerWidth()) + side.Render(" ║")
+}
+
+func (f Frame) rule(left, fill, right string) string {
+	return f.Corner.Render(left) + f.Line.Render(strings.Repeat(fill, f.InnerWidth()+2)) + f.Corner.Render(right)
+}
+
+// Fit pads or cuts a single line to exactly width cells
+func Fit(line string, width int) string {
+	return lipgloss.PlaceHorizontal(width, lipgloss.Left, ansi.Truncate(line, width, ""))
+}
+
+// Center centers a single line in width cells
+func Center(line string, width int) string {
+	return lipgloss.PlaceHorizontal(width, lipgloss.Center, ansi.Truncate(line, width, ""))
+}
+
+// Spread lays left, middle and right out across width: left and right at
+// the edges, middle in the gap between, each at least a space apart
+func Spread(left, middle, right string, width int) string {
+	gap := width - lipgloss.Width(left) - lipgloss.Width(middle) - lipgloss.Width(right)
+	before := max(1, gap/2-2)
+	after := max(1, gap-before)
+	return Fit(left+strings.Repeat(" ", before)+middle+strings.Repeat(" ", after)+right, width)
+}
+
+// Indent shifts every line of block right by n columns
+func Indent(block string, n int) string {
+	if n <= 0 {
+		return block
+	}
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(block, "\n", "\n"+pad)
+}
+
+// Block splits multi-line content into rows for a Section
+func Block(content string) []string {
+	return strings.Split(content, "\n")
+}
@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFrameRowsMatchWidth(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true)
+	for _, width := range []int{20, 21, 33, 80} {
+		f := Frame{Width: width, Corner: style, Line: style, Quiet: style}
+		out := f.Render("v1.2.3",
+			Section{Rows: []string{Spread("LOGO", "[VIEW]", "status │ 12:00", f.InnerWidth())}},
+			Section{Rows: []string{style.Render("a line far too long to fit in the narrower frames at all"), "日本語のテキストがはみ出す"}, Divider: None, Quiet: true},
+			Section{Rows: []string{"", Center("centered", f.InnerWidth())}, Divider: Thin},
+		)
+		for i, line := range strings.Split(out, "\n") {
+			if w := ansi.StringWidth(line); w != width {
+				t.Errorf("width %d: line %d is %d wide: %q", width, i, w, ansi.Strip(line))
+			}
+		}
+	}
+}
+
+func TestFrameDividersAndLabel(t *testing.T) {
+	f := Frame{Width: 60}
+	lines := strings.Split(f.Render("v1.2.3",
+		Section{Rows: []string{"header"}},
+		Section{Rows: []string{"body"}, Divider: None},
+		Section{Rows: []string{"input"}},
+		Section{Rows: []string{"hint"}, Divider: Thin},
+	), "\n")
+
+	want := []string{"╔", "║ header", "║ body", "╠", "║ input", "╟", "║ hint", "╚"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), strings.Join(lines, "\n"))
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want it to start with %q", i, lines[i], prefix)
+		}
+	}
+	if !strings.HasSuffix(lines[0], " v1.2.3 ══╗") {
+		t.Errorf("top rule = %q, want the label in its right end", lines[0])
+	}
+	if narrow := (Frame{Width: 30}).Top("v1.2.3"); strings.Contains(narrow, "v1.2.3") {
+		t.Errorf("narrow top rule = %q, want the label dropped", narrow)
+	}
+}
@@ -0,0 +1,86 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Overlay composites fg centered on top of base, dimming the base so the
+// foreground reads as a modal. The result is exactly width x height cells.
+func Overlay(dim lipgloss.Style, base, fg string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	// Flatten the base to plain text so it can be dimmed and cut safely
+	baseLines := strings.Split(base, "\n")
+	plain := make([]string, height)
+	for i := range plain {
+		line := ""
+		if i < len(baseLines) {
+			line = ansi.Truncate(ansi.Strip(baseLines[i]), width, "")
+		}
+		plain[i] = line + strings.Repeat(" ", max(0, width-ansi.StringWidth(line)))
+	}
+
+	fgLines := trimBlock(fg)
+	if len(fgLines) > height {
+		fgLines = fgLines[:height]
+	}
+	fgWidth := 0
+	for _, line := range fgLines {
+		fgWidth = max(fgWidth, ansi.StringWidth(line))
+	}
+	fgWidth = min(fgWidth, width)
+
+	x := max(0, (width-fgWidth)/2)
+	y := max(0, (height-len(fgLines))/2)
+
+	out := make([]string, height)
+	for i := range out {
+		if i < y || i >= y+len(fgLines) {
+			out[i] = dim.Render(plain[i])
+			continue
+		}
+		line := ansi.Truncate(fgLines[i-y], fgWidth, "")
+		fill := strings.Repeat(" ", max(0, fgWidth-ansi.StringWidth(line)))
+		out[i] = dim.Render(ansi.Cut(plain[i], 0, x)) +
+			line + fill +
+			dim.Render(ansi.Cut(plain[i], x+fgWidth, width))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// trimBlock drops surrounding blank lines and the common left indent of a
+// rendered block so it can be positioned precisely.
+func trimBlock(block string) []string {
+	lines := strings.Split(block, "\n")
+	for len(lines) > 0 && strings.TrimSpace(ansi.Strip(lines[0])) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(ansi.Strip(lines[len(lines)-1])) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	indent := -1
+	for _, line := range lines {
+		stripped := ansi.Strip(line)
+		if strings.TrimSpace(stripped) == "" {
+			continue
+		}
+		lead := len(stripped) - len(strings.TrimLeft(stripped, " "))
+		if indent < 0 || lead < indent {
+			indent = lead
+		}
+	}
+
+	if indent > 0 {
+		for i, line := range lines {
+			lines[i] = ansi.Cut(line, indent, ansi.StringWidth(line))
+		}
+	}
+	return lines
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -124,3 +126,45 @@ func certFileCandidates() []string {
 
 	return candidates
 }
+
+// TLSFiles points at PEM files for talking to a private endpoint: an extra
+// CA to trust and an optional client certificate for mutual TLS.
+type TLSFiles struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// NewHTTPTransportWithTLS is NewHTTPTransport plus the CA and client
+// certificate in files. Empty fields are ignored.
+func NewHTTPTransportWithTLS(files TLSFiles) (*http.Transport, error) {
+	transport := NewHTTPTransport()
+
+	if files.CAFile != "" {
+		pemData, err := os.ReadFile(files.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := transport.TLSClientConfig.RootCAs
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no certificates found in %s", files.CAFile)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if (files.CertFile == "") != (files.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+	if files.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return transport, nil
+}
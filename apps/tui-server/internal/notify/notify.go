@@ -0,0 +1,205 @@
+// Package notify pings the portfolio's owner when someone connects or
+// leaves feedback. Visit pings are low priority, rate limited and carry
+// no raw addresses: only the terminal type and a country read from the
+// visitor's locale.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+// sendTimeout bounds one ping, so a slow service can't pile them up
+const sendTimeout = 10 * time.Second
+
+// Config says where pings go. Either service may be left unset; with
+// neither, New returns nil and nothing is sent.
+type Config struct {
+	NtfyURL       string // topic URL, as in https://ntfy.sh/<topic>
+	NtfyToken     string // optional access token for a protected topic
+	TelegramToken string // bot token
+	TelegramChat  string // chat ID the bot writes to
+	// Interval is the least time between pings; visits in between are
+	// counted into the next one
+	Interval time.Duration
+}
+
+// Visit is what a ping says about a new session
+type Visit struct {
+	Terminal string
+	Country  string // ISO code, or "" when unknown
+}
+
+// ping is one message to the owner. A quiet ping doesn't make a sound.
+type ping struct {
+	title, priority, tags, text string
+	quiet                       bool
+}
+
+// Notifier sends visit and feedback pings. A nil Notifier does nothing.
+type Notifier struct {
+	senders  []func(ctx context.Context, p ping) error
+	interval time.Duration
+	logger   *telemetry.Logger
+	client   *http.Client
+
+	mu      sync.Mutex
+	last    time.Time
+	skipped int // visits since the last ping that weren't pinged
+}
+
+// New returns a notifier for the services cfg configures, or nil
+func New(cfg Config, logger *telemetry.Logger) *Notifier {
+	n := &Notifier{
+		interval: cfg.Interval,
+		logger:   logger,
+		client:   &http.Client{Transport: network.NewHTTPTransport(), Timeout: sendTimeout},
+	}
+	if cfg.NtfyURL != "" {
+		n.senders = append(n.senders, func(ctx context.Context, p ping) error {
+			return n.ntfy(ctx, cfg.NtfyURL, cfg.NtfyToken, p)
+		})
+	}
+	if cfg.TelegramToken != "" && cfg.TelegramChat != "" {
+		n.senders = append(n.senders, func(ctx context.Context, p ping) error {
+			return n.telegram(ctx, cfg.TelegramToken, cfg.TelegramChat, p)
+		})
+	}
+	if len(n.senders) == 0 {
+		return nil
+	}
+	return n
+}
+
+// SessionStarted pings about v unless a ping went out within the
+// interval. It doesn't wait for the ping to be delivered.
+func (n *Notifier) SessionStarted(v Visit) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	if !n.last.IsZero() && time.Since(n.last) < n.interval {
+		n.skipped++
+		n.mu.Unlock()
+		return
+	}
+	n.last = time.Now()
+	skipped := n.skipped
+	n.skipped = 0
+	n.mu.Unlock()
+
+	n.send(ping{title: "Portfolio visit", priority: "low", tags: "computer", text: message(v, skipped), quiet: true}, "Visit")
+}
+
+// Feedback pings about a /feedback submission: its rating out of 5 and
+// the comment, if any. Feedback isn't rate limited here; sessions are
+// limited in what they can submit.
+func (n *Notifier) Feedback(rating int, text string) {
+	if n == nil {
+		return
+	}
+	msg := fmt.Sprintf("Feedback: %d/5", rating)
+	if text != "" {
+		msg += "\n" + text
+	}
+	n.send(ping{title: "Portfolio feedback", priority: "default", tags: "speech_balloon", text: msg}, "Feedback")
+}
+
+// send delivers p through every service without waiting for them; what
+// names the ping in the log when one fails
+func (n *Notifier) send(p ping, what string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		for _, send := range n.senders {
+			if err := send(ctx, p); err != nil {
+				n.logger.Warn(what+" ping failed", telemetry.Ctx("error", err.Error()))
+			}
+		}
+	}()
+}
+
+// message is the text of a ping
+func message(v Visit, skipped int) string {
+	terminal := v.Terminal
+	if terminal == "" {
+		terminal = "unknown terminal"
+	}
+	country := v.Country
+	if country == "" {
+		country = "unknown country"
+	}
+	text := fmt.Sprintf("New visitor: %s from %s", terminal, country)
+	if skipped > 0 {
+		text += fmt.Sprintf(" (+%d more since the last ping)", skipped)
+	}
+	return text
+}
+
+// ntfy publishes a ping to a topic
+func (n *Notifier) ntfy(ctx context.Context, url, token string, p ping) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(p.text))
+	if err != nil {
+		return fmt.Errorf("ntfy: %w", err)
+	}
+	req.Header.Set("Title", p.title)
+	req.Header.Set("Priority", p.priority)
+	req.Header.Set("Tags", p.tags)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return n.do("ntfy", req)
+}
+
+// telegram sends a ping as a bot message
+func (n *Notifier) telegram(ctx context.Context, token, chat string, p ping) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id":              chat,
+		"text":                 p.text,
+		"disable_notification": p.quiet,
+	})
+	if err != nil {
+		return fmt.Errorf("telegram: %w", err)
+	}
+	url := "https://api.telegram.org/bot" + token + "/sendMessage"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		// The error would carry the URL, and with it the token
+		return fmt.Errorf("telegram: invalid request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return n.do("telegram", req)
+}
+
+func (n *Notifier) do(service string, req *http.Request) error {
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: request failed", service)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: %s", service, resp.Status)
+	}
+	return nil
+}
+
+// LocaleCountry reads the territory from a locale such as en_IN.UTF-8,
+// which is as close to a location as a session gets without its address
+func LocaleCountry(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	_, territory, ok := strings.Cut(locale, "_")
+	if !ok || len(territory) != 2 {
+		return ""
+	}
+	return strings.ToUpper(territory)
+}
@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+func TestSessionStartedPingsAtLowPriorityAndRateLimits(t *testing.T) {
+	pings := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Priority"); got != "low" {
+			t.Errorf("Priority = %q, want low", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		pings <- string(body)
+	}))
+	defer srv.Close()
+
+	n := New(Config{NtfyURL: srv.URL, Interval: time.Hour}, telemetry.NewLogger("test"))
+	n.SessionStarted(Visit{Terminal: "xterm-256color", Country: "IN"})
+	n.SessionStarted(Visit{Terminal: "alacritty"})
+	n.SessionStarted(Visit{Terminal: "kitty"})
+
+	select {
+	case got := <-pings:
+		if want := "New visitor: xterm-256color from IN"; got != want {
+			t.Errorf("ping = %q, want %q", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no ping sent")
+	}
+	if n.skipped != 2 {
+		t.Errorf("skipped = %d within the interval, want 2", n.skipped)
+	}
+
+	n.mu.Lock()
+	n.last = time.Now().Add(-2 * time.Hour)
+	n.mu.Unlock()
+	n.SessionStarted(Visit{Terminal: "foot", Country: "DE"})
+	if got, want := <-pings, "New visitor: foot from DE (+2 more since the last ping)"; got != want {
+		t.Errorf("ping = %q, want %q", got, want)
+	}
+}
+
+func TestFeedbackPingsWithTheComment(t *testing.T) {
+	pings := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		pings <- r
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+
+	n := New(Config{NtfyURL: srv.URL, Interval: time.Hour}, telemetry.NewLogger("test"))
+	n.SessionStarted(Visit{Terminal: "kitty"})
+	<-pings
+	<-bodies
+	n.Feedback(4, "Loved the snake game")
+
+	select {
+	case r := <-pings:
+		if got := r.Header.Get("Title"); got != "Portfolio feedback" {
+			t.Errorf("Title = %q, want Portfolio feedback", got)
+		}
+		if got, want := <-bodies, "Feedback: 4/5\nLoved the snake game"; got != want {
+			t.Errorf("ping = %q, want %q", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("feedback within the visit interval wasn't sent")
+	}
+}
+
+func TestNewWithoutServicesIsNil(t *testing.T) {
+	n := New(Config{TelegramToken: "token"}, telemetry.NewLogger("test"))
+	if n != nil {
+		t.Fatal("New() without a complete service should be nil")
+	}
+	n.SessionStarted(Visit{}) // a nil notifier does nothing
+	n.Feedback(5, "")
+}
+
+func TestLocaleCountry(t *testing.T) {
+	for locale, want := range map[string]string{
+		"en_IN.UTF-8":   "IN",
+		"de_de@euro":    "DE",
+		"C":             "",
+		"en":            "",
+		"":              "",
+		"zh_Hans.UTF-8": "",
+	} {
+		if got := LocaleCountry(locale); got != want {
+			t.Errorf("LocaleCountry(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
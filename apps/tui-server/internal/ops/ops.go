@@ -0,0 +1,85 @@
+// Package ops serves the operator's HTTP endpoints: pprof profiles and
+// runtime metrics. It binds to localhost by default and must not be
+// exposed publicly.
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Gauge is a metric read each time /metrics is scraped
+type Gauge struct {
+	Name  string
+	Help  string
+	Value func() float64
+}
+
+// Handler routes /debug/pprof/ to the standard profiles and /metrics to
+// the runtime gauges followed by extra
+func Handler(extra ...Gauge) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, append(runtimeGauges(), extra...))
+	})
+	return mux
+}
+
+// Serve listens on addr until ctx ends, then shuts down
+func Serve(ctx context.Context, addr string, h http.Handler) error {
+	srv := &http.Server{Addr: addr, Handler: h, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("ops listener: %w", err)
+	}
+	return nil
+}
+
+// runtimeGauges read goroutine, heap and GC figures. Memory stats are
+// read once per scrape and shared by the gauges that need them.
+func runtimeGauges() []Gauge {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return []Gauge{
+		{"go_goroutines", "Goroutines that currently exist.", func() float64 { return float64(runtime.NumGoroutine()) }},
+		{"go_memstats_heap_alloc_bytes", "Bytes of allocated heap objects.", func() float64 { return float64(mem.HeapAlloc) }},
+		{"go_memstats_heap_inuse_bytes", "Bytes in in-use heap spans.", func() float64 { return float64(mem.HeapInuse) }},
+		{"go_memstats_heap_objects", "Allocated heap objects.", func() float64 { return float64(mem.HeapObjects) }},
+		{"go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", func() float64 { return float64(mem.Sys) }},
+		{"go_gc_cycles_total", "Completed GC cycles.", func() float64 { return float64(mem.NumGC) }},
+		{"go_gc_pause_seconds_total", "Cumulative GC stop-the-world pause.", func() float64 { return float64(mem.PauseTotalNs) / 1e9 }},
+		{"go_gc_last_pause_seconds", "Most recent GC stop-the-world pause.", func() float64 {
+			return float64(mem.PauseNs[(mem.NumGC+255)%256]) / 1e9
+		}},
+	}
+}
+
+// writeMetrics renders gauges in the Prometheus text format
+func writeMetrics(w http.ResponseWriter, gauges []Gauge) {
+	var b strings.Builder
+	for _, g := range gauges {
+		kind := "gauge"
+		if strings.HasSuffix(g.Name, "_total") {
+			kind = "counter"
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", g.Name, g.Help, g.Name, kind, g.Name, g.Value())
+	}
+	_, _ = w.Write([]byte(b.String()))
+}
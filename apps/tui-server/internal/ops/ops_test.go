@@ -0,0 +1,35 @@
+package ops
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsIncludeRuntimeAndExtraGauges(t *testing.T) {
+	srv := httptest.NewServer(Handler(Gauge{Name: "tui_sessions_live", Help: "Sessions.", Value: func() float64 { return 3 }}))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, want := range []string{"\ngo_goroutines ", "# TYPE go_gc_cycles_total counter", "\ntui_sessions_live 3\n"} {
+		if !strings.Contains("\n"+string(body), want) {
+			t.Errorf("/metrics is missing %q:\n%s", want, body)
+		}
+	}
+
+	resp, err = srv.Client().Get(srv.URL + "/debug/pprof/goroutine?debug=1")
+	if err != nil {
+		t.Fatalf("GET goroutine profile error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("goroutine profile status = %d, want 200", resp.StatusCode)
+	}
+}
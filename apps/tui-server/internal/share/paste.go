@@ -0,0 +1,55 @@
+package share
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// uploadTimeout bounds one upload to a paste service
+const uploadTimeout = 15 * time.Second
+
+// Paste uploads transcripts to a paste service that takes the text as a
+// POST body and answers with its URL on the first line, like paste.rs
+type Paste struct {
+	URL    string
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// Share uploads transcript and returns the paste's URL
+func (p Paste) Share(ctx context.Context, transcript string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, strings.NewReader(transcript))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("paste service: %s", resp.Status)
+	}
+
+	line, err := bufio.NewReader(io.LimitReader(resp.Body, 2048)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if u, err := url.Parse(line); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("paste service answered %q, not a URL", line)
+	}
+	return line, nil
+}
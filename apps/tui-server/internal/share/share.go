@@ -0,0 +1,87 @@
+// Package share publishes /share transcripts: to a paste service, or to
+// files served from the resume's HTTP listener. What visitors typed is
+// redacted first, so a shared link can't leak an address or a key.
+package share
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxLength caps a transcript in bytes; Transcript leaves out the
+// earliest messages to fit
+const MaxLength = 64 << 10
+
+// Message is one chat message of a transcript
+type Message struct {
+	Role    string // "user" or "assistant"
+	Content string
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	secretPattern = regexp.MustCompile(`\b(?:sk|pk|rk|ghp|gho|ghs|github_pat|xox[abpr]|AKIA)[-_A-Za-z0-9]{12,}|\b[A-Za-z0-9_-]{32,}\b`)
+	ipPattern     = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b|\b(?:[0-9A-Fa-f]{1,4}:){3,7}[0-9A-Fa-f]{1,4}\b`)
+	phonePattern  = regexp.MustCompile(`\+?\d[\d\s().-]{7,}\d`)
+)
+
+// Redact replaces email addresses, API keys and tokens, IP addresses and
+// phone numbers in text with placeholders like [email]
+func Redact(text string) string {
+	text = emailPattern.ReplaceAllString(text, "[email]")
+	text = secretPattern.ReplaceAllString(text, "[secret]")
+	text = ipPattern.ReplaceAllString(text, "[ip]")
+	return phonePattern.ReplaceAllStringFunc(text, func(s string) string {
+		// Dates and years have fewer digits than a phone number
+		digits := 0
+		for _, r := range s {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits < 9 {
+			return s
+		}
+		return "[phone]"
+	})
+}
+
+// Transcript writes messages as plain text under title, redacted, since
+// answers can echo what the visitor gave. Past MaxLength, the earliest
+// messages are left out.
+func Transcript(title string, at time.Time, messages []Message) string {
+	const leftOut = "(earlier messages left out)\n\n"
+	header := fmt.Sprintf("%s\n%s\n\n", title, at.UTC().Format("2 January 2006"))
+	blocks := make([]string, len(messages))
+	total := len(header)
+	for i, msg := range messages {
+		name := "NEURAL"
+		if msg.Role == "user" {
+			name = "Visitor"
+		}
+		blocks[i] = name + ":\n" + Redact(strings.TrimSpace(msg.Content)) + "\n"
+		total += len(blocks[i]) + 1
+	}
+	if total <= MaxLength {
+		return header + strings.Join(blocks, "\n")
+	}
+
+	// Room for the latest messages, after the note saying some are missing
+	room := MaxLength - len(header) - len(leftOut)
+	size, first := 0, len(blocks)
+	for first > 0 && size+len(blocks[first-1])+1 <= room {
+		first--
+		size += len(blocks[first]) + 1
+	}
+	if first == len(blocks) {
+		// Even the last message alone is too long; cut it short
+		first--
+		blocks[first] = strings.ToValidUTF8(blocks[first][:room-1], "")
+	}
+	if first > 0 {
+		header += leftOut
+	}
+	return header + strings.Join(blocks[first:], "\n")
+}
@@ -0,0 +1,95 @@
+package share
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedact(t *testing.T) {
+	tests := map[string]string{
+		"mail me at jane.doe@example.com":       "mail me at [email]",
+		"my key is sk-proj-abcdefghijklmnop123": "my key is [secret]",
+		"I'm at 203.0.113.42 today":             "I'm at [ip] today",
+		"call +1 (555) 123-4567 later":          "call [phone] later",
+		"he started on 2024-10-17":              "he started on 2024-10-17",
+		"how long has he used Go?":              "how long has he used Go?",
+	}
+	for in, want := range tests {
+		if got := Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTranscriptRedactsEveryone(t *testing.T) {
+	got := Transcript("Q&A", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), []Message{
+		{Role: "user", Content: "reach me at a@b.io"},
+		{Role: "assistant", Content: "Noted, I'll pass a@b.io on to Mohak"},
+	})
+	if !strings.Contains(got, "Visitor:\nreach me at [email]") || strings.Contains(got, "a@b.io") {
+		t.Errorf("transcript:\n%s", got)
+	}
+	if !strings.Contains(got, "17 October 2026") {
+		t.Errorf("transcript has no date:\n%s", got)
+	}
+}
+
+func TestTranscriptFitsMaxLength(t *testing.T) {
+	got := Transcript("Q&A", time.Now(), []Message{
+		{Role: "user", Content: "tell me everything"},
+		{Role: "assistant", Content: strings.Repeat("word ", MaxLength)},
+	})
+	if len(got) > MaxLength {
+		t.Errorf("transcript is %d bytes, want at most %d", len(got), MaxLength)
+	}
+	if !strings.Contains(got, "(earlier messages left out)") || strings.Contains(got, "tell me everything") {
+		t.Errorf("transcript starts:\n%s", got[:200])
+	}
+}
+
+func TestStoreServesShared(t *testing.T) {
+	s := Store{Dir: t.TempDir(), BaseURL: "https://example.com/"}
+	link, err := s.Share(context.Background(), "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, ok := strings.CutPrefix(link, "https://example.com")
+	if !ok || !strings.HasPrefix(path, "/t/") {
+		t.Fatalf("Share = %q", link)
+	}
+
+	for _, tc := range []struct {
+		path   string
+		status int
+	}{
+		{path, http.StatusOK},
+		{"/t/../../etc/passwd", http.StatusNotFound},
+		{"/t/aaaaaaaaaa", http.StatusNotFound},
+	} {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != tc.status {
+			t.Errorf("GET %s: status %d, want %d", tc.path, rec.Code, tc.status)
+		}
+	}
+}
+
+func TestPasteReturnsFirstLine(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		io.WriteString(w, "https://paste.example/abc\n")
+	}))
+	defer srv.Close()
+
+	link, err := Paste{URL: srv.URL}.Share(context.Background(), "hello")
+	if err != nil || link != "https://paste.example/abc" || body != "hello" {
+		t.Errorf("Share = %q, %v; uploaded %q", link, err, body)
+	}
+}
@@ -0,0 +1,121 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// idLength is the length of a shared transcript's ID, a lowercase
+	// slice of rand.Text
+	idLength = 10
+	// Retention is how long the Store keeps a transcript
+	Retention = 30 * 24 * time.Hour
+	// maxStored caps how many transcripts the Store keeps; the oldest go
+	// first
+	maxStored = 1000
+)
+
+// Store keeps transcripts as files in Dir and serves them at /t/<id>, for
+// a server whose HTTP listener is public at BaseURL
+type Store struct {
+	Dir     string
+	BaseURL string // like https://bmohak.xyz, without the trailing slash
+}
+
+// Share writes transcript to a new file and returns its URL
+func (s Store) Share(_ context.Context, transcript string) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return "", err
+	}
+	s.prune()
+	id := strings.ToLower(rand.Text()[:idLength])
+	f, err := os.OpenFile(filepath.Join(s.Dir, id+".txt"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(transcript); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(s.BaseURL, "/") + "/t/" + id, nil
+}
+
+// prune removes transcripts past Retention, and the oldest past maxStored
+func (s Store) prune() {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+	var files []file
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !strings.HasSuffix(e.Name(), ".txt") {
+			continue
+		}
+		path := filepath.Join(s.Dir, e.Name())
+		if time.Since(info.ModTime()) > Retention {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, file{path, info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for len(files) >= maxStored {
+		os.Remove(files[0].path)
+		files = files[1:]
+	}
+}
+
+// Handler serves the transcripts at /t/<id> as plain text
+func (s Store) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/t/")
+		if !validID(id) || r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.NotFound(w, r)
+			return
+		}
+		f, err := os.Open(filepath.Join(s.Dir, id+".txt"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || time.Since(info.ModTime()) > Retention {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Robots-Tag", "noindex")
+		http.ServeContent(w, r, id+".txt", info.ModTime(), f)
+	})
+}
+
+// validID reports whether id is one Share could have made, so a request
+// can't reach outside Dir
+func validID(id string) bool {
+	if len(id) != idLength {
+		return false
+	}
+	for _, r := range id {
+		if (r < 'a' || r > 'z') && (r < '2' || r > '7') {
+			return false
+		}
+	}
+	return true
+}
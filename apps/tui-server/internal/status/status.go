@@ -0,0 +1,221 @@
+// Package status reads what the owner is up to for the welcome screen:
+// the track playing on Last.fm, or else a line from a status endpoint. It
+// is fetched only when someone connects and cached for every session.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+const (
+	lastFMEndpoint = "https://ws.audioscrobbler.com/2.0/"
+	// defaultTTL is how long a status is reused; a track lasts a few
+	// minutes, so this keeps it roughly current at one request a minute
+	defaultTTL = time.Minute
+	// fetchTimeout bounds one refresh, so a slow service only delays the
+	// status line, never the session
+	fetchTimeout = 5 * time.Second
+	// maxLength caps the status line in characters
+	maxLength = 80
+)
+
+// Config says where the status comes from. Either source may be left
+// unset; with neither, New returns nil.
+type Config struct {
+	LastFMUser string
+	LastFMKey  string
+	// URL serves a custom status: plain text, whose first line is used,
+	// or JSON with a "status" field
+	URL string
+	// TTL is how long a status is cached; zero means a minute
+	TTL time.Duration
+	// LastFMEndpoint overrides the Last.fm API URL, for tests
+	LastFMEndpoint string
+}
+
+// Status is a line for the welcome screen
+type Status struct {
+	Text string
+	// Listening marks Text as the track playing, "Title - Artist"
+	Listening bool
+}
+
+// Source fetches and caches the status
+type Source struct {
+	cfg    Config
+	client *http.Client
+	logger *telemetry.Logger
+
+	mu        sync.Mutex
+	cached    Status
+	fetchedAt time.Time
+}
+
+// New returns a source for cfg, or nil with nothing configured
+func New(cfg Config, logger *telemetry.Logger) *Source {
+	if (cfg.LastFMUser == "" || cfg.LastFMKey == "") && cfg.URL == "" {
+		return nil
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.LastFMEndpoint == "" {
+		cfg.LastFMEndpoint = lastFMEndpoint
+	}
+	return &Source{
+		cfg:    cfg,
+		client: &http.Client{Transport: network.NewHTTPTransport(), Timeout: fetchTimeout},
+		logger: logger,
+	}
+}
+
+// Current returns the status, refreshing it when older than the TTL. A
+// track playing wins over the custom status; a source that fails is
+// logged and skipped, and with both failing the status is empty until
+// the next refresh. Concurrent callers wait for a single refresh.
+func (s *Source) Current(ctx context.Context) Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < s.cfg.TTL {
+		return s.cached
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	var status Status
+	if s.cfg.LastFMUser != "" && s.cfg.LastFMKey != "" {
+		track, err := s.nowPlaying(ctx)
+		if err != nil {
+			s.logger.Warn("Status refresh failed", telemetry.Ctx("error", err.Error()))
+		}
+		status = Status{Text: track, Listening: track != ""}
+	}
+	if status.Text == "" && s.cfg.URL != "" {
+		text, err := s.custom(ctx)
+		if err != nil {
+			s.logger.Warn("Status refresh failed", telemetry.Ctx("error", err.Error()))
+		}
+		status = Status{Text: text}
+	}
+	status.Text = clip(status.Text)
+	s.cached, s.fetchedAt = status, time.Now()
+	return status
+}
+
+// recentTracks is the part of Last.fm's user.getRecentTracks reply the
+// source reads. "track" is a list, or a single object when there's one.
+type recentTracks struct {
+	RecentTracks struct {
+		Track json.RawMessage `json:"track"`
+	} `json:"recenttracks"`
+}
+
+type lastFMTrack struct {
+	Name   string `json:"name"`
+	Artist struct {
+		Text string `json:"#text"`
+	} `json:"artist"`
+	Attr struct {
+		NowPlaying string `json:"nowplaying"`
+	} `json:"@attr"`
+}
+
+// nowPlaying returns "Title - Artist" for the track playing, or "" when
+// nothing is
+func (s *Source) nowPlaying(ctx context.Context) (string, error) {
+	q := url.Values{
+		"method":  {"user.getrecenttracks"},
+		"user":    {s.cfg.LastFMUser},
+		"api_key": {s.cfg.LastFMKey},
+		"format":  {"json"},
+		"limit":   {"1"},
+	}
+	body, err := s.get(ctx, "last.fm", s.cfg.LastFMEndpoint+"?"+q.Encode())
+	if err != nil {
+		return "", err
+	}
+	var reply recentTracks
+	if err := json.Unmarshal(body, &reply); err != nil {
+		return "", fmt.Errorf("last.fm: invalid reply: %w", err)
+	}
+	var tracks []lastFMTrack
+	if err := json.Unmarshal(reply.RecentTracks.Track, &tracks); err != nil {
+		var one lastFMTrack
+		if json.Unmarshal(reply.RecentTracks.Track, &one) != nil {
+			return "", nil
+		}
+		tracks = []lastFMTrack{one}
+	}
+	for _, t := range tracks {
+		if t.Attr.NowPlaying == "true" && t.Name != "" {
+			if t.Artist.Text == "" {
+				return t.Name, nil
+			}
+			return t.Name + " - " + t.Artist.Text, nil
+		}
+	}
+	return "", nil
+}
+
+// custom reads the status endpoint
+func (s *Source) custom(ctx context.Context) (string, error) {
+	body, err := s.get(ctx, "status", s.cfg.URL)
+	if err != nil {
+		return "", err
+	}
+	var reply struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(body, &reply) == nil {
+		return reply.Status, nil
+	}
+	line, _, _ := strings.Cut(string(body), "\n")
+	return line, nil
+}
+
+func (s *Source) get(ctx context.Context, service, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		// The error would carry the URL, and with it the API key
+		return nil, fmt.Errorf("%s: invalid request", service)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: request failed", service)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", service, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, fmt.Errorf("%s: request failed", service)
+	}
+	return body, nil
+}
+
+// clip trims a status to one line of at most maxLength characters, with
+// control characters, which could move the cursor, dropped
+func clip(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r < 0x20 || r >= 0x7f && r < 0xa0 {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+	if runes := []rune(text); len(runes) > maxLength {
+		return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+	}
+	return text
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+)
+
+func TestCurrentPrefersTrackPlayingAndCaches(t *testing.T) {
+	var playing atomic.Bool
+	var requests atomic.Int32
+	lastFM := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("api_key") != "key" || r.URL.Query().Get("user") != "ada" {
+			t.Errorf("query = %v", r.URL.Query())
+		}
+		if playing.Load() {
+			_, _ = w.Write([]byte(`{"recenttracks":{"track":[
+				{"name":"Teardrop","artist":{"#text":"Massive Attack"},"@attr":{"nowplaying":"true"}},
+				{"name":"Angel","artist":{"#text":"Massive Attack"}}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"recenttracks":{"track":{"name":"Angel","artist":{"#text":"Massive Attack"}}}}`))
+	}))
+	defer lastFM.Close()
+	custom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("shipping \x1b[2Jthings\nsecond line"))
+	}))
+	defer custom.Close()
+
+	s := New(Config{LastFMUser: "ada", LastFMKey: "key", LastFMEndpoint: lastFM.URL, URL: custom.URL}, telemetry.NewLogger("test"))
+	if got := s.Current(context.Background()); got.Listening || got.Text != "shipping [2Jthings" {
+		t.Fatalf("status = %+v; want the custom line, first line only and without escapes", got)
+	}
+
+	playing.Store(true)
+	if s.Current(context.Background()); requests.Load() != 1 {
+		t.Fatalf("%d Last.fm requests within the TTL, want 1", requests.Load())
+	}
+	s.fetchedAt = s.fetchedAt.AddDate(0, 0, -1)
+	if got := s.Current(context.Background()); !got.Listening || got.Text != "Teardrop - Massive Attack" {
+		t.Fatalf("status = %+v; want the track playing", got)
+	}
+}
+
+func TestCurrentWithFailingSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := New(Config{URL: srv.URL}, telemetry.NewLogger("test"))
+	if got := s.Current(context.Background()); got.Text != "" {
+		t.Fatalf("status = %+v; want it empty", got)
+	}
+	if New(Config{LastFMUser: "ada"}, nil) != nil {
+		t.Error("New without a Last.fm key or URL should return nil")
+	}
+	if long := clip(strings.Repeat("a", 200)); len([]rune(long)) != maxLength {
+		t.Errorf("clipped to %d characters, want %d", len([]rune(long)), maxLength)
+	}
+}
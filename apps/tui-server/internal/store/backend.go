@@ -0,0 +1,188 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store is everything kept between sessions: each visitor's preferences,
+// visit history, high scores, bookmarks and session snapshot, the
+// all-time totals, /feedback submissions, past versions of the content,
+// and rate-limit windows. Visitor keys are opaque hashes.
+type Store interface {
+	Load(key string) (map[string]string, error)
+	Save(key string, prefs map[string]string) error
+	RecordVisit(key string, at time.Time) (Visits, error)
+	ForgetVisits(key string) error
+	HighScore(key, game string) (int, error)
+	RecordScore(key, game string, score int) (int, error)
+	Bookmarks(key string) ([]string, error)
+	SaveBookmarks(key string, ids []string) error
+	SaveSnapshot(key string, data []byte, at time.Time) error
+	TakeSnapshot(key string, since time.Time) ([]byte, error)
+
+	// RecordContentSeen notes the content version a visitor sees and
+	// returns the one they saw before; ArchiveContent keeps a version's
+	// content for ArchivedContent to compare against later
+	RecordContentSeen(key, version string) (string, error)
+	ArchiveContent(version string, data []byte) error
+	ArchivedContent(version string) ([]byte, error)
+
+	// SaveFeedback keeps a /feedback submission, the oldest going past a
+	// limit; RecentFeedback returns up to n of them, newest first
+	SaveFeedback(f Feedback) error
+	RecentFeedback(n int) ([]Feedback, error)
+
+	// Count adds one to the named all-time total and returns the new
+	// total; Total reads it, 0 if nothing was counted yet
+	Count(name string) (int, error)
+	Total(name string) int
+
+	// Hit counts one event under key in the current fixed window of the
+	// given length and returns the events so far in it
+	Hit(key string, window time.Duration) (int, error)
+
+	// Acquire takes one of limit slots under key, such as sessions from
+	// one address, and reports whether one was free; Release returns it
+	Acquire(key string, limit int) (bool, error)
+	Release(key string) error
+
+	// Presence registers this process in the live session count; call
+	// it once
+	Presence() (Presence, error)
+
+	Close() error
+}
+
+// Backend names a Store implementation
+type Backend string
+
+const (
+	// BackendFile keeps a JSON file and counter files beside it; replicas
+	// can share them only on one volume
+	BackendFile Backend = "file"
+	// BackendSQLite keeps a SQLite database file
+	BackendSQLite Backend = "sqlite"
+	// BackendRedis keeps everything in Redis, which replicas on different
+	// hosts can share
+	BackendRedis Backend = "redis"
+)
+
+// Config selects and locates a backend
+type Config struct {
+	Backend Backend
+	Path    string // the JSON file or SQLite database
+	URL     string // Redis URL, as in redis://:password@host:6379/0
+}
+
+// Open opens the configured backend
+func Open(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendFile, "":
+		return newFileBackend(cfg.Path)
+	case BackendSQLite:
+		return NewSQLiteStore(cfg.Path)
+	case BackendRedis:
+		return NewRedisStore(cfg.URL)
+	}
+	return nil, fmt.Errorf("unknown store backend %q (want file, sqlite or redis)", cfg.Backend)
+}
+
+// fileBackend is the FileStore with totals and the Registry kept in a
+// sessions/ directory beside it, and rate limits kept in memory
+type fileBackend struct {
+	*FileStore
+	fileCounters
+	*memorySlots
+	limiter *memoryLimiter
+}
+
+func newFileBackend(path string) (*fileBackend, error) {
+	fs, err := NewFileStore(path)
+	if err != nil {
+		return nil, err
+	}
+	counters := filepath.Join(filepath.Dir(path), "sessions")
+	if err := os.MkdirAll(counters, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create counters: %w", err)
+	}
+	return &fileBackend{
+		FileStore:    fs,
+		fileCounters: fileCounters(counters),
+		memorySlots:  newMemorySlots(),
+		limiter:      newMemoryLimiter(),
+	}, nil
+}
+
+func (b *fileBackend) Hit(key string, window time.Duration) (int, error) {
+	return b.limiter.Hit(key, window)
+}
+
+func (b *fileBackend) Presence() (Presence, error) {
+	r, err := NewRegistry(string(b.fileCounters))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (b *fileBackend) Close() error { return nil }
+
+// memoryLimiterSweep is the table size past which ended windows are
+// dropped
+const memoryLimiterSweep = 1024
+
+// memoryLimiter counts Hit windows for one process
+type memoryLimiter struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]hitWindow
+}
+
+type hitWindow struct {
+	end   time.Time
+	count int
+}
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{now: time.Now, windows: map[string]hitWindow{}}
+}
+
+func (l *memoryLimiter) Hit(key string, window time.Duration) (int, error) {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.windows) > memoryLimiterSweep {
+		for k, w := range l.windows {
+			if !now.Before(w.end) {
+				delete(l.windows, k)
+			}
+		}
+	}
+	w := l.windows[key]
+	if !now.Before(w.end) {
+		w = hitWindow{end: now.Truncate(window).Add(window)}
+	}
+	w.count++
+	l.windows[key] = w
+	return w.count, nil
+}
+
+// validCounterName limits total names to letters, digits, dots, dashes
+// and underscores, since the file backend uses them as file names
+func validCounterName(name string) error {
+	valid := name != "" && !strings.HasPrefix(name, ".")
+	for _, c := range name {
+		valid = valid && (c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("._-", c))
+	}
+	if !valid {
+		return fmt.Errorf("invalid counter name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Presence counts live sessions across every process sharing a backend.
+// Each process publishes its own count on every change and at least once
+// per heartbeat; counts that stop being refreshed, as after a crash, drop
+// out of Live.
+type Presence interface {
+	// Join counts a new session; call the returned func when it ends
+	Join() (leave func())
+	// Live returns the number of sessions open across all processes
+	Live() int
+	// Run refreshes this process's count until ctx ends, then removes it
+	Run(ctx context.Context)
+}
+
+// board is where processes publish their session counts
+type board interface {
+	publish(process string, sessions int, at time.Time) error
+	remove(process string) error
+	// sum adds up the counts published at or after since, dropping older
+	// ones
+	sum(since time.Time) (int, error)
+}
+
+// sharedPresence is Presence over a board in a shared backend
+type sharedPresence struct {
+	board   board
+	process string
+
+	mu       sync.Mutex
+	sessions int
+	live     int
+	readAt   time.Time
+}
+
+func newSharedPresence(b board) (*sharedPresence, error) {
+	p := &sharedPresence{board: b, process: processID()}
+	if err := b.publish(p.process, 0, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to register in session registry: %w", err)
+	}
+	return p, nil
+}
+
+func (p *sharedPresence) Join() (leave func()) {
+	p.adjust(1)
+	var once sync.Once
+	return func() { once.Do(func() { p.adjust(-1) }) }
+}
+
+func (p *sharedPresence) Live() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Since(p.readAt) < registryCacheTTL {
+		return p.live
+	}
+	now := time.Now()
+	live, err := p.board.sum(now.Add(-registryStale))
+	if err != nil {
+		// Keep the last good count rather than show zero
+		return p.live
+	}
+	p.live, p.readAt = live, now
+	return live
+}
+
+func (p *sharedPresence) Run(ctx context.Context) {
+	ticker := time.NewTicker(registryHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = p.board.remove(p.process)
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			_ = p.board.publish(p.process, p.sessions, time.Now())
+			p.mu.Unlock()
+		}
+	}
+}
+
+// adjust changes this process's count and publishes it
+func (p *sharedPresence) adjust(delta int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sessions += delta
+	p.readAt = time.Time{}
+	_ = p.board.publish(p.process, p.sessions, time.Now())
+}
+
+// processID names this process on a board: the host and pid, which
+// replicas in containers may share, plus a random suffix
+func processID() string {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// slotExpiry frees slots a crashed process never released, this long
+// after the last Acquire under their key
+const slotExpiry = time.Hour
+
+// memorySlots holds Acquire slots for one process
+type memorySlots struct {
+	mu   sync.Mutex
+	held map[string]int
+}
+
+func newMemorySlots() *memorySlots {
+	return &memorySlots{held: map[string]int{}}
+}
+
+func (m *memorySlots) Acquire(key string, limit int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.held[key] >= limit {
+		return false, nil
+	}
+	m.held[key]++
+	return true, nil
+}
+
+func (m *memorySlots) Release(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.held[key] <= 1 {
+		delete(m.held, key)
+	} else {
+		m.held[key]--
+	}
+	return nil
+}
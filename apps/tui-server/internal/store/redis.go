@@ -0,0 +1,407 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisPrefix starts every key, so the server can share a database
+	redisPrefix = "mohak-tui:"
+	// redisDialTimeout bounds the check that the server is reachable
+	redisDialTimeout = 5 * time.Second
+	// snapshotExpiry drops snapshots nobody came back for; Redis can't
+	// sweep them by age the way the other backends do
+	snapshotExpiry = 7 * 24 * time.Hour
+)
+
+// recordVisitScript counts a visit and returns the history before it,
+// atomically so two sessions of one visitor can't lose a count
+var recordVisitScript = redis.NewScript(`
+local prev = redis.call('HMGET', KEYS[1], 'count', 'first_seen', 'last_seen')
+redis.call('HINCRBY', KEYS[1], 'count', 1)
+redis.call('HSETNX', KEYS[1], 'first_seen', ARGV[1])
+redis.call('HSET', KEYS[1], 'last_seen', ARGV[1])
+return prev
+`)
+
+// recordScoreScript keeps the higher of the saved and the new score
+var recordScoreScript = redis.NewScript(`
+local best = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+local score = tonumber(ARGV[2])
+if score > best then
+	redis.call('HSET', KEYS[1], ARGV[1], score)
+	return score
+end
+return best
+`)
+
+// acquireScript takes a slot if fewer than the limit are held, and pushes
+// back the key's expiry so slots of a crashed process free themselves
+var acquireScript = redis.NewScript(`
+local held = tonumber(redis.call('GET', KEYS[1]) or '0')
+if held >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+// releaseScript returns a slot, deleting the key once none are held
+var releaseScript = redis.NewScript(`
+if redis.call('DECR', KEYS[1]) <= 0 then
+	redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisStore keeps everything in Redis, so replicas on different hosts
+// see the same visitors and totals. Calls use the client's own timeouts.
+type RedisStore struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewRedisStore connects to the server at url and checks it answers
+func NewRedisStore(url string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), redisDialTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to reach Redis: %w", err)
+	}
+	return &RedisStore{client: client, now: time.Now}, nil
+}
+
+// Load returns the preferences saved under key, or nil
+func (s *RedisStore) Load(key string) (map[string]string, error) {
+	prefs, err := s.client.HGetAll(context.Background(), redisPrefix+"prefs:"+key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prefs: %w", err)
+	}
+	if len(prefs) == 0 {
+		return nil, nil
+	}
+	return prefs, nil
+}
+
+// Save replaces the preferences under key; empty prefs delete the entry
+func (s *RedisStore) Save(key string, prefs map[string]string) error {
+	k := redisPrefix + "prefs:" + key
+	_, err := s.client.TxPipelined(context.Background(), func(p redis.Pipeliner) error {
+		p.Del(context.Background(), k)
+		if len(prefs) > 0 {
+			p.HSet(context.Background(), k, prefs)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save prefs: %w", err)
+	}
+	return nil
+}
+
+// RecordVisit counts a new visit under key at the given time and returns
+// the history as it was before it, so a first visit has a zero Count
+func (s *RedisStore) RecordVisit(key string, at time.Time) (Visits, error) {
+	prev, err := recordVisitScript.Run(context.Background(), s.client,
+		[]string{redisPrefix + "visits:" + key}, at.UnixNano()).Slice()
+	if err != nil {
+		return Visits{}, fmt.Errorf("failed to record visit: %w", err)
+	}
+	field := func(i int) int64 {
+		if i >= len(prev) {
+			return 0
+		}
+		v, _ := prev[i].(string)
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	}
+	if field(0) == 0 {
+		return Visits{}, nil
+	}
+	return Visits{
+		Count:     int(field(0)),
+		FirstSeen: time.Unix(0, field(1)),
+		LastSeen:  time.Unix(0, field(2)),
+	}, nil
+}
+
+// ForgetVisits deletes the visit history under key, along with the
+// content version last seen
+func (s *RedisStore) ForgetVisits(key string) error {
+	if err := s.client.Del(context.Background(), redisPrefix+"visits:"+key, redisPrefix+"seen:"+key).Err(); err != nil {
+		return fmt.Errorf("failed to forget visits: %w", err)
+	}
+	return nil
+}
+
+// RecordContentSeen notes that the visitor under key has seen the given
+// content version and returns the one they saw before, or ""
+func (s *RedisStore) RecordContentSeen(key, version string) (string, error) {
+	prev, err := s.client.SetArgs(context.Background(), redisPrefix+"seen:"+key, version, redis.SetArgs{Get: true}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("failed to record content seen: %w", err)
+	}
+	return prev, nil
+}
+
+// ArchiveContent keeps data under version; a version already kept is
+// left as it is
+func (s *RedisStore) ArchiveContent(version string, data []byte) error {
+	if err := s.client.SetNX(context.Background(), redisPrefix+"content:"+version, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to archive content: %w", err)
+	}
+	return nil
+}
+
+// ArchivedContent returns the content kept under version, or nil
+func (s *RedisStore) ArchivedContent(version string) ([]byte, error) {
+	data, err := s.client.Get(context.Background(), redisPrefix+"content:"+version).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived content: %w", err)
+	}
+	return data, nil
+}
+
+// HighScore returns the best score saved under key for game, or 0
+func (s *RedisStore) HighScore(key, game string) (int, error) {
+	best, err := s.client.HGet(context.Background(), redisPrefix+"scores:"+key, game).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read score: %w", err)
+	}
+	return best, nil
+}
+
+// RecordScore saves score under key for game if it beats the best so far,
+// and returns the best score after it
+func (s *RedisStore) RecordScore(key, game string, score int) (int, error) {
+	best, err := recordScoreScript.Run(context.Background(), s.client,
+		[]string{redisPrefix + "scores:" + key}, game, score).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record score: %w", err)
+	}
+	return best, nil
+}
+
+// Bookmarks returns the project IDs bookmarked under key, in the order
+// they were added, or nil
+func (s *RedisStore) Bookmarks(key string) ([]string, error) {
+	ids, err := s.client.LRange(context.Background(), redisPrefix+"bookmarks:"+key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return ids, nil
+}
+
+// SaveBookmarks replaces the project IDs bookmarked under key; none
+// deletes the entry
+func (s *RedisStore) SaveBookmarks(key string, ids []string) error {
+	k := redisPrefix + "bookmarks:" + key
+	_, err := s.client.TxPipelined(context.Background(), func(p redis.Pipeliner) error {
+		p.Del(context.Background(), k)
+		if len(ids) > 0 {
+			p.RPush(context.Background(), k, ids)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+	return nil
+}
+
+// SaveFeedback keeps a /feedback submission, dropping the oldest past
+// maxFeedback
+func (s *RedisStore) SaveFeedback(f Feedback) error {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	k := redisPrefix + "feedback"
+	_, err = s.client.TxPipelined(context.Background(), func(p redis.Pipeliner) error {
+		p.LPush(context.Background(), k, raw)
+		p.LTrim(context.Background(), k, 0, maxFeedback-1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save feedback: %w", err)
+	}
+	return nil
+}
+
+// RecentFeedback returns up to n submissions, newest first
+func (s *RedisStore) RecentFeedback(n int) ([]Feedback, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	raws, err := s.client.LRange(context.Background(), redisPrefix+"feedback", 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback: %w", err)
+	}
+	var recent []Feedback
+	for _, raw := range raws {
+		var f Feedback
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			return nil, fmt.Errorf("failed to read feedback: %w", err)
+		}
+		recent = append(recent, f)
+	}
+	return recent, nil
+}
+
+// SaveSnapshot replaces the session snapshot under key; empty data
+// deletes it
+func (s *RedisStore) SaveSnapshot(key string, data []byte, at time.Time) error {
+	k := redisPrefix + "snapshot:" + key
+	var err error
+	if len(data) == 0 {
+		err = s.client.Del(context.Background(), k).Err()
+	} else {
+		var raw []byte
+		raw, err = json.Marshal(Snapshot{Data: data, SavedAt: at})
+		if err == nil {
+			err = s.client.Set(context.Background(), k, raw, snapshotExpiry).Err()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// TakeSnapshot removes and returns the snapshot under key if it was saved
+// at or after since
+func (s *RedisStore) TakeSnapshot(key string, since time.Time) ([]byte, error) {
+	raw, err := s.client.GetDel(context.Background(), redisPrefix+"snapshot:"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(raw, &snap); err != nil || snap.SavedAt.Before(since) {
+		return nil, nil
+	}
+	return snap.Data, nil
+}
+
+// Count adds one to the named all-time total and returns the new total
+func (s *RedisStore) Count(name string) (int, error) {
+	if err := validCounterName(name); err != nil {
+		return 0, err
+	}
+	total, err := s.client.Incr(context.Background(), redisPrefix+"total:"+name).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", name, err)
+	}
+	return int(total), nil
+}
+
+// Total returns the named all-time total, 0 if nothing was counted yet
+func (s *RedisStore) Total(name string) int {
+	total, _ := s.client.Get(context.Background(), redisPrefix+"total:"+name).Int()
+	return total
+}
+
+// Hit counts one event under key in the current window and returns the
+// events so far in it. Each window is its own key, expiring as it ends.
+func (s *RedisStore) Hit(key string, window time.Duration) (int, error) {
+	end := s.now().Truncate(window).Add(window)
+	k := redisPrefix + "hits:" + key + ":" + strconv.FormatInt(end.UnixNano(), 10)
+	var incr *redis.IntCmd
+	_, err := s.client.TxPipelined(context.Background(), func(p redis.Pipeliner) error {
+		incr = p.Incr(context.Background(), k)
+		p.PExpireAt(context.Background(), k, end)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count hit: %w", err)
+	}
+	return int(incr.Val()), nil
+}
+
+// Acquire takes one of limit slots under key and reports whether one was
+// free
+func (s *RedisStore) Acquire(key string, limit int) (bool, error) {
+	took, err := acquireScript.Run(context.Background(), s.client,
+		[]string{redisPrefix + "slots:" + key}, limit, slotExpiry.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire slot: %w", err)
+	}
+	return took == 1, nil
+}
+
+// Release returns a slot taken under key
+func (s *RedisStore) Release(key string) error {
+	if err := releaseScript.Run(context.Background(), s.client,
+		[]string{redisPrefix + "slots:" + key}).Err(); err != nil {
+		return fmt.Errorf("failed to release slot: %w", err)
+	}
+	return nil
+}
+
+// Presence registers this process in the presence hash
+func (s *RedisStore) Presence() (Presence, error) {
+	return newSharedPresence(redisBoard{s.client})
+}
+
+// redisBoard keeps each process's session count in one hash, as
+// "sessions seen_at" under the process
+type redisBoard struct{ client *redis.Client }
+
+const redisPresenceKey = redisPrefix + "presence"
+
+func (b redisBoard) publish(process string, sessions int, at time.Time) error {
+	return b.client.HSet(context.Background(), redisPresenceKey, process,
+		fmt.Sprintf("%d %d", sessions, at.UnixNano())).Err()
+}
+
+func (b redisBoard) remove(process string) error {
+	return b.client.HDel(context.Background(), redisPresenceKey, process).Err()
+}
+
+func (b redisBoard) sum(since time.Time) (int, error) {
+	entries, err := b.client.HGetAll(context.Background(), redisPresenceKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	live := 0
+	for process, entry := range entries {
+		var sessions int
+		var seenAt int64
+		if _, err := fmt.Sscan(entry, &sessions, &seenAt); err != nil || seenAt < since.UnixNano() {
+			b.client.HDel(context.Background(), redisPresenceKey, process)
+			continue
+		}
+		live += sessions
+	}
+	return live, nil
+}
+
+// Close closes the connection pool
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// registryHeartbeat is how often a process refreshes its entry
+	registryHeartbeat = 10 * time.Second
+	// registryStale ignores entries of processes that stopped refreshing,
+	// such as ones that crashed
+	registryStale = 3 * registryHeartbeat
+	// registryCacheTTL bounds how often Live rereads the directory
+	registryCacheTTL = 2 * time.Second
+	// registryLockStale breaks a counter lock left by a crash
+	registryLockStale = 5 * time.Second
+)
+
+// registryEntry is one process's file in the registry directory
+type registryEntry struct {
+	Sessions int       `json:"sessions"`
+	Updated  time.Time `json:"updated"`
+}
+
+// Registry counts live sessions across every server process sharing its
+// directory, such as replicas on one volume. Each process owns one file
+// holding its own count; Live sums the fresh ones.
+type Registry struct {
+	dir  string
+	file string
+
+	mu       sync.Mutex
+	sessions int
+	live     int
+	readAt   time.Time
+}
+
+// NewRegistry registers this process in dir, creating it if needed
+func NewRegistry(dir string) (*Registry, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create session registry: %w", err)
+	}
+	host, _ := os.Hostname()
+	r := &Registry{
+		dir:  dir,
+		file: filepath.Join(dir, fmt.Sprintf("%s-%d.json", host, os.Getpid())),
+	}
+	if err := r.write(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Join counts a new session; call the returned func when it ends
+func (r *Registry) Join() (leave func()) {
+	r.adjust(1)
+	var once sync.Once
+	return func() { once.Do(func() { r.adjust(-1) }) }
+}
+
+// Live returns the number of sessions open across all processes
+func (r *Registry) Live() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.readAt) < registryCacheTTL {
+		return r.live
+	}
+
+	now := time.Now()
+	total := r.sessions
+	files, _ := os.ReadDir(r.dir)
+	for _, f := range files {
+		path := filepath.Join(r.dir, f.Name())
+		if path == r.file || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		var entry registryEntry
+		raw, err := os.ReadFile(path)
+		if err != nil || json.Unmarshal(raw, &entry) != nil {
+			continue
+		}
+		if now.Sub(entry.Updated) < registryStale {
+			total += entry.Sessions
+		}
+	}
+	r.live, r.readAt = total, now
+	return total
+}
+
+// Run refreshes this process's entry until ctx ends, then removes it
+func (r *Registry) Run(ctx context.Context) {
+	ticker := time.NewTicker(registryHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = os.Remove(r.file)
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			_ = r.write()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// CountVisit adds a visit to the all-time total shared by every process
+// and returns the new total
+func (r *Registry) CountVisit() (int, error) {
+	return r.Count("visits")
+}
+
+// Count adds one to the named all-time total shared by every process and
+// returns the new total
+func (r *Registry) Count(name string) (int, error) {
+	return fileCounters(r.dir).Count(name)
+}
+
+// Total returns the named all-time total, 0 if nothing was counted yet
+func (r *Registry) Total(name string) int {
+	return fileCounters(r.dir).Total(name)
+}
+
+// fileCounters keeps all-time totals as files in a directory, one per
+// name, shared by every process using it
+type fileCounters string
+
+// Count adds one to the named total and returns the new total. Totals are
+// updated under a lock file so concurrent sessions on different processes
+// can't lose counts.
+func (c fileCounters) Count(name string) (int, error) {
+	path, err := c.counterPath(name)
+	if err != nil {
+		return 0, err
+	}
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	total := readCounter(path) + 1
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(total)), 0o600); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", name, err)
+	}
+	return total, nil
+}
+
+// Total returns the named total, 0 if nothing was counted yet
+func (c fileCounters) Total(name string) int {
+	path, err := c.counterPath(name)
+	if err != nil {
+		return 0
+	}
+	return readCounter(path)
+}
+
+// counterPath is the file holding a total
+func (c fileCounters) counterPath(name string) (string, error) {
+	if err := validCounterName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(string(c), name+".total"), nil
+}
+
+func readCounter(path string) int {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	total, _ := strconv.Atoi(strings.TrimSpace(string(raw)))
+	return total
+}
+
+// lock takes the counters' lock file, waiting briefly for other holders
+func (c fileCounters) lock() (unlock func(), err error) {
+	path := filepath.Join(string(c), "visits.lock")
+	deadline := time.Now().Add(time.Second)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock counters: %w", err)
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > registryLockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("failed to lock counters: timed out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// adjust changes this process's count and publishes it
+func (r *Registry) adjust(delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions += delta
+	r.readAt = time.Time{}
+	_ = r.write()
+}
+
+// write publishes this process's count; the caller holds mu unless the
+// registry is still being created
+func (r *Registry) write() error {
+	raw, err := json.Marshal(registryEntry{Sessions: r.sessions, Updated: time.Now()})
+	if err != nil {
+		return err
+	}
+	tmp := r.file + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to update session registry: %w", err)
+	}
+	if err := os.Rename(tmp, r.file); err != nil {
+		return fmt.Errorf("failed to update session registry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,478 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite" // pure Go, so builds stay CGO_ENABLED=0
+)
+
+// sqlitePruneInterval is how often ended rate-limit windows are deleted
+const sqlitePruneInterval = time.Minute
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS prefs (
+	key   TEXT PRIMARY KEY,
+	prefs TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS visits (
+	key        TEXT PRIMARY KEY,
+	count      INTEGER NOT NULL,
+	first_seen INTEGER NOT NULL,
+	last_seen  INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS scores (
+	key   TEXT NOT NULL,
+	game  TEXT NOT NULL,
+	score INTEGER NOT NULL,
+	PRIMARY KEY (key, game)
+);
+CREATE TABLE IF NOT EXISTS bookmarks (
+	key TEXT PRIMARY KEY,
+	ids TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS snapshots (
+	key      TEXT PRIMARY KEY,
+	data     BLOB NOT NULL,
+	saved_at INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS seen (
+	key     TEXT PRIMARY KEY,
+	version TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS contents (
+	version TEXT PRIMARY KEY,
+	data    BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS feedback (
+	id      INTEGER PRIMARY KEY AUTOINCREMENT,
+	rating  INTEGER NOT NULL,
+	text    TEXT NOT NULL,
+	visitor TEXT NOT NULL,
+	at      INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS counters (
+	name  TEXT PRIMARY KEY,
+	total INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS hits (
+	key        TEXT PRIMARY KEY,
+	window_end INTEGER NOT NULL,
+	count      INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS hits_window_end ON hits (window_end);
+CREATE TABLE IF NOT EXISTS slots (
+	key     TEXT PRIMARY KEY,
+	held    INTEGER NOT NULL,
+	expires INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS presence (
+	process  TEXT PRIMARY KEY,
+	sessions INTEGER NOT NULL,
+	seen_at  INTEGER NOT NULL
+);
+`
+
+// SQLiteStore keeps everything in one SQLite database. Processes on one
+// host may share the file; times are stored as Unix nanoseconds.
+type SQLiteStore struct {
+	db  *sql.DB
+	now func() time.Time
+
+	mu       sync.Mutex
+	prunedAt time.Time
+}
+
+// NewSQLiteStore opens the database at path, creating it and its tables
+// if needed
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+	// WAL lets readers carry on during a write; the busy timeout makes
+	// other processes wait for a write instead of failing. Transactions
+	// take the write lock as they begin, so another process can't write
+	// between one's read and its write.
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	// One connection serializes this process's writes, which SQLite would
+	// otherwise turn away as busy
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set up store %s: %w", path, err)
+	}
+	return &SQLiteStore{db: db, now: time.Now}, nil
+}
+
+// Load returns the preferences saved under key, or nil
+func (s *SQLiteStore) Load(key string) (map[string]string, error) {
+	var raw string
+	err := s.db.QueryRow(`SELECT prefs FROM prefs WHERE key = ?`, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prefs: %w", err)
+	}
+	var prefs map[string]string
+	if err := json.Unmarshal([]byte(raw), &prefs); err != nil {
+		return nil, fmt.Errorf("failed to load prefs: %w", err)
+	}
+	return prefs, nil
+}
+
+// Save replaces the preferences under key; empty prefs delete the entry
+func (s *SQLiteStore) Save(key string, prefs map[string]string) error {
+	if len(prefs) == 0 {
+		return s.exec("save prefs", `DELETE FROM prefs WHERE key = ?`, key)
+	}
+	raw, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	return s.exec("save prefs", `INSERT INTO prefs (key, prefs) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET prefs = excluded.prefs`, key, string(raw))
+}
+
+// RecordVisit counts a new visit under key at the given time and returns
+// the history as it was before it, so a first visit has a zero Count
+func (s *SQLiteStore) RecordVisit(key string, at time.Time) (Visits, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Visits{}, fmt.Errorf("failed to record visit: %w", err)
+	}
+	defer tx.Rollback()
+
+	var prev Visits
+	var first, last int64
+	err = tx.QueryRow(`SELECT count, first_seen, last_seen FROM visits WHERE key = ?`, key).Scan(&prev.Count, &first, &last)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		first = at.UnixNano()
+	case err != nil:
+		return Visits{}, fmt.Errorf("failed to record visit: %w", err)
+	default:
+		prev.FirstSeen, prev.LastSeen = time.Unix(0, first), time.Unix(0, last)
+	}
+	if _, err := tx.Exec(`INSERT INTO visits (key, count, first_seen, last_seen) VALUES (?, ?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET count = excluded.count, last_seen = excluded.last_seen`,
+		key, prev.Count+1, first, at.UnixNano()); err != nil {
+		return Visits{}, fmt.Errorf("failed to record visit: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return Visits{}, fmt.Errorf("failed to record visit: %w", err)
+	}
+	return prev, nil
+}
+
+// ForgetVisits deletes the visit history under key, along with the
+// content version last seen
+func (s *SQLiteStore) ForgetVisits(key string) error {
+	if err := s.exec("forget visits", `DELETE FROM visits WHERE key = ?`, key); err != nil {
+		return err
+	}
+	return s.exec("forget visits", `DELETE FROM seen WHERE key = ?`, key)
+}
+
+// RecordContentSeen notes that the visitor under key has seen the given
+// content version and returns the one they saw before, or ""
+func (s *SQLiteStore) RecordContentSeen(key, version string) (string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to record content seen: %w", err)
+	}
+	defer tx.Rollback()
+
+	var prev string
+	err = tx.QueryRow(`SELECT version FROM seen WHERE key = ?`, key).Scan(&prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to record content seen: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT INTO seen (key, version) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET version = excluded.version`, key, version); err != nil {
+		return "", fmt.Errorf("failed to record content seen: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to record content seen: %w", err)
+	}
+	return prev, nil
+}
+
+// ArchiveContent keeps data under version; a version already kept is
+// left as it is
+func (s *SQLiteStore) ArchiveContent(version string, data []byte) error {
+	return s.exec("archive content", `INSERT INTO contents (version, data) VALUES (?, ?)
+		ON CONFLICT (version) DO NOTHING`, version, data)
+}
+
+// ArchivedContent returns the content kept under version, or nil
+func (s *SQLiteStore) ArchivedContent(version string) ([]byte, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM contents WHERE version = ?`, version).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived content: %w", err)
+	}
+	return data, nil
+}
+
+// HighScore returns the best score saved under key for game, or 0
+func (s *SQLiteStore) HighScore(key, game string) (int, error) {
+	var best int
+	err := s.db.QueryRow(`SELECT score FROM scores WHERE key = ? AND game = ?`, key, game).Scan(&best)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to read score: %w", err)
+	}
+	return best, nil
+}
+
+// RecordScore saves score under key for game if it beats the best so far,
+// and returns the best score after it
+func (s *SQLiteStore) RecordScore(key, game string, score int) (int, error) {
+	var best int
+	err := s.db.QueryRow(`INSERT INTO scores (key, game, score) VALUES (?, ?, ?)
+		ON CONFLICT (key, game) DO UPDATE SET score = max(score, excluded.score)
+		RETURNING score`, key, game, score).Scan(&best)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record score: %w", err)
+	}
+	return best, nil
+}
+
+// Bookmarks returns the project IDs bookmarked under key, in the order
+// they were added, or nil
+func (s *SQLiteStore) Bookmarks(key string) ([]string, error) {
+	var raw string
+	err := s.db.QueryRow(`SELECT ids FROM bookmarks WHERE key = ?`, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(raw), &ids); err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+	return ids, nil
+}
+
+// SaveBookmarks replaces the project IDs bookmarked under key; none
+// deletes the entry
+func (s *SQLiteStore) SaveBookmarks(key string, ids []string) error {
+	if len(ids) == 0 {
+		return s.exec("save bookmarks", `DELETE FROM bookmarks WHERE key = ?`, key)
+	}
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return s.exec("save bookmarks", `INSERT INTO bookmarks (key, ids) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET ids = excluded.ids`, key, string(raw))
+}
+
+// SaveFeedback keeps a /feedback submission, dropping the oldest past
+// maxFeedback
+func (s *SQLiteStore) SaveFeedback(f Feedback) error {
+	if err := s.exec("save feedback", `INSERT INTO feedback (rating, text, visitor, at) VALUES (?, ?, ?, ?)`,
+		f.Rating, f.Text, f.Visitor, f.At.UnixNano()); err != nil {
+		return err
+	}
+	return s.exec("save feedback", `DELETE FROM feedback WHERE id <= (SELECT MAX(id) FROM feedback) - ?`, maxFeedback)
+}
+
+// RecentFeedback returns up to n submissions, newest first
+func (s *SQLiteStore) RecentFeedback(n int) ([]Feedback, error) {
+	rows, err := s.db.Query(`SELECT rating, text, visitor, at FROM feedback ORDER BY id DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var recent []Feedback
+	for rows.Next() {
+		var f Feedback
+		var at int64
+		if err := rows.Scan(&f.Rating, &f.Text, &f.Visitor, &at); err != nil {
+			return nil, fmt.Errorf("failed to read feedback: %w", err)
+		}
+		f.At = time.Unix(0, at)
+		recent = append(recent, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feedback: %w", err)
+	}
+	return recent, nil
+}
+
+// SaveSnapshot replaces the session snapshot under key; empty data
+// deletes it
+func (s *SQLiteStore) SaveSnapshot(key string, data []byte, at time.Time) error {
+	if len(data) == 0 {
+		return s.exec("save snapshot", `DELETE FROM snapshots WHERE key = ?`, key)
+	}
+	return s.exec("save snapshot", `INSERT INTO snapshots (key, data, saved_at) VALUES (?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET data = excluded.data, saved_at = excluded.saved_at`,
+		key, data, at.UnixNano())
+}
+
+// TakeSnapshot removes and returns the snapshot under key if it was saved
+// at or after since. Snapshots of every key older than since are deleted
+// along the way.
+func (s *SQLiteStore) TakeSnapshot(key string, since time.Time) ([]byte, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	var data []byte
+	var savedAt int64
+	err = tx.QueryRow(`SELECT data, saved_at FROM snapshots WHERE key = ?`, key).Scan(&data, &savedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM snapshots WHERE key = ? OR saved_at < ?`, key, since.UnixNano()); err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	if data == nil || savedAt < since.UnixNano() {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// Count adds one to the named all-time total and returns the new total
+func (s *SQLiteStore) Count(name string) (int, error) {
+	if err := validCounterName(name); err != nil {
+		return 0, err
+	}
+	var total int
+	err := s.db.QueryRow(`INSERT INTO counters (name, total) VALUES (?, 1)
+		ON CONFLICT (name) DO UPDATE SET total = total + 1
+		RETURNING total`, name).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", name, err)
+	}
+	return total, nil
+}
+
+// Total returns the named all-time total, 0 if nothing was counted yet
+func (s *SQLiteStore) Total(name string) int {
+	var total int
+	_ = s.db.QueryRow(`SELECT total FROM counters WHERE name = ?`, name).Scan(&total)
+	return total
+}
+
+// Hit counts one event under key in the current window and returns the
+// events so far in it
+func (s *SQLiteStore) Hit(key string, window time.Duration) (int, error) {
+	now := s.now()
+	s.prune(now)
+	end := now.Truncate(window).Add(window).UnixNano()
+	var count int
+	err := s.db.QueryRow(`INSERT INTO hits (key, window_end, count) VALUES (?, ?, 1)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN window_end = excluded.window_end THEN count + 1 ELSE 1 END,
+			window_end = excluded.window_end
+		RETURNING count`, key, end).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count hit: %w", err)
+	}
+	return count, nil
+}
+
+// Acquire takes one of limit slots under key and reports whether one was
+// free
+func (s *SQLiteStore) Acquire(key string, limit int) (bool, error) {
+	now := s.now()
+	s.prune(now)
+	var held int
+	err := s.db.QueryRow(`INSERT INTO slots (key, held, expires) VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			held = CASE WHEN expires <= ? THEN 1 ELSE held + 1 END,
+			expires = excluded.expires
+		WHERE expires <= ? OR held < ?
+		RETURNING held`, key, now.Add(slotExpiry).UnixNano(), now.UnixNano(), now.UnixNano(), limit).Scan(&held)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire slot: %w", err)
+	}
+	return true, nil
+}
+
+// Release returns a slot taken under key
+func (s *SQLiteStore) Release(key string) error {
+	return s.exec("release slot", `UPDATE slots SET held = held - 1 WHERE key = ? AND held > 0`, key)
+}
+
+// Presence registers this process in the presence table
+func (s *SQLiteStore) Presence() (Presence, error) {
+	return newSharedPresence(sqliteBoard{s.db})
+}
+
+// sqliteBoard keeps each process's session count as a presence row
+type sqliteBoard struct{ db *sql.DB }
+
+func (b sqliteBoard) publish(process string, sessions int, at time.Time) error {
+	_, err := b.db.Exec(`INSERT INTO presence (process, sessions, seen_at) VALUES (?, ?, ?)
+		ON CONFLICT (process) DO UPDATE SET sessions = excluded.sessions, seen_at = excluded.seen_at`,
+		process, sessions, at.UnixNano())
+	return err
+}
+
+func (b sqliteBoard) remove(process string) error {
+	_, err := b.db.Exec(`DELETE FROM presence WHERE process = ?`, process)
+	return err
+}
+
+func (b sqliteBoard) sum(since time.Time) (int, error) {
+	if _, err := b.db.Exec(`DELETE FROM presence WHERE seen_at < ?`, since.UnixNano()); err != nil {
+		return 0, err
+	}
+	var live int
+	err := b.db.QueryRow(`SELECT COALESCE(SUM(sessions), 0) FROM presence`).Scan(&live)
+	return live, err
+}
+
+// prune deletes ended rate-limit windows and expired or empty slots, at
+// most once per sqlitePruneInterval
+func (s *SQLiteStore) prune(now time.Time) {
+	s.mu.Lock()
+	due := now.Sub(s.prunedAt) >= sqlitePruneInterval
+	if due {
+		s.prunedAt = now
+	}
+	s.mu.Unlock()
+	if due {
+		_, _ = s.db.Exec(`DELETE FROM hits WHERE window_end <= ?`, now.UnixNano())
+		_, _ = s.db.Exec(`DELETE FROM slots WHERE expires <= ? OR held <= 0`, now.UnixNano())
+	}
+}
+
+// Close closes the database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) exec(what, query string, args ...any) error {
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefs maps setting names to their values, e.g. "theme" to "synthwave"
+type Prefs map[string]string
+
+// Visits is one visitor's connection history
+type Visits struct {
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Scores maps game names to a visitor's best score, e.g. "snake" to 42
+type Scores map[string]int
+
+// Feedback is one /feedback submission: a rating out of 5 and an
+// optional comment. Visitor is the sender's opaque key, or "" without one.
+type Feedback struct {
+	Rating  int       `json:"rating"`
+	Text    string    `json:"text,omitempty"`
+	Visitor string    `json:"visitor,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// maxFeedback is how many submissions the store keeps; older ones go
+const maxFeedback = 1000
+
+// Snapshot is a session's state, saved so a dropped connection can pick
+// up where it left off. Data is opaque to the store.
+type Snapshot struct {
+	Data    json.RawMessage `json:"data"`
+	SavedAt time.Time       `json:"saved_at"`
+}
+
+// fileData is the JSON layout of the store file; every map but Contents
+// is keyed by an opaque visitor hash
+type fileData struct {
+	Prefs     map[string]Prefs    `json:"prefs"`
+	Visits    map[string]Visits   `json:"visits"`
+	Scores    map[string]Scores   `json:"scores,omitempty"`
+	Bookmarks map[string][]string `json:"bookmarks,omitempty"`
+	// Seen is the content version each visitor saw last
+	Seen map[string]string `json:"seen,omitempty"`
+	// Contents maps content versions to what the content was
+	Contents map[string]json.RawMessage `json:"contents,omitempty"`
+	// Feedback lists /feedback submissions, oldest first
+	Feedback []Feedback `json:"feedback,omitempty"`
+}
+
+// FileStore keeps every visitor's preferences and visit history in one
+// JSON file. The file is read once and rewritten on each change. Session
+// snapshots, saved far more often, get a file each in a directory beside
+// it.
+type FileStore struct {
+	path string
+
+	mu   sync.Mutex
+	data fileData
+}
+
+// NewFileStore opens the store at path. A missing file is an empty store;
+// one that can't be parsed is an error rather than silently discarded.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &s.data); err != nil {
+			return nil, fmt.Errorf("failed to parse store %s: %w", path, err)
+		}
+	}
+	if s.data.Prefs == nil {
+		s.data.Prefs = make(map[string]Prefs)
+	}
+	if s.data.Visits == nil {
+		s.data.Visits = make(map[string]Visits)
+	}
+	if s.data.Scores == nil {
+		s.data.Scores = make(map[string]Scores)
+	}
+	if s.data.Bookmarks == nil {
+		s.data.Bookmarks = make(map[string][]string)
+	}
+	if s.data.Seen == nil {
+		s.data.Seen = make(map[string]string)
+	}
+	if s.data.Contents == nil {
+		s.data.Contents = make(map[string]json.RawMessage)
+	}
+	return s, nil
+}
+
+// Load returns a copy of the preferences saved under key, or nil
+func (s *FileStore) Load(key string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.data.Prefs[key]), nil
+}
+
+// Save replaces the preferences under key; empty prefs delete the entry
+func (s *FileStore) Save(key string, prefs map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(prefs) == 0 {
+		delete(s.data.Prefs, key)
+	} else {
+		s.data.Prefs[key] = maps.Clone(prefs)
+	}
+	return s.write()
+}
+
+// RecordVisit counts a new visit under key at the given time and returns
+// the history as it was before it, so a first visit has a zero Count
+func (s *FileStore) RecordVisit(key string, at time.Time) (Visits, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.data.Visits[key]
+	next := prev
+	next.Count++
+	next.LastSeen = at
+	if next.FirstSeen.IsZero() {
+		next.FirstSeen = at
+	}
+	s.data.Visits[key] = next
+	return prev, s.write()
+}
+
+// ForgetVisits deletes the visit history under key, along with the
+// content version last seen
+func (s *FileStore) ForgetVisits(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, visited := s.data.Visits[key]
+	_, seen := s.data.Seen[key]
+	if !visited && !seen {
+		return nil
+	}
+	delete(s.data.Visits, key)
+	delete(s.data.Seen, key)
+	return s.write()
+}
+
+// RecordContentSeen notes that the visitor under key has seen the given
+// content version and returns the one they saw before, or ""
+func (s *FileStore) RecordContentSeen(key, version string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.data.Seen[key]
+	if prev == version {
+		return prev, nil
+	}
+	s.data.Seen[key] = version
+	return prev, s.write()
+}
+
+// ArchiveContent keeps data, JSON describing the content, under its
+// version; a version already kept is left as it is
+func (s *FileStore) ArchiveContent(version string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Contents[version]; ok {
+		return nil
+	}
+	s.data.Contents[version] = append(json.RawMessage(nil), data...)
+	return s.write()
+}
+
+// ArchivedContent returns the content kept under version, or nil
+func (s *FileStore) ArchivedContent(version string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Contents[version], nil
+}
+
+// HighScore returns the best score saved under key for game, or 0
+func (s *FileStore) HighScore(key, game string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Scores[key][game], nil
+}
+
+// RecordScore saves score under key for game if it beats the best so far,
+// and returns the best score after it
+func (s *FileStore) RecordScore(key, game string, score int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	best := s.data.Scores[key][game]
+	if score <= best {
+		return best, nil
+	}
+	if s.data.Scores[key] == nil {
+		s.data.Scores[key] = make(Scores)
+	}
+	s.data.Scores[key][game] = score
+	return score, s.write()
+}
+
+// Bookmarks returns a copy of the project IDs bookmarked under key, in
+// the order they were added, or nil
+func (s *FileStore) Bookmarks(key string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.data.Bookmarks[key]), nil
+}
+
+// SaveBookmarks replaces the project IDs bookmarked under key; none
+// deletes the entry
+func (s *FileStore) SaveBookmarks(key string, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(ids) == 0 {
+		if _, ok := s.data.Bookmarks[key]; !ok {
+			return nil
+		}
+		delete(s.data.Bookmarks, key)
+	} else {
+		s.data.Bookmarks[key] = slices.Clone(ids)
+	}
+	return s.write()
+}
+
+// SaveFeedback keeps a /feedback submission, dropping the oldest past
+// maxFeedback
+func (s *FileStore) SaveFeedback(f Feedback) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Feedback = append(s.data.Feedback, f)
+	if over := len(s.data.Feedback) - maxFeedback; over > 0 {
+		s.data.Feedback = slices.Clone(s.data.Feedback[over:])
+	}
+	return s.write()
+}
+
+// RecentFeedback returns up to n submissions, newest first
+func (s *FileStore) RecentFeedback(n int) ([]Feedback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	recent := slices.Clone(s.data.Feedback[max(len(s.data.Feedback)-n, 0):])
+	slices.Reverse(recent)
+	return recent, nil
+}
+
+// SaveSnapshot replaces the session snapshot under key; empty data
+// deletes it. Only the key's own file is written.
+func (s *FileStore) SaveSnapshot(key string, data []byte, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.snapshotPath(key)
+	if len(data) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+		return nil
+	}
+	raw, err := json.Marshal(Snapshot{Data: data, SavedAt: at})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	// The file's time is when it was saved, for sweeping without reading it
+	if err := os.Chtimes(tmp, at, at); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// TakeSnapshot removes and returns the snapshot under key if it was saved
+// at or after since, so a session is resumed once. Snapshots of every key
+// older than since are deleted along the way.
+func (s *FileStore) TakeSnapshot(key string, since time.Time) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.snapshotPath(key)
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err == nil {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && info.ModTime().Before(since) {
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+
+	var snap Snapshot
+	if raw == nil || json.Unmarshal(raw, &snap) != nil || snap.SavedAt.Before(since) {
+		return nil, nil
+	}
+	return snap.Data, nil
+}
+
+// snapshotPath is the file of key's snapshot, named by a hash of the key
+// in the snapshots directory beside the store file
+func (s *FileStore) snapshotPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	base := strings.TrimSuffix(filepath.Base(s.path), filepath.Ext(s.path))
+	return filepath.Join(filepath.Dir(s.path), base+"-snapshots", hex.EncodeToString(sum[:16])+".json")
+}
+
+// write saves the whole store; the caller holds mu
+func (s *FileStore) write() error {
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save store: %w", err)
+	}
+	// Write then rename, so a crash never leaves a half-written file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to save store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save store: %w", err)
+	}
+	return nil
+}
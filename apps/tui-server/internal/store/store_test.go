@@ -0,0 +1,430 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestFileStorePersistsAcrossOpens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "prefs.json")
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := s.Save("key:abc", map[string]string{"theme": "synthwave", "mouse": "off"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopen error = %v", err)
+	}
+	prefs, _ := reopened.Load("key:abc")
+	if prefs["theme"] != "synthwave" || prefs["mouse"] != "off" {
+		t.Fatalf("Load() = %v, want saved prefs", prefs)
+	}
+
+	// Callers get a copy they can't use to change the store
+	prefs["theme"] = "cyberpunk"
+	if again, _ := reopened.Load("key:abc"); again["theme"] != "synthwave" {
+		t.Fatalf("Load() shares its map with callers")
+	}
+
+	if err := reopened.Save("key:abc", nil); err != nil {
+		t.Fatalf("Save(nil) error = %v", err)
+	}
+	if prefs, _ := reopened.Load("key:abc"); prefs != nil {
+		t.Fatalf("Load() after clearing = %v, want nil", prefs)
+	}
+}
+
+func TestFileStoreRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefs.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Fatal("NewFileStore() on a corrupt file should fail")
+	}
+}
+
+func TestFileStoreCountsVisits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	first := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if prev, _ := s.RecordVisit("key:abc", first); prev.Count != 0 {
+		t.Fatalf("first RecordVisit() = %+v, want no history", prev)
+	}
+	s.RecordVisit("key:abc", first.Add(24*time.Hour))
+
+	reopened, _ := NewFileStore(path)
+	prev, err := reopened.RecordVisit("key:abc", first.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("RecordVisit() error = %v", err)
+	}
+	if prev.Count != 2 || !prev.FirstSeen.Equal(first) || !prev.LastSeen.Equal(first.Add(24*time.Hour)) {
+		t.Fatalf("RecordVisit() = %+v, want 2 visits first seen %v", prev, first)
+	}
+
+	if err := reopened.ForgetVisits("key:abc"); err != nil {
+		t.Fatalf("ForgetVisits() error = %v", err)
+	}
+	if prev, _ := reopened.RecordVisit("key:abc", first); prev.Count != 0 {
+		t.Fatalf("RecordVisit() after forgetting = %+v, want no history", prev)
+	}
+}
+
+func TestFileStoreKeepsBestScore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefs.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	for _, tc := range []struct{ score, best int }{{12, 12}, {7, 12}, {30, 30}} {
+		best, err := s.RecordScore("key:abc", "snake", tc.score)
+		if err != nil {
+			t.Fatalf("RecordScore(%d) error = %v", tc.score, err)
+		}
+		if best != tc.best {
+			t.Fatalf("RecordScore(%d) = %d, want %d", tc.score, best, tc.best)
+		}
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopen error = %v", err)
+	}
+	if best, _ := reopened.HighScore("key:abc", "snake"); best != 30 {
+		t.Fatalf("HighScore() after reopen = %d, want 30", best)
+	}
+	if best, _ := reopened.HighScore("key:other", "snake"); best != 0 {
+		t.Fatalf("HighScore() of another key = %d, want 0", best)
+	}
+}
+
+func TestFileStoreTakesSnapshotOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefs.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.SaveSnapshot("key:abc", []byte(`{"view":2}`), now); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+	if err := s.SaveSnapshot("key:old", []byte(`{"view":1}`), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("saving snapshots wrote the store file: %v", err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopen error = %v", err)
+	}
+	since := now.Add(-15 * time.Minute)
+	data, err := reopened.TakeSnapshot("key:abc", since)
+	var state struct{ View int }
+	if err != nil || json.Unmarshal(data, &state) != nil || state.View != 2 {
+		t.Fatalf("TakeSnapshot() = %q, %v, want the saved state", data, err)
+	}
+	if data, _ := reopened.TakeSnapshot("key:abc", since); data != nil {
+		t.Fatalf("TakeSnapshot() again = %q, want nil", data)
+	}
+	if _, err := os.Stat(reopened.snapshotPath("key:old")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("a snapshot older than the window was kept")
+	}
+}
+
+func TestRegistrySharesCountsAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	a, err := NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	// Another process on the same volume, stood in for by a second file
+	b := &Registry{dir: dir, file: filepath.Join(dir, "other-1.json")}
+	b.write()
+
+	leaveA := a.Join()
+	a.Join()
+	b.Join()
+	if live := a.Live(); live != 3 {
+		t.Fatalf("Live() = %d, want 3", live)
+	}
+	leaveA()
+	leaveA()
+	if live := a.Live(); live != 2 {
+		t.Fatalf("Live() after leaving twice = %d, want 2", live)
+	}
+
+	a.CountVisit()
+	if total, _ := b.CountVisit(); total != 2 {
+		t.Fatalf("CountVisit() = %d, want 2", total)
+	}
+}
+
+func TestRegistryCounters(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	r.Count("project.echo")
+	if total, _ := r.Count("project.echo"); total != 2 {
+		t.Fatalf("Count() = %d, want 2", total)
+	}
+	if total := r.Total("project.echo"); total != 2 {
+		t.Fatalf("Total() = %d, want 2", total)
+	}
+	if total := r.Total("questions"); total != 0 {
+		t.Fatalf("Total() of an unused counter = %d, want 0", total)
+	}
+	if _, err := r.Count("../escape"); err == nil {
+		t.Fatal("Count() accepted a name with a path in it")
+	}
+}
+
+// backends opens every Store implementation on a fresh location, each
+// with its clock set to now
+func backends(t *testing.T, now time.Time) map[string]Store {
+	t.Helper()
+	clock := func() time.Time { return now }
+
+	file, err := Open(Config{Backend: BackendFile, Path: filepath.Join(t.TempDir(), "store.json")})
+	if err != nil {
+		t.Fatalf("Open(file) error = %v", err)
+	}
+	file.(*fileBackend).limiter.now = clock
+
+	sqlite, err := NewSQLiteStore(filepath.Join(t.TempDir(), "store.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore() error = %v", err)
+	}
+	sqlite.now = clock
+
+	mr := miniredis.RunT(t)
+	mr.SetTime(now)
+	redis, err := NewRedisStore("redis://" + mr.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisStore() error = %v", err)
+	}
+	redis.now = clock
+
+	stores := map[string]Store{"file": file, "sqlite": sqlite, "redis": redis}
+	t.Cleanup(func() {
+		for _, s := range stores {
+			s.Close()
+		}
+	})
+	return stores
+}
+
+func TestBackendsBehaveAlike(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 30, 0, time.UTC)
+	for name, s := range backends(t, now) {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Save("key:abc", map[string]string{"theme": "synthwave"}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if prefs, _ := s.Load("key:abc"); prefs["theme"] != "synthwave" || len(prefs) != 1 {
+				t.Errorf("Load() = %v, want the saved theme", prefs)
+			}
+			s.Save("key:abc", nil)
+			if prefs, _ := s.Load("key:abc"); prefs != nil {
+				t.Errorf("Load() after saving nothing = %v, want nil", prefs)
+			}
+
+			if prev, _ := s.RecordVisit("key:abc", now.Add(-time.Hour)); prev.Count != 0 {
+				t.Errorf("first RecordVisit() = %+v, want a zero history", prev)
+			}
+			prev, err := s.RecordVisit("key:abc", now)
+			if err != nil || prev.Count != 1 || !prev.LastSeen.Equal(now.Add(-time.Hour)) {
+				t.Errorf("second RecordVisit() = %+v, %v, want one visit an hour ago", prev, err)
+			}
+			if prev, err := s.RecordContentSeen("key:abc", "v1"); prev != "" || err != nil {
+				t.Errorf("first RecordContentSeen() = %q, %v, want nothing seen", prev, err)
+			}
+			if prev, _ := s.RecordContentSeen("key:abc", "v2"); prev != "v1" {
+				t.Errorf("RecordContentSeen() = %q, want v1", prev)
+			}
+			s.ForgetVisits("key:abc")
+			if prev, _ := s.RecordVisit("key:abc", now); prev.Count != 0 {
+				t.Errorf("RecordVisit() after ForgetVisits() = %+v, want a zero history", prev)
+			}
+			if prev, _ := s.RecordContentSeen("key:abc", "v2"); prev != "" {
+				t.Errorf("RecordContentSeen() after ForgetVisits() = %q, want nothing seen", prev)
+			}
+
+			s.ArchiveContent("v1", []byte(`{"facts":1}`))
+			s.ArchiveContent("v1", []byte(`{"facts":2}`))
+			if data, err := s.ArchivedContent("v1"); string(data) != `{"facts":1}` || err != nil {
+				t.Errorf("ArchivedContent() = %q, %v, want the first archived", data, err)
+			}
+			if data, _ := s.ArchivedContent("v9"); data != nil {
+				t.Errorf("ArchivedContent() of an unknown version = %q, want nil", data)
+			}
+
+			s.RecordScore("key:abc", "snake", 30)
+			if best, _ := s.RecordScore("key:abc", "snake", 12); best != 30 {
+				t.Errorf("RecordScore() of a lower score = %d, want 30", best)
+			}
+			if best, _ := s.HighScore("key:abc", "quiz"); best != 0 {
+				t.Errorf("HighScore() of an unplayed game = %d, want 0", best)
+			}
+
+			s.SaveBookmarks("key:abc", []string{"echo", "uncut"})
+			if ids, err := s.Bookmarks("key:abc"); !slices.Equal(ids, []string{"echo", "uncut"}) || err != nil {
+				t.Errorf("Bookmarks() = %v, %v, want echo and uncut in order", ids, err)
+			}
+			s.SaveBookmarks("key:abc", nil)
+			if ids, _ := s.Bookmarks("key:abc"); ids != nil {
+				t.Errorf("Bookmarks() after saving none = %v, want nil", ids)
+			}
+
+			s.SaveFeedback(Feedback{Rating: 4, Text: "Neat", Visitor: "key:abc", At: now.Add(-time.Minute)})
+			s.SaveFeedback(Feedback{Rating: 2, At: now})
+			recent, err := s.RecentFeedback(5)
+			if err != nil || len(recent) != 2 || recent[0].Rating != 2 || recent[1].Text != "Neat" || !recent[1].At.Equal(now.Add(-time.Minute)) {
+				t.Errorf("RecentFeedback() = %+v, %v, want both, newest first", recent, err)
+			}
+			if recent, _ := s.RecentFeedback(1); len(recent) != 1 || recent[0].Rating != 2 {
+				t.Errorf("RecentFeedback(1) = %+v, want the newest", recent)
+			}
+
+			s.SaveSnapshot("key:abc", []byte(`{"view":2}`), now)
+			if data, _ := s.TakeSnapshot("key:abc", now.Add(-time.Minute)); data == nil {
+				t.Error("TakeSnapshot() = nil, want the saved state")
+			}
+			if data, _ := s.TakeSnapshot("key:abc", now.Add(-time.Minute)); data != nil {
+				t.Error("TakeSnapshot() again should find nothing")
+			}
+			s.SaveSnapshot("key:abc", []byte(`{"view":2}`), now.Add(-time.Hour))
+			if data, _ := s.TakeSnapshot("key:abc", now.Add(-time.Minute)); data != nil {
+				t.Error("TakeSnapshot() returned a snapshot older than the window")
+			}
+
+			s.Count("visits")
+			if total, _ := s.Count("visits"); total != 2 || s.Total("visits") != 2 {
+				t.Errorf("Count() = %d, Total() = %d, want 2", total, s.Total("visits"))
+			}
+			if _, err := s.Count("../escape"); err == nil {
+				t.Error("Count() accepted a name with a path in it")
+			}
+
+			for want := 1; want <= 3; want++ {
+				if got, err := s.Hit("ip:1", time.Minute); err != nil || got != want {
+					t.Errorf("Hit() = %d, %v, want %d", got, err, want)
+				}
+			}
+			if got, _ := s.Hit("ip:2", time.Minute); got != 1 {
+				t.Errorf("Hit() of another key = %d, want 1", got)
+			}
+
+			for i := 0; i < 2; i++ {
+				if ok, err := s.Acquire("sessions:ip1", 2); !ok || err != nil {
+					t.Errorf("Acquire() %d = %v, %v, want a slot", i+1, ok, err)
+				}
+			}
+			if ok, _ := s.Acquire("sessions:ip1", 2); ok {
+				t.Error("Acquire() past the limit should fail")
+			}
+			s.Release("sessions:ip1")
+			if ok, _ := s.Acquire("sessions:ip1", 2); !ok {
+				t.Error("Acquire() after Release() should get the freed slot")
+			}
+		})
+	}
+}
+
+func TestPresenceSharedAcrossProcesses(t *testing.T) {
+	for name, s := range backends(t, time.Now()) {
+		if name == "file" {
+			continue // the Registry, covered above
+		}
+		t.Run(name, func(t *testing.T) {
+			// Each Presence stands in for a replica
+			a, err := s.Presence()
+			if err != nil {
+				t.Fatalf("Presence() error = %v", err)
+			}
+			b, _ := s.Presence()
+
+			leaveA := a.Join()
+			b.Join()
+			b.Join()
+			if live := a.Live(); live != 3 {
+				t.Fatalf("Live() = %d, want 3", live)
+			}
+			leaveA()
+			leaveA()
+			if live := b.Live(); live != 2 {
+				t.Fatalf("Live() after leaving twice = %d, want 2", live)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() { b.Run(ctx); close(done) }()
+			cancel()
+			<-done
+			a.(*sharedPresence).readAt = time.Time{}
+			if live := a.Live(); live != 0 {
+				t.Fatalf("Live() after the other replica stopped = %d, want 0", live)
+			}
+		})
+	}
+}
+
+func TestSQLiteCountsVisitsAcrossProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.db")
+	// Two processes on the same file, stood in for by two stores
+	var stores [2]*SQLiteStore
+	for i := range stores {
+		s, err := NewSQLiteStore(path)
+		if err != nil {
+			t.Fatalf("NewSQLiteStore() error = %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		stores[i] = s
+	}
+
+	const visits = 40
+	counts := make(chan int, visits)
+	var wg sync.WaitGroup
+	for i := range visits {
+		wg.Go(func() {
+			prev, err := stores[i%2].RecordVisit("key:abc", time.Now())
+			if err != nil {
+				t.Errorf("RecordVisit() error = %v", err)
+			}
+			counts <- prev.Count
+		})
+	}
+	wg.Wait()
+	close(counts)
+
+	seen := map[int]bool{}
+	for c := range counts {
+		if seen[c] {
+			t.Errorf("two visits both came after %d others", c)
+		}
+		seen[c] = true
+	}
+}
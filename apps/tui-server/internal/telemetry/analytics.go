@@ -4,8 +4,10 @@ import (
 	"os"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
 	"github.com/posthog/posthog-go"
 )
 
@@ -14,8 +16,28 @@ type Analytics struct {
 	client posthog.Client
 	logger *Logger
 	mu     sync.Mutex
+
+	// buffer holds events PostHog couldn't take until it's back; nil
+	// drops them
+	buffer *EventBuffer
+	replay chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
 }
 
+const (
+	// bufferMaxEvents bounds the outage buffer; past it the oldest go
+	bufferMaxEvents = 10000
+	// replayBatch is how many buffered events are sent again at a time
+	replayBatch = 500
+	// replayInterval retries the buffer when no live event has gone
+	// through to say PostHog is back
+	replayInterval = time.Minute
+	// ratedTextLimit caps, in runes, the question and answer sent with a
+	// rating
+	ratedTextLimit = 2000
+)
+
 // Event types
 const (
 	EventSessionConnected    = "tui_session_connected"
@@ -25,16 +47,21 @@ const (
 	EventChatSent            = "tui_chat_sent"
 	EventChatReceived        = "tui_chat_received"
 	EventChatError           = "tui_chat_error"
+	EventEasterEggFound      = "tui_easter_egg_found"
+	EventAnswerRated         = "tui_answer_rated"
 	EventServerStart         = "tui_server_start"
 	EventServerStop          = "tui_server_stop"
 	EventAIRequest           = "ai_gateway_chat_request"
 	EventAIResponse          = "ai_gateway_chat_response"
 	EventAIError             = "ai_gateway_chat_error"
 	EventAIRateLimitHit      = "ai_gateway_rate_limit_hit"
+	EventAIMessageFiltered   = "ai_gateway_message_filtered"
 )
 
-// NewAnalytics creates a new Analytics instance
-func NewAnalytics(logger *Logger) *Analytics {
+// NewAnalytics creates a new Analytics instance. Events PostHog can't
+// take are kept in a buffer at bufferPath and sent again once it's back;
+// an empty bufferPath drops them.
+func NewAnalytics(logger *Logger, bufferPath string) *Analytics {
 	apiKey := os.Getenv("POSTHOG_API_KEY")
 	host := os.Getenv("POSTHOG_HOST")
 	if host == "" {
@@ -50,11 +77,21 @@ func NewAnalytics(logger *Logger) *Analytics {
 		return a
 	}
 
+	if bufferPath != "" {
+		buffer, err := NewEventBuffer(bufferPath, bufferMaxEvents)
+		if err != nil {
+			logger.Error("Analytics buffer disabled", Ctx("error", err.Error()))
+		} else {
+			a.buffer = buffer
+		}
+	}
+
 	client, err := posthog.NewWithConfig(apiKey, posthog.Config{
 		Endpoint:  host,
 		BatchSize: 10,
 		Interval:  5 * time.Second,
 		Transport: network.NewHTTPTransport(),
+		Callback:  a,
 	})
 
 	if err != nil {
@@ -65,12 +102,24 @@ func NewAnalytics(logger *Logger) *Analytics {
 	a.client = client
 	logger.Info("PostHog analytics initialized", Ctx("host", host))
 
+	if a.buffer != nil {
+		a.replay = make(chan struct{}, 1)
+		a.stop = make(chan struct{})
+		a.done = make(chan struct{})
+		go a.replayLoop()
+		if depth := a.buffer.Depth(); depth > 0 {
+			logger.Info("Analytics events buffered by a previous run", Ctx("depth", depth))
+			a.replay <- struct{}{}
+		}
+	}
+
 	return a
 }
 
-// capture sends an event to PostHog
+// capture sends an event to PostHog. A nil Analytics sends nothing, so
+// every Track method is safe to call on one.
 func (a *Analytics) capture(event string, distinctID string, properties posthog.Properties) {
-	if a.client == nil {
+	if a == nil || a.client == nil {
 		return
 	}
 
@@ -83,6 +132,8 @@ func (a *Analytics) capture(event string, distinctID string, properties posthog.
 
 	properties.Set("service", "tui-server")
 	properties.Set("environment", getEnv("NODE_ENV", "development"))
+	properties.Set("version", version.Version)
+	properties.Set("commit", version.Commit)
 
 	err := a.client.Enqueue(posthog.Capture{
 		DistinctId: distinctID,
@@ -135,6 +186,22 @@ func (a *Analytics) TrackCommandExecuted(sessionID string, command string) {
 		Set("command", command))
 }
 
+// TrackEasterEgg tracks a visitor finding a hidden command
+func (a *Analytics) TrackEasterEgg(sessionID string, egg string) {
+	a.capture(EventEasterEggFound, sessionID, posthog.NewProperties().
+		Set("egg", egg))
+}
+
+// TrackAnswerRated tracks a visitor rating an AI answer "up" or "down",
+// with the question it answered, so bad answers can be found and fixed
+func (a *Analytics) TrackAnswerRated(sessionID string, question, answer, rating string, faq bool) {
+	a.capture(EventAnswerRated, sessionID, posthog.NewProperties().
+		Set("question", clip(question, ratedTextLimit)).
+		Set("answer", clip(answer, ratedTextLimit)).
+		Set("rating", rating).
+		Set("faq", faq))
+}
+
 // TrackChatSent tracks when user sends a chat message
 func (a *Analytics) TrackChatSent(sessionID string, messageLength int) {
 	a.capture(EventChatSent, sessionID, posthog.NewProperties().
@@ -183,6 +250,13 @@ func (a *Analytics) TrackAIRateLimit(sessionID string, remaining int) {
 		Set("remaining", remaining))
 }
 
+// TrackAIFiltered tracks a message caught by the pre-send filter.
+func (a *Analytics) TrackAIFiltered(sessionID string, rule string, action string) {
+	a.capture(EventAIMessageFiltered, sessionID, posthog.NewProperties().
+		Set("rule", rule).
+		Set("action", action))
+}
+
 // TrackServerStart tracks server startup
 func (a *Analytics) TrackServerStart(host, port string) {
 	a.capture(EventServerStart, "system", posthog.NewProperties().
@@ -197,7 +271,7 @@ func (a *Analytics) TrackServerStop() {
 
 // Identify associates user properties with a session
 func (a *Analytics) Identify(sessionID string, properties map[string]interface{}) {
-	if a.client == nil {
+	if a == nil || a.client == nil {
 		return
 	}
 
@@ -214,14 +288,110 @@ func (a *Analytics) Identify(sessionID string, properties map[string]interface{}
 
 // Close shuts down the analytics client
 func (a *Analytics) Close() error {
-	if a.client == nil {
+	if a == nil || a.client == nil {
 		return nil
 	}
 
 	a.logger.Info("Shutting down PostHog client")
+	if a.stop != nil {
+		close(a.stop)
+		<-a.done
+	}
+	// Events that fail during the final flush still reach the buffer
 	return a.client.Close()
 }
 
+// Success is called by the client for each event PostHog took. With
+// events waiting in the buffer, it means PostHog is back.
+func (a *Analytics) Success(posthog.APIMessage) {
+	if a.buffer == nil || a.buffer.Depth() == 0 {
+		return
+	}
+	select {
+	case a.replay <- struct{}{}:
+	default:
+	}
+}
+
+// Failure is called by the client for each event it gave up on after
+// retrying; the event is buffered to send again later
+func (a *Analytics) Failure(msg posthog.APIMessage, err error) {
+	if a.buffer == nil {
+		return
+	}
+	event, ok := bufferedFromAPI(msg)
+	if !ok {
+		return
+	}
+	dropped := a.buffer.Dropped()
+	if pushErr := a.buffer.Push(event); pushErr != nil {
+		a.logger.Error("Failed to buffer analytics event", Ctx("error", pushErr.Error()))
+		return
+	}
+	if a.buffer.Dropped() > dropped {
+		a.logger.Warn("Analytics buffer full, dropped oldest events", Ctx(
+			"depth", a.buffer.Depth(),
+			"dropped", a.buffer.Dropped(),
+		))
+	}
+}
+
+// BufferStats reports the events waiting to be sent again and how many
+// were dropped for room since startup
+func (a *Analytics) BufferStats() (depth, dropped int) {
+	if a == nil || a.buffer == nil {
+		return 0, 0
+	}
+	return a.buffer.Depth(), a.buffer.Dropped()
+}
+
+// replayLoop sends buffered events again when PostHog seems back, and
+// every replayInterval in case nothing else is being sent
+func (a *Analytics) replayLoop() {
+	defer close(a.done)
+	ticker := time.NewTicker(replayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-a.replay:
+		case <-ticker.C:
+		}
+		a.replayBuffered()
+	}
+}
+
+// replayBuffered hands a batch of buffered events back to the client.
+// Any that fail again return to the buffer through Failure.
+func (a *Analytics) replayBuffered() {
+	events, err := a.buffer.Take(replayBatch)
+	if err != nil {
+		a.logger.Error("Failed to read analytics buffer", Ctx("error", err.Error()))
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+	a.logger.Info("Replaying buffered analytics events", Ctx(
+		"count", len(events),
+		"depth", a.buffer.Depth(),
+	))
+	for _, e := range events {
+		if err := a.client.Enqueue(e.message()); err != nil {
+			_ = a.buffer.Push(e)
+		}
+	}
+}
+
+// clip cuts s to at most n runes, marking the cut with an ellipsis
+func clip(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,189 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/posthog/posthog-go"
+)
+
+// bufferedEvent is an event PostHog didn't take, as one line of the
+// buffer file. UUID and Timestamp are the originals, so a replayed event
+// keeps its time and can't be counted twice.
+type bufferedEvent struct {
+	Type       string                 `json:"type"` // "capture" or "identify"
+	UUID       string                 `json:"uuid,omitempty"`
+	DistinctID string                 `json:"distinct_id"`
+	Event      string                 `json:"event,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// bufferedFromAPI recovers the event behind a message the client gave up
+// on; messages of other kinds aren't buffered
+func bufferedFromAPI(msg posthog.APIMessage) (bufferedEvent, bool) {
+	switch m := msg.(type) {
+	case posthog.CaptureInApi:
+		return bufferedEvent{Type: "capture", UUID: m.Uuid, DistinctID: m.DistinctId,
+			Event: m.Event, Timestamp: m.Timestamp, Properties: m.Properties}, true
+	case posthog.IdentifyInApi:
+		return bufferedEvent{Type: "identify", UUID: m.Uuid, DistinctID: m.DistinctId,
+			Timestamp: m.Timestamp, Properties: m.Set}, true
+	}
+	return bufferedEvent{}, false
+}
+
+// message rebuilds the event for the client to send again
+func (e bufferedEvent) message() posthog.Message {
+	if e.Type == "identify" {
+		return posthog.Identify{Uuid: e.UUID, DistinctId: e.DistinctID, Timestamp: e.Timestamp, Properties: e.Properties}
+	}
+	return posthog.Capture{Uuid: e.UUID, DistinctId: e.DistinctID, Event: e.Event, Timestamp: e.Timestamp, Properties: e.Properties}
+}
+
+// EventBuffer is a bounded on-disk queue of events PostHog couldn't take,
+// one JSON line each. When full, the oldest events are dropped to make
+// room, so an outage costs the start of it rather than the end.
+type EventBuffer struct {
+	path string
+	max  int
+
+	mu      sync.Mutex
+	depth   int
+	dropped int
+}
+
+// NewEventBuffer opens the buffer at path holding at most max events,
+// picking up events left by a previous run
+func NewEventBuffer(path string, max int) (*EventBuffer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event buffer: %w", err)
+	}
+	b := &EventBuffer{path: path, max: max}
+	events, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	b.depth = len(events)
+	return b, nil
+}
+
+// Push appends events, dropping the oldest beyond the limit
+func (b *EventBuffer) Push(events ...bufferedEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.depth+len(events) <= b.max {
+		f, err := os.OpenFile(b.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to buffer events: %w", err)
+		}
+		defer f.Close()
+		for _, e := range events {
+			line, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			if _, err := f.Write(append(line, '\n')); err != nil {
+				return fmt.Errorf("failed to buffer events: %w", err)
+			}
+			b.depth++
+		}
+		return nil
+	}
+
+	all, err := b.read()
+	if err != nil {
+		return err
+	}
+	all = append(all, events...)
+	if over := len(all) - b.max; over > 0 {
+		b.dropped += over
+		all = all[over:]
+	}
+	return b.write(all)
+}
+
+// Take removes and returns up to n of the oldest events. Taken events are
+// gone from disk, so ones the client fails again must be pushed back.
+func (b *EventBuffer) Take(n int) ([]bufferedEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.depth == 0 {
+		return nil, nil
+	}
+	all, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	n = min(n, len(all))
+	if err := b.write(all[n:]); err != nil {
+		return nil, err
+	}
+	return all[:n], nil
+}
+
+// Depth is how many events are waiting
+func (b *EventBuffer) Depth() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.depth
+}
+
+// Dropped is how many events were dropped for room since startup
+func (b *EventBuffer) Dropped() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// read loads every event; lines that don't parse are skipped. The caller
+// holds mu unless the buffer is still being created.
+func (b *EventBuffer) read() ([]bufferedEvent, error) {
+	raw, err := os.ReadFile(b.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event buffer: %w", err)
+	}
+	var events []bufferedEvent
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var e bufferedEvent
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// write replaces the buffer with events; the caller holds mu
+func (b *EventBuffer) write(events []bufferedEvent) error {
+	var buf bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		buf.Write(append(line, '\n'))
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write event buffer: %w", err)
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		return fmt.Errorf("failed to write event buffer: %w", err)
+	}
+	b.depth = len(events)
+	return nil
+}
@@ -0,0 +1,38 @@
+package telemetry
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventBufferDropsOldestWhenFull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "buffer.jsonl")
+	b, err := NewEventBuffer(path, 3)
+	if err != nil {
+		t.Fatalf("NewEventBuffer() error = %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := b.Push(bufferedEvent{Type: "capture", Event: fmt.Sprintf("e%d", i)}); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	if depth, dropped := b.Depth(), b.Dropped(); depth != 3 || dropped != 2 {
+		t.Fatalf("Depth(), Dropped() = %d, %d, want 3, 2", depth, dropped)
+	}
+
+	reopened, err := NewEventBuffer(path, 3)
+	if err != nil {
+		t.Fatalf("NewEventBuffer() reopen error = %v", err)
+	}
+	events, err := reopened.Take(2)
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Event != "e3" || events[1].Event != "e4" {
+		t.Fatalf("Take(2) = %+v, want e3 and e4", events)
+	}
+	if depth := reopened.Depth(); depth != 1 {
+		t.Fatalf("Depth() after Take = %d, want 1", depth)
+	}
+}
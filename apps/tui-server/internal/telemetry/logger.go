@@ -3,9 +3,12 @@ package telemetry
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
 )
 
 // LogLevel represents the severity of a log entry
@@ -43,6 +46,8 @@ type LogEntry struct {
 	Level     string                 `json:"level"`
 	Message   string                 `json:"message"`
 	Service   string                 `json:"service"`
+	Version   string                 `json:"version"`
+	Commit    string                 `json:"commit,omitempty"`
 	Context   map[string]interface{} `json:"context,omitempty"`
 }
 
@@ -51,6 +56,7 @@ type Logger struct {
 	minLevel   LogLevel
 	service    string
 	jsonFormat bool
+	out        io.Writer
 }
 
 // NewLogger creates a new logger instance
@@ -75,9 +81,15 @@ func NewLogger(service string) *Logger {
 		minLevel:   minLevel,
 		service:    service,
 		jsonFormat: jsonFormat,
+		out:        os.Stderr,
 	}
 }
 
+// SetOutput redirects log lines, such as away from a terminal running the TUI
+func (l *Logger) SetOutput(w io.Writer) {
+	l.out = w
+}
+
 func (l *Logger) shouldLog(level LogLevel) bool {
 	return level >= l.minLevel
 }
@@ -92,12 +104,14 @@ func (l *Logger) log(level LogLevel, message string, context map[string]interfac
 		Level:     levelNames[level],
 		Message:   message,
 		Service:   l.service,
+		Version:   version.Version,
+		Commit:    version.Commit,
 		Context:   context,
 	}
 
 	if l.jsonFormat {
 		data, _ := json.Marshal(entry)
-		fmt.Fprintln(os.Stderr, string(data))
+		fmt.Fprintln(l.out, string(data))
 	} else {
 		// Pretty format
 		var contextStr string
@@ -106,10 +120,10 @@ func (l *Logger) log(level LogLevel, message string, context map[string]interfac
 			contextStr = fmt.Sprintf(" %s%s%s", colorDim, string(data), colorReset)
 		}
 
-		fmt.Fprintf(os.Stderr, "%s%s%s %s%-5s%s %s[%s]%s %s%s\n",
+		fmt.Fprintf(l.out, "%s%s%s %s%-5s%s %s[%s %s]%s %s%s\n",
 			colorDim, entry.Timestamp, colorReset,
 			levelColors[level], entry.Level, colorReset,
-			colorDim, l.service, colorReset,
+			colorDim, l.service, entry.Version, colorReset,
 			message, contextStr)
 	}
 }
@@ -144,6 +158,7 @@ func (l *Logger) With(context map[string]interface{}) *Logger {
 		minLevel:   l.minLevel,
 		service:    l.service,
 		jsonFormat: l.jsonFormat,
+		out:        l.out,
 	}
 }
 
@@ -0,0 +1,71 @@
+// Package textutil measures, cuts, pads and wraps text by display width:
+// the cells a terminal draws, not bytes or runes. ANSI sequences take no
+// cells, wide CJK and emoji take two and combining marks none, and styled
+// text keeps its escape sequences whatever is done to it.
+package textutil
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Ellipsis marks truncated text
+const Ellipsis = "..."
+
+// Width returns the display width of s in terminal cells
+func Width(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Truncate shortens s to at most width cells, ending in an ellipsis when cut.
+// Styled text keeps its escape sequences and never splits a wide character.
+func Truncate(s string, width int) string {
+	if width <= len(Ellipsis) {
+		return Ellipsis[:max(width, 0)]
+	}
+	if Width(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, Ellipsis)
+}
+
+// Pad right-pads s with spaces to exactly width cells, truncating if needed
+func Pad(s string, width int) string {
+	w := Width(s)
+	if w > width {
+		s = Truncate(s, width)
+		w = Width(s)
+	}
+	return s + strings.Repeat(" ", max(0, width-w))
+}
+
+// PadLeft left-pads s with spaces so it ends at width cells, truncating if needed
+func PadLeft(s string, width int) string {
+	w := Width(s)
+	if w > width {
+		s = Truncate(s, width)
+		w = Width(s)
+	}
+	return strings.Repeat(" ", max(0, width-w)) + s
+}
+
+// PadCenter centers s in exactly width cells, truncating if needed
+func PadCenter(s string, width int) string {
+	w := Width(s)
+	if w >= width {
+		return Truncate(s, width)
+	}
+	left := (width - w) / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", width-w-left)
+}
+
+// Center indents s to the middle of width cells without padding the
+// right, for a line on its own. Unlike PadCenter, wider text is left as is.
+func Center(s string, width int) string {
+	w := Width(s)
+	if w >= width {
+		return s
+	}
+	return strings.Repeat(" ", (width-w)/2) + s
+}
@@ -0,0 +1,81 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestWidthCountsCells(t *testing.T) {
+	for s, want := range map[string]int{
+		"abc":                 3,
+		"日本":                  4,
+		"\x1b[1mbold\x1b[0m":  4,
+		"é":                  1,
+		"\x1b[31m日本語\x1b[0m!": 7,
+	} {
+		if got := Width(s); got != want {
+			t.Errorf("Width(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncateAndPad(t *testing.T) {
+	if got := Truncate("日本語テキスト", 8); got != "日本..." {
+		t.Errorf("Truncate wide = %q", got)
+	}
+	if got := Truncate("abc", 2); got != ".." {
+		t.Errorf("Truncate narrow = %q", got)
+	}
+	if got := PadCenter("日本", 7); got != " 日本  " {
+		t.Errorf("PadCenter = %q", got)
+	}
+	if got := Center("hi", 7); got != "  hi" {
+		t.Errorf("Center = %q", got)
+	}
+	if got := Pad("\x1b[1mab\x1b[0m", 4); Width(got) != 4 || !strings.HasPrefix(got, "\x1b[1m") {
+		t.Errorf("Pad styled = %q", got)
+	}
+}
+
+func TestWrapFitsWidth(t *testing.T) {
+	text := "Building 日本語 terminal portfolios with supercalifragilisticexpialidocious Go code"
+	styled := "\x1b[36m" + text + "\x1b[0m"
+	for _, s := range []string{text, styled} {
+		for width := 4; width <= 30; width++ {
+			for _, line := range strings.Split(Wrap(s, width), "\n") {
+				if w := Width(line); w > width {
+					t.Fatalf("Wrap(%q, %d) has a line %d wide: %q", s, width, w, line)
+				}
+			}
+		}
+	}
+	joined := strings.NewReplacer("-\n", "", "\n", " ").Replace(ansi.Strip(Wrap(styled, 12)))
+	if joined != text {
+		t.Errorf("Wrap lost words: %q", joined)
+	}
+}
+
+func TestWrapCarriesStyles(t *testing.T) {
+	lines := strings.Split(Wrap("\x1b[1mone two three\x1b[0m", 8), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], reset) || !strings.HasPrefix(lines[1], "\x1b[1m") {
+		t.Errorf("styles not carried: %q", lines)
+	}
+}
+
+func TestHyphenate(t *testing.T) {
+	got := Hyphenate("abcdefghij", 4)
+	want := []string{"abc-", "def-", "ghij"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Hyphenate = %q, want %q", got, want)
+	}
+	for _, chunk := range Hyphenate("日本語日本語", 4) {
+		if Width(chunk) > 4 {
+			t.Errorf("chunk %q is wider than 4", chunk)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package textutil
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sgrPattern matches the escape sequences that set colors and styles
+var sgrPattern = regexp.MustCompile(`\x1b\[([0-9;:]*)m`)
+
+// reset turns off every style
+const reset = "\x1b[0m"
+
+// Wrap word-wraps s to lines of at most width cells. Lines of s that fit
+// are kept as they are; blank ones become empty. Words longer than a line
+// are hyphenated, and the leading indent of a wrapped line is kept on its
+// first line. Styles stay on across the lines they're wrapped onto and are
+// reset at each line's end, so a line can be bordered or padded alone.
+func Wrap(s string, width int) string {
+	width = max(width, 1)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.TrimSpace(ansi.Strip(line)) == "":
+			lines[i] = ""
+		case Width(line) > width:
+			lines[i] = strings.Join(carryStyles(wrapLine(line, width)), "\n")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine breaks one line that doesn't fit at its spaces
+func wrapLine(line string, width int) []string {
+	rest := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(rest)]
+	if len(indent) > width/2 {
+		indent = ""
+	}
+
+	var lines []string
+	cur, curW, empty := indent, len(indent), true
+	flush := func() {
+		lines = append(lines, cur)
+		cur, curW, empty = "", 0, true
+	}
+	for _, word := range strings.Fields(rest) {
+		ww, sep := Width(word), 0
+		if !empty {
+			sep = 1
+		}
+		if curW+sep+ww > width {
+			if empty {
+				cur, curW = "", 0 // no room after the indent
+			} else {
+				flush()
+			}
+			if ww > width {
+				chunks := Hyphenate(word, width)
+				lines = append(lines, chunks[:len(chunks)-1]...)
+				word = chunks[len(chunks)-1]
+				ww = Width(word)
+			}
+		}
+		if !empty {
+			cur += " "
+			curW++
+		}
+		cur += word
+		curW += ww
+		empty = false
+	}
+	if !empty {
+		flush()
+	}
+	return lines
+}
+
+// Hyphenate breaks word into pieces of at most width cells, each but the
+// last ending in a hyphen. Wide characters aren't split, and a styled word
+// keeps its escape sequences.
+func Hyphenate(word string, width int) []string {
+	if Width(word) <= width {
+		return []string{word}
+	}
+	hyphen := "-"
+	if width < 2 {
+		hyphen, width = "", 1
+	}
+
+	var chunks []string
+	start, cells, total := 0, 0, Width(word)
+	for _, r := range ansi.Strip(word) {
+		rw := Width(string(r))
+		if total-start <= width {
+			break // the rest fits without a hyphen
+		}
+		if cells+rw > start+width-len(hyphen) && cells > start {
+			chunks = append(chunks, ansi.Cut(word, start, cells)+hyphen)
+			start = cells
+		}
+		cells += rw
+	}
+	return append(chunks, ansi.Cut(word, start, total))
+}
+
+// carryStyles starts each line with the styles still on at the end of
+// the line before, and resets them at the end of the line
+func carryStyles(lines []string) []string {
+	var active []string
+	for i, line := range lines {
+		carried := strings.Join(active, "")
+		for _, m := range sgrPattern.FindAllStringSubmatch(line, -1) {
+			params := m[1]
+			if params == "" || params == "0" || strings.HasPrefix(params, "0;") {
+				active = active[:0]
+				if len(params) <= 1 {
+					continue
+				}
+			}
+			active = append(active, m[0])
+		}
+		line = carried + line
+		if len(active) > 0 {
+			line += reset
+		}
+		lines[i] = line
+	}
+	return lines
+}
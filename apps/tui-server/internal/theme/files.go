@@ -0,0 +1,174 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	paletteNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	hexColorPattern    = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// colorFields maps the keys of a theme file to the palette's colors
+func (p *Palette) colorFields() map[string]*string {
+	return map[string]*string{
+		"background":     &p.Background,
+		"foreground":     &p.Foreground,
+		"neon":           &p.Neon,
+		"cyan":           &p.Cyan,
+		"yellow":         &p.Yellow,
+		"green":          &p.Green,
+		"orange":         &p.Orange,
+		"red":            &p.Red,
+		"purple":         &p.Purple,
+		"blue":           &p.Blue,
+		"muted":          &p.Muted,
+		"dim":            &p.Dim,
+		"border":         &p.Border,
+		"border_bright":  &p.BorderBright,
+		"highlight":      &p.Highlight,
+		"body_text":      &p.BodyText,
+		"user_text":      &p.UserText,
+		"assistant_text": &p.AssistantText,
+	}
+}
+
+// LoadPalettes reads every *.json and *.toml theme file in dir. Each must
+// set all colors as hex strings; "name" defaults to the file name and
+// "spinner" to "blocks". A missing dir loads nothing.
+func LoadPalettes(dir string) ([]Palette, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes: %w", err)
+	}
+
+	var palettes []Palette
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read theme %s: %w", path, err)
+		}
+		var raw map[string]string
+		if ext == ".json" {
+			err = json.Unmarshal(data, &raw)
+		} else {
+			raw, err = parseFlatTOML(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+		}
+		p, err := paletteFromFile(strings.TrimSuffix(e.Name(), ext), raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+		}
+		palettes = append(palettes, p)
+	}
+	return palettes, nil
+}
+
+// AddPalettes makes loaded palettes available alongside the built-in ones.
+// Call it at startup, before any session starts.
+func AddPalettes(palettes ...Palette) error {
+	for i, p := range palettes {
+		if _, taken := PaletteByName(p.Name); taken {
+			return fmt.Errorf("theme %q is already defined", p.Name)
+		}
+		for _, other := range palettes[:i] {
+			if other.Name == p.Name {
+				return fmt.Errorf("theme %q is defined twice", p.Name)
+			}
+		}
+	}
+	Palettes = append(Palettes, palettes...)
+	return nil
+}
+
+// paletteFromFile validates a theme file's keys and builds its palette
+func paletteFromFile(fileName string, raw map[string]string) (Palette, error) {
+	p := Palette{Name: fileName, Spinner: "blocks"}
+	fields := p.colorFields()
+
+	var missing, unknown []string
+	for key, value := range raw {
+		switch field, ok := fields[key]; {
+		case key == "name":
+			p.Name = value
+		case key == "spinner":
+			p.Spinner = value
+		case !ok:
+			unknown = append(unknown, key)
+		case !hexColorPattern.MatchString(value):
+			return Palette{}, fmt.Errorf("%s: %q is not a #rgb or #rrggbb color", key, value)
+		default:
+			*field = value
+		}
+	}
+	for key, field := range fields {
+		if *field == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+
+	switch {
+	case len(unknown) > 0:
+		return Palette{}, fmt.Errorf("unknown keys: %s", strings.Join(unknown, ", "))
+	case len(missing) > 0:
+		return Palette{}, fmt.Errorf("missing colors: %s", strings.Join(missing, ", "))
+	case !paletteNamePattern.MatchString(p.Name):
+		return Palette{}, fmt.Errorf("name %q must be lowercase letters, digits and dashes", p.Name)
+	}
+	return p, nil
+}
+
+// parseFlatTOML reads the subset of TOML theme files need: one
+// key = "string" per line, with # comments
+func parseFlatTOML(data []byte) (map[string]string, error) {
+	raw := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = \"value\"", n)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if i := strings.Index(value, " #"); i >= 0 && strings.Count(value[:i], `"`)%2 == 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			if len(value) < 2 || value[0] != '\'' || value[len(value)-1] != '\'' {
+				return nil, fmt.Errorf("line %d: %s must be a quoted string", n, key)
+			}
+			unquoted = value[1 : len(value)-1]
+		}
+		if _, dup := raw[key]; dup {
+			return nil, fmt.Errorf("line %d: %s is set twice", n, key)
+		}
+		raw[key] = unquoted
+	}
+	return raw, scanner.Err()
+}
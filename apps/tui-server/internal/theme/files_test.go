@@ -0,0 +1,95 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const solarizedTOML = `# Solarized dark
+name = "solarized"
+spinner = "pulse"
+
+background = "#002b36"
+foreground = "#fdf6e3"
+neon = "#d33682"
+cyan = "#2aa198"
+yellow = "#b58900"
+green = "#859900"
+orange = "#cb4b16"
+red = "#dc322f"
+purple = "#6c71c4"
+blue = "#268bd2"
+muted = "#839496"
+dim = "#586e75"
+border = "#073642"
+border_bright = "#586e75"
+highlight = "#073642" # selection
+body_text = "#eee8d5"
+user_text = "#93a1a1"
+assistant_text = '#eee8d5'
+`
+
+func writeTheme(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPalettesReadsTOMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme(t, dir, "solarized.toml", solarizedTOML)
+	writeTheme(t, dir, "paper.json", `{
+		"background": "#fff", "foreground": "#111", "neon": "#c0c", "cyan": "#0aa",
+		"yellow": "#a80", "green": "#080", "orange": "#c60", "red": "#c00",
+		"purple": "#60c", "blue": "#06c", "muted": "#777", "dim": "#999",
+		"border": "#ddd", "border_bright": "#bbb", "highlight": "#eee",
+		"body_text": "#222", "user_text": "#024", "assistant_text": "#131"
+	}`)
+	writeTheme(t, dir, "notes.txt", "ignored")
+
+	palettes, err := LoadPalettes(dir)
+	if err != nil {
+		t.Fatalf("LoadPalettes() error = %v", err)
+	}
+	if len(palettes) != 2 {
+		t.Fatalf("LoadPalettes() loaded %d palettes, want 2", len(palettes))
+	}
+	// Files load in name order; a JSON theme without a name takes the file's
+	paper, solarized := palettes[0], palettes[1]
+	if paper.Name != "paper" || paper.Spinner != "blocks" || paper.BorderBright != "#bbb" {
+		t.Errorf("paper = %+v", paper)
+	}
+	if solarized.Name != "solarized" || solarized.Spinner != "pulse" || solarized.Highlight != "#073642" || solarized.AssistantText != "#eee8d5" {
+		t.Errorf("solarized = %+v", solarized)
+	}
+}
+
+func TestLoadPalettesRejectsIncompleteThemes(t *testing.T) {
+	cases := map[string]string{
+		"missing.toml":  strings.Replace(solarizedTOML, `red = "#dc322f"`, "", 1),
+		"badcolor.toml": strings.Replace(solarizedTOML, `"#dc322f"`, `"crimson"`, 1),
+		"unknown.toml":  solarizedTOML + `shadow = "#000000"` + "\n",
+		"table.toml":    "[colors]\n" + solarizedTOML,
+		"badname.toml":  strings.Replace(solarizedTOML, `"solarized"`, `"Solar Ized"`, 1),
+	}
+	for name, body := range cases {
+		dir := t.TempDir()
+		writeTheme(t, dir, name, body)
+		if _, err := LoadPalettes(dir); err == nil {
+			t.Errorf("LoadPalettes(%s) should fail", name)
+		}
+	}
+
+	if palettes, err := LoadPalettes(filepath.Join(t.TempDir(), "none")); err != nil || palettes != nil {
+		t.Errorf("LoadPalettes() on a missing dir = %v, %v; want nothing", palettes, err)
+	}
+}
+
+func TestAddPalettesRejectsDuplicates(t *testing.T) {
+	if err := AddPalettes(Palette{Name: Colors.Name}); err == nil {
+		t.Fatal("AddPalettes() should refuse a built-in palette's name")
+	}
+}
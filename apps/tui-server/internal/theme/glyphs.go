@@ -0,0 +1,108 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// GlyphSet controls which characters reach the terminal. The Unicode set
+// passes output through untouched; the ASCII set swaps box drawing, block
+// and symbol glyphs for ASCII look-alikes of the same cell width.
+type GlyphSet struct {
+	Name  string
+	ASCII bool
+}
+
+var (
+	// UnicodeGlyphs is the default full glyph set
+	UnicodeGlyphs = GlyphSet{Name: "unicode"}
+	// ASCIIGlyphs is the fallback for terminals without UTF-8
+	ASCIIGlyphs = GlyphSet{Name: "ascii", ASCII: true}
+)
+
+// asciiFallback maps every glyph the UI draws to an ASCII equivalent
+var asciiFallback = map[rune]string{
+	// Double-line frame
+	'═': "=", '║': "|", '╔': "+", '╗': "+", '╚': "+", '╝': "+",
+	'╠': "+", '╣': "+", '╦': "+", '╩': "+", '╟': "+", '╢': "+",
+	// Single-line boxes and tables
+	'─': "-", '│': "|", '┃': "|", '┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+	// Blocks and shades
+	'█': "#", '▓': "#", '▒': "=", '░': "-", '▀': "\"", '▄': "_", '▐': "|",
+	'▌': "|", '▏': "|", '▎': "|", '▍': "|", '▋': "|", '▊': "|", '▉': "#",
+	'▖': ".", '▘': "'", '▝': "'", '▗': ".",
+	// Markers and symbols
+	'•': "*", '·': "-", '◈': "*", '◆': "*", '◦': "-", '▸': ">", '▹': ">", '▾': "v",
+	'▪': "*", '▫': "o",
+	'●': "*", '○': "o", '◉': "*", '◌': "o", '◎': "o",
+	'⟨': "<", '⟩': ">", '›': ">", '❯': ">", '⏎': "<", '✓': "+", '⚠': "!",
+	'✉': "@", '⚡': "!", '⏻': "!", '♪': "~", '★': "*", '☆': ".", '👍': "+1", '👎': "-1",
+	'→': ">", '←': "<", '↑': "^", '↓': "v",
+	// Typography common in content
+	'‘': "'", '’': "'", '“': "\"", '”': "\"", '–': "-", '—': "-", '−': "-", '…': ".",
+}
+
+// DetectGlyphSet picks the ASCII set for terminals that cannot be trusted
+// with UTF-8: legacy TERM types, or a locale that names a non-UTF-8 charset.
+// The first non-empty of LC_ALL, LC_CTYPE and LANG decides the locale.
+func DetectGlyphSet(term string, locales ...string) GlyphSet {
+	switch strings.ToLower(term) {
+	case "vt52", "vt100", "vt102", "vt220", "dumb":
+		return ASCIIGlyphs
+	}
+	for _, locale := range locales {
+		if locale == "" {
+			continue
+		}
+		l := strings.ToLower(locale)
+		if l == "c" || l == "posix" {
+			return ASCIIGlyphs
+		}
+		if strings.Contains(l, ".") && !strings.Contains(l, "utf-8") && !strings.Contains(l, "utf8") {
+			return ASCIIGlyphs
+		}
+		break
+	}
+	return UnicodeGlyphs
+}
+
+// GlyphSetByName returns the glyph set called name
+func GlyphSetByName(name string) (GlyphSet, bool) {
+	switch name {
+	case UnicodeGlyphs.Name:
+		return UnicodeGlyphs, true
+	case ASCIIGlyphs.Name:
+		return ASCIIGlyphs, true
+	}
+	return GlyphSet{}, false
+}
+
+// Render converts s to the glyph set. ANSI escape sequences are pure ASCII
+// and pass through; any other non-ASCII rune is replaced by a fallback
+// padded to the rune's display width so layouts stay aligned.
+func (g GlyphSet) Render(s string) string {
+	if !g.ASCII {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r < 0x80 {
+			b.WriteRune(r)
+			continue
+		}
+		width := ansi.StringWidth(string(r))
+		fallback, ok := asciiFallback[r]
+		if !ok {
+			fallback = strings.Repeat("?", width)
+		}
+		b.WriteString(fallback)
+		if pad := width - len(fallback); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+	}
+	return b.String()
+}
@@ -2,10 +2,14 @@ package theme
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-// Cyberpunk color palette - vibrant neon on dark
-var Colors = struct {
+// Palette defines every color used to build the TUI styles
+type Palette struct {
+	Name    string
+	Spinner string // spinner set used for streaming indicators
+
 	// Base
 	Background string
 	Foreground string
@@ -31,7 +35,13 @@ var Colors = struct {
 	BodyText      string
 	UserText      string
 	AssistantText string
-}{
+}
+
+// Cyberpunk color palette - vibrant neon on dark
+var Colors = Palette{
+	Name:    "cyberpunk",
+	Spinner: "blocks",
+
 	Background: "#0d0d12",
 	Foreground: "#e8f0f8", // Bright white-blue
 
@@ -57,6 +67,75 @@ var Colors = struct {
 	AssistantText: "#e0f0e8", // Light green tint
 }
 
+// Synthwave palette - purple/orange retro sunset
+var synthwave = Palette{
+	Name:    "synthwave",
+	Spinner: "pulse",
+
+	Background: "#1a1029",
+	Foreground: "#f4eeff",
+
+	Neon:   "#ff7edb",
+	Cyan:   "#72f1b8",
+	Yellow: "#fede5d",
+	Green:  "#72f1b8",
+	Orange: "#f97e72",
+	Red:    "#fe4450",
+	Purple: "#b893ce",
+	Blue:   "#36f9f6",
+
+	Muted:        "#8a7fa8",
+	Dim:          "#5f5583",
+	Border:       "#2b1f44",
+	BorderBright: "#6d5c9a",
+	Highlight:    "#2a1f3d",
+
+	BodyText:      "#e5dcf5",
+	UserText:      "#d9f4ff",
+	AssistantText: "#ffe3f6",
+}
+
+// Matrix palette - monochrome phosphor green
+var matrix = Palette{
+	Name:    "matrix",
+	Spinner: "glyphs",
+
+	Background: "#000800",
+	Foreground: "#c8ffc8",
+
+	Neon:   "#00ff41",
+	Cyan:   "#39ff88",
+	Yellow: "#b6ff00",
+	Green:  "#00ff41",
+	Orange: "#8cff66",
+	Red:    "#ff3b3b",
+	Purple: "#00cc66",
+	Blue:   "#66ffb2",
+
+	Muted:        "#3f8f56",
+	Dim:          "#2d6b3f",
+	Border:       "#0f2a16",
+	BorderBright: "#1f5f33",
+	Highlight:    "#0a1f10",
+
+	BodyText:      "#b5f5c0",
+	UserText:      "#d0ffd8",
+	AssistantText: "#a8ffb8",
+}
+
+// Palettes lists the built-in palettes in display order
+var Palettes = []Palette{Colors, synthwave, matrix}
+
+// PaletteByName finds a built-in palette by name
+func PaletteByName(name string) (Palette, bool) {
+	for _, p := range Palettes {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Palette{}, false
+}
+
 // Styles contains all lipgloss styles for the TUI
 type Styles struct {
 	// Base
@@ -107,14 +186,25 @@ type Styles struct {
 	// Cyberpunk specific
 	Glitch   lipgloss.Style
 	Scanline lipgloss.Style
+
+	// Accessible switches views to linear, low-decoration output
+	Accessible bool
+	// Glyphs is the character set output is rendered with
+	Glyphs GlyphSet
+	// Generation is the manager's Generation these styles were built in
+	Generation int
 }
 
 // Manager handles styles
 type Manager struct {
-	styles   Styles
-	width    int
-	height   int
-	renderer *lipgloss.Renderer
+	styles     Styles
+	palette    Palette
+	accessible bool
+	glyphs     GlyphSet
+	width      int
+	height     int
+	renderer   *lipgloss.Renderer
+	generation int // bumped each time styles are rebuilt
 }
 
 // NewManager creates a theme manager with an optional renderer
@@ -124,16 +214,18 @@ func NewManager(width, height int, renderer *lipgloss.Renderer) *Manager {
 		width:    width,
 		height:   height,
 		renderer: renderer,
+		palette:  Colors,
+		glyphs:   UnicodeGlyphs,
 	}
 	m.buildStyles()
 	return m
 }
 
-// SetSize updates dimensions and rebuilds styles
+// SetSize updates dimensions. No style depends on them, so styles are
+// left as they are.
 func (m *Manager) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.buildStyles()
 }
 
 // Styles returns the current styles
@@ -141,6 +233,72 @@ func (m *Manager) Styles() Styles {
 	return m.styles
 }
 
+// Generation changes whenever styles are rebuilt, so callers holding
+// styles can tell when theirs are stale
+func (m *Manager) Generation() int {
+	return m.generation
+}
+
+// Palette returns the active palette
+func (m *Manager) Palette() Palette {
+	return m.palette
+}
+
+// SetPalette switches to a built-in palette by name and rebuilds styles
+func (m *Manager) SetPalette(name string) bool {
+	p, ok := PaletteByName(name)
+	if !ok {
+		return false
+	}
+	m.palette = p
+	m.buildStyles()
+	return true
+}
+
+// Accessible reports whether accessibility mode is on
+func (m *Manager) Accessible() bool {
+	return m.accessible
+}
+
+// SetAccessible toggles accessibility mode and rebuilds styles
+func (m *Manager) SetAccessible(on bool) {
+	m.accessible = on
+	m.buildStyles()
+}
+
+// ColorProfile returns the color capability of the session's terminal
+func (m *Manager) ColorProfile() termenv.Profile {
+	if m.renderer != nil {
+		return m.renderer.ColorProfile()
+	}
+	return lipgloss.ColorProfile()
+}
+
+// BackgroundSequence returns the escape sequence that sets the palette's
+// background color, or "" when the terminal has no colors
+func (m *Manager) BackgroundSequence() string {
+	profile := m.ColorProfile()
+	if profile == termenv.Ascii {
+		return ""
+	}
+	color := profile.Color(m.palette.Background)
+	if color == nil {
+		return ""
+	}
+	return termenv.CSI + color.Sequence(true) + "m"
+}
+
+// Glyphs returns the active glyph set
+func (m *Manager) Glyphs() GlyphSet {
+	return m.glyphs
+}
+
+// SetGlyphs switches the glyph set and rebuilds styles
+func (m *Manager) SetGlyphs(g GlyphSet) {
+	m.glyphs = g
+	m.buildStyles()
+}
+
 // Width returns current width
 func (m *Manager) Width() int {
 	return m.width
@@ -160,119 +318,126 @@ func (m *Manager) newStyle() lipgloss.Style {
 }
 
 func (m *Manager) buildStyles() {
+	c := m.palette
+	m.generation++
+
 	// Base styles
 	m.styles.App = m.newStyle().
-		Background(lipgloss.Color(Colors.Background)).
-		Foreground(lipgloss.Color(Colors.Foreground))
+		Background(lipgloss.Color(c.Background)).
+		Foreground(lipgloss.Color(c.Foreground))
 
 	m.styles.Header = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Neon)).
+		Foreground(lipgloss.Color(c.Neon)).
 		Bold(true)
 
 	m.styles.Footer = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Muted))
+		Foreground(lipgloss.Color(c.Muted))
 
 	// Text styles
 	m.styles.Title = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Neon)).
+		Foreground(lipgloss.Color(c.Neon)).
 		Bold(true)
 
 	m.styles.Subtitle = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Cyan)).
+		Foreground(lipgloss.Color(c.Cyan)).
 		Bold(true)
 
 	m.styles.Body = m.newStyle().
-		Foreground(lipgloss.Color(Colors.BodyText))
+		Foreground(lipgloss.Color(c.BodyText))
 
 	m.styles.Muted = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Muted))
+		Foreground(lipgloss.Color(c.Muted))
 
 	m.styles.Dim = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Dim))
+		Foreground(lipgloss.Color(c.Dim))
 
 	m.styles.Error = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Red)).
+		Foreground(lipgloss.Color(c.Red)).
 		Bold(true)
 
 	m.styles.Success = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Green))
+		Foreground(lipgloss.Color(c.Green))
 
 	m.styles.Warning = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Yellow))
+		Foreground(lipgloss.Color(c.Yellow))
 
 	m.styles.Info = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Cyan))
+		Foreground(lipgloss.Color(c.Cyan))
 
 	// Neon color styles
-	m.styles.Neon = m.newStyle().Foreground(lipgloss.Color(Colors.Neon))
-	m.styles.Cyan = m.newStyle().Foreground(lipgloss.Color(Colors.Cyan))
-	m.styles.Yellow = m.newStyle().Foreground(lipgloss.Color(Colors.Yellow))
-	m.styles.Green = m.newStyle().Foreground(lipgloss.Color(Colors.Green))
-	m.styles.Orange = m.newStyle().Foreground(lipgloss.Color(Colors.Orange))
-	m.styles.Red = m.newStyle().Foreground(lipgloss.Color(Colors.Red))
-	m.styles.Purple = m.newStyle().Foreground(lipgloss.Color(Colors.Purple))
-	m.styles.Blue = m.newStyle().Foreground(lipgloss.Color(Colors.Blue))
+	m.styles.Neon = m.newStyle().Foreground(lipgloss.Color(c.Neon))
+	m.styles.Cyan = m.newStyle().Foreground(lipgloss.Color(c.Cyan))
+	m.styles.Yellow = m.newStyle().Foreground(lipgloss.Color(c.Yellow))
+	m.styles.Green = m.newStyle().Foreground(lipgloss.Color(c.Green))
+	m.styles.Orange = m.newStyle().Foreground(lipgloss.Color(c.Orange))
+	m.styles.Red = m.newStyle().Foreground(lipgloss.Color(c.Red))
+	m.styles.Purple = m.newStyle().Foreground(lipgloss.Color(c.Purple))
+	m.styles.Blue = m.newStyle().Foreground(lipgloss.Color(c.Blue))
 
 	// Interactive styles
 	m.styles.Prompt = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Cyan)).
+		Foreground(lipgloss.Color(c.Cyan)).
 		Bold(true)
 
 	m.styles.Input = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Foreground))
+		Foreground(lipgloss.Color(c.Foreground))
 
 	m.styles.Command = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Green)).
+		Foreground(lipgloss.Color(c.Green)).
 		Bold(true)
 
 	m.styles.CommandHint = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Muted)).
+		Foreground(lipgloss.Color(c.Muted)).
 		Italic(true)
 
 	// Chat styles
 	m.styles.UserLabel = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Cyan)).
+		Foreground(lipgloss.Color(c.Cyan)).
 		Bold(true)
 
 	m.styles.UserMessage = m.newStyle().
-		Foreground(lipgloss.Color(Colors.UserText))
+		Foreground(lipgloss.Color(c.UserText))
 
 	m.styles.AssistantLabel = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Neon)).
+		Foreground(lipgloss.Color(c.Neon)).
 		Bold(true)
 
 	m.styles.AssistantMessage = m.newStyle().
-		Foreground(lipgloss.Color(Colors.AssistantText))
+		Foreground(lipgloss.Color(c.AssistantText))
 
 	// Component styles
 	m.styles.Border = m.newStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(Colors.BorderBright))
+		BorderForeground(lipgloss.Color(c.BorderBright))
 
 	m.styles.Box = m.newStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(Colors.Cyan)).
+		BorderForeground(lipgloss.Color(c.Cyan)).
 		Padding(0, 1)
 
 	m.styles.Tag = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Background)).
-		Background(lipgloss.Color(Colors.Cyan)).
+		Foreground(lipgloss.Color(c.Background)).
+		Background(lipgloss.Color(c.Cyan)).
 		Padding(0, 1).
 		Bold(true)
 
 	m.styles.Link = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Blue)).
+		Foreground(lipgloss.Color(c.Blue)).
 		Underline(true)
 
 	m.styles.Highlight = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Yellow)).
+		Foreground(lipgloss.Color(c.Yellow)).
 		Bold(true)
 
 	// Cyberpunk specific
 	m.styles.Glitch = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Neon)).
-		Background(lipgloss.Color(Colors.Highlight))
+		Foreground(lipgloss.Color(c.Neon)).
+		Background(lipgloss.Color(c.Highlight))
 
 	m.styles.Scanline = m.newStyle().
-		Foreground(lipgloss.Color(Colors.Dim))
+		Foreground(lipgloss.Color(c.Dim))
+
+	m.styles.Accessible = m.accessible
+	m.styles.Glyphs = m.glyphs
+	m.styles.Generation = m.generation
 }
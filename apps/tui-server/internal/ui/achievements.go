@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// AchievementsPerPage is how many achievements /achievements shows at once
+const AchievementsPerPage = 4
+
+// resumeAchievements is how many achievements the resume shows before
+// pointing at /achievements
+const resumeAchievements = 3
+
+// AchievementPages is how many pages n achievements fill
+func AchievementPages(n int) int {
+	return max(1, (n+AchievementsPerPage-1)/AchievementsPerPage)
+}
+
+// Achievements renders one page of the achievements in full, with the
+// page's place among the others
+func Achievements(styles theme.Styles, achievements []content.Achievement, page, width int) string {
+	cw := innerWidth(width)
+	pages := AchievementPages(len(achievements))
+	page = min(max(page, 0), pages-1)
+
+	var lines []string
+	if len(achievements) == 0 {
+		lines = append(lines, styles.Muted.Render("No achievements listed yet"))
+	}
+	first := page * AchievementsPerPage
+	for i := first; i < min(first+AchievementsPerPage, len(achievements)); i++ {
+		if i > first {
+			lines = append(lines, "")
+		}
+		lines = append(lines, achievementLines(styles, achievements[i], fmt.Sprintf("%02d ", i+1), cw)...)
+	}
+	if pages > 1 {
+		lines = append(lines, "", pageDots(styles, page, pages, cw))
+	}
+
+	return "\n" + Box{Title: "ACHIEVEMENTS", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// achievementLines are an achievement's text, wrapped after the marker,
+// then a line with its date and link
+func achievementLines(styles theme.Styles, a content.Achievement, marker string, cw int) []string {
+	var lines []string
+	indent := strings.Repeat(" ", textutil.Width(marker))
+	for i, l := range wrapTextForBox(a.Text, cw-textutil.Width(marker), styles) {
+		if i == 0 {
+			lines = append(lines, styles.Neon.Render(marker)+l)
+			continue
+		}
+		lines = append(lines, indent+l)
+	}
+	switch {
+	case a.Date != "" && a.URL != "":
+		link := textutil.Truncate(a.URL, cw-textutil.Width(marker)-textutil.Width(a.Date)-3)
+		lines = append(lines, indent+styles.Cyan.Render(a.Date)+styles.Dim.Render(" · ")+Hyperlink(a.URL, styles.Link.Render(link)))
+	case a.Date != "":
+		lines = append(lines, indent+styles.Cyan.Render(a.Date))
+	case a.URL != "":
+		lines = append(lines, indent+Hyperlink(a.URL, styles.Link.Render(textutil.Truncate(a.URL, cw-textutil.Width(marker)))))
+	}
+	return lines
+}
+
+// pageDots is the carousel's "● ○ ○  page 1 of 3", centered, spelled out
+// in accessibility mode
+func pageDots(styles theme.Styles, page, pages, cw int) string {
+	label := "page " + strconv.Itoa(page+1) + " of " + strconv.Itoa(pages)
+	if styles.Accessible {
+		return label
+	}
+	dots := make([]string, pages)
+	for i := range dots {
+		dots[i] = styles.Dim.Render("○")
+		if i == page {
+			dots[i] = styles.Neon.Render("●")
+		}
+	}
+	return textutil.Center(strings.Join(dots, " ")+"  "+styles.Muted.Render(label), cw)
+}
+
+// resumeAchievementLines are the resume's achievements section: the
+// first few in full, pointing at /achievements when there are more
+func resumeAchievementLines(styles theme.Styles, achievements []content.Achievement, cw int) []string {
+	if len(achievements) == 0 {
+		return nil
+	}
+	lines := []string{styles.Green.Bold(true).Render("◈ ACHIEVEMENTS")}
+	for _, a := range achievements[:min(resumeAchievements, len(achievements))] {
+		for _, l := range achievementLines(styles, a, "▸ ", cw-2) {
+			lines = append(lines, "  "+l)
+		}
+	}
+	if n := len(achievements); n > resumeAchievements {
+		lines = append(lines, styles.Dim.Render("    "+textutil.Truncate("/achievements lists all "+strconv.Itoa(n), cw-4)))
+	}
+	return lines
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/github"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// activityLabelWidth is the column the grid starts at, after the
+// weekday labels
+const activityLabelWidth = 4
+
+// activityShades draw the contribution levels 0-4, one cell each. The
+// ASCII set gets its own, since its fallbacks for the shade blocks
+// collide.
+var (
+	activityShades      = []string{"·", "░", "▒", "▓", "█"}
+	activityShadesASCII = []string{".", "-", "=", "+", "#"}
+)
+
+// ActivityData is what /activity shows. Selected indexes Calendar.Weeks;
+// the calendar is empty while Loading or when Err says why.
+type ActivityData struct {
+	Calendar github.Calendar
+	Selected int
+	Loading  bool
+	Err      string
+}
+
+// Activity renders the contribution calendar as a heatmap of the most
+// recent weeks that fit in width, two columns a week when there's room,
+// with the selected week marked and described below
+func Activity(styles theme.Styles, data ActivityData, width int) string {
+	cal := data.Calendar
+	// Screen readers get lines flush left
+	line := func(s string) string {
+		if styles.Accessible {
+			return s
+		}
+		return textutil.Center(s, width)
+	}
+	switch {
+	case data.Loading:
+		return "\n" + line(styles.Muted.Render("Fetching the contribution calendar from GitHub...")) + "\n"
+	case data.Err != "":
+		return "\n" + line(styles.Error.Render(data.Err)) + "\n"
+	case len(cal.Weeks) == 0:
+		return "\n" + line(styles.Muted.Render("No contributions to show yet")) + "\n"
+	}
+	selected := min(max(data.Selected, 0), len(cal.Weeks)-1)
+
+	var b strings.Builder
+	b.WriteString("\n")
+	title := styles.Cyan.Bold(true).Render(groupDigits(cal.Total) + " contributions in the last year")
+	if profile := styles.Muted.Render(" · github.com/" + cal.Login); textutil.Width(title+profile) <= width {
+		title += profile
+	}
+	b.WriteString(line(textutil.Truncate(title, width)) + "\n\n")
+
+	if !styles.Accessible {
+		grid := activityGrid(styles, cal.Weeks, selected, width)
+		pad := strings.Repeat(" ", max((width-textutil.Width(grid[0]))/2, 0))
+		for _, row := range grid {
+			b.WriteString(pad + row + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(line(textutil.Truncate(weekSummary(styles, cal.Weeks[selected], width), width)) + "\n")
+	if styles.Accessible {
+		busiest := 0
+		for i, w := range cal.Weeks {
+			if w.Total() > cal.Weeks[busiest].Total() {
+				busiest = i
+			}
+		}
+		b.WriteString(styles.Muted.Render("Busiest week: ") + weekSummary(styles, cal.Weeks[busiest], width-14) + "\n")
+		return b.String()
+	}
+
+	shades := activityShades
+	if styles.Glyphs.ASCII {
+		shades = activityShadesASCII
+	}
+	legend := styles.Dim.Render("Less ")
+	for level, shade := range shades {
+		legend += activityCell(styles, shade, level, false) + " "
+	}
+	legend += styles.Dim.Render("More")
+	b.WriteString(line(legend) + "\n")
+	return b.String()
+}
+
+// activityGrid draws month labels, a row per weekday and a marker row
+// under the selected week, all of the same width. The window of weeks
+// ends at the latest, moving back to keep the selection in view.
+func activityGrid(styles theme.Styles, weeks []github.Week, selected, width int) []string {
+	cellWidth := 2
+	if activityLabelWidth+cellWidth*len(weeks) > width-2 {
+		cellWidth = 1
+	}
+	visible := min(len(weeks), max((width-2-activityLabelWidth)/cellWidth, 1))
+	start := len(weeks) - visible
+	if selected < start {
+		start = selected
+	}
+	shown := weeks[start : start+visible]
+	gridWidth := activityLabelWidth + cellWidth*visible
+
+	// Month names where a month's first week begins, if there's room
+	months := []rune(strings.Repeat(" ", gridWidth))
+	free := 0
+	for i, w := range shown {
+		if len(w) == 0 {
+			continue
+		}
+		first := w[0].Date
+		if i > 0 && len(shown[i-1]) > 0 && shown[i-1][0].Date.Month() == first.Month() {
+			continue
+		}
+		col := activityLabelWidth + i*cellWidth
+		name := first.Format("Jan")
+		if col < free || col+len(name) > gridWidth {
+			continue
+		}
+		copy(months[col:], []rune(name))
+		free = col + len(name) + 1
+	}
+	rows := []string{styles.Dim.Render(string(months))}
+
+	shades := activityShades
+	if styles.Glyphs.ASCII {
+		shades = activityShadesASCII
+	}
+	for day := range 7 {
+		label := ""
+		if day%2 == 1 {
+			label = []string{"", "Mon", "", "Wed", "", "Fri", ""}[day]
+		}
+		var row strings.Builder
+		row.WriteString(styles.Dim.Render(fmt.Sprintf("%-*s", activityLabelWidth, label)))
+		for i, w := range shown {
+			cell := " "
+			for _, d := range w {
+				if int(d.Date.Weekday()) == day {
+					cell = activityCell(styles, shades[min(max(d.Level, 0), 4)], d.Level, start+i == selected)
+				}
+			}
+			row.WriteString(cell + strings.Repeat(" ", cellWidth-1))
+		}
+		rows = append(rows, row.String())
+	}
+
+	marker := strings.Repeat(" ", activityLabelWidth+(selected-start)*cellWidth) + styles.Yellow.Bold(true).Render("↑")
+	rows = append(rows, textutil.Pad(marker, gridWidth))
+	return rows
+}
+
+// activityCell colors a shade: empty days dim, the rest green, and the
+// selected week yellow so it stands out
+func activityCell(styles theme.Styles, shade string, level int, selected bool) string {
+	switch {
+	case selected:
+		return styles.Yellow.Render(shade)
+	case level == 0:
+		return styles.Dim.Render(shade)
+	}
+	return styles.Green.Render(shade)
+}
+
+// weekSummary is the tooltip line for a week: its dates, total and,
+// when it fits in width, busiest day
+func weekSummary(styles theme.Styles, w github.Week, width int) string {
+	if len(w) == 0 {
+		return ""
+	}
+	first, last := w[0].Date, w[len(w)-1].Date
+	dates := first.Format("Jan 2") + " – " + last.Format("Jan 2, 2006")
+	total := w.Total()
+	count := fmt.Sprintf("%s contributions", groupDigits(total))
+	if total == 1 {
+		count = "1 contribution"
+	}
+	line := styles.Yellow.Bold(true).Render(dates) + styles.Muted.Render(": ") + styles.Neon.Render(count)
+	busiest := w[0]
+	for _, d := range w {
+		if d.Count > busiest.Count {
+			busiest = d
+		}
+	}
+	if total > 0 && len(w) > 1 {
+		if more := styles.Muted.Render(fmt.Sprintf(", busiest %s (%d)", busiest.Date.Format("Mon"), busiest.Count)); textutil.Width(line+more) <= width {
+			line += more
+		}
+	}
+	return line
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/github"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestActivityGridFitsAndFollowsSelection(t *testing.T) {
+	t.Parallel()
+
+	var weeks []github.Week
+	day := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC) // a Sunday
+	for range 53 {
+		var w github.Week
+		for range 7 {
+			w = append(w, github.Day{Date: day, Count: 1, Level: 1})
+			day = day.AddDate(0, 0, 1)
+		}
+		weeks = append(weeks, w)
+	}
+	styles := theme.NewManager(80, 24, nil).Styles()
+
+	testCases := []struct {
+		name      string
+		width     int
+		selected  int
+		cellWidth int
+	}{
+		{name: "wide", width: 120, selected: 52, cellWidth: 2},
+		{name: "narrow", width: 56, selected: 52, cellWidth: 1},
+		{name: "narrow, early week", width: 40, selected: 0, cellWidth: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows := activityGrid(styles, weeks, tc.selected, tc.width)
+			if len(rows) != 9 {
+				t.Fatalf("%d rows, want months, 7 days and the marker", len(rows))
+			}
+			for _, r := range rows {
+				if textutil.Width(r) != textutil.Width(rows[0]) || textutil.Width(r) > tc.width {
+					t.Fatalf("row %q is %d wide, want %d within %d", r, textutil.Width(r), textutil.Width(rows[0]), tc.width)
+				}
+			}
+			if got := textutil.Width(strings.TrimRight(rows[1], " ")) - activityLabelWidth; tc.cellWidth == 2 && got != 2*53-1 {
+				t.Errorf("wide grid spans %d columns, want two a week", got)
+			}
+			marker := strings.Index(rows[8], "↑")
+			if marker < activityLabelWidth {
+				t.Fatalf("no marker under the selected week: %q", rows[8])
+			}
+		})
+	}
+}
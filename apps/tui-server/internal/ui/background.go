@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// FillBackground paints every cell of a width x height frame with the
+// background SGR sequence bg. Styled text resets its colors when it
+// ends, so the background is reapplied after each reset; lines are padded
+// to the full width and the frame to the full height, so nothing of the
+// terminal's own background shows through, even right after a resize.
+func FillBackground(frame string, width, height int, bg string) string {
+	if bg == "" || width <= 0 {
+		return frame
+	}
+	lines := strings.Split(frame, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w > width {
+			line = ansi.Truncate(line, width, "")
+		} else {
+			line += strings.Repeat(" ", width-w)
+		}
+		line = strings.ReplaceAll(line, "\x1b[0m", "\x1b[0m"+bg)
+		line = strings.ReplaceAll(line, "\x1b[m", "\x1b[m"+bg)
+		lines[i] = bg + line + "\x1b[0m"
+	}
+	return strings.Join(lines, "\n")
+}
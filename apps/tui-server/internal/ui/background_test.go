@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFillBackgroundCoversEveryCell(t *testing.T) {
+	bg := "\x1b[48;2;13;13;18m"
+	frame := "\x1b[1mhi\x1b[0m there\n" + strings.Repeat("x", 12)
+
+	got := FillBackground(frame, 8, 3, bg)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want the frame padded to 3", len(lines))
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != 8 {
+			t.Errorf("line %d is %d wide, want 8", i, w)
+		}
+		if !strings.HasPrefix(line, bg) {
+			t.Errorf("line %d doesn't start with the background: %q", i, line)
+		}
+	}
+	// A reset inside styled text must not drop the background
+	if !strings.Contains(lines[0], "\x1b[0m"+bg+" there") {
+		t.Errorf("background not restored after a reset: %q", lines[0])
+	}
+
+	if FillBackground(frame, 8, 3, "") != frame {
+		t.Error("FillBackground() without a color should leave the frame alone")
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// BookmarksData is what /bookmarks shows: the projects the visitor
+// bookmarked, in the order they were added, and whether they're kept
+// for their next visit
+type BookmarksData struct {
+	Projects []content.Project
+	Saved    bool
+}
+
+// bookmarkMark is the star next to a bookmarked project, spelled out in
+// accessibility mode
+func bookmarkMark(styles theme.Styles) string {
+	if styles.Accessible {
+		return "(bookmarked)"
+	}
+	return styles.Yellow.Render("★")
+}
+
+// Bookmarks renders the bookmarked projects side by side with the list's
+// rows, numbered the same way so a number key opens one
+func Bookmarks(styles theme.Styles, data BookmarksData, width int) string {
+	cw := innerWidth(width)
+
+	var lines []string
+	if len(data.Projects) == 0 {
+		lines = append(lines, styles.Muted.Render(textutil.Truncate("No bookmarks yet", cw)), "")
+		lines = append(lines, styles.Dim.Render(textutil.Truncate("Open a project and press b to keep it here", cw)), "")
+	}
+	for i, p := range data.Projects {
+		lines = append(lines, projectRow(styles, p, i+1, cw, false)...)
+	}
+
+	lines = append(lines, styles.Dim.Render(strings.Repeat("─", min(cw-2, 40))))
+	if !data.Saved {
+		lines = append(lines, styles.Muted.Render(textutil.Truncate("This session only: connect with an SSH key or /login to keep them", cw)))
+	}
+	lines = append(lines, styles.Muted.Render(textutil.Truncate("b on a project toggles it · /bookmarks clear", cw)))
+
+	return "\n" + Box{Title: "BOOKMARKS", Lines: lines}.Render(styles, width) + "\n"
+}
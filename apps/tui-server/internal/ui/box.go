@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// Box is a bordered panel, the frame every view draws in: Title in the
+// top border, then the body and Footer in the bottom border. The body is
+// Lines followed by the Children, boxes drawn to fit inside it, with
+// Padding blank rows above and below. Lines too wide are truncated.
+//
+// Height scrolls the body: only Height rows from Offset show, and the
+// footer says which. In accessible mode a box is a plain heading followed
+// by its body, without borders.
+type Box struct {
+	Title    string
+	Lines    []string
+	Children []Box
+	Footer   string
+	Padding  int
+	Height   int // body rows shown; 0 shows them all
+	Offset   int // first body row shown when Height cuts the body
+}
+
+// boxWidth calculates optimal box width based on screen width
+func boxWidth(screenWidth int) int {
+	if screenWidth <= 0 {
+		return 24
+	}
+
+	// Responsive box sizing
+	if screenWidth < 60 {
+		return max(20, screenWidth-4)
+	}
+	if screenWidth < 100 {
+		return max(20, min(60, screenWidth-8))
+	}
+	return max(20, min(70, screenWidth-20))
+}
+
+// contentWidth returns usable content width inside a box
+func contentWidth(boxW int) int {
+	return max(8, boxW-4) // 2 chars for borders on each side
+}
+
+// innerWidth is the width of a line in a Box rendered on a screen width
+// columns wide
+func innerWidth(width int) int {
+	return contentWidth(boxWidth(width))
+}
+
+// Render draws the box at the width views use on a screen width columns
+// wide, centered
+func (b Box) Render(styles theme.Styles, width int) string {
+	rows := b.Rows(styles, boxWidth(width))
+	if !styles.Accessible {
+		for i, row := range rows {
+			rows[i] = textutil.Center(row, width)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Rows draws the box exactly bw columns wide, one string per row
+func (b Box) Rows(styles theme.Styles, bw int) []string {
+	cw := contentWidth(bw)
+	body, footer := b.scroll(b.body(styles, cw))
+	rows := make([]string, 0, len(body)+2)
+
+	if styles.Accessible {
+		rows = append(rows, styles.Cyan.Bold(true).Render(b.Title+":"))
+		for _, line := range body {
+			rows = append(rows, textutil.Wrap(line, max(bw, 20)))
+		}
+		if footer != "" {
+			rows = append(rows, styles.Muted.Render(footer))
+		}
+		return append(rows, "")
+	}
+
+	// Top border with title
+	title := textutil.Truncate(b.Title, max(1, cw-4))
+	titleLen := textutil.Width(title)
+	titlePad := max(1, (cw-titleLen)/2)
+	rows = append(rows, styles.Yellow.Render("┌")+
+		styles.Muted.Render(strings.Repeat("─", titlePad))+
+		styles.Cyan.Bold(true).Render(" "+title+" ")+
+		styles.Muted.Render(strings.Repeat("─", max(1, cw-titlePad-titleLen)))+
+		styles.Yellow.Render("┐"))
+
+	for _, line := range body {
+		line = textutil.Pad(line, cw)
+		rows = append(rows, styles.Muted.Render("│ ")+line+styles.Muted.Render(" │"))
+	}
+
+	// Bottom border, with the footer at its right end
+	if footer == "" {
+		return append(rows, styles.Yellow.Render("└")+styles.Muted.Render(strings.Repeat("─", cw+2))+styles.Yellow.Render("┘"))
+	}
+	footer = textutil.Truncate(footer, cw-2)
+	return append(rows, styles.Yellow.Render("└")+
+		styles.Muted.Render(strings.Repeat("─", cw-1-textutil.Width(footer)))+
+		styles.Dim.Render(" "+footer+" ")+
+		styles.Muted.Render("─")+
+		styles.Yellow.Render("┘"))
+}
+
+// body is the box's lines and its children's rows, padded, cw columns
+// wide at most
+func (b Box) body(styles theme.Styles, cw int) []string {
+	body := make([]string, 0, len(b.Lines)+2*b.Padding)
+	for range b.Padding {
+		body = append(body, "")
+	}
+	body = append(body, b.Lines...)
+	for _, child := range b.Children {
+		body = append(body, child.Rows(styles, cw)...)
+	}
+	for range b.Padding {
+		body = append(body, "")
+	}
+	return body
+}
+
+// scroll cuts body to the rows Height and Offset show, and returns the
+// footer to draw: Footer, and the rows shown when some are cut
+func (b Box) scroll(body []string) ([]string, string) {
+	if b.Height <= 0 || len(body) <= b.Height {
+		return body, b.Footer
+	}
+	offset := min(max(b.Offset, 0), len(body)-b.Height)
+	position := strconv.Itoa(offset+1) + "-" + strconv.Itoa(offset+b.Height) + " of " + strconv.Itoa(len(body))
+	switch {
+	case offset == 0:
+		position = "▼ " + position
+	case offset+b.Height == len(body):
+		position = "▲ " + position
+	default:
+		position = "▲▼ " + position
+	}
+	if b.Footer != "" {
+		position = b.Footer + " · " + position
+	}
+	return body[offset : offset+b.Height], position
+}
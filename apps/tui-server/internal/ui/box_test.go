@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestBoxScrollsAndNests(t *testing.T) {
+	t.Parallel()
+
+	styles := theme.NewManager(80, 24, nil).Styles()
+	var lines []string
+	for i := range 20 {
+		lines = append(lines, "row "+strconv.Itoa(i))
+	}
+	outer := Box{
+		Title:    "OUTER",
+		Lines:    []string{"query"},
+		Children: []Box{{Title: "INNER", Lines: lines, Footer: "keys", Height: 5, Offset: 8}},
+	}
+	rows := outer.Rows(styles, 40)
+
+	if len(rows) != 1+1+7+1 {
+		t.Fatalf("got %d rows:\n%s", len(rows), ansi.Strip(strings.Join(rows, "\n")))
+	}
+	for i, row := range rows {
+		if w := textutil.Width(row); w != 40 {
+			t.Errorf("row %d is %d wide, want 40: %q", i, w, ansi.Strip(row))
+		}
+	}
+	if got := ansi.Strip(rows[3]); !strings.Contains(got, "row 8") {
+		t.Errorf("first shown row = %q, want row 8", got)
+	}
+	if got := ansi.Strip(rows[8]); !strings.Contains(got, "keys · ▲▼ 9-13 of 20") {
+		t.Errorf("footer = %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"rsc.io/qr"
+)
+
+const (
+	// qrQuiet is the light border around a QR code, in modules. The spec
+	// asks for 4; phone cameras manage with 2, which saves rows.
+	qrQuiet = 2
+	// qrColors are white modules on black: set explicitly, since a
+	// code only scans dark-on-light whatever the terminal's theme
+	qrColors = "\x1b[97;40m"
+)
+
+// CardData is what /card shows
+type CardData struct {
+	Name     string
+	Title    string
+	Email    string
+	Website  string // a link, as in https://example.com
+	VCard    string // encoded when its code fits, else Website is
+	Download string // command fetching the .vcf, as in "scp host:contact.vcf ."
+	// LocalTime is what time it is for the owner, as on the welcome screen
+	LocalTime string
+}
+
+// Card renders a QR code of the vCard, or of the website when the vCard's
+// code won't fit in width x height, above the contact details. Terminals
+// without Unicode block glyphs, and accessibility mode, get the details
+// only.
+func Card(styles theme.Styles, data CardData, width, height int) string {
+	cw := innerWidth(width)
+	row := func(label, value string) string {
+		return textutil.Truncate(styles.Dim.Render(fmt.Sprintf("%-8s", label))+styles.Link.Render(value), cw)
+	}
+	details := []string{
+		textutil.Truncate(styles.Neon.Bold(true).Render(data.Name), cw),
+		textutil.Truncate(styles.Muted.Render(data.Title), cw),
+		"",
+	}
+	if data.Email != "" {
+		details = append(details, row("EMAIL", data.Email))
+	}
+	if data.Website != "" {
+		details = append(details, row("WEB", data.Website))
+	}
+	if data.LocalTime != "" {
+		details = append(details, "")
+		for _, line := range strings.Split(textutil.Wrap(data.LocalTime, cw), "\n") {
+			details = append(details, styles.Dim.Render(line))
+		}
+	}
+	if data.Download != "" {
+		details = append(details, "", styles.Muted.Render("Save the card:"), textutil.Truncate(styles.Yellow.Render(data.Download), cw))
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	if !styles.Accessible && !styles.Glyphs.ASCII {
+		// One row is left for the caption under the code
+		rows, caption := fitQR(data, width, height-2)
+		for _, r := range rows {
+			b.WriteString(textutil.Center(r, width) + "\n")
+		}
+		if caption != "" {
+			b.WriteString(textutil.Center(styles.Cyan.Render(caption), width) + "\n\n")
+		}
+	}
+	b.WriteString(Box{Title: "CONTACT", Lines: details}.Render(styles, width) + "\n")
+	return b.String()
+}
+
+// fitQR draws the largest code that fits: the vCard, else the website.
+// Neither fitting leaves a hint to enlarge the window instead.
+func fitQR(data CardData, width, height int) (rows []string, caption string) {
+	for _, choice := range []struct{ payload, caption string }{
+		{data.VCard, "Scan to add me to your contacts"},
+		{qrLink(data.Website), "Scan to open " + strings.TrimPrefix(data.Website, "https://")},
+	} {
+		if choice.payload == "" {
+			continue
+		}
+		code, err := qr.Encode(choice.payload, qr.L)
+		if err != nil {
+			continue
+		}
+		side := code.Size + 2*qrQuiet
+		if side <= width && (side+1)/2 <= height {
+			return qrRows(code), choice.caption
+		}
+	}
+	if data.VCard == "" && data.Website == "" {
+		return nil, ""
+	}
+	return nil, "Enlarge the window to show a QR code"
+}
+
+// qrLink uppercases a link with no path, as in https://example.com, so
+// it encodes in QR's denser alphanumeric mode; scheme and host ignore case
+func qrLink(link string) string {
+	rest, ok := strings.CutPrefix(link, "https://")
+	if !ok || strings.ContainsAny(strings.TrimSuffix(rest, "/"), "/?#") {
+		return link
+	}
+	return strings.ToUpper(link)
+}
+
+// qrRows draws code with half blocks, two modules to a row, white on
+// black with its quiet zone
+func qrRows(code *qr.Code) []string {
+	side := code.Size + 2*qrQuiet
+	light := func(x, y int) bool {
+		if y >= side {
+			return false // below an odd last row; drawn black, unseen
+		}
+		return !code.Black(x-qrQuiet, y-qrQuiet)
+	}
+	rows := make([]string, 0, (side+1)/2)
+	for y := 0; y < side; y += 2 {
+		var b strings.Builder
+		b.WriteString(qrColors)
+		for x := range side {
+			switch top, bottom := light(x, y), light(x, y+1); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\x1b[0m")
+		rows = append(rows, b.String())
+	}
+	return rows
+}
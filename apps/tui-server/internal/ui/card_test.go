@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestCardFitsQRToWindow(t *testing.T) {
+	t.Parallel()
+
+	data := CardData{Name: "Ada", Website: "https://example.com", VCard: "BEGIN:VCARD\r\n" + strings.Repeat("NOTE:x\r\n", 20) + "END:VCARD\r\n"}
+	testCases := []struct {
+		name          string
+		width, height int
+		caption       string
+	}{
+		{name: "vcard", width: 100, height: 40, caption: "Scan to add me"},
+		{name: "website", width: 80, height: 16, caption: "Scan to open example.com"},
+		{name: "too small", width: 20, height: 8, caption: "Enlarge the window"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, caption := fitQR(data, tc.width, tc.height)
+			if !strings.HasPrefix(caption, tc.caption) {
+				t.Fatalf("caption = %q, want %q...", caption, tc.caption)
+			}
+			if len(rows) > tc.height {
+				t.Fatalf("%d rows overflow a height of %d", len(rows), tc.height)
+			}
+			for _, r := range rows {
+				if w := textutil.Width(r); w > tc.width || w != textutil.Width(rows[0]) {
+					t.Fatalf("row %q is %d wide in a width of %d", r, w, tc.width)
+				}
+			}
+		})
+	}
+}
+
+func TestCardWithoutBlockGlyphsHasNoQR(t *testing.T) {
+	t.Parallel()
+
+	manager := theme.NewManager(80, 24, nil)
+	manager.SetAccessible(true)
+	styles := manager.Styles()
+	out := Card(styles, CardData{Name: "Ada", Website: "https://example.com", VCard: "BEGIN:VCARD"}, 80, 40)
+	if strings.ContainsAny(out, "▀▄█") || !strings.Contains(out, "example.com") {
+		t.Fatalf("accessible card should list details without a code:\n%s", out)
+	}
+}
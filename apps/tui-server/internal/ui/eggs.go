@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// matrixGlyphs are the characters of the rain; both sets are one cell wide
+var (
+	matrixGlyphs      = []rune("ｦｱｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄﾅﾆﾇﾈﾉﾊﾋﾌﾍﾎﾏﾐﾑﾒﾓﾔﾕﾖﾗﾘﾙﾚﾛﾜﾝ0123456789")
+	matrixASCIIGlyphs = []rune("0123456789ABCDEFXZ:=*+<>")
+)
+
+// matrixTrail is how many cells follow each drop's head
+const matrixTrail = 8
+
+// MatrixRain renders frame of the /matrix digital rain, width by height.
+// Every column falls at its own speed from its own start, derived from
+// the column number so the same frame always draws the same.
+func MatrixRain(styles theme.Styles, width, height, frame int) string {
+	glyphs := matrixGlyphs
+	if styles.Glyphs.ASCII {
+		glyphs = matrixASCIIGlyphs
+	}
+	head := styles.Highlight.Bold(true)
+	cycle := height + matrixTrail
+
+	rows := make([]string, height)
+	for y := range rows {
+		var b strings.Builder
+		for x := 0; x < width; x++ {
+			seed := uint32(x)*2654435761 + 0x9e3779b9
+			if x%2 == 1 || seed%5 == 0 {
+				// Every other column stays dark, and a few more, so the rain is sparse
+				b.WriteByte(' ')
+				continue
+			}
+			speed := 1 + int(seed>>8)%3
+			pos := (frame*speed + int(seed>>16)%cycle) % cycle
+			dist := pos - y
+			if dist < 0 || dist > matrixTrail {
+				b.WriteByte(' ')
+				continue
+			}
+			ch := string(glyphs[(int(seed>>4)+y*7+frame/2)%len(glyphs)])
+			switch {
+			case dist == 0:
+				b.WriteString(head.Render(ch))
+			case dist < matrixTrail/2:
+				b.WriteString(styles.Green.Render(ch))
+			default:
+				b.WriteString(styles.Dim.Render(ch))
+			}
+		}
+		rows[y] = b.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// hackScript is the /hack sequence, one line per step
+var hackScript = []string{
+	"> ssh root@mainframe.bmohak.xyz",
+	"  resolving neural uplink.......... ok",
+	"  bypassing firewall [##########] 100%",
+	"  injecting payload................ ok",
+	"  decrypting /etc/secrets.......... ok",
+	"  covering tracks.................. ok",
+}
+
+// HackSteps is how many steps the /hack sequence takes to play out
+var HackSteps = len(hackScript) + 1
+
+// HackSequence renders the /hack screen with step lines revealed, ending
+// on ACCESS GRANTED
+func HackSequence(styles theme.Styles, width, step int) string {
+	var lines []string
+	for i, line := range hackScript {
+		if i >= step {
+			break
+		}
+		if i == 0 {
+			lines = append(lines, styles.Cyan.Render(line))
+		} else {
+			lines = append(lines, styles.Green.Render(line))
+		}
+	}
+	if step >= len(hackScript) {
+		lines = append(lines, "", styles.Red.Bold(true).Render(textutil.Center(">>> ACCESS GRANTED <<<", innerWidth(width))))
+	}
+	return "\n" + Box{Title: "MAINFRAME", Lines: lines}.Render(styles, width)
+}
+
+// Cowsay renders text in a speech bubble said by a cow, wrapped to fit
+// width
+func Cowsay(text string, width int) string {
+	lines := strings.Split(textutil.Wrap(strings.TrimSpace(text), max(width-4, 8)), "\n")
+	inner := 0
+	for _, line := range lines {
+		inner = max(inner, textutil.Width(line))
+	}
+
+	var b strings.Builder
+	b.WriteString(" " + strings.Repeat("_", inner+2) + "\n")
+	for i, line := range lines {
+		left, right := "|", "|"
+		switch {
+		case len(lines) == 1:
+			left, right = "<", ">"
+		case i == 0:
+			left, right = "/", "\\"
+		case i == len(lines)-1:
+			left, right = "\\", "/"
+		}
+		b.WriteString(left + " " + line + strings.Repeat(" ", inner-textutil.Width(line)) + " " + right + "\n")
+	}
+	b.WriteString(" " + strings.Repeat("-", inner+2) + "\n")
+	b.WriteString(`        \   ^__^
+         \  (oo)\_______
+            (__)\       )\/\
+                ||----w |
+                ||     ||`)
+	return b.String()
+}
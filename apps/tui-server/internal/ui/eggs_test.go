@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+)
+
+func TestCowsayBubble(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		text  string
+		width int
+		first string
+	}{
+		{name: "one line", text: "moo", width: 40, first: "< moo >"},
+		{name: "wrapped", text: "the quick brown fox jumps", width: 16, first: "/ the quick \\"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines := strings.Split(Cowsay(tc.text, tc.width), "\n")
+			if lines[1] != tc.first {
+				t.Fatalf("first bubble line = %q, want %q", lines[1], tc.first)
+			}
+			top := lines[0]
+			for _, line := range lines[1:] {
+				if strings.HasPrefix(line, " -") {
+					break
+				}
+				if textutil.Width(line) != textutil.Width(top)+1 {
+					t.Fatalf("bubble line %q is %d wide, want %d", line, textutil.Width(line), textutil.Width(top)+1)
+				}
+			}
+		})
+	}
+}
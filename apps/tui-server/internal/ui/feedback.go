@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// FeedbackData is the /feedback form: the rating picked so far, 0 for
+// none, and how many the visitor has sent this session out of Limit
+type FeedbackData struct {
+	Rating int
+	Sent   int
+	Limit  int
+}
+
+// Feedback renders the feedback form: five stars picked with the number
+// keys, then an optional comment typed in the input and sent with Enter
+func Feedback(styles theme.Styles, data FeedbackData, width int) string {
+	cw := innerWidth(width)
+
+	lines := []string{styles.Body.Render(textutil.Truncate("How was your visit?", cw)), ""}
+	if styles.Accessible {
+		rating := "No rating yet."
+		if data.Rating > 0 {
+			rating = fmt.Sprintf("Rating: %d out of 5.", data.Rating)
+		}
+		lines = append(lines, rating+" Press 1 to 5 to rate.")
+	} else {
+		stars := styles.Yellow.Render(strings.Repeat("★ ", data.Rating)) + styles.Dim.Render(strings.Repeat("☆ ", 5-data.Rating))
+		lines = append(lines, "  "+stars+styles.Muted.Render(" press 1-5"))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case data.Sent >= data.Limit:
+		lines = append(lines, styles.Green.Render(textutil.Truncate("Thanks! That's all the feedback one session can send.", cw)))
+	case data.Rating == 0:
+		lines = append(lines, styles.Dim.Render(textutil.Truncate("Pick a rating, then add a comment if you like", cw)))
+	default:
+		lines = append(lines, styles.Dim.Render(textutil.Truncate("Type a comment below, or leave it empty, and press Enter", cw)))
+	}
+	if data.Sent > 0 && data.Sent < data.Limit {
+		lines = append(lines, "", styles.Green.Render(textutil.Truncate("✓ Sent - thank you, Mohak reads every one", cw)))
+	}
+
+	return "\n" + Box{Title: "FEEDBACK", Lines: lines}.Render(styles, width) + "\n"
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// findHeaderLines is how many lines the view has above its first hit:
+// the blank line and top border, the count and a blank line
+const findHeaderLines = 4
+
+// findHitLines is how many lines each hit takes, the gap after it included
+const findHitLines = 3
+
+// FindData is what /find shows: the term, its ranked hits and the one
+// selected
+type FindData struct {
+	Term     string
+	Hits     []content.Hit
+	Selected int
+}
+
+// Find renders the hits of a content search, each as where it is and the
+// text around the match. Every hit takes the same lines, so FindHitLine
+// can say where one is.
+func Find(styles theme.Styles, data FindData, width int) string {
+	cw := innerWidth(width)
+
+	var lines []string
+	if len(data.Hits) == 0 {
+		lines = append(lines, styles.Muted.Render(textutil.Truncate(fmt.Sprintf("Nothing found for %q", data.Term), cw)))
+		lines = append(lines, "", styles.Dim.Render(textutil.Truncate("Try a shorter term, or ask the AI", cw)))
+	} else {
+		noun := "results"
+		if len(data.Hits) == 1 {
+			noun = "result"
+		}
+		count := fmt.Sprintf("%d %s for %q", len(data.Hits), noun, data.Term)
+		lines = append(lines, styles.Muted.Render(textutil.Truncate(count, cw)), "")
+	}
+
+	for i, hit := range data.Hits {
+		marker, whereStyle := "  ", styles.Cyan
+		where := hit.Where
+		if i == data.Selected && styles.Accessible {
+			marker, where = "> ", where+" (selected)"
+		}
+		if i == data.Selected && !styles.Accessible {
+			marker, whereStyle = styles.Neon.Bold(true).Render("▸ "), styles.Neon.Bold(true)
+		}
+		lines = append(lines, marker+whereStyle.Render(textutil.Truncate(where, cw-2)))
+		lines = append(lines, "    "+highlightTerm(styles, textutil.Truncate(hit.Snippet, cw-4), hit.Match))
+		if i < len(data.Hits)-1 {
+			lines = append(lines, "")
+		}
+	}
+
+	return "\n" + Box{Title: "FIND", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// FindHitLine is the line of the Find view that hit i starts on
+func FindHitLine(i int) int {
+	return findHeaderLines + i*findHitLines
+}
+
+// highlightTerm styles the first case-insensitive occurrence of term in
+// plain text, and the rest as body text
+func highlightTerm(styles theme.Styles, text, term string) string {
+	i := strings.Index(strings.ToLower(text), term)
+	if term == "" || i < 0 || len(strings.ToLower(text)) != len(text) {
+		return styles.Body.Render(text)
+	}
+	end := i + len(term)
+	return styles.Body.Render(text[:i]) + styles.Highlight.Bold(true).Render(text[i:end]) + styles.Body.Render(text[end:])
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/muesli/termenv"
+)
+
+// MessageRenderer renders a finished assistant message at a given width.
+// The built-in MarkdownRenderer and GlamourRenderer both implement it.
+type MessageRenderer interface {
+	Render(text string) string
+	SetWidth(width int)
+}
+
+// GlamourRenderer renders markdown through glamour using a stylesheet
+// generated from the active palette. It is only used for completed
+// messages; streaming stays on the lightweight renderer.
+type GlamourRenderer struct {
+	palette  theme.Palette
+	profile  termenv.Profile
+	width    int
+	fallback *MarkdownRenderer
+	term     *glamour.TermRenderer // rebuilt lazily when palette or width change
+}
+
+// NewGlamourRenderer creates a glamour-backed renderer. fallback is used
+// if glamour fails to render.
+func NewGlamourRenderer(palette theme.Palette, profile termenv.Profile, fallback *MarkdownRenderer) *GlamourRenderer {
+	return &GlamourRenderer{palette: palette, profile: profile, width: 80, fallback: fallback}
+}
+
+// SetTheme switches the stylesheet palette and the fallback renderer
+func (g *GlamourRenderer) SetTheme(palette theme.Palette, fallback *MarkdownRenderer) {
+	g.fallback = fallback
+	if palette.Name != g.palette.Name {
+		g.palette = palette
+		g.term = nil
+	}
+}
+
+// SetWidth updates the word-wrap width
+func (g *GlamourRenderer) SetWidth(width int) {
+	g.fallback.SetWidth(width)
+	if width >= 20 && width != g.width {
+		g.width = width
+		g.term = nil
+	}
+}
+
+// Render renders markdown, falling back to the built-in renderer on error
+func (g *GlamourRenderer) Render(text string) string {
+	if g.term == nil {
+		term, err := g.newTermRenderer()
+		if err != nil {
+			return g.fallback.Render(text)
+		}
+		g.term = term
+	}
+
+	out, err := g.term.Render(text)
+	if err != nil {
+		return g.fallback.Render(text)
+	}
+	// Glamour pads blocks with blank lines; the chat frame adds its own
+	return strings.Trim(out, "\n")
+}
+
+func (g *GlamourRenderer) newTermRenderer() (*glamour.TermRenderer, error) {
+	return glamour.NewTermRenderer(
+		glamour.WithStyles(glamourStyle(g.palette)),
+		glamour.WithColorProfile(g.profile),
+		glamour.WithWordWrap(g.width-4),
+		glamour.WithEmoji(),
+	)
+}
+
+// glamourStyle is the stylesheet for palette, in the built-in renderer's
+// glyphs and colors
+func glamourStyle(p theme.Palette) ansi.StyleConfig {
+	yes := true
+	color := func(c string) ansi.StylePrimitive { return ansi.StylePrimitive{Color: &c} }
+	bold := func(c string) ansi.StylePrimitive { return ansi.StylePrimitive{Color: &c, Bold: &yes} }
+	italic := func(c string) ansi.StylePrimitive { return ansi.StylePrimitive{Color: &c, Italic: &yes} }
+	prefixed := func(prefix string, style ansi.StylePrimitive) ansi.StyleBlock {
+		style.Prefix = prefix
+		return ansi.StyleBlock{StylePrimitive: style}
+	}
+	uintp := func(n uint) *uint { return &n }
+	strp := func(s string) *string { return &s }
+
+	heading := bold(p.Neon)
+	heading.BlockSuffix = "\n"
+	h1 := prefixed("═══ ", ansi.StylePrimitive{})
+	h1.Suffix = " ═══"
+	enumeration := color(p.Yellow)
+	enumeration.BlockPrefix = ". "
+	hr := color(p.Dim)
+	hr.Format = "\n────────────────────────────────────────────\n"
+	linkText := color(p.Blue)
+	linkText.Underline = &yes
+	imageText := color(p.Purple)
+	imageText.Format = "Image: {{.text}} →"
+	code := color(p.Green)
+	code.Prefix, code.Suffix = "⟨", "⟩"
+
+	return ansi.StyleConfig{
+		Document: ansi.StyleBlock{StylePrimitive: color(p.BodyText), Margin: uintp(0)},
+		BlockQuote: ansi.StyleBlock{
+			StylePrimitive: italic(p.Muted),
+			Indent:         uintp(1),
+			IndentToken:    strp("┃ "),
+		},
+		List:           ansi.StyleList{LevelIndent: 2},
+		Heading:        ansi.StyleBlock{StylePrimitive: heading},
+		H1:             h1,
+		H2:             prefixed("◈ ", ansi.StylePrimitive{}),
+		H3:             prefixed("◆ ", color(p.Cyan)),
+		H4:             prefixed("▸ ", color(p.Yellow)),
+		H5:             prefixed("▸ ", color(p.Yellow)),
+		H6:             prefixed("▸ ", color(p.Muted)),
+		Strikethrough:  ansi.StylePrimitive{CrossedOut: &yes},
+		Emph:           italic(p.Muted),
+		Strong:         bold(p.Neon),
+		HorizontalRule: hr,
+		Item:           ansi.StylePrimitive{BlockPrefix: "▹ "},
+		Enumeration:    enumeration,
+		Task:           ansi.StyleTask{Ticked: "[✓] ", Unticked: "[ ] "},
+		Link:           color(p.Dim),
+		LinkText:       linkText,
+		Image:          color(p.Purple),
+		ImageText:      imageText,
+		Code:           ansi.StyleBlock{StylePrimitive: code},
+		CodeBlock: ansi.StyleCodeBlock{
+			StyleBlock: ansi.StyleBlock{StylePrimitive: color(p.Green), Margin: uintp(1)},
+			Chroma: &ansi.Chroma{
+				Text:                color(p.BodyText),
+				Error:               color(p.Red),
+				Comment:             italic(p.Muted),
+				CommentPreproc:      color(p.Orange),
+				Keyword:             bold(p.Neon),
+				KeywordType:         color(p.Cyan),
+				Operator:            color(p.Yellow),
+				Punctuation:         color(p.Dim),
+				NameBuiltin:         color(p.Cyan),
+				NameFunction:        color(p.Yellow),
+				NameTag:             color(p.Neon),
+				NameAttribute:       color(p.Cyan),
+				LiteralNumber:       color(p.Purple),
+				LiteralString:       color(p.Green),
+				LiteralStringEscape: color(p.Orange),
+				GenericDeleted:      color(p.Red),
+				GenericInserted:     color(p.Green),
+				GenericEmph:         ansi.StylePrimitive{Italic: &yes},
+				GenericStrong:       ansi.StylePrimitive{Bold: &yes},
+			},
+		},
+		Table: ansi.StyleTable{
+			CenterSeparator: strp("┼"),
+			ColumnSeparator: strp("│"),
+			RowSeparator:    strp("─"),
+		},
+		DefinitionDescription: ansi.StylePrimitive{BlockPrefix: "\n▸ "},
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// IntroFrames is the length of the banner glitch intro in frames
+const IntroFrames = 16
+
+// glitchGlyphs are the noise characters unresolved banner cells cycle through
+var glitchGlyphs = []rune("░▒▓█▀▄▌▐#%&@$*+=<>/\\|")
+
+// glitchHash mixes a cell position and frame into a stable pseudo-random value.
+// Deterministic so identical frames render identically.
+func glitchHash(row, col, frame int) uint32 {
+	h := uint32(row)*73856093 ^ uint32(col)*19349663 ^ uint32(frame)*83492791
+	h ^= h >> 13
+	h *= 0x5bd1e995
+	h ^= h >> 15
+	return h
+}
+
+// glitchLine renders one banner row at the given intro frame. Each cell
+// resolves at its own frame; until then it shows flickering noise.
+func glitchLine(styles theme.Styles, line string, row int, style lipgloss.Style, frame int) string {
+	noise := []lipgloss.Style{styles.Neon, styles.Cyan, styles.Purple, styles.Yellow, styles.Dim}
+
+	var b strings.Builder
+	for col, r := range []rune(line) {
+		if r == ' ' {
+			b.WriteRune(r)
+			continue
+		}
+		// Cells resolve over the first three quarters so the end holds still
+		if int(glitchHash(row, col, 0)%uint32(IntroFrames*3/4)) < frame {
+			b.WriteString(style.Render(string(r)))
+			continue
+		}
+		h := glitchHash(row, col, frame)
+		glyph := glitchGlyphs[h%uint32(len(glitchGlyphs))]
+		b.WriteString(noise[(h>>8)%uint32(len(noise))].Render(string(glyph)))
+	}
+	return b.String()
+}
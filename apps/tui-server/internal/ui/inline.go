@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// inlineFlags are the emphasis styles active on a span
+type inlineFlags struct {
+	bold   bool
+	italic bool
+	strike bool
+}
+
+// inlineSpan is a run of text sharing one set of inline styles
+type inlineSpan struct {
+	text  string
+	flags inlineFlags
+	code  bool
+	url   string // set for link text
+}
+
+// parseInline tokenizes inline markdown (code, bold, italic, strikethrough,
+// links, backslash escapes) into styled spans. Emphasis nests freely; code
+// spans are literal. Unmatched delimiters are kept as plain text.
+func parseInline(text string) []inlineSpan {
+	var spans []inlineSpan
+	parseInlineInto(&spans, text, inlineFlags{}, "")
+	return mergeSpans(spans)
+}
+
+func parseInlineInto(spans *[]inlineSpan, s string, flags inlineFlags, url string) {
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			*spans = append(*spans, inlineSpan{text: plain.String(), flags: flags, url: url})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.ContainsRune("\\`*_~[]()", rune(s[i+1])):
+			plain.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				flush()
+				*spans = append(*spans, inlineSpan{text: s[i+1 : i+1+end], flags: flags, code: true, url: url})
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "**") || strings.HasPrefix(s[i:], "__") || strings.HasPrefix(s[i:], "~~"):
+			delim := s[i : i+2]
+			if delim == "__" && !wordBoundaryBefore(s, i) {
+				break
+			}
+			if end := findClosing(s, i+2, delim); end > i+2 {
+				flush()
+				inner := flags
+				if delim == "~~" {
+					inner.strike = true
+				} else {
+					inner.bold = true
+				}
+				parseInlineInto(spans, s[i+2:end], inner, url)
+				i = end + 2
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if c == '_' && !wordBoundaryBefore(s, i) {
+				break
+			}
+			if end := findClosing(s, i+1, string(c)); end > i+1 {
+				flush()
+				inner := flags
+				inner.italic = true
+				parseInlineInto(spans, s[i+1:end], inner, url)
+				i = end + 1
+				continue
+			}
+
+		case c == '[' && url == "":
+			if textEnd := findClosing(s, i+1, "]"); textEnd > i+1 && strings.HasPrefix(s[textEnd:], "](") {
+				if urlEnd := strings.IndexByte(s[textEnd+2:], ')'); urlEnd > 0 {
+					flush()
+					parseInlineInto(spans, s[i+1:textEnd], flags, s[textEnd+2:textEnd+2+urlEnd])
+					i = textEnd + 3 + urlEnd
+					continue
+				}
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		plain.WriteString(s[i : i+size])
+		i += size
+	}
+	flush()
+}
+
+// findClosing returns the index of the next delim at or after from that is
+// outside code spans, or -1. A single * or _ never matches half of a double.
+func findClosing(s string, from int, delim string) int {
+	for i := from; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '`' && delim != "`":
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case strings.HasPrefix(s[i:], delim):
+			if len(delim) == 1 && (delim == "*" || delim == "_") && i+1 < len(s) && s[i+1] == delim[0] {
+				i++ // skip the double delimiter; it belongs to bold
+				continue
+			}
+			if delim == "_" && i+1 < len(s) && isWordByte(s[i+1]) {
+				continue // intra-word underscore, e.g. snake_case
+			}
+			// In a run like "***", the double closes last: ***both***
+			for len(delim) == 2 && i+2 < len(s) && s[i+2] == delim[0] {
+				i++
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// wordBoundaryBefore reports whether position i starts a word, so that
+// underscores inside identifiers are not read as emphasis
+func wordBoundaryBefore(s string, i int) bool {
+	return i == 0 || !isWordByte(s[i-1])
+}
+
+func isWordByte(b byte) bool {
+	r := rune(b)
+	return b >= utf8.RuneSelf || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// mergeSpans joins adjacent spans with identical styling
+func mergeSpans(spans []inlineSpan) []inlineSpan {
+	var out []inlineSpan
+	for _, sp := range spans {
+		if n := len(out); n > 0 && !sp.code && !out[n-1].code && out[n-1].flags == sp.flags && out[n-1].url == sp.url {
+			out[n-1].text += sp.text
+			continue
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+// renderInline renders inline markdown with theme styles
+func (r *MarkdownRenderer) renderInline(text string) string {
+	spans := parseInline(text)
+
+	var b strings.Builder
+	for i, sp := range spans {
+		b.WriteString(r.renderSpan(sp))
+		// A link's URL follows the last span of its text
+		if sp.url != "" && (i == len(spans)-1 || spans[i+1].url != sp.url) {
+			b.WriteString(r.styles.Dim.Render(" (" + sp.url + ")"))
+		}
+	}
+	return b.String()
+}
+
+// renderSpan styles one span, layering its emphasis on the base style
+func (r *MarkdownRenderer) renderSpan(sp inlineSpan) string {
+	text := sp.text
+	if r.styles.Accessible && sp.flags.strike {
+		text = "[deleted: " + text + "]"
+	}
+
+	style := r.styles.Body
+	switch {
+	case sp.code:
+		style = r.styles.Green
+	case sp.url != "":
+		style = r.styles.Blue.Underline(true)
+	case sp.flags.bold:
+		style = r.styles.Neon
+	case sp.flags.italic:
+		style = r.styles.Muted
+	case !sp.flags.strike:
+		return text // plain text stays unstyled
+	}
+	style = style.Bold(sp.flags.bold).Italic(sp.flags.italic).Strikethrough(sp.flags.strike)
+
+	if sp.code {
+		return r.styles.Cyan.Render("⟨") + style.Render(text) + r.styles.Cyan.Render("⟩")
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInline(t *testing.T) {
+	t.Parallel()
+
+	bold := inlineFlags{bold: true}
+	testCases := []struct {
+		name     string
+		text     string
+		expected []inlineSpan
+	}{
+		{
+			name:     "plain",
+			text:     "just text",
+			expected: []inlineSpan{{text: "just text"}},
+		},
+		{
+			name: "strikethrough",
+			text: "was ~~old~~ new",
+			expected: []inlineSpan{
+				{text: "was "},
+				{text: "old", flags: inlineFlags{strike: true}},
+				{text: " new"},
+			},
+		},
+		{
+			name: "bold with code and link",
+			text: "**bold `code` with [link](https://x.dev)**",
+			expected: []inlineSpan{
+				{text: "bold ", flags: bold},
+				{text: "code", flags: bold, code: true},
+				{text: " with ", flags: bold},
+				{text: "link", flags: bold, url: "https://x.dev"},
+			},
+		},
+		{
+			name: "code is literal",
+			text: "`**not bold**`",
+			expected: []inlineSpan{
+				{text: "**not bold**", code: true},
+			},
+		},
+		{
+			name: "bold italic",
+			text: "***both***",
+			expected: []inlineSpan{
+				{text: "both", flags: inlineFlags{bold: true, italic: true}},
+			},
+		},
+		{
+			name:     "snake_case is not emphasis",
+			text:     "use snake_case_names here",
+			expected: []inlineSpan{{text: "use snake_case_names here"}},
+		},
+		{
+			name:     "unmatched delimiter",
+			text:     "2 * 3 = 6",
+			expected: []inlineSpan{{text: "2 * 3 = 6"}},
+		},
+		{
+			name:     "escaped",
+			text:     `\*literal\*`,
+			expected: []inlineSpan{{text: "*literal*"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := parseInline(testCase.text); !reflect.DeepEqual(actual, testCase.expected) {
+				t.Fatalf("expected %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
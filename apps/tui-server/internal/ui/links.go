@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// markdownLinkPattern matches a markdown link's URL, or a bare URL
+var markdownLinkPattern = regexp.MustCompile(`\]\((https?://[^)\s]+)\)|https?://[^\s()<>\[\]]+`)
+
+// MarkdownLinks are the URLs in markdown text, each once, in order
+func MarkdownLinks(text string) []string {
+	var links []string
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(text, -1) {
+		link := match[1]
+		if link == "" {
+			// A bare URL ending a sentence doesn't take its punctuation
+			link = strings.TrimRight(match[0], ".,;:!?'\"")
+		}
+		if !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// LinkList numbers the links on screen, to be copied with /link or, with
+// keys, the number keys. The link shown is written out in full, wrapped
+// rather than cut to fit, for terminals that can't take a copy.
+func LinkList(styles theme.Styles, links []string, shown string, keys bool, width int) string {
+	if len(links) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if styles.Accessible {
+		b.WriteString("Links, type /link and the number to copy one:\n")
+		for i, link := range links {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, link)
+		}
+		return b.String()
+	}
+
+	how := "/link N"
+	switch {
+	case keys && len(links) == 1:
+		how = "1"
+	case keys:
+		how = fmt.Sprintf("1-%d", min(len(links), 9))
+	}
+	b.WriteString(styles.Dim.Render("┄ links ") + styles.Yellow.Render(how) + styles.Dim.Render(" copy"))
+	b.WriteString("\n")
+	for i, link := range links {
+		num := styles.Cyan.Render(fmt.Sprintf("[%d]", i+1)) + " "
+		if link != shown {
+			b.WriteString(num + Hyperlink(link, styles.Link.Render(textutil.Truncate(link, max(width-textutil.Width(num), 10)))) + "\n")
+			continue
+		}
+		// Unindented, so a selection spanning the rows is the whole URL
+		b.WriteString(num + styles.Dim.Render("copied") + "\n")
+		for _, part := range strings.Split(ansi.Hardwrap(link, max(width, 10), false), "\n") {
+			b.WriteString(Hyperlink(link, styles.Highlight.Render(part)) + "\n")
+		}
+	}
+	return b.String()
+}
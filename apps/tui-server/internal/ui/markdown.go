@@ -1,10 +1,11 @@
 package ui
 
 import (
-	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
 )
 
@@ -12,6 +13,8 @@ import (
 type MarkdownRenderer struct {
 	styles   theme.Styles
 	maxWidth int
+	lists    listStack // indentation of each open list level
+	stream   streamCache
 }
 
 // NewMarkdownRenderer creates a new markdown renderer
@@ -36,10 +39,16 @@ func (r *MarkdownRenderer) SetWidth(width int) {
 
 // Render converts markdown text to styled terminal output
 func (r *MarkdownRenderer) Render(text string) string {
-	lines := strings.Split(text, "\n")
+	r.lists = r.lists[:0]
+	return r.render(strings.Split(text, "\n"))
+}
+
+// render renders markdown lines, continuing from the current list state
+func (r *MarkdownRenderer) render(lines []string) string {
 	var result strings.Builder
 	inCodeBlock := false
 	codeBlockLang := ""
+	codeLine, codeDigits := 0, 0 // line number in the code block, and the digits the last takes
 
 	// Calculate content width (leave room for borders/prefix)
 	contentWidth := r.maxWidth - 4
@@ -49,15 +58,35 @@ func (r *MarkdownRenderer) Render(text string) string {
 		line := lines[i]
 
 		// Code block handling
+		if strings.HasPrefix(line, "```") && r.styles.Accessible {
+			// Announce code blocks in words instead of drawing a frame
+			if !inCodeBlock {
+				inCodeBlock = true
+				codeBlockLang = strings.TrimPrefix(line, "```")
+				label := "Code:"
+				if codeBlockLang != "" {
+					label = "Code (" + codeBlockLang + "):"
+				}
+				result.WriteString(r.styles.Cyan.Render(label))
+			} else {
+				inCodeBlock = false
+				codeBlockLang = ""
+				result.WriteString(r.styles.Cyan.Render("End of code."))
+			}
+			result.WriteString("\n")
+			i++
+			continue
+		}
 		if strings.HasPrefix(line, "```") {
 			if !inCodeBlock {
 				inCodeBlock = true
 				codeBlockLang = strings.TrimPrefix(line, "```")
+				codeLine, codeDigits = 0, len(strconv.Itoa(codeBlockLines(lines[i+1:])))
 				borderLen := min(contentWidth-4, 40)
 				result.WriteString(r.styles.Dim.Render("┌─"))
 				if codeBlockLang != "" {
 					result.WriteString(r.styles.Cyan.Render(" " + codeBlockLang + " "))
-					borderLen -= len(codeBlockLang) + 2
+					borderLen -= textutil.Width(codeBlockLang) + 2
 				}
 				result.WriteString(r.styles.Dim.Render(strings.Repeat("─", max(borderLen, 10))))
 				result.WriteString("\n")
@@ -72,14 +101,18 @@ func (r *MarkdownRenderer) Render(text string) string {
 			continue
 		}
 
+		if inCodeBlock && r.styles.Accessible {
+			result.WriteString(r.styles.Green.Render(line))
+			result.WriteString("\n")
+			i++
+			continue
+		}
 		if inCodeBlock {
-			// Code blocks: truncate if too long, don't wrap
-			codeLine := line
-			if len(codeLine) > contentWidth-4 {
-				codeLine = codeLine[:contentWidth-7] + "..."
-			}
-			result.WriteString(r.styles.Dim.Render("│ "))
-			result.WriteString(r.styles.Green.Render(codeLine))
+			// Code blocks: numbered, truncated if too long, not wrapped
+			codeLine++
+			number := strconv.Itoa(codeLine)
+			result.WriteString(r.styles.Dim.Render("│ " + strings.Repeat(" ", codeDigits-len(number)) + number + " "))
+			result.WriteString(r.styles.Green.Render(textutil.Truncate(line, contentWidth-5-codeDigits)))
 			result.WriteString("\n")
 			i++
 			continue
@@ -109,6 +142,33 @@ func (r *MarkdownRenderer) Render(text string) string {
 	return strings.TrimSuffix(result.String(), "\n")
 }
 
+// codeBlockLines counts the lines of a code block up to its closing
+// fence, or to the end while it's still arriving
+func codeBlockLines(lines []string) int {
+	for n, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			return n
+		}
+	}
+	return len(lines)
+}
+
+// CodeBlocks are the contents of the fenced code blocks in markdown text,
+// in order. A block still open at the end runs to it.
+func CodeBlocks(text string) []string {
+	var blocks []string
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "```") {
+			continue
+		}
+		n := codeBlockLines(lines[i+1:])
+		blocks = append(blocks, strings.Join(lines[i+1:i+1+n], "\n"))
+		i += n + 1
+	}
+	return blocks
+}
+
 func (r *MarkdownRenderer) isTableRow(line string) bool {
 	trimmed := strings.TrimSpace(line)
 	return strings.HasPrefix(trimmed, "|") && strings.HasSuffix(trimmed, "|")
@@ -145,6 +205,12 @@ func (r *MarkdownRenderer) renderTable(lines []string, maxWidth int) string {
 	header := r.parseTableRow(lines[0])
 	numCols := len(header)
 
+	if r.styles.Accessible {
+		return r.renderTableLinear(header, lines[2:])
+	}
+
+	aligns := r.parseTableAlignments(lines[1], numCols)
+
 	var dataRows [][]string
 	for i := 2; i < len(lines); i++ {
 		if !r.isTableSeparator(lines[i]) {
@@ -156,159 +222,195 @@ func (r *MarkdownRenderer) renderTable(lines []string, maxWidth int) string {
 		}
 	}
 
-	// Calculate column widths, respecting maxWidth
+	// Natural column widths: the widest cell plus one space each side
 	colWidths := make([]int, numCols)
 	for i, h := range header {
-		if len(h) > colWidths[i] {
-			colWidths[i] = len(h)
-		}
+		colWidths[i] = max(colWidths[i], textutil.Width(h))
 	}
 	for _, row := range dataRows {
 		for i, cell := range row {
-			if i < numCols && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
-			}
+			colWidths[i] = max(colWidths[i], textutil.Width(cell))
 		}
 	}
-
-	// Add padding and enforce max column width
-	totalWidth := numCols + 1 // borders
-	maxColWidth := (maxWidth - totalWidth) / numCols
-	if maxColWidth < 8 {
-		maxColWidth = 8
+	for i := range colWidths {
+		colWidths[i] = max(colWidths[i]+2, 5)
 	}
+	fitColumns(colWidths, maxWidth-numCols-1)
 
-	for i := range colWidths {
-		colWidths[i] += 2
-		if colWidths[i] > maxColWidth {
-			colWidths[i] = maxColWidth
-		}
-		if colWidths[i] < 5 {
-			colWidths[i] = 5
+	var result strings.Builder
+	border := func(left, mid, right string) {
+		result.WriteString(r.styles.Cyan.Render(left))
+		for i, w := range colWidths {
+			result.WriteString(r.styles.Dim.Render(strings.Repeat("─", w)))
+			if i < numCols-1 {
+				result.WriteString(r.styles.Cyan.Render(mid))
+			}
 		}
+		result.WriteString(r.styles.Cyan.Render(right))
+		result.WriteString("\n")
 	}
 
-	var result strings.Builder
+	border("┌", "┬", "┐")
+	r.writeTableRow(&result, header, colWidths, aligns, r.styles.Cyan, r.styles.Neon.Bold(true))
+	border("├", "┼", "┤")
 
-	// Top border
-	result.WriteString(r.styles.Cyan.Render("┌"))
-	for i, w := range colWidths {
-		result.WriteString(r.styles.Dim.Render(strings.Repeat("─", w)))
-		if i < numCols-1 {
-			result.WriteString(r.styles.Cyan.Render("┬"))
-		}
+	colorCycle := []lipgloss.Style{r.styles.Body, r.styles.Muted}
+	for rowIdx, row := range dataRows {
+		r.writeTableRow(&result, row, colWidths, aligns, r.styles.Dim, colorCycle[rowIdx%2])
 	}
-	result.WriteString(r.styles.Cyan.Render("┐"))
-	result.WriteString("\n")
 
-	// Header row
-	result.WriteString(r.styles.Cyan.Render("│"))
-	for i, h := range header {
-		cell := r.padCenter(r.truncateCell(h, colWidths[i]-2), colWidths[i])
-		result.WriteString(r.styles.Neon.Bold(true).Render(cell))
-		if i < numCols-1 {
-			result.WriteString(r.styles.Cyan.Render("│"))
+	border("└", "┴", "┘")
+
+	return strings.TrimSuffix(result.String(), "\n")
+}
+
+// tableAlign is a column's alignment from the separator row
+type tableAlign int
+
+const (
+	alignLeft tableAlign = iota
+	alignCenter
+	alignRight
+)
+
+// parseTableAlignments reads :--- / :---: / ---: markers per column
+func (r *MarkdownRenderer) parseTableAlignments(separator string, numCols int) []tableAlign {
+	aligns := make([]tableAlign, numCols)
+	for i, cell := range r.parseTableRow(separator) {
+		if i >= numCols {
+			break
 		}
-	}
-	result.WriteString(r.styles.Cyan.Render("│"))
-	result.WriteString("\n")
-
-	// Header separator
-	result.WriteString(r.styles.Cyan.Render("├"))
-	for i, w := range colWidths {
-		result.WriteString(r.styles.Dim.Render(strings.Repeat("─", w)))
-		if i < numCols-1 {
-			result.WriteString(r.styles.Cyan.Render("┼"))
+		left := strings.HasPrefix(cell, ":")
+		right := strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns[i] = alignCenter
+		case right:
+			aligns[i] = alignRight
 		}
 	}
-	result.WriteString(r.styles.Cyan.Render("┤"))
-	result.WriteString("\n")
+	return aligns
+}
 
-	// Data rows
-	colorCycle := []lipgloss.Style{r.styles.Body, r.styles.Muted}
-	for rowIdx, row := range dataRows {
-		result.WriteString(r.styles.Dim.Render("│"))
-		rowStyle := colorCycle[rowIdx%2]
-		for i, cell := range row {
-			paddedCell := r.padCenter(r.truncateCell(cell, colWidths[i]-2), colWidths[i])
-			result.WriteString(rowStyle.Render(paddedCell))
-			if i < numCols-1 {
-				result.WriteString(r.styles.Dim.Render("│"))
+// fitColumns shrinks the widest columns until the total fits in available,
+// never below five cells
+func fitColumns(widths []int, available int) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > available {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
 			}
 		}
-		result.WriteString(r.styles.Dim.Render("│"))
-		result.WriteString("\n")
-	}
-
-	// Bottom border
-	result.WriteString(r.styles.Cyan.Render("└"))
-	for i, w := range colWidths {
-		result.WriteString(r.styles.Dim.Render(strings.Repeat("─", w)))
-		if i < numCols-1 {
-			result.WriteString(r.styles.Cyan.Render("┴"))
+		if widths[widest] <= 5 {
+			return
 		}
+		widths[widest]--
+		total--
 	}
-	result.WriteString(r.styles.Cyan.Render("┘"))
-
-	return result.String()
 }
 
-func (r *MarkdownRenderer) truncateCell(text string, maxLen int) string {
-	if len(text) <= maxLen {
-		return text
+// writeTableRow writes one logical row, wrapping long cells onto as many
+// physical lines as the tallest cell needs
+func (r *MarkdownRenderer) writeTableRow(b *strings.Builder, cells []string, widths []int, aligns []tableAlign, borderStyle, cellStyle lipgloss.Style) {
+	wrapped := make([][]string, len(cells))
+	height := 1
+	for i, cell := range cells {
+		wrapped[i] = strings.Split(textutil.Wrap(cell, widths[i]-2), "\n")
+		height = max(height, len(wrapped[i]))
 	}
-	if maxLen <= 3 {
-		return "..."
+
+	for line := 0; line < height; line++ {
+		b.WriteString(borderStyle.Render("│"))
+		for i := range cells {
+			text := ""
+			if line < len(wrapped[i]) {
+				text = wrapped[i][line]
+			}
+			inner := widths[i] - 2
+			switch aligns[i] {
+			case alignCenter:
+				text = textutil.PadCenter(text, inner)
+			case alignRight:
+				text = textutil.PadLeft(text, inner)
+			default:
+				text = textutil.Pad(text, inner)
+			}
+			b.WriteString(cellStyle.Render(" " + text + " "))
+			if i < len(cells)-1 {
+				b.WriteString(borderStyle.Render("│"))
+			}
+		}
+		b.WriteString(borderStyle.Render("│"))
+		b.WriteString("\n")
 	}
-	return text[:maxLen-3] + "..."
 }
 
-func (r *MarkdownRenderer) padCenter(text string, width int) string {
-	textLen := len(text)
-	if textLen >= width {
-		return text
+// renderTableLinear reads a table row by row as "header: value" pairs,
+// which screen readers follow far better than a drawn grid
+func (r *MarkdownRenderer) renderTableLinear(header []string, rows []string) string {
+	var out []string
+	for n, line := range rows {
+		if r.isTableSeparator(line) {
+			continue
+		}
+		cells := r.parseTableRow(line)
+		pairs := make([]string, 0, len(cells))
+		for i, cell := range cells {
+			if i < len(header) && header[i] != "" {
+				cell = header[i] + ": " + cell
+			}
+			pairs = append(pairs, cell)
+		}
+		out = append(out, r.styles.Body.Render("Row "+strconv.Itoa(n+1)+": "+strings.Join(pairs, "; ")))
 	}
-	leftPad := (width - textLen) / 2
-	rightPad := width - textLen - leftPad
-	return strings.Repeat(" ", leftPad) + text + strings.Repeat(" ", rightPad)
+	return strings.Join(out, "\n")
 }
 
 func (r *MarkdownRenderer) renderLine(line string, maxWidth int) string {
+	if item := listItemRe.FindStringSubmatch(line); item != nil {
+		return r.renderListItem(item, maxWidth)
+	}
+	// Any unindented text ends the current list
+	if trimmed := strings.TrimSpace(line); trimmed != "" && trimmed == strings.TrimRight(line, " \t") {
+		r.lists = r.lists[:0]
+	}
+
+	if r.styles.Accessible {
+		return r.renderLineLinear(line, maxWidth)
+	}
+
 	// Headers - don't wrap, truncate if needed
 	if strings.HasPrefix(line, "#### ") {
 		text := strings.TrimPrefix(line, "#### ")
-		if len(text) > maxWidth-4 {
-			text = text[:maxWidth-7] + "..."
-		}
+		text = textutil.Truncate(text, maxWidth-4)
 		return r.styles.Yellow.Render("▸ ") + r.styles.Yellow.Render(text)
 	}
 	if strings.HasPrefix(line, "### ") {
 		text := strings.TrimPrefix(line, "### ")
-		if len(text) > maxWidth-4 {
-			text = text[:maxWidth-7] + "..."
-		}
+		text = textutil.Truncate(text, maxWidth-4)
 		return r.styles.Cyan.Render("◆ ") + r.styles.Cyan.Bold(true).Render(text)
 	}
 	if strings.HasPrefix(line, "## ") {
 		text := strings.TrimPrefix(line, "## ")
-		if len(text) > maxWidth-4 {
-			text = text[:maxWidth-7] + "..."
-		}
+		text = textutil.Truncate(text, maxWidth-4)
 		return r.styles.Neon.Render("◈ ") + r.styles.Neon.Bold(true).Render(text)
 	}
 	if strings.HasPrefix(line, "# ") {
 		text := strings.TrimPrefix(line, "# ")
 		headerWidth := maxWidth - 8
-		if len(text) > headerWidth {
-			text = text[:headerWidth-3] + "..."
-		}
+		text = textutil.Truncate(text, headerWidth)
 		return r.styles.Neon.Bold(true).Render("═══ " + text + " ═══")
 	}
 
 	// Blockquote
 	if strings.HasPrefix(line, "> ") {
 		text := strings.TrimPrefix(line, "> ")
-		wrapped := r.wrapText(text, maxWidth-4)
+		wrapped := textutil.Wrap(text, maxWidth-4)
 		lines := strings.Split(wrapped, "\n")
 		var result strings.Builder
 		for i, l := range lines {
@@ -326,231 +428,26 @@ func (r *MarkdownRenderer) renderLine(line string, maxWidth int) string {
 		return r.styles.Dim.Render(strings.Repeat("─", ruleLen))
 	}
 
-	// Unordered list items - wrap with indent
-	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
-		text := line[2:]
-		text = r.renderInline(text)
-		wrapped := r.wrapText(text, maxWidth-6)
-		lines := strings.Split(wrapped, "\n")
-		var result strings.Builder
-		for i, l := range lines {
-			if i == 0 {
-				result.WriteString(r.styles.Green.Render("  ▹ ") + l)
-			} else {
-				result.WriteString("\n" + r.styles.Dim.Render("    ") + l)
-			}
-		}
-		return result.String()
-	}
-
-	// Nested list items
-	if strings.HasPrefix(line, "  - ") || strings.HasPrefix(line, "  * ") {
-		text := line[4:]
-		text = r.renderInline(text)
-		wrapped := r.wrapText(text, maxWidth-8)
-		lines := strings.Split(wrapped, "\n")
-		var result strings.Builder
-		for i, l := range lines {
-			if i == 0 {
-				result.WriteString(r.styles.Cyan.Render("    ◦ ") + l)
-			} else {
-				result.WriteString("\n" + r.styles.Dim.Render("      ") + l)
-			}
-		}
-		return result.String()
-	}
-
-	// Ordered list items
-	if matched, _ := regexp.MatchString(`^\d+\.\s`, line); matched {
-		parts := strings.SplitN(line, ". ", 2)
-		if len(parts) == 2 {
-			num := parts[0]
-			text := r.renderInline(parts[1])
-			wrapped := r.wrapText(text, maxWidth-6)
-			lines := strings.Split(wrapped, "\n")
-			var result strings.Builder
-			for i, l := range lines {
-				if i == 0 {
-					result.WriteString(r.styles.Yellow.Render("  "+num+". ") + l)
-				} else {
-					result.WriteString("\n" + r.styles.Dim.Render("     ") + l)
-				}
-			}
-			return result.String()
-		}
-	}
-
 	// Regular paragraph - wrap and apply inline formatting
 	text := r.renderInline(line)
-	return r.wrapText(text, maxWidth)
-}
-
-// wrapText wraps plain text to maxWidth
-func (r *MarkdownRenderer) wrapText(text string, maxWidth int) string {
-	if maxWidth <= 0 {
-		maxWidth = 60
-	}
-
-	// For styled text, use visible width
-	visibleWidth := lipgloss.Width(text)
-	if visibleWidth <= maxWidth {
-		return text
-	}
-
-	// If it contains ANSI codes, try simpler approach
-	if strings.Contains(text, "\x1b[") {
-		return r.wrapStyledText(text, maxWidth)
-	}
-
-	// Plain text word wrapping
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return ""
-	}
-
-	var result strings.Builder
-	currentLen := 0
-
-	for i, word := range words {
-		wordLen := len(word)
-
-		// Word too long - break it
-		if wordLen > maxWidth {
-			if currentLen > 0 {
-				result.WriteString("\n")
-				currentLen = 0
-			}
-			for j := 0; j < len(word); j += maxWidth - 1 {
-				end := j + maxWidth - 1
-				if end > len(word) {
-					end = len(word)
-				}
-				if j > 0 {
-					result.WriteString("\n")
-				}
-				result.WriteString(word[j:end])
-			}
-			currentLen = len(word) % (maxWidth - 1)
-			continue
-		}
-
-		spaceNeeded := wordLen
-		if i > 0 && currentLen > 0 {
-			spaceNeeded++
-		}
-
-		if currentLen+spaceNeeded > maxWidth {
-			result.WriteString("\n")
-			currentLen = 0
-		} else if currentLen > 0 {
-			result.WriteString(" ")
-			currentLen++
-		}
-
-		result.WriteString(word)
-		currentLen += wordLen
-	}
-
-	return result.String()
-}
-
-// wrapStyledText attempts to wrap text with ANSI codes
-func (r *MarkdownRenderer) wrapStyledText(text string, maxWidth int) string {
-	// Simplified: just return as-is if it's styled
-	// The caller should handle overflow via viewport scrolling
-	return text
-}
-
-func (r *MarkdownRenderer) renderInline(text string) string {
-	text = r.processInlineCode(text)
-	text = r.processBold(text)
-	text = r.processItalic(text)
-	text = r.processLinks(text)
-	return text
-}
-
-func (r *MarkdownRenderer) processInlineCode(text string) string {
-	re := regexp.MustCompile("`([^`]+)`")
-	return re.ReplaceAllStringFunc(text, func(match string) string {
-		code := strings.Trim(match, "`")
-		return r.styles.Cyan.Render("⟨") + r.styles.Green.Render(code) + r.styles.Cyan.Render("⟩")
-	})
-}
-
-func (r *MarkdownRenderer) processBold(text string) string {
-	re := regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
-	return re.ReplaceAllStringFunc(text, func(match string) string {
-		inner := strings.Trim(match, "*_")
-		return r.styles.Neon.Bold(true).Render(inner)
-	})
-}
-
-func (r *MarkdownRenderer) processItalic(text string) string {
-	re := regexp.MustCompile(`\*([^*]+)\*`)
-	return re.ReplaceAllStringFunc(text, func(match string) string {
-		inner := strings.Trim(match, "*")
-		if inner == "" {
-			return match
-		}
-		return r.styles.Muted.Italic(true).Render(inner)
-	})
+	return textutil.Wrap(text, maxWidth)
 }
 
-func (r *MarkdownRenderer) processLinks(text string) string {
-	re := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
-	return re.ReplaceAllStringFunc(text, func(match string) string {
-		re2 := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
-		matches := re2.FindStringSubmatch(match)
-		if len(matches) == 3 {
-			linkText := matches[1]
-			url := matches[2]
-			return r.styles.Blue.Underline(true).Render(linkText) + r.styles.Dim.Render(" ("+url+")")
-		}
-		return match
-	})
-}
-
-// RenderStreaming renders partial markdown (for streaming)
-func (r *MarkdownRenderer) RenderStreaming(text string) string {
-	lines := strings.Split(text, "\n")
-	var result strings.Builder
-	inCodeBlock := false
-	contentWidth := r.maxWidth - 4
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "```") {
-			inCodeBlock = !inCodeBlock
-			if inCodeBlock {
-				lang := strings.TrimPrefix(line, "```")
-				borderLen := min(contentWidth-4, 30)
-				result.WriteString(r.styles.Dim.Render("┌─"))
-				if lang != "" {
-					result.WriteString(r.styles.Cyan.Render(" " + lang + " "))
-					borderLen -= len(lang) + 2
-				}
-				result.WriteString(r.styles.Dim.Render(strings.Repeat("─", max(borderLen, 5))))
-			} else {
-				result.WriteString(r.styles.Dim.Render("└" + strings.Repeat("─", min(contentWidth, 34))))
-			}
-			result.WriteString("\n")
-			continue
-		}
+// renderLineLinear renders a line without decorative glyphs: headings become
+// "Heading:" lines, quotes are announced and lists use plain markers
+func (r *MarkdownRenderer) renderLineLinear(line string, maxWidth int) string {
+	trimmed := strings.TrimLeft(line, " ")
 
-		if inCodeBlock {
-			codeLine := line
-			if len(codeLine) > contentWidth-4 {
-				codeLine = codeLine[:contentWidth-7] + "..."
-			}
-			result.WriteString(r.styles.Dim.Render("│ "))
-			result.WriteString(r.styles.Green.Render(codeLine))
-			result.WriteString("\n")
-			continue
+	switch {
+	case strings.HasPrefix(trimmed, "#"):
+		text := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+		if text != "" {
+			return r.styles.Neon.Bold(true).Render("Heading: " + text)
 		}
-
-		rendered := r.renderLine(line, contentWidth)
-		result.WriteString(rendered)
-		result.WriteString("\n")
+	case strings.HasPrefix(trimmed, "> "):
+		return textutil.Wrap(r.styles.Muted.Render("Quote: ")+r.renderInline(strings.TrimPrefix(trimmed, "> ")), maxWidth)
+	case trimmed == "---" || trimmed == "***" || trimmed == "___":
+		return ""
 	}
-
-	return strings.TrimSuffix(result.String(), "\n")
+	return textutil.Wrap(r.renderInline(line), maxWidth)
 }
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+)
+
+// listItemRe matches bullet (-, *, +) and ordered (1. or 1)) list items,
+// capturing indentation, marker and text
+var listItemRe = regexp.MustCompile(`^([ \t]*)([-*+]|\d+[.)])\s+(.*)$`)
+
+// bulletGlyphs are the bullet markers per nesting depth, cycling when deeper
+var bulletGlyphs = []string{"▹", "◦", "▪", "▫"}
+
+// listStack records the indentation column of each open list level
+type listStack []int
+
+// depth returns the nesting level for an item indented by indent columns,
+// closing deeper levels and opening a new one as needed
+func (s *listStack) depth(indent int) int {
+	for len(*s) > 0 && (*s)[len(*s)-1] > indent {
+		*s = (*s)[:len(*s)-1]
+	}
+	if len(*s) == 0 || (*s)[len(*s)-1] < indent {
+		*s = append(*s, indent)
+	}
+	return len(*s) - 1
+}
+
+// indentWidth measures leading whitespace, counting tabs as four columns
+func indentWidth(ws string) int {
+	n := 0
+	for _, r := range ws {
+		if r == '\t' {
+			n += 4
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// renderListItem renders one list item at its nesting depth, wrapping the
+// text so continuation lines align under the first character after the marker
+func (r *MarkdownRenderer) renderListItem(item []string, maxWidth int) string {
+	depth := r.lists.depth(indentWidth(item[1]))
+	marker := item[2]
+	ordered := marker != "-" && marker != "*" && marker != "+"
+
+	indent := strings.Repeat("  ", depth+1)
+	if r.styles.Accessible {
+		indent = strings.Repeat("  ", depth)
+		if !ordered {
+			marker = "-"
+		}
+		return r.wrapHanging(indent+marker+" ", r.renderInline(item[3]), maxWidth)
+	}
+
+	markerStyles := []lipgloss.Style{r.styles.Green, r.styles.Cyan, r.styles.Purple, r.styles.Yellow}
+	style := markerStyles[depth%len(markerStyles)]
+	if ordered {
+		style = r.styles.Yellow
+	} else {
+		marker = bulletGlyphs[depth%len(bulletGlyphs)]
+	}
+
+	return r.wrapHanging(indent+style.Render(marker)+" ", r.renderInline(item[3]), maxWidth)
+}
+
+// wrapHanging wraps text after prefix, indenting continuation lines to
+// the prefix's width
+func (r *MarkdownRenderer) wrapHanging(prefix, text string, maxWidth int) string {
+	prefixWidth := textutil.Width(prefix)
+	wrapped := textutil.Wrap(text, max(maxWidth-prefixWidth, 10))
+	pad := strings.Repeat(" ", prefixWidth)
+
+	lines := strings.Split(wrapped, "\n")
+	for i, l := range lines {
+		if i == 0 {
+			lines[i] = prefix + l
+		} else {
+			lines[i] = pad + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// streamCache holds the rendered form of the completed blocks of a
+// response that is still streaming in
+type streamCache struct {
+	source   string    // prefix of the response covered by rendered
+	rendered string    // output for source, ending in a newline
+	lists    listStack // list nesting after the last cached block
+	width    int
+	gen      int // Generation of the styles it was rendered with
+}
+
+// SetStyles swaps the renderer's styles. Streaming output cached with
+// styles of another generation is dropped on the next RenderStreaming.
+func (r *MarkdownRenderer) SetStyles(styles theme.Styles) {
+	r.styles = styles
+}
+
+// RenderStreaming renders a partial response. Blocks that are complete
+// (closed by a blank line outside a code fence) are rendered once and
+// cached; each call only renders what arrived since, plus the trailing
+// partial block. Output matches Render for the same text.
+func (r *MarkdownRenderer) RenderStreaming(text string) string {
+	c := &r.stream
+	if !strings.HasPrefix(text, c.source) || c.width != r.maxWidth || c.gen != r.styles.Generation {
+		*c = streamCache{width: r.maxWidth, gen: r.styles.Generation}
+	}
+
+	// Cut newly completed blocks off the front of the unrendered remainder
+	rest := text[len(c.source):]
+	inFence := false
+	blockStart := 0
+	offset := 0
+	for {
+		nl := strings.IndexByte(rest[offset:], '\n')
+		if nl < 0 {
+			break // last line is still arriving
+		}
+		line := rest[offset : offset+nl]
+		offset += nl + 1
+
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.TrimSpace(line) != "" {
+			continue
+		}
+
+		// Blank line outside a fence: everything before it is a finished block
+		block := rest[blockStart : offset-nl-1]
+		r.lists = append(r.lists[:0], c.lists...)
+		if block != "" {
+			c.rendered += r.render(strings.Split(strings.TrimSuffix(block, "\n"), "\n")) + "\n"
+		}
+		c.rendered += r.render([]string{line}) + "\n"
+		c.lists = append(c.lists[:0], r.lists...)
+		c.source += rest[blockStart:offset]
+		blockStart = offset
+	}
+
+	r.lists = append(r.lists[:0], c.lists...)
+	return c.rendered + r.render(strings.Split(text[len(c.source):], "\n"))
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+func TestRenderStreamingMatchesRender(t *testing.T) {
+	t.Parallel()
+
+	text := "# Title\n\nIntro with **bold** text.\n\n- one\n  - nested\n\n  - loose nested\n- two\n\n" +
+		"```go\nfunc main() {\n\n\tfmt.Println()\n}\n```\n\n| a | b |\n|:--|--:|\n| 1 | 2 |\n\n\nDone."
+
+	styles := theme.NewManager(80, 24, nil).Styles()
+	streaming := NewMarkdownRendererWithWidth(styles, 60)
+	full := NewMarkdownRendererWithWidth(styles, 60)
+
+	// Feed the text a few bytes at a time, as chunks arrive
+	for end := 1; end <= len(text); end += 3 {
+		prefix := text[:end]
+		if got, want := streaming.RenderStreaming(prefix), full.Render(prefix); got != want {
+			t.Fatalf("prefix %q:\nstreaming: %q\nfull:      %q", prefix[max(0, len(prefix)-20):], got[max(0, len(got)-60):], want[max(0, len(want)-60):])
+		}
+	}
+	if got, want := streaming.RenderStreaming(text), full.Render(text); got != want {
+		t.Fatalf("full text:\nstreaming:\n%s\nfull:\n%s", got, want)
+	}
+}
+
+func TestMarkdownLinks(t *testing.T) {
+	t.Parallel()
+
+	text := "See [the repo](https://github.com/x/y) or https://example.com/a.\n" +
+		"Again: <https://github.com/x/y> and [docs](https://example.com/docs)"
+	want := []string{"https://github.com/x/y", "https://example.com/a", "https://example.com/docs"}
+	if got := MarkdownLinks(text); !slices.Equal(got, want) {
+		t.Errorf("MarkdownLinks = %q, want %q", got, want)
+	}
+}
+
+func TestCodeBlocks(t *testing.T) {
+	t.Parallel()
+
+	text := "Try:\n\n```go\nfunc main() {\n}\n```\n\nthen\n\n```\nstill arriving"
+	want := []string{"func main() {\n}", "still arriving"}
+	if got := CodeBlocks(text); !slices.Equal(got, want) {
+		t.Errorf("CodeBlocks = %q, want %q", got, want)
+	}
+}
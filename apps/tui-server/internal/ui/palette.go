@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// PaletteEntry is a single row in the command palette
+type PaletteEntry struct {
+	Group string
+	Label string
+	Hint  string
+}
+
+// CommandPalette renders the fuzzy command palette box: the query, then
+// every match in a box showing shown of them at a time, scrolled to keep
+// the selected one in view
+func CommandPalette(styles theme.Styles, inputView string, entries []PaletteEntry, selected, shown int, width int) string {
+	groupStyles := map[string]lipgloss.Style{
+		"VIEW":    styles.Cyan,
+		"COMMAND": styles.Green,
+		"THEME":   styles.Purple,
+		"PROJECT": styles.Yellow,
+	}
+
+	var rows []string
+	if len(entries) == 0 {
+		rows = append(rows, styles.Muted.Render("no matches"))
+	}
+	for i, entry := range entries {
+		groupStyle, ok := groupStyles[entry.Group]
+		if !ok {
+			groupStyle = styles.Muted
+		}
+
+		marker := "  "
+		labelStyle := styles.Body
+		if i == selected && styles.Accessible {
+			marker = "> "
+			entry.Hint += " (selected)"
+		}
+		if i == selected && !styles.Accessible {
+			marker = styles.Neon.Bold(true).Render("▸ ")
+			labelStyle = styles.Neon.Bold(true)
+		}
+
+		group := groupStyle.Render(fmt.Sprintf("%-7s", entry.Group))
+		row := marker + group + " " + labelStyle.Render(entry.Label)
+		if entry.Hint != "" {
+			row += styles.Dim.Render(" · " + entry.Hint)
+		}
+		rows = append(rows, row)
+	}
+
+	matches := Box{
+		Title:  fmt.Sprintf("%d match%s", len(entries), pluralSuffix(len(entries))),
+		Lines:  rows,
+		Footer: "↑↓ select · ⏎ run · ESC close",
+		Height: shown,
+		Offset: selected - shown + 1,
+	}
+	palette := Box{
+		Title:    "COMMAND PALETTE",
+		Lines:    []string{styles.Yellow.Bold(true).Render("❯ ") + inputView},
+		Children: []Box{matches},
+	}
+	return "\n" + palette.Render(styles, width) + "\n"
+}
+
+func pluralSuffix(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "es"
+}
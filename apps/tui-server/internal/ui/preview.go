@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// PreviewPages lists the pages Preview renders; "project" takes a project ID
+// and "achievements" an optional page number
+var PreviewPages = []string{"about", "projects", "project", "resume", "experience", "uses", "talks", "certs", "achievements"}
+
+// PreviewContent is the portfolio content a preview is rendered from
+type PreviewContent struct {
+	Resume   *content.Resume
+	Projects *content.Projects
+	Bio      string
+	Uses     string
+	Talks    *content.Talks
+	Certs    *content.Certifications
+}
+
+// Preview renders one page the way the TUI's viewport shows it, without
+// a session, so content edits can be checked from the command line
+func Preview(styles theme.Styles, c PreviewContent, page, arg string, width int) (string, error) {
+	switch page {
+	case "about":
+		return About(styles, c.Bio, width), nil
+	case "projects":
+		return ProjectsList(styles, ProjectsData{
+			Sections: ProjectSections(content.GroupProjects(c.Projects.Projects), nil),
+			Total:    len(c.Projects.Projects),
+			Cursor:   -1,
+		}, width), nil
+	case "project":
+		project := c.Projects.GetProjectByID(arg)
+		if project == nil {
+			return "", fmt.Errorf("unknown project %q (have %s)", arg, strings.Join(projectIDs(c.Projects), ", "))
+		}
+		return ProjectDetail(styles, project, false, nil, width), nil
+	case "resume":
+		return Resume(styles, c.Resume, c.Talks, c.Certs, width), nil
+	case "experience":
+		return Experience(styles, c.Resume, width), nil
+	case "uses":
+		return Uses(styles, c.Uses, width), nil
+	case "talks":
+		return Talks(styles, c.Talks, width), nil
+	case "certs":
+		return Certifications(styles, c.Certs, width), nil
+	case "achievements":
+		page := 1
+		if arg != "" {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 || n > AchievementPages(len(c.Resume.Achievements)) {
+				return "", fmt.Errorf("no achievements page %q (have 1-%d)", arg, AchievementPages(len(c.Resume.Achievements)))
+			}
+			page = n
+		}
+		return Achievements(styles, c.Resume.Achievements, page-1, width), nil
+	}
+	return "", fmt.Errorf("unknown page %q (have %s)", page, strings.Join(PreviewPages, ", "))
+}
+
+// PlainStyles are for text headed to a file or pipe rather than a
+// terminal: no colors, and the accessible layouts, which draw no boxes
+func PlainStyles(width int) theme.Styles {
+	m := theme.NewManager(width, 24, lipgloss.NewRenderer(io.Discard))
+	m.SetAccessible(true)
+	return m.Styles()
+}
+
+// Plain finishes output rendered with PlainStyles: what styling is left
+// is stripped, box drawing becomes ASCII and trailing spaces are trimmed
+func Plain(s string) string {
+	lines := strings.Split(ansi.Strip(s), "\n")
+	for i, line := range lines {
+		line = strings.Map(func(r rune) rune {
+			if r >= 0x2500 && r <= 0x257f { // box drawing
+				return []rune(theme.ASCIIGlyphs.Render(string(r)))[0]
+			}
+			return r
+		}, line)
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func projectIDs(projects *content.Projects) []string {
+	ids := make([]string, 0, len(projects.Projects))
+	for _, p := range projects.Projects {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// TestPreviewGolden compares views against testdata/preview, which matches
+// `CONTENT_PATH=internal/ui/testdata/content tui-server preview <page>`.
+// After an intended layout change: go test ./internal/ui -run Golden -update
+func TestPreviewGolden(t *testing.T) {
+	loader := content.NewLoader(filepath.Join("testdata", "content"))
+	resume, err := loader.LoadResume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, err := loader.LoadProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bio, err := loader.LoadBio()
+	if err != nil {
+		t.Fatal(err)
+	}
+	uses, err := loader.LoadUses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	talks, err := loader.LoadTalks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs, err := loader.LoadCertifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := PreviewContent{Resume: resume, Projects: projects, Bio: bio, Uses: uses, Talks: talks, Certs: certs}
+
+	cases := []struct {
+		name, page, arg string
+		width           int
+	}{
+		{"about", "about", "", 80},
+		{"projects", "projects", "", 80},
+		{"project-echo", "project", "echo", 80},
+		{"resume", "resume", "", 80},
+		{"resume-100", "resume", "", 100},
+		{"experience", "experience", "", 80},
+		{"about-narrow", "about", "", 40},
+		{"uses", "uses", "", 80},
+		{"talks", "talks", "", 80},
+		{"certs", "certs", "", 80},
+		{"achievements-2", "achievements", "2", 80},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := lipgloss.NewRenderer(io.Discard)
+			renderer.SetColorProfile(termenv.TrueColor)
+			styles := theme.NewManager(tc.width, 24, renderer).Styles()
+			out, err := Preview(styles, c, tc.page, tc.arg, tc.width)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ansi.Strip(out) + "\n"
+
+			path := filepath.Join("testdata", "preview", tc.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s changed:\n%s\nwant:\n%s", tc.name, got, want)
+			}
+		})
+	}
+}
+
+func TestPreviewUnknownPage(t *testing.T) {
+	styles := theme.NewManager(80, 24, nil).Styles()
+	if _, err := Preview(styles, PreviewContent{Projects: &content.Projects{}}, "project", "missing", 80); err == nil {
+		t.Fatal("Preview() of a missing project should fail")
+	}
+	if _, err := Preview(styles, PreviewContent{}, "posts", "", 80); err == nil {
+		t.Fatal("Preview() of an unknown page should fail")
+	}
+}
+
+func TestPlainHasNoDecoration(t *testing.T) {
+	loader := content.NewLoader(filepath.Join("testdata", "content"))
+	resume, _ := loader.LoadResume()
+	projects, _ := loader.LoadProjects()
+	bio, _ := loader.LoadBio()
+	uses, _ := loader.LoadUses()
+	talks, _ := loader.LoadTalks()
+	certs, _ := loader.LoadCertifications()
+	c := PreviewContent{Resume: resume, Projects: projects, Bio: bio, Uses: uses, Talks: talks, Certs: certs}
+
+	for _, page := range []string{"about", "projects", "resume", "experience", "uses", "talks", "certs"} {
+		out, err := Preview(PlainStyles(80), c, page, "", 80)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(Plain(out), "\n") {
+			if strings.ContainsAny(line, "\x1b─│┌┐└┘") || strings.HasSuffix(line, " ") {
+				t.Errorf("%s line %d is decorated: %q", page, i+1, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// ProjectsData is what the projects list shows: the projects left after
+// filtering, in sections, out of Total, and the filter chips above them
+type ProjectsData struct {
+	Sections []ProjectSection
+	Total    int
+	Chips    []Chip
+	// Bookmarks are the IDs of the projects the visitor bookmarked
+	Bookmarks []string
+	// Cursor is the chip the arrow keys are on or, counting on past the
+	// chips, the section header; -1 before they're used
+	Cursor int
+}
+
+// ProjectSection is a category's projects under a header that collapses
+// them. An unnamed section has no header.
+type ProjectSection struct {
+	Name      string
+	Projects  []content.Project
+	Collapsed bool
+}
+
+// ProjectSections names the groups' sections, calling the uncategorized
+// Other when there are categories, and collapses the sections named in
+// collapsed
+func ProjectSections(groups []content.ProjectGroup, collapsed []string) []ProjectSection {
+	sections := make([]ProjectSection, 0, len(groups))
+	for _, g := range groups {
+		name := g.Category
+		if name == "" && len(groups) > 1 {
+			name = "Other"
+		}
+		sections = append(sections, ProjectSection{
+			Name:      name,
+			Projects:  g.Projects,
+			Collapsed: name != "" && slices.Contains(collapsed, name),
+		})
+	}
+	return sections
+}
+
+// count is how many projects the sections hold, collapsed or not
+func (d ProjectsData) count() int {
+	n := 0
+	for _, s := range d.Sections {
+		n += len(s.Projects)
+	}
+	return n
+}
+
+// Chip is one filter that can be toggled, shown in a row with the rest
+// of its Group, as in STATUS or TECH
+type Chip struct {
+	Group string
+	Label string
+	On    bool
+}
+
+// chipLines renders the chips a row per group, wrapping long groups,
+// then how many projects are shown. Nothing without chips.
+func chipLines(styles theme.Styles, data ProjectsData, cw int) []string {
+	if len(data.Chips) == 0 {
+		return nil
+	}
+
+	labelWidth := 0
+	for _, c := range data.Chips {
+		labelWidth = max(labelWidth, textutil.Width(c.Group))
+	}
+	indent := strings.Repeat(" ", labelWidth+1)
+
+	var lines []string
+	var line string
+	used := 0
+	for i, c := range data.Chips {
+		if i == 0 || c.Group != data.Chips[i-1].Group {
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = styles.Dim.Render(fmt.Sprintf("%-*s ", labelWidth, c.Group))
+			used = labelWidth + 1
+		}
+		chip := renderChip(styles, c, i == data.Cursor)
+		if w := textutil.Width(chip); used > labelWidth+1 && used+w > cw {
+			lines = append(lines, line)
+			line, used = indent, labelWidth+1
+		}
+		line += chip + " "
+		used += textutil.Width(chip) + 1
+	}
+	lines = append(lines, line)
+
+	shown := fmt.Sprintf("%d of %d projects", data.count(), data.Total)
+	if data.count() == data.Total {
+		shown = fmt.Sprintf("All %d projects", data.Total)
+	}
+	lines = append(lines, styles.Muted.Render(textutil.Truncate(shown+" · ←/→ pick, ENTER toggles", cw)))
+	lines = append(lines, styles.Dim.Render(strings.Repeat("─", min(cw-2, 40))), "")
+	return lines
+}
+
+// renderChip draws a chip, filled when on and pointed at under the cursor
+func renderChip(styles theme.Styles, c Chip, cursor bool) string {
+	if styles.Accessible {
+		label := "[" + c.Label + "]"
+		if c.On {
+			label = "[" + c.Label + " (on)]"
+		}
+		if cursor {
+			label = "> " + label
+		}
+		return label
+	}
+
+	style := styles.Muted
+	if c.On {
+		style = styles.Neon.Bold(true).Reverse(true)
+	}
+	label := style.Render(" " + c.Label + " ")
+	if cursor {
+		return styles.Yellow.Render("▸") + label
+	}
+	return " " + label
+}
+
+// sectionHeader renders a section's name and size, with whether it is
+// collapsed, marked when the cursor is on it
+func sectionHeader(styles theme.Styles, s ProjectSection, cursor bool) string {
+	n := len(s.Projects)
+	if styles.Accessible {
+		state := "shown"
+		if s.Collapsed {
+			state = "collapsed"
+		}
+		header := fmt.Sprintf("%s (%d %s, %s)", s.Name, n, projectNoun(n), state)
+		if cursor {
+			header = "> " + header
+		}
+		return styles.Purple.Bold(true).Render(header)
+	}
+
+	arrow, count := "▾ ", fmt.Sprintf(" · %d", n)
+	if s.Collapsed {
+		arrow, count = "▸ ", fmt.Sprintf(" · %d hidden", n)
+	}
+	name := styles.Purple.Bold(true).Render(strings.ToUpper(s.Name))
+	if cursor {
+		name = styles.Neon.Bold(true).Reverse(true).Render(" " + strings.ToUpper(s.Name) + " ")
+	}
+	return styles.Purple.Render(arrow) + name + styles.Dim.Render(count)
+}
+
+// projectNoun is "project" or "projects" for n of them
+func projectNoun(n int) string {
+	if n == 1 {
+		return "project"
+	}
+	return "projects"
+}
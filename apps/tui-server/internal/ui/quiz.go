@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// QuizQuestion is a multiple-choice question; Answer indexes Choices
+type QuizQuestion struct {
+	Prompt  string
+	Choices []string
+	Answer  int
+}
+
+// QuizCard is the quiz as it stands: the current question, where it sits
+// in the round and the score so far. Picked is -1 until it's answered.
+type QuizCard struct {
+	Question      QuizQuestion
+	Number, Total int
+	Score         int
+	Picked        int
+}
+
+// Quiz renders the current question. Once answered, the right choice is
+// marked and a wrong pick is crossed out.
+func Quiz(styles theme.Styles, card QuizCard, width int) string {
+	cw := innerWidth(width)
+	q := card.Question
+
+	lines := []string{
+		styles.Dim.Render(fmt.Sprintf("question %d of %d", card.Number, card.Total)) +
+			styles.Dim.Render("  ·  score ") + styles.Neon.Render(fmt.Sprint(card.Score)),
+		"",
+	}
+	lines = append(lines, wrapTextForBox(q.Prompt, cw, styles)...)
+	lines = append(lines, "")
+
+	for i, choice := range q.Choices {
+		label := fmt.Sprintf(" %d) ", i+1)
+		text := textutil.Truncate(choice, cw-len(label)-2)
+		switch {
+		case card.Picked < 0:
+			lines = append(lines, styles.Yellow.Render(label)+styles.Body.Render(text))
+		case i == q.Answer:
+			lines = append(lines, styles.Green.Bold(true).Render(label+text+" ✓"))
+		case i == card.Picked:
+			lines = append(lines, styles.Red.Render(label+text+" ✗"))
+		default:
+			lines = append(lines, styles.Dim.Render(label+text))
+		}
+	}
+
+	lines = append(lines, "")
+	switch {
+	case card.Picked < 0:
+		lines = append(lines, styles.Dim.Render(fmt.Sprintf("press 1-%d to answer", len(q.Choices))))
+	case card.Picked == q.Answer:
+		lines = append(lines, styles.Green.Bold(true).Render("Correct!")+styles.Dim.Render("  enter for the next one"))
+	default:
+		lines = append(lines, styles.Red.Bold(true).Render("Not quite.")+styles.Dim.Render("  enter for the next one"))
+	}
+
+	return "\n" + Box{Title: "QUIZ", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// QuizResult renders the end of a round: the score and a verdict on it
+func QuizResult(styles theme.Styles, score, total int, verdict string, width int) string {
+	cw := innerWidth(width)
+	lines := []string{
+		"",
+		textutil.Center(styles.Neon.Bold(true).Render(fmt.Sprintf("%d / %d", score, total)), cw),
+		"",
+	}
+	for _, line := range wrapTextForBox(verdict, cw, styles) {
+		lines = append(lines, textutil.Center(line, cw))
+	}
+	lines = append(lines, "", textutil.Center(styles.Dim.Render("r to play again  ·  esc to leave"), cw))
+	return "\n" + Box{Title: "QUIZ RESULTS", Lines: lines}.Render(styles, width) + "\n"
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// skillBarWidth is the cells a full skill bar takes
+const skillBarWidth = 10
+
+// skillLines lists every skill under its group's heading: ones with a
+// level as bars, ██████░░░░ 5y, and the rest as tags wrapped to cw
+func skillLines(styles theme.Styles, skills content.Skills, cw int) []string {
+	colors := []lipgloss.Style{styles.Neon, styles.Cyan, styles.Green, styles.Yellow, styles.Purple}
+	var lines []string
+	for i, g := range skills.Groups() {
+		style := colors[i%len(colors)]
+		lines = append(lines, "  "+styles.Dim.Render(strings.ToUpper(g.Name)))
+
+		nameWidth := 0
+		for _, skill := range g.Skills {
+			if skill.Level > 0 {
+				nameWidth = max(nameWidth, textutil.Width(skill.Name))
+			}
+		}
+		nameWidth = min(nameWidth, cw-6-skillBarWidth-4)
+
+		var tags []string
+		for _, skill := range g.Skills {
+			if skill.Level == 0 {
+				tags = append(tags, skill.Name+skillYears(skill, " · "))
+				continue
+			}
+			if styles.Accessible {
+				lines = append(lines, fmt.Sprintf("    %s: level %d of %d%s", skill.Name, skill.Level, content.MaxSkillLevel, skillYears(skill, ", ")))
+				continue
+			}
+			filled := skill.Level * skillBarWidth / content.MaxSkillLevel
+			lines = append(lines, "    "+styles.Body.Render(textutil.Pad(skill.Name, nameWidth))+" "+
+				style.Render(strings.Repeat("█", filled))+styles.Dim.Render(strings.Repeat("░", skillBarWidth-filled))+
+				styles.Muted.Render(skillYears(skill, " ")))
+		}
+		lines = append(lines, tagLines(styles, style, tags, cw-4)...)
+	}
+	return lines
+}
+
+// skillYears is the skill's years after sep, as in " 5y", or "" when
+// they're not given
+func skillYears(skill content.Skill, sep string) string {
+	if skill.Years == 0 {
+		return ""
+	}
+	return sep + strconv.Itoa(skill.Years) + "y"
+}
+
+// tagLines wraps tags, drawn ⟨like this⟩, into lines of at most width
+// cells, indented under a heading
+func tagLines(styles theme.Styles, style lipgloss.Style, tags []string, width int) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	if styles.Accessible {
+		return []string{"    " + strings.Join(tags, ", ")}
+	}
+	var lines []string
+	line, lineWidth := "", 0
+	for _, tag := range tags {
+		tagWidth := textutil.Width(tag) + 3
+		if lineWidth > 0 && lineWidth+tagWidth > width {
+			lines = append(lines, "    "+line)
+			line, lineWidth = "", 0
+		}
+		line += style.Render("⟨"+tag+"⟩") + " "
+		lineWidth += tagWidth
+	}
+	return append(lines, "    "+line)
+}
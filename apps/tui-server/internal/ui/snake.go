@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// Cell is a square of the snake board, (0, 0) at the top left
+type Cell struct {
+	X, Y int
+}
+
+// SnakeBoard is the state of a snake game to draw. Body starts at the head.
+type SnakeBoard struct {
+	Width, Height int
+	Body          []Cell
+	Food          Cell
+	Score, Best   int
+	Over          bool
+}
+
+// Snake renders the game centered in width. Every cell is two columns
+// wide so the board looks square.
+func Snake(styles theme.Styles, board SnakeBoard, width int) string {
+	occupied := make(map[Cell]bool, len(board.Body))
+	for _, c := range board.Body {
+		occupied[c] = true
+	}
+
+	score := styles.Yellow.Bold(true).Render("SNAKE") +
+		styles.Dim.Render("  score ") + styles.Neon.Render(fmt.Sprint(board.Score)) +
+		styles.Dim.Render("  best ") + styles.Cyan.Render(fmt.Sprint(board.Best))
+
+	rows := []string{"", textutil.Center(score, width)}
+	rows = append(rows, textutil.Center(styles.Muted.Render("┌"+strings.Repeat("─", board.Width*2)+"┐"), width))
+	for y := 0; y < board.Height; y++ {
+		var b strings.Builder
+		b.WriteString(styles.Muted.Render("│"))
+		for x := 0; x < board.Width; x++ {
+			c := Cell{x, y}
+			switch {
+			case len(board.Body) > 0 && c == board.Body[0]:
+				head := styles.Neon.Bold(true)
+				if board.Over {
+					head = styles.Red.Bold(true)
+				}
+				b.WriteString(head.Render("██"))
+			case occupied[c]:
+				b.WriteString(styles.Green.Render("▓▓"))
+			case c == board.Food:
+				b.WriteString(styles.Red.Render("◆ "))
+			default:
+				b.WriteString("  ")
+			}
+		}
+		b.WriteString(styles.Muted.Render("│"))
+		rows = append(rows, textutil.Center(b.String(), width))
+	}
+	rows = append(rows, textutil.Center(styles.Muted.Render("└"+strings.Repeat("─", board.Width*2)+"┘"), width))
+
+	if board.Over {
+		over := styles.Red.Bold(true).Render("GAME OVER")
+		if board.Score > 0 && board.Score >= board.Best {
+			over += styles.Yellow.Bold(true).Render("  new best!")
+		}
+		rows = append(rows, textutil.Center(over+styles.Dim.Render("  r to play again"), width))
+	} else {
+		rows = append(rows, textutil.Center(styles.Dim.Render("arrows or wasd to steer"), width))
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// Anim carries the animation frame for renderers that support motion.
+// When Reduced is set, renderers fall back to their static form.
+type Anim struct {
+	Frame   int
+	Reduced bool
+}
+
+// spinnerSets are the available spinner frame sets, selected per palette
+var spinnerSets = map[string][]string{
+	"blocks": {"▖", "▘", "▝", "▗"},
+	"dots":   {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	"pulse":  {"◜", "◠", "◝", "◞", "◡", "◟"},
+	"glyphs": {"ｦ", "ｱ", "ｳ", "ｴ", "ｵ", "ｶ", "ｷ", "ｸ"},
+	"ascii":  {"|", "/", "-", "\\"},
+}
+
+// cursorFrames animate the streaming cursor block growing and shrinking
+var cursorFrames = []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉", "█", "▉", "▊", "▋", "▌", "▍", "▎"}
+
+// HasSpinnerSet reports whether name is a spinner set palettes can use
+func HasSpinnerSet(name string) bool {
+	_, ok := spinnerSets[name]
+	return ok
+}
+
+// Spinner renders the current frame of the named spinner set
+func Spinner(styles theme.Styles, set string, anim Anim) string {
+	if styles.Glyphs.ASCII {
+		set = "ascii"
+	}
+	frames, ok := spinnerSets[set]
+	if !ok {
+		frames = spinnerSets["blocks"]
+	}
+	if anim.Reduced {
+		return styles.Neon.Render("◉")
+	}
+	return styles.Neon.Bold(true).Render(frames[anim.Frame%len(frames)])
+}
+
+// Shimmer renders text with a highlight sweeping across it
+func Shimmer(styles theme.Styles, text string, anim Anim) string {
+	if anim.Reduced {
+		return styles.Cyan.Render(text)
+	}
+
+	runes := []rune(text)
+	head := anim.Frame % (len(runes) + 4)
+	var b strings.Builder
+	for i, r := range runes {
+		switch d := head - i; {
+		case d == 0:
+			b.WriteString(styles.Neon.Bold(true).Render(string(r)))
+		case d == 1 || d == -1:
+			b.WriteString(styles.Cyan.Bold(true).Render(string(r)))
+		default:
+			b.WriteString(styles.Muted.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// Cursor renders the streaming cursor block
+func Cursor(styles theme.Styles, anim Anim) string {
+	if anim.Reduced {
+		return styles.Neon.Render("▌")
+	}
+	return styles.Neon.Render(cursorFrames[anim.Frame%len(cursorFrames)])
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// spotlightLines is how many lines of description the spotlight keeps
+const spotlightLines = 2
+
+// spotlight renders the welcome screen's compact card for a project: its
+// name, the start of its description, its tech and the key that opens it
+func spotlight(styles theme.Styles, project *content.Project, width int) string {
+	if styles.Accessible {
+		return "Featured project: " + project.Name + ". " + project.Description + " Press capital O to open it.\n"
+	}
+
+	cw := innerWidth(width)
+	lines := []string{styles.Neon.Bold(true).Render(textutil.Truncate(project.Name, cw))}
+
+	desc := strings.Split(textutil.Wrap(project.Description, cw), "\n")
+	if len(desc) > spotlightLines {
+		desc = desc[:spotlightLines]
+		desc[spotlightLines-1] = textutil.Truncate(strings.TrimRight(desc[spotlightLines-1], " .")+"...", cw)
+	}
+	for _, line := range desc {
+		lines = append(lines, styles.Body.Render(line))
+	}
+
+	var tech []string
+	for _, t := range project.Tech {
+		tech = append(tech, "⟨"+t+"⟩")
+	}
+	if len(tech) > 0 {
+		lines = append(lines, styles.Cyan.Render(textutil.Truncate(strings.Join(tech, " "), cw)))
+	}
+	lines = append(lines, "", styles.Dim.Render("press ")+styles.Yellow.Bold(true).Render("O")+styles.Dim.Render(" to open"))
+	return Box{Title: "FEATURED", Lines: lines}.Render(styles, width) + "\n"
+}
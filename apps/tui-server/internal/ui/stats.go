@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// StatsData is what /stats shows. Counts of zero are shown as such; an
+// empty TopProject means no project has been viewed yet.
+type StatsData struct {
+	Visitors   int
+	Online     int
+	Questions  int
+	TopProject string
+	TopViews   int
+	Uptime     string
+}
+
+// Stats renders the server statistics panel
+func Stats(styles theme.Styles, data StatsData, width int) string {
+	cw := innerWidth(width)
+	row := func(label, value, note string) string {
+		line := styles.Dim.Render(fmt.Sprintf("%-12s", label)) +
+			styles.Neon.Bold(true).Render(fmt.Sprintf("%9s", value))
+		if note != "" {
+			line += styles.Muted.Render("  " + note)
+		}
+		return textutil.Truncate(line, cw)
+	}
+
+	lines := []string{
+		textutil.Center(styles.Cyan.Bold(true).Render("LIVE FROM THE SERVER"), cw),
+		"",
+		row("VISITORS", groupDigits(data.Visitors), "all-time connections"),
+		row("ONLINE", groupDigits(data.Online), "right now"),
+		row("QUESTIONS", groupDigits(data.Questions), "answered by the AI"),
+		row("UPTIME", data.Uptime, "since the last restart"),
+		"",
+	}
+	top := styles.Muted.Render("no views yet")
+	if data.TopProject != "" {
+		views := groupDigits(data.TopViews) + " views"
+		if data.TopViews == 1 {
+			views = "1 view"
+		}
+		top = styles.Yellow.Bold(true).Render(data.TopProject) + styles.Muted.Render(" · "+views)
+	}
+	lines = append(lines, textutil.Truncate(styles.Dim.Render(fmt.Sprintf("%-12s", "TOP PROJECT"))+top, cw))
+	return "\n" + Box{Title: "STATS", Lines: lines}.Render(styles, width) + "\n"
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// tabSeparator sits between tabs; every tab is its label padded by one space each side
+const tabSeparator = "│"
+
+// TabBar renders the view tab strip with the active tab highlighted.
+// The result is exactly width columns wide.
+func TabBar(styles theme.Styles, labels []string, active int, width int) string {
+	var b strings.Builder
+	for i, label := range labels {
+		if i > 0 {
+			b.WriteString(styles.Dim.Render(tabSeparator))
+		}
+		if i == active {
+			b.WriteString(styles.Tag.Render(label))
+		} else {
+			b.WriteString(styles.Muted.Render(" " + label + " "))
+		}
+	}
+
+	tabs := b.String()
+	tabsWidth := textutil.Width(tabs)
+	if tabsWidth > width {
+		return textutil.Truncate(tabs, width)
+	}
+
+	hint := styles.Dim.Render("^PgUp/PgDn")
+	hintWidth := textutil.Width(hint)
+	if tabsWidth+hintWidth+2 <= width {
+		return tabs + strings.Repeat(" ", width-tabsWidth-hintWidth) + hint
+	}
+	return tabs + strings.Repeat(" ", width-tabsWidth)
+}
+
+// TabAt returns the index of the tab under column x of a tab bar rendered
+// by TabBar starting at column 0, or -1 when x falls outside every tab.
+func TabAt(labels []string, x int) int {
+	start := 0
+	for i, label := range labels {
+		if i > 0 {
+			start += textutil.Width(tabSeparator)
+		}
+		end := start + textutil.Width(label) + 2
+		if x >= start && x < end {
+			return i
+		}
+		start = end
+	}
+	return -1
+}
+
+// AnnouncementBar renders an operator's announcement in place of the tab
+// bar, exactly width columns wide
+func AnnouncementBar(styles theme.Styles, text string, width int) string {
+	label := styles.Tag.Render("NOTICE")
+	bar := label + " " + styles.Orange.Bold(true).Render(textutil.Truncate(text, max(width-textutil.Width(label)-1, 1)))
+	return bar + strings.Repeat(" ", max(width-textutil.Width(bar), 0))
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// resumeHighlights is how many talks and certifications the resume
+// shows before pointing at the full lists
+const resumeHighlights = 2
+
+// Talks renders talks, publications and podcasts, newest first
+func Talks(styles theme.Styles, talks *content.Talks, width int) string {
+	cw := innerWidth(width)
+
+	var lines []string
+	if talks == nil || len(talks.Talks) == 0 {
+		lines = append(lines, styles.Muted.Render("No talks or publications listed yet"))
+	} else {
+		for i, t := range talks.Talks {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			kind, style := talkKind(styles, t.Kind)
+			lines = append(lines, style.Render(kind)+" "+styles.Neon.Bold(true).Render(textutil.Truncate(t.Title, cw-textutil.Width(kind)-1)))
+			lines = append(lines, "    "+styles.Cyan.Render(textutil.Truncate(joinNonEmpty(" · ", t.Event, t.Date), cw-4)))
+			for _, sl := range wrapTextForBox(t.Summary, cw-4, styles) {
+				lines = append(lines, "    "+sl)
+			}
+			if t.URL != "" {
+				lines = append(lines, "    "+Hyperlink(t.URL, styles.Link.Render(textutil.Truncate(t.URL, cw-4))))
+			}
+		}
+	}
+
+	return "\n" + Box{Title: "TALKS", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// Certifications renders certifications, newest first
+func Certifications(styles theme.Styles, certs *content.Certifications, width int) string {
+	cw := innerWidth(width)
+
+	var lines []string
+	if certs == nil || len(certs.Certifications) == 0 {
+		lines = append(lines, styles.Muted.Render("No certifications listed yet"))
+	} else {
+		for i, c := range certs.Certifications {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, styles.Green.Render("◈ ")+styles.Neon.Bold(true).Render(textutil.Truncate(c.Name, cw-2)))
+			lines = append(lines, "    "+styles.Cyan.Render(textutil.Truncate(joinNonEmpty(" · ", c.Issuer, c.Date), cw-4)))
+			if c.Expires != "" {
+				lines = append(lines, "    "+styles.Dim.Render("Valid until ")+styles.Body.Render(c.Expires))
+			}
+			if c.CredentialID != "" {
+				lines = append(lines, "    "+styles.Dim.Render("ID ")+styles.Muted.Render(textutil.Truncate(c.CredentialID, cw-7)))
+			}
+			if c.URL != "" {
+				lines = append(lines, "    "+Hyperlink(c.URL, styles.Link.Render(textutil.Truncate(c.URL, cw-4))))
+			}
+		}
+	}
+
+	return "\n" + Box{Title: "CERTIFICATIONS", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// credentialLines are the resume's certification and talk sections: the
+// latest few of each, with a pointer to the full list when there are
+// more. Empty lists add nothing.
+func credentialLines(styles theme.Styles, talks *content.Talks, certs *content.Certifications, cw int) []string {
+	var lines []string
+	more := func(n int, command string) {
+		if n > resumeHighlights {
+			lines = append(lines, styles.Dim.Render("    "+textutil.Truncate(command+" lists all "+strconv.Itoa(n), cw-4)))
+		}
+	}
+
+	if certs != nil && len(certs.Certifications) > 0 {
+		lines = append(lines, "", styles.Neon.Bold(true).Render("◈ CERTIFICATIONS"))
+		for _, c := range certs.Certifications[:min(len(certs.Certifications), resumeHighlights)] {
+			line := styles.Body.Render(c.Name) + styles.Dim.Render(" · "+joinNonEmpty(", ", c.Issuer, c.Date))
+			lines = append(lines, styles.Green.Render("  ▸ ")+textutil.Truncate(line, cw-4))
+		}
+		more(len(certs.Certifications), "/certs")
+	}
+
+	if talks != nil && len(talks.Talks) > 0 {
+		lines = append(lines, "", styles.Cyan.Bold(true).Render("◈ TALKS & WRITING"))
+		for _, t := range talks.Talks[:min(len(talks.Talks), resumeHighlights)] {
+			line := styles.Body.Render(t.Title) + styles.Dim.Render(" · "+joinNonEmpty(", ", t.Event, t.Date))
+			lines = append(lines, styles.Cyan.Render("  ▸ ")+textutil.Truncate(line, cw-4))
+		}
+		more(len(talks.Talks), "/talks")
+	}
+	return lines
+}
+
+// talkKind is the label and color of a talk's kind
+func talkKind(styles theme.Styles, kind string) (string, lipgloss.Style) {
+	switch kind {
+	case "publication":
+		return "[WRITING]", styles.Green
+	case "podcast":
+		return "[PODCAST]", styles.Purple
+	}
+	return "[TALK]", styles.Yellow
+}
+
+// joinNonEmpty joins the parts that aren't empty
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -2,159 +2,122 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
 )
 
-func center(text string, width int) string {
-	w := lipgloss.Width(text)
-	if w >= width {
-		return text
+// wrapTextForBox wraps text to fit within box content width, one body
+// styled string per line
+func wrapTextForBox(text string, maxWidth int, styles theme.Styles) []string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(textutil.Wrap(text, maxWidth), "\n")
+	for i, line := range lines {
+		lines[i] = styles.Body.Render(line)
 	}
-	pad := (width - w) / 2
-	return strings.Repeat(" ", pad) + text
+	return lines
 }
 
-// boxWidth calculates optimal box width based on screen width
-func boxWidth(screenWidth int) int {
-	if screenWidth <= 0 {
-		return 24
+func max(a, b int) int {
+	if a > b {
+		return a
 	}
+	return b
+}
 
-	// Responsive box sizing
-	if screenWidth < 60 {
-		return max(20, screenWidth-4)
-	}
-	if screenWidth < 100 {
-		return max(20, min(60, screenWidth-8))
+func min(a, b int) int {
+	if a < b {
+		return a
 	}
-	return max(20, min(70, screenWidth-20))
+	return b
 }
 
-// contentWidth returns usable content width inside a box
-func contentWidth(boxW int) int {
-	return max(8, boxW-4) // 2 chars for borders on each side
+// Visitor is what the welcome screen knows about the person connecting
+type Visitor struct {
+	// Greeting welcomes a returning visitor back; empty on a first visit
+	Greeting string
+	// Number is this connection's place in the all-time visit count
+	Number int
+	// Live is how many sessions are open right now, this one included
+	Live int
+	// Status is what the owner is up to, if known; Listening marks it as
+	// the track playing
+	Status    string
+	Listening bool
+	// LocalTime is what time it is for the owner, as in "it's 03:12 for
+	// Mohak — probably asleep, expect replies later"
+	LocalTime string
 }
 
-func box(title string, lines []string, styles theme.Styles, width int) string {
-	var b strings.Builder
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
-
-	// Top border with title
-	titleLen := min(len(title), max(1, cw-4))
-	titlePad := (cw - titleLen) / 2
-	if titlePad < 1 {
-		titlePad = 1
-	}
-
-	top := styles.Yellow.Render("┌") +
-		styles.Muted.Render(strings.Repeat("─", titlePad)) +
-		styles.Cyan.Bold(true).Render(" "+title[:min(len(title), titleLen)]+" ") +
-		styles.Muted.Render(strings.Repeat("─", max(1, cw-titlePad-titleLen))) +
-		styles.Yellow.Render("┐")
-	b.WriteString(center(top, width))
-	b.WriteString("\n")
-
-	// Content lines
-	for _, line := range lines {
-		lineWidth := lipgloss.Width(line)
-
-		// Handle lines that are too long
-		if lineWidth > cw {
-			// Truncate with ellipsis for styled text
-			line = TruncateText(line, cw-1)
-			lineWidth = lipgloss.Width(line)
-		}
-
-		padding := cw - lineWidth
-		if padding < 0 {
-			padding = 0
-		}
-
-		row := styles.Muted.Render("│ ") + line + strings.Repeat(" ", padding) + styles.Muted.Render(" │")
-		b.WriteString(center(row, width))
-		b.WriteString("\n")
+// stats renders "visitor #1,204 · 3 browsing now", omitting unknown parts
+func (v Visitor) stats() string {
+	var parts []string
+	if v.Number > 0 {
+		parts = append(parts, "visitor #"+groupDigits(v.Number))
 	}
-
-	// Bottom border
-	bottom := styles.Yellow.Render("└") + styles.Muted.Render(strings.Repeat("─", cw+2)) + styles.Yellow.Render("┘")
-	b.WriteString(center(bottom, width))
-
-	return b.String()
+	if v.Live > 1 {
+		parts = append(parts, fmt.Sprintf("%d browsing now", v.Live))
+	}
+	return strings.Join(parts, " · ")
 }
 
-// wrapTextForBox wraps text to fit within box content width
-func wrapTextForBox(text string, maxWidth int, styles theme.Styles) []string {
-	var result []string
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return result
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
 	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
 
-	var currentLine strings.Builder
-	currentLen := 0
+// groupDigits renders n with thousands separators
+func groupDigits(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
 
-	for _, word := range words {
-		wordLen := len(word)
+// WelcomeMessage renders centered welcome screen, ending with a card for
+// the featured project when there is one. While intro.Frame is below
+// IntroFrames the banner is drawn mid-glitch.
+func WelcomeMessage(styles theme.Styles, width int, intro Anim, visitor Visitor, featured *content.Project) string {
+	var b strings.Builder
 
-		// Word too long - truncate it
-		if wordLen > maxWidth {
-			if currentLen > 0 {
-				result = append(result, styles.Body.Render(currentLine.String()))
-				currentLine.Reset()
-				currentLen = 0
-			}
-			result = append(result, styles.Body.Render(word[:maxWidth-3]+"..."))
-			continue
+	if styles.Accessible {
+		b.WriteString("Welcome to Mohak Bajaj's terminal portfolio.\n")
+		if visitor.Greeting != "" {
+			b.WriteString(capitalize(visitor.Greeting) + ".\n")
 		}
-
-		spaceNeeded := wordLen
-		if currentLen > 0 {
-			spaceNeeded++
+		if stats := visitor.stats(); stats != "" {
+			b.WriteString("You are " + stats + ".\n")
 		}
-
-		if currentLen+spaceNeeded > maxWidth {
-			result = append(result, styles.Body.Render(currentLine.String()))
-			currentLine.Reset()
-			currentLen = 0
+		switch {
+		case visitor.Listening:
+			b.WriteString("Mohak is listening to " + visitor.Status + ".\n")
+		case visitor.Status != "":
+			b.WriteString("Mohak's status: " + visitor.Status + "\n")
 		}
-
-		if currentLen > 0 {
-			currentLine.WriteString(" ")
-			currentLen++
+		if visitor.LocalTime != "" {
+			b.WriteString(capitalize(visitor.LocalTime) + ".\n")
 		}
-		currentLine.WriteString(word)
-		currentLen += wordLen
-	}
-
-	if currentLen > 0 {
-		result = append(result, styles.Body.Render(currentLine.String()))
-	}
-
-	return result
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
+		b.WriteString("Type a question to chat with the AI assistant, or a slash command.\n")
+		b.WriteString("Commands: /about, /projects, /resume, /exp, /help, /exit.\n")
+		if featured != nil {
+			b.WriteString(spotlight(styles, featured, width))
+		}
+		b.WriteString("Accessibility mode is on. Type /accessible to turn it off.\n")
+		return b.String()
 	}
-	return b
-}
-
-// WelcomeMessage renders centered welcome screen
-func WelcomeMessage(styles theme.Styles, width int) string {
-	var b strings.Builder
 
 	// "WELCOME TO" text
 	welcomeText := styles.Yellow.Render("░▒▓") + styles.Muted.Render(" WELCOME TO ") + styles.Yellow.Render("▓▒░")
@@ -189,23 +152,50 @@ func WelcomeMessage(styles theme.Styles, width int) string {
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(center(welcomeText, width))
+	b.WriteString(textutil.Center(welcomeText, width))
 	b.WriteString("\n\n")
 
+	glitching := !intro.Reduced && intro.Frame < IntroFrames
 	for i, line := range banner {
-		styleIdx := i % len(bannerStyles)
-		b.WriteString(center(bannerStyles[styleIdx].Bold(true).Render(line), width))
+		style := bannerStyles[i%len(bannerStyles)].Bold(true)
+		if glitching {
+			b.WriteString(textutil.Center(glitchLine(styles, line, i, style, intro.Frame), width))
+		} else {
+			b.WriteString(textutil.Center(style.Render(line), width))
+		}
 		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
 	tagline := styles.Yellow.Render("▓▒░") + styles.Cyan.Render(" FULL STACK · SYSTEMS · AI · DEVOPS ") + styles.Yellow.Render("░▒▓")
-	b.WriteString(center(tagline, width))
+	b.WriteString(textutil.Center(tagline, width))
 	b.WriteString("\n\n")
+	if visitor.Greeting != "" {
+		b.WriteString(textutil.Center(styles.Green.Render(textutil.Truncate(visitor.Greeting, innerWidth(width))), width))
+		b.WriteString("\n")
+	}
+	if stats := visitor.stats(); stats != "" {
+		b.WriteString(textutil.Center(styles.Dim.Render(textutil.Truncate(stats, innerWidth(width))), width))
+		b.WriteString("\n")
+	}
+	if visitor.Status != "" {
+		line := styles.Muted.Italic(true).Render(visitor.Status)
+		if visitor.Listening {
+			line = styles.Purple.Render("♪ ") + styles.Muted.Render("currently listening to ") + styles.Cyan.Render(visitor.Status)
+		}
+		b.WriteString(textutil.Center(textutil.Truncate(line, innerWidth(width)), width))
+		b.WriteString("\n")
+	}
+	if visitor.LocalTime != "" {
+		b.WriteString(textutil.Center(styles.Dim.Render(textutil.Truncate(visitor.LocalTime, innerWidth(width))), width))
+		b.WriteString("\n")
+	}
+	if visitor.Greeting != "" || visitor.stats() != "" || visitor.Status != "" || visitor.LocalTime != "" {
+		b.WriteString("\n")
+	}
 
 	// Shortcuts box - responsive to width
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
 	var cmdLines []string
 	if cw >= 45 {
@@ -226,64 +216,79 @@ func WelcomeMessage(styles theme.Styles, width int) string {
 			styles.Cyan.Render("type to chat"),
 		}
 	}
-	b.WriteString(box("SHORTCUTS", cmdLines, styles, width))
+	b.WriteString(Box{Title: "SHORTCUTS", Lines: cmdLines}.Render(styles, width))
 	b.WriteString("\n")
+	if featured != nil {
+		b.WriteString(spotlight(styles, featured, width))
+	}
 
 	return b.String()
 }
 
-// Help renders help screen
-func Help(styles theme.Styles, width int) string {
-	var b strings.Builder
-	b.WriteString("\n")
-
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
-
-	// Adjust content based on available width
-	if cw >= 40 {
-		shortcuts := []string{
-			styles.Yellow.Bold(true).Render("NAVIGATION"),
-			"",
-			styles.Purple.Bold(true).Render("Alt+H") + styles.Dim.Render(" ") + styles.Muted.Render("help"),
-			styles.Green.Bold(true).Render("Alt+A") + styles.Dim.Render(" ") + styles.Muted.Render("about"),
-			styles.Yellow.Bold(true).Render("Alt+P") + styles.Dim.Render(" ") + styles.Muted.Render("projects"),
-			styles.Orange.Bold(true).Render("Alt+E") + styles.Dim.Render(" ") + styles.Muted.Render("experience"),
-			styles.Neon.Bold(true).Render("Alt+R") + styles.Dim.Render(" ") + styles.Muted.Render("resume"),
-			styles.Cyan.Bold(true).Render("Alt+W") + styles.Dim.Render(" ") + styles.Muted.Render("home"),
-			styles.Cyan.Bold(true).Render("Alt+C") + styles.Dim.Render(" ") + styles.Muted.Render("clear chat"),
-			styles.Red.Bold(true).Render("Alt+Q") + styles.Dim.Render(" ") + styles.Muted.Render("quit"),
-		}
-		b.WriteString(box("ALT+KEY", shortcuts, styles, width))
-		b.WriteString("\n")
-
-		commands := []string{
-			styles.Yellow.Bold(true).Render("COMMANDS"),
-			"",
-			styles.Purple.Bold(true).Render("/help") + styles.Muted.Render(" show help"),
-			styles.Green.Bold(true).Render("/about") + styles.Muted.Render(" profile"),
-			styles.Yellow.Bold(true).Render("/projects") + styles.Muted.Render(" list"),
-			styles.Yellow.Bold(true).Render("/open <id>") + styles.Muted.Render(" view"),
-			styles.Red.Bold(true).Render("/exit") + styles.Muted.Render(" quit"),
-		}
-		b.WriteString(box("SLASH", commands, styles, width))
-		b.WriteString("\n")
-	} else {
-		// Compact view for narrow screens
-		compact := []string{
-			styles.Cyan.Bold(true).Render("Alt+") + styles.Muted.Render(" shortcuts"),
-			"A about, P projects",
-			"R resume, E exp",
-			"H help, Q quit",
-			"",
-			styles.Cyan.Bold(true).Render("Commands:"),
-			"/help /about /exit",
-		}
-		b.WriteString(box("HELP", compact, styles, width))
-		b.WriteString("\n")
-	}
+// HelpCommand is a slash command's line in the help overlay
+type HelpCommand struct {
+	Usage string // like "/open <project-id>"
+	Desc  string
+}
 
-	return b.String()
+// Help renders the help overlay, placing panels side by side when wide enough
+// and falling back to a compact panel when stacking would not fit the height
+func Help(styles theme.Styles, width, height int, commands []HelpCommand) string {
+	shortcuts := []string{
+		styles.Yellow.Bold(true).Render("NAVIGATION"),
+		"",
+		styles.Purple.Bold(true).Render("Alt+H") + styles.Dim.Render(" ") + styles.Muted.Render("help"),
+		styles.Green.Bold(true).Render("Alt+A") + styles.Dim.Render(" ") + styles.Muted.Render("about"),
+		styles.Yellow.Bold(true).Render("Alt+P") + styles.Dim.Render(" ") + styles.Muted.Render("projects"),
+		styles.Orange.Bold(true).Render("Alt+E") + styles.Dim.Render(" ") + styles.Muted.Render("experience"),
+		styles.Neon.Bold(true).Render("Alt+R") + styles.Dim.Render(" ") + styles.Muted.Render("resume"),
+		styles.Cyan.Bold(true).Render("Alt+W") + styles.Dim.Render(" ") + styles.Muted.Render("home"),
+		styles.Cyan.Bold(true).Render("Alt+C") + styles.Dim.Render(" ") + styles.Muted.Render("clear chat"),
+		styles.Red.Bold(true).Render("Alt+Q") + styles.Dim.Render(" ") + styles.Muted.Render("quit"),
+		styles.Cyan.Bold(true).Render("Ctrl+K") + styles.Dim.Render(" ") + styles.Muted.Render("command palette"),
+		styles.Cyan.Bold(true).Render("Alt+←/→") + styles.Dim.Render(" ") + styles.Muted.Render("back / forward"),
+		styles.Cyan.Bold(true).Render("Ctrl+PgUp/PgDn") + styles.Dim.Render(" ") + styles.Muted.Render("switch tab"),
+	}
+
+	lines := []string{styles.Yellow.Bold(true).Render("COMMANDS"), ""}
+	for _, c := range commands {
+		lines = append(lines, styles.Yellow.Bold(true).Render(c.Usage)+styles.Muted.Render(" "+c.Desc))
+	}
+
+	// Side by side when both panels fit at a readable width
+	if width >= 70 && !styles.Accessible {
+		bw := min(36, (width-2)/2)
+		left := Box{Title: "ALT+KEY", Lines: shortcuts}.Rows(styles, bw)
+		right := Box{Title: "SLASH", Lines: lines, Footer: "ESC to close"}.Rows(styles, bw)
+		for len(left) < len(right) {
+			left = append(left, strings.Repeat(" ", bw))
+		}
+		for len(right) < len(left) {
+			right = append(right, strings.Repeat(" ", bw))
+		}
+		rows := make([]string, len(left))
+		for i := range left {
+			rows[i] = left[i] + "  " + right[i]
+		}
+		return strings.Join(rows, "\n")
+	}
+
+	if styles.Accessible || innerWidth(width) >= 40 && len(shortcuts)+len(lines)+5 <= height {
+		return Box{Title: "ALT+KEY", Lines: shortcuts}.Render(styles, width) + "\n" + Box{Title: "SLASH", Lines: lines, Footer: "ESC to close"}.Render(styles, width)
+	}
+
+	// Compact view for narrow screens
+	compact := []string{
+		styles.Cyan.Bold(true).Render("Alt+") + styles.Muted.Render(" shortcuts"),
+		"A about, P projects",
+		"R resume, E exp",
+		"H help, Q quit",
+		"^K palette",
+		"",
+		styles.Cyan.Bold(true).Render("Commands:"),
+		"/help /about /exit",
+	}
+	return Box{Title: "HELP", Lines: compact, Footer: "ESC to close"}.Render(styles, width)
 }
 
 // About renders about screen
@@ -291,8 +296,7 @@ func About(styles theme.Styles, bio string, width int) string {
 	var b strings.Builder
 	b.WriteString("\n")
 
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
 	var lines []string
 	bioLines := strings.Split(bio, "\n")
@@ -310,21 +314,19 @@ func About(styles theme.Styles, bio string, width int) string {
 				key := parts[1]
 				value := parts[2]
 				// Truncate value if too long
-				maxVal := cw - len(key) - 6
+				maxVal := cw - textutil.Width(key) - 6
 				if maxVal < 10 {
 					maxVal = 10
 				}
-				if len(value) > maxVal {
-					value = value[:maxVal-3] + "..."
-				}
+				value = textutil.Truncate(value, maxVal)
 				lines = append(lines, styles.Green.Render("▸ ")+styles.Neon.Bold(true).Render(key)+styles.Body.Render(value))
 			}
 		} else if strings.HasPrefix(line, "- ") {
 			text := strings.TrimPrefix(line, "- ")
 			text = renderInlineBold(text, styles)
 			// Wrap long list items
-			if lipgloss.Width(text) > cw-4 {
-				text = TruncateText(text, cw-4)
+			if textutil.Width(text) > cw-4 {
+				text = textutil.Truncate(text, cw-4)
 			}
 			lines = append(lines, styles.Green.Render("▸ ")+text)
 		} else if line != "" {
@@ -334,12 +336,33 @@ func About(styles theme.Styles, bio string, width int) string {
 		}
 	}
 
-	b.WriteString(box("PROFILE", lines, styles, width))
+	b.WriteString(Box{Title: "PROFILE", Lines: lines}.Render(styles, width))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// Uses renders the uses page, the hardware and tools, through the
+// markdown renderer inside a box
+func Uses(styles theme.Styles, uses string, width int) string {
+	cw := innerWidth(width)
+
+	// The box is titled, so the page's own title is skipped as in About
+	var body []string
+	for _, line := range strings.Split(strings.TrimSpace(uses), "\n") {
+		if !strings.HasPrefix(line, "# ") {
+			body = append(body, line)
+		}
+	}
+	lines := []string{styles.Muted.Render("Nothing listed yet")}
+	if text := strings.TrimSpace(strings.Join(body, "\n")); text != "" {
+		// The renderer keeps four columns spare, which the box provides
+		lines = strings.Split(NewMarkdownRendererWithWidth(styles, cw+4).Render(text), "\n")
+	}
+
+	return "\n" + Box{Title: "USES", Lines: lines}.Render(styles, width) + "\n"
+}
+
 // renderInlineBold handles **bold** text inline
 func renderInlineBold(text string, styles theme.Styles) string {
 	result := text
@@ -362,86 +385,120 @@ func renderInlineBold(text string, styles theme.Styles) string {
 	return result
 }
 
-// ProjectsList renders projects list
-func ProjectsList(styles theme.Styles, projects *content.Projects, width int) string {
+// ProjectsList renders projects list, under its filter chips when there
+// are any
+func ProjectsList(styles theme.Styles, data ProjectsData, width int) string {
 	var b strings.Builder
 	b.WriteString("\n")
 
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
-	var lines []string
-	for i, p := range projects.Projects {
-		var statusStyle lipgloss.Style
-		var statusIcon string
-		switch p.Status {
-		case "active":
-			statusStyle = styles.Green
-			statusIcon = "●"
-		case "completed":
-			statusStyle = styles.Cyan
-			statusIcon = "◈"
-		default:
-			statusStyle = styles.Yellow
-			statusIcon = "○"
-		}
-
-		// Project header
-		header := styles.Dim.Render(fmt.Sprintf("[%d] ", i+1)) +
-			styles.Neon.Bold(true).Render(p.Name) + " " +
-			statusStyle.Render(statusIcon)
-		lines = append(lines, header)
-
-		lines = append(lines, styles.Dim.Render("    ID: ")+styles.Muted.Render(p.ID))
-
-		// Description - truncate to fit
-		desc := p.Description
-		maxDesc := cw - 6
-		if maxDesc < 20 {
-			maxDesc = 20
-		}
-		if len(desc) > maxDesc {
-			desc = desc[:maxDesc-3] + "..."
-		}
-		lines = append(lines, styles.Dim.Render("    ")+styles.Body.Render(desc))
-
-		// Tech tags - limit based on width
-		var tags string
-		colorCycle := []lipgloss.Style{styles.Cyan, styles.Neon, styles.Green, styles.Yellow}
-		maxTags := 3
-		if cw < 40 {
-			maxTags = 2
-		}
-		for j, tech := range p.Tech {
-			if j < maxTags {
-				tags += colorCycle[j%4].Render("⟨"+tech+"⟩") + " "
-			}
+	lines := chipLines(styles, data, cw)
+	if data.count() == 0 && data.Total > 0 {
+		lines = append(lines, styles.Muted.Render(textutil.Truncate("No projects match these filters", cw)), "")
+	}
+	n := 0
+	for si, section := range data.Sections {
+		if section.Name != "" {
+			lines = append(lines, sectionHeader(styles, section, data.Cursor == len(data.Chips)+si), "")
+		}
+		if section.Collapsed {
+			continue
+		}
+		for _, p := range section.Projects {
+			n++
+			lines = append(lines, projectRow(styles, p, n, cw, slices.Contains(data.Bookmarks, p.ID))...)
 		}
-		lines = append(lines, styles.Dim.Render("    ")+tags)
-		lines = append(lines, "")
 	}
 
 	sepLen := min(cw-2, 40)
 	lines = append(lines, styles.Dim.Render(strings.Repeat("─", sepLen)))
 	lines = append(lines, styles.Muted.Render("/open <id> to view details"))
 
-	b.WriteString(box("PROJECTS", lines, styles, width))
+	b.WriteString(Box{Title: "PROJECTS", Lines: lines}.Render(styles, width))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-// ProjectDetail renders project details
-func ProjectDetail(styles theme.Styles, project *content.Project, width int) string {
+// projectRow renders a project's block in the list, numbered n and
+// starred when bookmarked
+func projectRow(styles theme.Styles, p content.Project, n, cw int, bookmarked bool) []string {
+	var lines []string
+
+	var statusStyle lipgloss.Style
+	var statusIcon string
+	switch p.Status {
+	case "active":
+		statusStyle = styles.Green
+		statusIcon = "●"
+	case "completed":
+		statusStyle = styles.Cyan
+		statusIcon = "◈"
+	default:
+		statusStyle = styles.Yellow
+		statusIcon = "○"
+	}
+	if styles.Accessible {
+		// Spell out status rather than relying on icon color
+		statusIcon = "(" + p.Status + ")"
+	}
+
+	// Project header
+	header := styles.Dim.Render(fmt.Sprintf("[%d] ", n)) +
+		styles.Neon.Bold(true).Render(p.Name) + " " +
+		statusStyle.Render(statusIcon)
+	if bookmarked {
+		header += " " + bookmarkMark(styles)
+	}
+	lines = append(lines, header)
+
+	lines = append(lines, styles.Dim.Render("    ID: ")+styles.Muted.Render(p.ID))
+
+	// Description - truncate to fit
+	desc := p.Description
+	maxDesc := cw - 6
+	if maxDesc < 20 {
+		maxDesc = 20
+	}
+	desc = textutil.Truncate(desc, maxDesc)
+	lines = append(lines, styles.Dim.Render("    ")+styles.Body.Render(desc))
+
+	// Tech tags - limit based on width
+	var tags string
+	colorCycle := []lipgloss.Style{styles.Cyan, styles.Neon, styles.Green, styles.Yellow}
+	maxTags := 3
+	if cw < 40 {
+		maxTags = 2
+	}
+	for j, tech := range p.Tech {
+		if j < maxTags {
+			tags += colorCycle[j%4].Render("⟨"+tech+"⟩") + " "
+		}
+	}
+	lines = append(lines, styles.Dim.Render("    ")+tags)
+	lines = append(lines, "")
+	return lines
+}
+
+// Screenshot draws a picture in at most cols by rows cells, one string
+// per row
+type Screenshot func(cols, rows int) []string
+
+// screenshotRows is the most rows a screenshot takes
+const screenshotRows = 12
+
+// ProjectDetail renders project details, marked when the visitor
+// bookmarked the project, with its screenshots
+func ProjectDetail(styles theme.Styles, project *content.Project, bookmarked bool, shots []Screenshot, width int) string {
 	if project == nil {
-		return center(styles.Red.Render("⚠ PROJECT_NOT_FOUND"), width)
+		return textutil.Center(styles.Red.Render("⚠ PROJECT_NOT_FOUND"), width)
 	}
 
 	var b strings.Builder
 	b.WriteString("\n")
 
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
 	var lines []string
 
@@ -459,7 +516,27 @@ func ProjectDetail(styles theme.Styles, project *content.Project, width int) str
 		statusStyle = styles.Yellow
 		statusText = "○ IN_PROGRESS"
 	}
-	lines = append(lines, styles.Dim.Render("STATUS: ")+statusStyle.Bold(true).Render(statusText))
+	status := styles.Dim.Render("STATUS: ") + statusStyle.Bold(true).Render(statusText)
+	if project.Year != 0 {
+		status += styles.Dim.Render(" · SINCE: ") + styles.Body.Render(strconv.Itoa(project.Year))
+	}
+	if project.Date != "" {
+		status += styles.Dim.Render(" · UPDATED: ") + styles.Body.Render(project.Date)
+	}
+	if bookmarked {
+		status += " " + bookmarkMark(styles)
+	}
+	lines = append(lines, textutil.Truncate(status, cw))
+	var filed []string
+	if project.Category != "" {
+		filed = append(filed, styles.Dim.Render("CATEGORY: ")+styles.Purple.Render(project.Category))
+	}
+	if len(project.Tags) > 0 {
+		filed = append(filed, styles.Dim.Render("TAGS: ")+styles.Purple.Render("#"+strings.Join(project.Tags, " #")))
+	}
+	if len(filed) > 0 {
+		lines = append(lines, textutil.Truncate(strings.Join(filed, styles.Dim.Render(" · ")), cw))
+	}
 	lines = append(lines, "")
 
 	// Description - wrap to fit
@@ -477,7 +554,7 @@ func ProjectDetail(styles theme.Styles, project *content.Project, width int) str
 	currentTagLen := 0
 	for i, tech := range project.Tech {
 		tag := colorCycle[i%4].Render("⟨"+tech+"⟩") + " "
-		tagLen := len(tech) + 3
+		tagLen := textutil.Width(tech) + 3
 		if currentTagLen+tagLen > cw-4 {
 			lines = append(lines, "  "+tags)
 			tags = ""
@@ -491,62 +568,75 @@ func ProjectDetail(styles theme.Styles, project *content.Project, width int) str
 	}
 	lines = append(lines, "")
 
+	// Screenshots
+	if len(shots) > 0 {
+		lines = append(lines, styles.Neon.Bold(true).Render("◈ SCREENSHOTS"))
+		for _, shot := range shots {
+			for _, row := range shot(cw-4, screenshotRows) {
+				lines = append(lines, "  "+row)
+			}
+			lines = append(lines, "")
+		}
+	}
+
 	// Links
 	if project.Links.Demo != "" || project.Links.Github != "" {
 		lines = append(lines, styles.Yellow.Bold(true).Render("◈ LINKS"))
 		if project.Links.Demo != "" {
 			demo := project.Links.Demo
-			if len(demo) > cw-12 {
-				demo = demo[:cw-15] + "..."
-			}
-			lines = append(lines, styles.Dim.Render("  DEMO:   ")+styles.Link.Render(demo))
+			demo = textutil.Truncate(demo, cw-12)
+			lines = append(lines, styles.Dim.Render("  DEMO:   ")+Hyperlink(project.Links.Demo, styles.Link.Render(demo)))
 		}
 		if project.Links.Github != "" {
 			gh := project.Links.Github
-			if len(gh) > cw-12 {
-				gh = gh[:cw-15] + "..."
-			}
-			lines = append(lines, styles.Dim.Render("  SOURCE: ")+styles.Link.Render(gh))
+			gh = textutil.Truncate(gh, cw-12)
+			lines = append(lines, styles.Dim.Render("  SOURCE: ")+Hyperlink(project.Links.Github, styles.Link.Render(gh)))
 		}
 	}
 
-	b.WriteString(box(project.Name, lines, styles, width))
+	b.WriteString(Box{Title: project.Name, Lines: lines}.Render(styles, width))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-// Resume renders resume
-func Resume(styles theme.Styles, resume *content.Resume, width int) string {
+// Hyperlink makes rendered text an OSC 8 link to url in terminals that
+// support it; others show the text unchanged
+func Hyperlink(url, text string) string {
+	if !strings.Contains(url, "://") {
+		url = "https://" + url
+	}
+	return ansi.SetHyperlink(url) + text + ansi.ResetHyperlink()
+}
+
+// Resume renders resume, with the latest talks and certifications
+func Resume(styles theme.Styles, resume *content.Resume, talks *content.Talks, certs *content.Certifications, width int) string {
 	var b strings.Builder
 	b.WriteString("\n")
 
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
 	var lines []string
 
 	// Header
-	lines = append(lines, center(styles.Neon.Bold(true).Render(resume.Name), cw))
-	lines = append(lines, center(styles.Cyan.Render(resume.Title), cw))
+	lines = append(lines, textutil.Center(styles.Neon.Bold(true).Render(resume.Name), cw))
+	lines = append(lines, textutil.Center(styles.Cyan.Render(resume.Title), cw))
 	if resume.Tagline != "" {
 		tagline := resume.Tagline
-		if len(tagline) > cw-4 {
-			tagline = tagline[:cw-7] + "..."
-		}
-		lines = append(lines, center(styles.Muted.Italic(true).Render("\""+tagline+"\""), cw))
+		tagline = textutil.Truncate(tagline, cw-4)
+		lines = append(lines, textutil.Center(styles.Muted.Italic(true).Render("\""+tagline+"\""), cw))
 	}
 	lines = append(lines, "")
 
 	// Contact
 	contact := styles.Green.Render("✉ ") + styles.Body.Render(resume.Contact.Email)
-	lines = append(lines, center(contact, cw))
+	lines = append(lines, textutil.Center(contact, cw))
 	if resume.Contact.Website != "" {
-		web := styles.Cyan.Render("⚡ ") + styles.Link.Render(resume.Contact.Website)
-		lines = append(lines, center(web, cw))
+		web := styles.Cyan.Render("⚡ ") + Hyperlink(resume.Contact.Website, styles.Link.Render(resume.Contact.Website))
+		lines = append(lines, textutil.Center(web, cw))
 	}
 	github := styles.Purple.Render("◈ ") + styles.Body.Render(resume.Contact.Github)
-	lines = append(lines, center(github, cw))
+	lines = append(lines, textutil.Center(github, cw))
 	lines = append(lines, "")
 
 	sepLen := min(cw-2, 44)
@@ -563,63 +653,27 @@ func Resume(styles theme.Styles, resume *content.Resume, width int) string {
 
 	// Skills
 	lines = append(lines, styles.Cyan.Bold(true).Render("◈ SKILLS"))
-	skillLine := func(skills []string, style lipgloss.Style, maxSkills int) string {
-		var s string
-		currentLen := 0
-		for i, skill := range skills {
-			if i >= maxSkills {
-				break
-			}
-			tag := style.Render("⟨"+skill+"⟩") + " "
-			tagLen := len(skill) + 3
-			if currentLen+tagLen > cw-4 {
-				break
-			}
-			s += tag
-			currentLen += tagLen
-		}
-		return s
-	}
-	lines = append(lines, "  "+skillLine(resume.Skills.Languages, styles.Neon, 5))
-	lines = append(lines, "  "+skillLine(resume.Skills.Frontend, styles.Cyan, 4))
-	lines = append(lines, "  "+skillLine(resume.Skills.Backend, styles.Green, 4))
-	lines = append(lines, "  "+skillLine(resume.Skills.DevOps, styles.Yellow, 4))
+	lines = append(lines, skillLines(styles, resume.Skills, cw)...)
 	lines = append(lines, "")
 
 	// Education
 	lines = append(lines, styles.Yellow.Bold(true).Render("◈ EDUCATION"))
 	for _, edu := range resume.Education {
 		degree := edu.Degree
-		if len(degree) > cw-4 {
-			degree = degree[:cw-7] + "..."
-		}
+		degree = textutil.Truncate(degree, cw-4)
 		lines = append(lines, "  "+styles.Neon.Bold(true).Render(degree))
 
 		inst := edu.Institution + ", " + edu.Location
-		if len(inst) > cw-4 {
-			inst = inst[:cw-7] + "..."
-		}
+		inst = textutil.Truncate(inst, cw-4)
 		lines = append(lines, "  "+styles.Cyan.Render(inst))
 		lines = append(lines, "  "+styles.Dim.Render(edu.Period)+" │ "+styles.Green.Render(edu.Score))
 		lines = append(lines, "")
 	}
 
-	// Achievements
-	if len(resume.Achievements) > 0 {
-		lines = append(lines, styles.Green.Bold(true).Render("◈ ACHIEVEMENTS"))
-		for i, ach := range resume.Achievements {
-			if i < 3 {
-				a := ach
-				maxAch := cw - 6
-				if len(a) > maxAch {
-					a = a[:maxAch-3] + "..."
-				}
-				lines = append(lines, styles.Neon.Render("  ▸ ")+styles.Body.Render(a))
-			}
-		}
-	}
+	lines = append(lines, resumeAchievementLines(styles, resume.Achievements, cw)...)
+	lines = append(lines, credentialLines(styles, talks, certs, cw)...)
 
-	b.WriteString(box("CREDENTIALS", lines, styles, width))
+	b.WriteString(Box{Title: "CREDENTIALS", Lines: lines}.Render(styles, width))
 	b.WriteString("\n")
 
 	return b.String()
@@ -630,13 +684,12 @@ func Experience(styles theme.Styles, resume *content.Resume, width int) string {
 	var b strings.Builder
 	b.WriteString("\n")
 
-	bw := boxWidth(width)
-	cw := contentWidth(bw)
+	cw := innerWidth(width)
 
 	var lines []string
 
-	lines = append(lines, center(styles.Neon.Bold(true).Render("WORK EXPERIENCE"), cw))
-	lines = append(lines, center(styles.Muted.Render(resume.Name), cw))
+	lines = append(lines, textutil.Center(styles.Neon.Bold(true).Render("WORK EXPERIENCE"), cw))
+	lines = append(lines, textutil.Center(styles.Muted.Render(resume.Name), cw))
 	lines = append(lines, "")
 
 	sepLen := min(cw-2, 44)
@@ -645,15 +698,11 @@ func Experience(styles theme.Styles, resume *content.Resume, width int) string {
 
 	for i, exp := range resume.Experience {
 		role := exp.Role
-		if len(role) > cw-2 {
-			role = role[:cw-5] + "..."
-		}
+		role = textutil.Truncate(role, cw-2)
 		lines = append(lines, styles.Neon.Bold(true).Render(role))
 
 		company := exp.Company
-		if len(company) > cw-4 {
-			company = company[:cw-7] + "..."
-		}
+		company = textutil.Truncate(company, cw-4)
 		lines = append(lines, styles.Dim.Render("@ ")+styles.Cyan.Bold(true).Render(company))
 		lines = append(lines, styles.Muted.Render("  "+exp.Period))
 		lines = append(lines, "")
@@ -662,9 +711,7 @@ func Experience(styles theme.Styles, resume *content.Resume, width int) string {
 		for _, h := range exp.Highlights {
 			hl := h
 			maxHL := cw - 6
-			if len(hl) > maxHL {
-				hl = hl[:maxHL-3] + "..."
-			}
+			hl = textutil.Truncate(hl, maxHL)
 			lines = append(lines, styles.Green.Render("  ▸ ")+styles.Body.Render(hl))
 		}
 
@@ -676,14 +723,15 @@ func Experience(styles theme.Styles, resume *content.Resume, width int) string {
 		}
 	}
 
-	b.WriteString(box("EXPERIENCE", lines, styles, width))
+	b.WriteString(Box{Title: "EXPERIENCE", Lines: lines}.Render(styles, width))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-// ChatMessage renders a chat message
-func ChatMessage(styles theme.Styles, role, content string, width int, mdRenderer *MarkdownRenderer) string {
+// ChatMessage renders a chat message, ending its header with stamp if set.
+// Role "faq" is an assistant answer served from the FAQ.
+func ChatMessage(styles theme.Styles, role, content, stamp string, width int, mdRenderer MessageRenderer) string {
 	var b strings.Builder
 
 	// Calculate border width based on screen
@@ -692,13 +740,32 @@ func ChatMessage(styles theme.Styles, role, content string, width int, mdRendere
 		borderLen = 20
 	}
 
+	if styles.Accessible {
+		when := ""
+		if stamp != "" {
+			when = " (" + stamp + ")"
+		}
+		if role == "user" {
+			b.WriteString(styles.Cyan.Bold(true).Render("You"+when+": ") + styles.Body.Render(textutil.Wrap(content, width-8)))
+		} else {
+			mdRenderer.SetWidth(width - 6)
+			label := "Assistant"
+			if role == "faq" {
+				label = "Assistant, from the FAQ"
+			}
+			b.WriteString(styles.Neon.Bold(true).Render(label+when+":") + "\n" + mdRenderer.Render(content))
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	if role == "user" {
-		b.WriteString(styles.Cyan.Bold(true).Render("┌─ YOU " + strings.Repeat("─", borderLen-6)))
+		b.WriteString(messageHeader(styles.Cyan.Bold(true), "┌─ YOU ", stamp, styles.Dim, borderLen+1))
 		b.WriteString("\n")
 
 		// Wrap user message
 		maxMsgWidth := width - 8
-		wrapped := WrapText(content, maxMsgWidth)
+		wrapped := textutil.Wrap(content, maxMsgWidth)
 		for _, line := range strings.Split(wrapped, "\n") {
 			b.WriteString(styles.Dim.Render("│ ") + styles.Body.Render(line))
 			b.WriteString("\n")
@@ -706,7 +773,11 @@ func ChatMessage(styles theme.Styles, role, content string, width int, mdRendere
 
 		b.WriteString(styles.Dim.Render("└" + strings.Repeat("─", borderLen)))
 	} else {
-		b.WriteString(styles.Neon.Bold(true).Render("┌─ MOHAK.AI " + strings.Repeat("─", borderLen-11)))
+		label := "┌─ MOHAK.AI "
+		if role == "faq" {
+			label = "┌─ MOHAK.AI · FAQ "
+		}
+		b.WriteString(messageHeader(styles.Neon.Bold(true), label, stamp, styles.Dim, borderLen+1))
 		b.WriteString("\n")
 
 		// Set markdown renderer width
@@ -724,8 +795,114 @@ func ChatMessage(styles theme.Styles, role, content string, width int, mdRendere
 	return b.String()
 }
 
+// SupersededMessage renders an answer replaced by /regen as one collapsed line
+func SupersededMessage(styles theme.Styles, content string, width int) string {
+	lines := strings.Count(strings.TrimSpace(content), "\n") + 1
+	noun := "lines"
+	if lines == 1 {
+		noun = "line"
+	}
+	if styles.Accessible {
+		return styles.Dim.Render(fmt.Sprintf("Earlier answer, replaced (%d %s hidden).", lines, noun)) + "\n"
+	}
+	preview := textutil.Truncate(strings.Join(strings.Fields(content), " "), max(width-34, 10))
+	return styles.Dim.Render(fmt.Sprintf("┄ superseded · %d %s · ", lines, noun)) +
+		styles.Muted.Italic(true).Render(preview) + "\n"
+}
+
+// TruncatedNote marks an answer cut off at the length cap
+func TruncatedNote(styles theme.Styles) string {
+	if styles.Accessible {
+		return styles.Dim.Render("Response truncated. Type /continue for the rest.") + "\n"
+	}
+	return styles.Dim.Render("┄ …response truncated · ") + styles.Yellow.Render("/continue") +
+		styles.Dim.Render(" for the rest") + "\n"
+}
+
+// RatePrompt follows the last answer, offering + and - to rate it
+func RatePrompt(styles theme.Styles) string {
+	if styles.Accessible {
+		return styles.Dim.Render("Did this help? Press plus for yes, minus for no.") + "\n"
+	}
+	return styles.Dim.Render("┄ helpful? ") + styles.Yellow.Render("+") + styles.Dim.Render(" 👍 · ") +
+		styles.Yellow.Render("-") + styles.Dim.Render(" 👎") + "\n"
+}
+
+// RatedNote follows an answer the visitor rated
+func RatedNote(styles theme.Styles, helpful bool) string {
+	if styles.Accessible {
+		if helpful {
+			return styles.Dim.Render("You rated this answer helpful.") + "\n"
+		}
+		return styles.Dim.Render("You rated this answer not helpful.") + "\n"
+	}
+	if helpful {
+		return styles.Dim.Render("┄ 👍 rated helpful") + "\n"
+	}
+	return styles.Dim.Render("┄ 👎 rated not helpful") + "\n"
+}
+
+// EarlierNote heads a chat whose first messages are archived: archived
+// can still be loaded, forgotten were cleared for good
+func EarlierNote(styles theme.Styles, archived, forgotten, width int) string {
+	var parts []string
+	if archived > 0 {
+		noun := "messages"
+		if archived == 1 {
+			noun = "message"
+		}
+		parts = append(parts, fmt.Sprintf("%d earlier %s · scroll up to load", archived, noun))
+	}
+	if forgotten > 0 {
+		parts = append(parts, fmt.Sprintf("%d older cleared", forgotten))
+	}
+	text := strings.Join(parts, " · ")
+	if styles.Accessible {
+		return styles.Dim.Render(text+".") + "\n\n"
+	}
+	return textutil.Center(styles.Dim.Render("┄ "+text+" ┄"), width) + "\n\n"
+}
+
+// FollowUps renders suggested questions as numbered chips; selected is
+// the chip Tab has moved to, or -1
+func FollowUps(styles theme.Styles, items []string, selected, width int) string {
+	var b strings.Builder
+	if styles.Accessible {
+		b.WriteString("Suggested questions, press the number to ask:\n")
+		for i, item := range items {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, item)
+		}
+		return b.String()
+	}
+
+	b.WriteString(styles.Dim.Render("┄ try asking ") + styles.Yellow.Render("1-"+fmt.Sprint(len(items))) +
+		styles.Dim.Render(" send · ") + styles.Yellow.Render("TAB") + styles.Dim.Render(" select"))
+	b.WriteString("\n")
+	for i, item := range items {
+		num := fmt.Sprint(i + 1)
+		text := textutil.Truncate(item, max(width-14, 10))
+		if i == selected {
+			// Tag's padding makes " 1 " the same width as "[1]"
+			b.WriteString(styles.Tag.Render(num) + " " + styles.Highlight.Render(text))
+		} else {
+			b.WriteString(styles.Cyan.Render("["+num+"]") + " " + styles.Muted.Render(text))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// messageHeader draws a message's top rule, ending it with the timestamp
+func messageHeader(style lipgloss.Style, label, stamp string, stampStyle lipgloss.Style, width int) string {
+	if stamp == "" {
+		return style.Render(label + strings.Repeat("─", max(width-textutil.Width(label), 0)))
+	}
+	fill := max(width-textutil.Width(label)-textutil.Width(stamp)-3, 1)
+	return style.Render(label+strings.Repeat("─", fill)+" ") + stampStyle.Render(stamp) + style.Render(" ─")
+}
+
 // StreamingMessage renders streaming AI response
-func StreamingMessage(styles theme.Styles, content string, width int, mdRenderer *MarkdownRenderer) string {
+func StreamingMessage(styles theme.Styles, content string, width int, mdRenderer *MarkdownRenderer, spinner string, anim Anim) string {
 	var b strings.Builder
 
 	borderLen := min(width-8, 40)
@@ -733,7 +910,20 @@ func StreamingMessage(styles theme.Styles, content string, width int, mdRenderer
 		borderLen = 20
 	}
 
-	b.WriteString(styles.Neon.Bold(true).Render("┌─ MOHAK.AI ") + styles.Neon.Render("▓▒░ streaming ░▒▓"))
+	if styles.Accessible {
+		b.WriteString(styles.Neon.Bold(true).Render("Assistant (responding):"))
+		b.WriteString("\n")
+		if content == "" {
+			b.WriteString(styles.Muted.Render("Waiting for response..."))
+		} else {
+			mdRenderer.SetWidth(width - 6)
+			b.WriteString(mdRenderer.RenderStreaming(content))
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(styles.Neon.Bold(true).Render("┌─ MOHAK.AI ") + Spinner(styles, spinner, anim) + " " + Shimmer(styles, "streaming", anim))
 	b.WriteString("\n")
 
 	if content != "" {
@@ -744,9 +934,9 @@ func StreamingMessage(styles theme.Styles, content string, width int, mdRenderer
 			b.WriteString(styles.Dim.Render("│ ") + line)
 			b.WriteString("\n")
 		}
-		b.WriteString(styles.Dim.Render("│ ") + styles.Neon.Render("▌"))
+		b.WriteString(styles.Dim.Render("│ ") + Cursor(styles, anim))
 	} else {
-		b.WriteString(styles.Dim.Render("│ ") + styles.Neon.Render("▓▒░ ") + styles.Muted.Render("initializing...") + styles.Neon.Render(" ░▒▓"))
+		b.WriteString(styles.Dim.Render("│ ") + Spinner(styles, spinner, anim) + " " + Shimmer(styles, "initializing...", anim))
 	}
 	b.WriteString("\n")
 	b.WriteString(styles.Dim.Render("└" + strings.Repeat("─", borderLen)))
@@ -757,10 +947,16 @@ func StreamingMessage(styles theme.Styles, content string, width int, mdRenderer
 
 // Error renders error
 func Error(styles theme.Styles, message string) string {
+	if styles.Accessible {
+		return styles.Red.Render("Error: " + message)
+	}
 	return styles.Red.Render("⚠ ERR: " + message)
 }
 
 // Success renders success
 func Success(styles theme.Styles, message string) string {
+	if styles.Accessible {
+		return styles.Green.Render("Done: " + message)
+	}
 	return styles.Green.Render("✓ " + message)
 }
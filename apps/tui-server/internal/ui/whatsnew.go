@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/textutil"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// WhatsNewData is what /whatsnew shows: the changes since the visitor's
+// last session, described by Since ("3 days ago"), or a Note saying why
+// there's nothing to compare
+type WhatsNewData struct {
+	Changes []content.Change
+	Since   string
+	Note    string
+}
+
+// WhatsNew renders the content changes since the last visit, grouped by
+// section
+func WhatsNew(styles theme.Styles, data WhatsNewData, width int) string {
+	cw := innerWidth(width)
+
+	var lines []string
+	switch {
+	case data.Note != "":
+		lines = append(lines, wrapTextForBox(data.Note, cw, styles)...)
+	case len(data.Changes) == 0:
+		lines = append(lines, styles.Muted.Render(textutil.Truncate("Nothing has changed since your last visit, "+data.Since, cw)))
+	default:
+		lines = append(lines, styles.Muted.Render(textutil.Truncate("Since your last visit, "+data.Since+":", cw)))
+		section := ""
+		for _, c := range data.Changes {
+			if c.Section != section {
+				section = c.Section
+				lines = append(lines, "", styles.Cyan.Bold(true).Render("◈ "+strings.ToUpper(section)))
+			}
+			lines = append(lines, changeLines(styles, c, cw)...)
+		}
+	}
+
+	return "\n" + Box{Title: "WHAT'S NEW", Lines: lines}.Render(styles, width) + "\n"
+}
+
+// changeLines describes one change: the entry, marked added, removed or
+// changed, then its edits below it
+func changeLines(styles theme.Styles, c content.Change, cw int) []string {
+	mark := func(symbol, word string) string {
+		if styles.Accessible {
+			return word + " "
+		}
+		return symbol + " "
+	}
+
+	arrow := " → "
+	if styles.Accessible {
+		arrow = " to "
+	}
+
+	var lines []string
+	indent := "  "
+	switch c.Kind {
+	case content.Added:
+		line := styles.Green.Render(mark("+", "Added:")) + styles.Neon.Bold(true).Render(c.Item)
+		if len(c.Edits) > 0 {
+			line += styles.Dim.Render(" · " + c.Edits[0].New)
+		}
+		return []string{"  " + textutil.Truncate(line, cw-2)}
+	case content.Removed:
+		line := styles.Red.Render(mark("−", "Removed:")) + styles.Muted.Render(c.Item)
+		return []string{"  " + textutil.Truncate(line, cw-2)}
+	}
+	if c.Item != "" {
+		lines = append(lines, "  "+textutil.Truncate(styles.Yellow.Render(mark("~", "Changed:"))+styles.Neon.Bold(true).Render(c.Item), cw-2))
+		indent = "    "
+	}
+	for _, e := range c.Edits {
+		var line string
+		switch {
+		case e.Old == "":
+			line = styles.Green.Render(mark("+", "Added")) + styles.Dim.Render(e.Field+" ") + styles.Body.Render(e.New)
+		case e.New == "":
+			line = styles.Red.Render(mark("−", "Removed")) + styles.Dim.Render(e.Field+" ") + styles.Muted.Render(e.Old)
+		case textutil.Width(e.Field+e.Old+e.New+arrow)+3 > cw-len(indent):
+			// Too long to show both, as a rewritten summary is
+			line = styles.Yellow.Render(mark("~", "Changed")) + styles.Dim.Render(e.Field+strings.TrimRight(arrow, " ")+" ") + styles.Body.Render(e.New)
+		default:
+			line = styles.Yellow.Render(mark("~", "Changed")) + styles.Dim.Render(e.Field+" ") +
+				styles.Muted.Render(e.Old) + styles.Dim.Render(arrow) + styles.Body.Render(e.New)
+		}
+		lines = append(lines, indent+textutil.Truncate(line, cw-len(indent)))
+	}
+	return lines
+}
@@ -0,0 +1,56 @@
+// Package version identifies the running build. Release builds set the
+// variables with ldflags:
+//
+//	go build -ldflags "-X github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version.Version=v1.2.3 \
+//	  -X github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+var (
+	// Version is the release's semver, or "dev" for local builds
+	Version = "dev"
+	// Commit is the git commit the binary was built from
+	Commit = ""
+	// Date is when the binary was built, in RFC 3339
+	Date = ""
+)
+
+func init() {
+	// Plain `go build` in a checkout still records the commit
+	if Commit != "" && Date != "" {
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, s := range info.Settings {
+		switch {
+		case s.Key == "vcs.revision" && Commit == "":
+			Commit = s.Value
+		case s.Key == "vcs.time" && Date == "":
+			Date = s.Value
+		}
+	}
+	if len(Commit) > 7 {
+		Commit = Commit[:7]
+	}
+}
+
+// String renders the version as "v1.2.3 (abc1234, 2026-10-17T12:00:00Z)"
+func String() string {
+	switch {
+	case Commit == "" && Date == "":
+		return Version
+	case Date == "":
+		return fmt.Sprintf("%s (%s)", Version, Commit)
+	case Commit == "":
+		return fmt.Sprintf("%s (%s)", Version, Date)
+	}
+	return fmt.Sprintf("%s (%s, %s)", Version, Commit, Date)
+}
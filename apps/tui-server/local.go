@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/app"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+)
+
+// runLocal runs the TUI on this terminal with no SSH server, so content
+// and view changes can be tried without connecting. Logs go to logPath
+// since anything written to the terminal would tear the UI.
+func runLocal(cfg app.Config, logger *telemetry.Logger, logPath string) error {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+	logger.SetOutput(logFile)
+	defer logger.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Bubble Tea sends the real size on start
+	width, height := 80, 24
+	cfg.ThemeManager = theme.NewManager(width, height, lipgloss.NewRenderer(os.Stdout))
+	cfg.SessionID = "local"
+	cfg.Width = width
+	cfg.Height = height
+	cfg.Context = ctx
+	cfg.ReducedMotion = envFlag("REDUCED_MOTION")
+	cfg.Accessible = envFlag("ACCESSIBLE")
+	cfg.ASCII = theme.DetectGlyphSet(os.Getenv("TERM"), os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE"), os.Getenv("LANG")).ASCII
+	cfg.Graphics = graphics.Detect(os.Getenv("TERM"), os.Getenv)
+
+	var program *tea.Program
+	cfg.Send = func(msg tea.Msg) { program.Send(msg) }
+	program = tea.NewProgram(app.NewModel(cfg), tea.WithAltScreen())
+
+	logger.Info("Local session started")
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	logger.Info("Local session ended")
+	return nil
+}
+
+// envFlag reports whether a local env var is set to a truthy value
+func envFlag(key string) bool {
+	return sessionEnvFlag(os.Environ(), key)
+}
@@ -3,9 +3,15 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,14 +19,34 @@ import (
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/activeterm"
 	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/scp"
 	"github.com/joho/godotenv"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/access"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/app"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/audit"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/availability"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/broadcast"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/control"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/export"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/github"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/guard"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/network"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/notify"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ops"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/share"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/status"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
 	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
 )
 
 const (
@@ -28,69 +54,200 @@ const (
 	defaultPort      = "2222"
 	idleTimeout      = 10 * time.Minute
 	maxSessionsPerIP = 5
+	// programKillGrace is how long a program has to exit after its
+	// session ends before it's killed
+	programKillGrace = 5 * time.Second
 )
 
 func main() {
+	serverStart := time.Now()
+
 	// Load .env file (ignore error if not found)
 	_ = godotenv.Load()
 
+	// --local runs the TUI on this terminal instead of serving it over SSH
+	local := flag.Bool("local", false, "run the TUI in this terminal without the SSH server")
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println("tui-server " + version.String())
+		return
+	}
+
+	// `preview <page>` renders a view to stdout and exits
+	if flag.Arg(0) == "preview" {
+		if err := runPreview(os.Stdout, os.Getenv("CONTENT_PATH"), flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize logger
 	logger := telemetry.NewLogger("tui-server")
 
-	// Initialize analytics
-	analytics := telemetry.NewAnalytics(logger)
-	defer analytics.Close()
-
 	// Configuration from environment
 	host := getEnv("SSH_HOST", defaultHost)
 	port := getEnv("SSH_PORT", defaultPort)
 	contentPath := os.Getenv("CONTENT_PATH")
+	// STORE_BACKEND=redis lets replicas on different hosts share visitors
+	// and totals; the rest of .data stays beside STORE_PATH
+	storeBackend := store.Backend(getEnv("STORE_BACKEND", string(store.BackendFile)))
+	defaultStorePath := ".data/store.json"
+	if storeBackend == store.BackendSQLite {
+		defaultStorePath = ".data/store.db"
+	}
+	storePath := getEnv("STORE_PATH", defaultStorePath)
+
+	// `feedback` prints the latest /feedback submissions and exits
+	if flag.Arg(0) == "feedback" {
+		s, err := store.Open(store.Config{Backend: storeBackend, Path: storePath, URL: os.Getenv("REDIS_URL")})
+		if err == nil {
+			err = runFeedback(os.Stdout, s, flag.Args()[1:])
+			s.Close()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	// `ctl <command>` controls the server running on this host through
+	// its control socket, CONTROL_SOCKET
+	controlSocket := getEnv("CONTROL_SOCKET", filepath.Join(filepath.Dir(storePath), "control.sock"))
+	if flag.Arg(0) == "ctl" {
+		if err := runCtl(os.Stdout, controlSocket, flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	recordingsDir := filepath.Join(filepath.Dir(storePath), "casts")
+	// PUBLIC_HOST/PUBLIC_PORT are what visitors connect to, which behind
+	// Docker or a proxy can differ from the bind address
+	publicHost := getEnv("PUBLIC_HOST", "localhost")
+	publicPort := getEnv("PUBLIC_PORT", port)
 	modelName := getEnv("AI_GATEWAY_MODEL", "openai/gpt-oss-20b")
 	maxTokens := getEnvInt("AI_GATEWAY_MAX_TOKENS", 1024)
 	temperature := getEnvFloat("AI_TEMPERATURE", 0.7)
 	rateLimit := getEnvInt("AI_GATEWAY_RATE_LIMIT", 10)
+	maxHistory := getEnvInt("AI_MAX_HISTORY", 10)
+	maxResponseLength := getEnvInt("AI_MAX_RESPONSE_LENGTH", 4000)
+	maxWidth := getEnvInt("MAX_WIDTH", 120)
+	filterActions, err := ai.ParseFilterActions(os.Getenv("AI_FILTER_ACTIONS"))
+	if err != nil {
+		logger.Error("Invalid AI_FILTER_ACTIONS", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Initialize analytics; events PostHog can't take wait beside the
+	// store until it's back
+	analytics := telemetry.NewAnalytics(logger, filepath.Join(filepath.Dir(storePath), "analytics-buffer.jsonl"))
+	defer analytics.Close()
+
+	// Extra palettes from THEMES_DIR; a bad file stops startup rather
+	// than failing when a visitor picks it
+	themes, err := loadThemes(getEnv("THEMES_DIR", "themes"))
+	if err != nil {
+		logger.Error("Invalid theme", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	if themes > 0 {
+		logger.Info("Themes loaded", telemetry.Ctx("count", themes))
+	}
 
 	contentSource := "embedded"
 	if contentPath != "" {
 		contentSource = contentPath
 	}
 
-	logger.Info("Starting SSH server", telemetry.Ctx(
-		"host", host,
-		"port", port,
-		"model", modelName,
-		"contentSource", contentSource,
-	))
-
-	// Track server start
-	analytics.TrackServerStart(host, port)
+	if !*local {
+		logger.Info("Starting SSH server", telemetry.Ctx(
+			"version", version.String(),
+			"host", host,
+			"port", port,
+			"model", modelName,
+			"contentSource", contentSource,
+		))
+
+		// Track server start
+		analytics.TrackServerStart(host, port)
+	}
 
-	// Load content
+	// Load content; the control socket's reload-content loads it again
 	contentLoader := content.NewLoader(contentPath)
-
-	resume, err := contentLoader.LoadResume()
+	loaded, err := loadSite(contentLoader, serverStart)
+	if err != nil {
+		logger.Error("Failed to load content", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Debug("Content loaded", telemetry.Ctx("projects", len(loaded.projects.Projects), "faqs", len(loaded.faq.FAQs)))
+	current := &liveSite{}
+	current.Store(loaded)
+
+	// HOME_TIMEZONE puts Mohak's local time, and what it means for replies,
+	// on the welcome and contact screens and in the AI's context
+	var ownerName string
+	if names := strings.Fields(loaded.resume.Name); len(names) > 0 {
+		ownerName = names[0]
+	}
+	clock, err := availability.New(os.Getenv("HOME_TIMEZONE"), ownerName)
 	if err != nil {
-		logger.Error("Failed to load resume", telemetry.Ctx("error", err.Error()))
+		logger.Error("Invalid HOME_TIMEZONE", telemetry.Ctx("error", err.Error()))
 		os.Exit(1)
 	}
-	logger.Debug("Resume loaded successfully")
 
-	projects, err := contentLoader.LoadProjects()
+	promptBuilder := ai.NewPromptBuilder(loaded.resume, loaded.projects, loaded.bio).
+		WithClock(clock).
+		WithVersion(loaded.digest.Version())
+	aiConversations := os.Getenv("AI_CONVERSATIONS") == "on"
+	providerSpecs, err := ai.ParseProviderSpecs(os.Getenv("AI_PROVIDERS"))
+	if err != nil {
+		logger.Error("Invalid AI_PROVIDERS", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	aiProvider, err := ai.NewProviderChain(providerSpecs, ai.GatewayConfig{
+		APIKey:       os.Getenv("AI_GATEWAY_API_KEY"),
+		APIKeyHeader: os.Getenv("AI_GATEWAY_API_KEY_HEADER"),
+		BaseURL:      os.Getenv("AI_GATEWAY_URL"),
+		TLS: network.TLSFiles{
+			CAFile:   os.Getenv("AI_GATEWAY_CA_FILE"),
+			CertFile: os.Getenv("AI_GATEWAY_CLIENT_CERT"),
+			KeyFile:  os.Getenv("AI_GATEWAY_CLIENT_KEY"),
+		},
+		// AI_CONVERSATIONS=on is for a gateway that keeps each chat's
+		// history under its conversation ID
+		Conversations: aiConversations,
+		OnEvent: func(sessionID string, event ai.GatewayEvent) {
+			logger.Info("AI gateway event", telemetry.Ctx(
+				"session_hash", sessionID,
+				"event", event.Name,
+			))
+		},
+	}, ai.ProviderKeys{
+		OpenAI:     os.Getenv("OPENAI_API_KEY"),
+		Anthropic:  os.Getenv("ANTHROPIC_API_KEY"),
+		OpenRouter: os.Getenv("OPENROUTER_API_KEY"),
+	}, logger)
 	if err != nil {
-		logger.Error("Failed to load projects", telemetry.Ctx("error", err.Error()))
+		logger.Error("Invalid AI provider config", telemetry.Ctx("error", err.Error()))
 		os.Exit(1)
 	}
-	logger.Debug("Projects loaded", telemetry.Ctx("count", len(projects.Projects)))
 
-	bio, err := contentLoader.LoadBio()
+	visitorStore, err := store.Open(store.Config{
+		Backend: storeBackend,
+		Path:    storePath,
+		URL:     os.Getenv("REDIS_URL"),
+	})
 	if err != nil {
-		logger.Error("Failed to load bio", telemetry.Ctx("error", err.Error()))
+		logger.Error("Failed to open visitor store", telemetry.Ctx("backend", string(storeBackend), "error", err.Error()))
 		os.Exit(1)
 	}
-	logger.Debug("Bio loaded successfully")
+	defer visitorStore.Close()
 
-	promptBuilder := ai.NewPromptBuilder(resume, projects, bio)
-	aiProvider := ai.NewVercelGatewayProvider(os.Getenv("AI_GATEWAY_API_KEY"))
+	loaded.archive(visitorStore, logger)
 	aiService := ai.NewService(ai.Config{
 		Provider:         aiProvider,
 		Logger:           logger,
@@ -102,22 +259,279 @@ func main() {
 		TopP:             0.9,
 		FrequencyPenalty: 0.3,
 		PresencePenalty:  0.1,
-		MaxHistoryLength: 10,
+		MaxHistoryLength: maxHistory,
 		RateLimitMax:     rateLimit,
 		RateLimitWindow:  time.Minute,
+		Limiter:          visitorStore,
+		Filter:           ai.FilterConfig{Actions: filterActions},
+		Conversations:    aiConversations,
 	})
 
-	// Session counter for rate limiting
-	sessionCounter := NewSessionCounter(maxSessionsPerIP)
+	// A visitor whose connection drops gets the session back on
+	// reconnecting with the same key within RESUME_WINDOW; 0 disables it
+	resumeWindow, err := time.ParseDuration(getEnv("RESUME_WINDOW", "15m"))
+	if err != nil {
+		logger.Error("Invalid RESUME_WINDOW", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// /activity draws the GitHub contribution calendar of the resume's
+	// profile, or GITHUB_LOGIN's; it needs a token to call the API
+	var activity app.Activity
+	if client := github.New(github.Config{
+		Token: os.Getenv("GITHUB_TOKEN"),
+		Login: getEnv("GITHUB_LOGIN", github.LoginFromURL(loaded.resume.Contact.Github)),
+	}); client != nil {
+		activity = client
+	}
+
+	// The welcome screen shows the track playing on Last.fm, or else the
+	// first line of STATUS_URL
+	var ownerStatus app.StatusSource
+	if source := status.New(status.Config{
+		LastFMUser: os.Getenv("LASTFM_USER"),
+		LastFMKey:  os.Getenv("LASTFM_API_KEY"),
+		URL:        os.Getenv("STATUS_URL"),
+	}, logger); source != nil {
+		ownerStatus = source
+	}
+
+	// /share uploads to SHARE_PASTE_URL, or else keeps transcripts for
+	// the resume listener to serve at SHARE_BASE_URL/t/<id>
+	resumeAddr := getEnv("RESUME_HTTP_ADDR", "off")
+	var sharer app.Sharer
+	shared := share.Store{Dir: filepath.Join(filepath.Dir(storePath), "shared"), BaseURL: os.Getenv("SHARE_BASE_URL")}
+	switch {
+	case os.Getenv("SHARE_PASTE_URL") != "":
+		sharer = share.Paste{URL: os.Getenv("SHARE_PASTE_URL")}
+	case shared.BaseURL != "" && resumeAddr != "off":
+		sharer = shared
+	}
+
+	if *local {
+		// Arguments deep link like an SSH command: --local projects/mohak-tui
+		err := runLocal(loaded.sessionConfig(app.Config{
+			AIService:         aiService,
+			ServerStart:       serverStart,
+			MaxResponseLength: maxResponseLength,
+			MaxWidth:          maxWidth,
+			InitialRoute:      strings.Join(flag.Args(), "/"),
+			Store:             visitorStore,
+			VisitorKey:        "local",
+			Activity:          activity,
+			Status:            ownerStatus,
+			Clock:             clock,
+			Conversations:     aiConversations,
+			Share:             sharer,
+		}), logger, filepath.Join(filepath.Dir(storePath), "local.log"))
+		if err != nil {
+			logger.Error("Local session failed", telemetry.Ctx("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Live and total counts are shared by every process using the same
+	// store, so replicas behind a load balancer agree on them
+	registry, err := visitorStore.Presence()
+	if err != nil {
+		logger.Error("Failed to open session registry", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	registryCtx, stopRegistry := context.WithCancel(context.Background())
+	defer stopRegistry()
+	go registry.Run(registryCtx)
+
+	// Every connection attempt gets a line in the audit log, which holds
+	// client addresses and so is kept for AUDIT_RETENTION_DAYS only
+	var auditLog *audit.Log
+	if auditPath := getEnv("AUDIT_LOG", filepath.Join(filepath.Dir(storePath), "audit.log")); auditPath != "off" {
+		auditLog, err = audit.Open(auditPath, getEnvInt("AUDIT_RETENTION_DAYS", 14))
+		if err != nil {
+			logger.Error("Failed to open audit log", telemetry.Ctx("error", err.Error()))
+			os.Exit(1)
+		}
+		defer auditLog.Close()
+	}
+
+	// Scanners and bots are kept out of analytics; GUARD_MODE=ban or
+	// tarpit also shuts out repeat offenders for GUARD_BAN_FOR
+	guardMode, err := guard.ParseMode(getEnv("GUARD_MODE", "log"))
+	if err != nil {
+		logger.Error("Invalid GUARD_MODE", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	banFor, err := time.ParseDuration(getEnv("GUARD_BAN_FOR", "1h"))
+	if err != nil {
+		logger.Error("Invalid GUARD_BAN_FOR", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	botGuard := guard.New(guard.Config{
+		Mode:   guardMode,
+		BanFor: banFor,
+		OnBlock: func(addr net.Addr) {
+			auditLog.Record(audit.Event{Outcome: audit.Rejected, Addr: addr, Reason: "banned"})
+		},
+	}, logger)
+	go botGuard.Run(registryCtx)
+
+	// ACCESS_DENYLIST refuses keys and addresses outright; with an
+	// ACCESS_ALLOWLIST only its keys get chat and saved settings, and
+	// ACCESS_ADMINS' keys get /broadcast. The files are picked up again
+	// when edited.
+	accessLists := access.New(access.Config{
+		AllowPath: getEnv("ACCESS_ALLOWLIST", ""),
+		DenyPath:  getEnv("ACCESS_DENYLIST", ""),
+		AdminPath: getEnv("ACCESS_ADMINS", ""),
+		OnDeny: func(s ssh.Session) {
+			auditLog.Record(sessionAudit(s, audit.Rejected, "denied"))
+		},
+	}, logger)
+	go accessLists.Run(registryCtx)
+
+	// pprof profiles and runtime metrics for the operator; localhost only
+	// unless OPS_ADDR says otherwise, and "off" disables them
+	if opsAddr := getEnv("OPS_ADDR", "127.0.0.1:6060"); opsAddr != "off" {
+		handler := ops.Handler(
+			ops.Gauge{Name: "tui_sessions_live", Help: "Sessions open across every server process.",
+				Value: func() float64 { return float64(registry.Live()) }},
+			ops.Gauge{Name: "tui_streams_active", Help: "Replies streaming in this process.",
+				Value: func() float64 { return float64(app.ActiveStreams()) }},
+			ops.Gauge{Name: "tui_guard_blocked_total", Help: "Connections from banned clients refused or tarpitted.",
+				Value: func() float64 { return float64(botGuard.Blocked()) }},
+			ops.Gauge{Name: "tui_analytics_buffer_depth", Help: "Analytics events waiting for PostHog.",
+				Value: func() float64 { depth, _ := analytics.BufferStats(); return float64(depth) }},
+			ops.Gauge{Name: "tui_analytics_buffer_dropped_total", Help: "Analytics events dropped from a full buffer.",
+				Value: func() float64 { _, dropped := analytics.BufferStats(); return float64(dropped) }},
+		)
+		go func() {
+			if err := ops.Serve(registryCtx, opsAddr, handler); err != nil {
+				logger.Error("Ops listener failed", telemetry.Ctx("error", err.Error()))
+			}
+		}()
+		logger.Info("Ops listener ready", telemetry.Ctx("addr", opsAddr))
+	}
+
+	// /resume.pdf and /resume.txt over plain HTTP, safe to expose
+	// publicly, and shared transcripts at /t/<id>; off unless
+	// RESUME_HTTP_ADDR is set
+	if resumeAddr != "off" {
+		mux := http.NewServeMux()
+		mux.Handle("/", export.Handler(func() export.Files { return current.Load().files }))
+		mux.Handle("/t/", shared.Handler())
+		go func() {
+			if err := ops.Serve(registryCtx, resumeAddr, mux); err != nil {
+				logger.Error("Resume listener failed", telemetry.Ctx("error", err.Error()))
+			}
+		}()
+		logger.Info("Resume listener ready", telemetry.Ctx("addr", resumeAddr))
+	}
+
+	// Optional pings to the owner when someone connects or sends
+	// /feedback; NOTIFY_SESSIONS=off and NOTIFY_FEEDBACK=off keep them
+	// configured but quiet
+	notifyInterval, err := time.ParseDuration(getEnv("NOTIFY_INTERVAL", "10m"))
+	if err != nil {
+		logger.Error("Invalid NOTIFY_INTERVAL", telemetry.Ctx("error", err.Error()))
+		os.Exit(1)
+	}
+	notifier := notify.New(notify.Config{
+		NtfyURL:       os.Getenv("NOTIFY_NTFY_URL"),
+		NtfyToken:     os.Getenv("NOTIFY_NTFY_TOKEN"),
+		TelegramToken: os.Getenv("NOTIFY_TELEGRAM_TOKEN"),
+		TelegramChat:  os.Getenv("NOTIFY_TELEGRAM_CHAT"),
+		Interval:      notifyInterval,
+	}, logger)
+	var visitPings *notify.Notifier
+	if getEnv("NOTIFY_SESSIONS", "on") != "off" {
+		visitPings = notifier
+	}
+	feedback := feedbackSink{store: visitorStore}
+	if getEnv("NOTIFY_FEEDBACK", "on") != "off" {
+		feedback.notifier = notifier
+	}
+
+	// Session rate limiting, counted in the store so the limit holds
+	// across replicas
+	sessionLimit := func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			slot := "sessions:" + telemetry.ExtractSessionInfo(s).IPHash
+			ok, err := visitorStore.Acquire(slot, maxSessionsPerIP)
+			if err != nil {
+				// Let visitors in rather than lock everyone out
+				// while the store is down
+				logger.Warn("Session limit check failed", telemetry.Ctx("error", err.Error()))
+				ok = true
+			}
+			if !ok {
+				auditLog.Record(sessionAudit(s, audit.RateLimited, ""))
+				// Hash IP for logging (PII-safe)
+				logger.Warn("Rate limited connection", telemetry.Ctx(
+					"ip_hash", telemetry.ShortHash(s.RemoteAddr().String()),
+				))
+				s.Write([]byte("Too many sessions from your IP. Please try again later.\n"))
+				s.Exit(1)
+				return
+			}
+			if err == nil {
+				defer visitorStore.Release(slot)
+			}
+			auditLog.Record(sessionAudit(s, audit.Accepted, ""))
+			next(s)
+		}
+	}
+
+	// The operator's /broadcast reaches every session in this process
+	var announcements broadcast.Hub
+
+	// The control socket lets whoever runs the server list and kick
+	// sessions, announce, reload content and hold off new sessions from
+	// this host, without SSHing in as an admin key
+	op := &operator{
+		started:       serverStart,
+		loader:        contentLoader,
+		site:          current,
+		prompts:       promptBuilder,
+		store:         visitorStore,
+		announcements: &announcements,
+		logger:        logger,
+	}
+	if controlSocket != "off" {
+		go func() {
+			if err := control.Serve(registryCtx, controlSocket, control.Handler(op)); err != nil {
+				logger.Error("Control socket failed", telemetry.Ctx("error", err.Error()))
+			}
+		}()
+		logger.Info("Control socket ready", telemetry.Ctx("path", controlSocket))
+	}
+
+	// The resume and casts download over either scp protocol
+	files := downloads{site: current, casts: castHandler{dir: recordingsDir}}
 
 	// Create SSH server
 	s, err := wish.NewServer(
 		wish.WithAddress(host+":"+port),
 		wish.WithHostKeyPath(".ssh/id_ed25519"),
 		wish.WithIdleTimeout(idleTimeout),
+		ssh.WrapConn(botGuard.ConnCallback),
+		func(srv *ssh.Server) error {
+			srv.ConnectionFailedCallback = func(conn net.Conn, err error) {
+				auditLog.Record(audit.Event{Outcome: audit.Rejected, Addr: conn.RemoteAddr(), Reason: "handshake"})
+			}
+			return nil
+		},
+		// Any key is welcome; it only recognizes returning visitors and
+		// their saved preferences. Visitors without one get in with no prompt.
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
+		// Subsystems skip the middleware chain, so SFTP gets the guards
+		// it needs itself
+		wish.WithSubsystem("sftp", ssh.SubsystemHandler(
+			botGuard.Middleware()(accessLists.Middleware()(op.middleware()(sessionLimit(files.sftp)))))),
 		wish.WithMiddleware(
-			// Bubble Tea middleware
-			bubbletea.Middleware(func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+			// Bubble Tea middleware; the program handler gives the model
+			// Program.Send for pushing streamed replies
+			bubbletea.MiddlewareWithProgramHandler(func(s ssh.Session) *tea.Program {
 				sessionStart := time.Now()
 
 				// Extract comprehensive session info (PII-safe)
@@ -133,7 +547,7 @@ func main() {
 						"session_hash", sessionID,
 						"user_hash", sessionInfo.UserHash,
 					))
-					return nil, nil
+					return nil
 				}
 
 				width := pty.Window.Width
@@ -152,8 +566,22 @@ func main() {
 				// Log comprehensive session data (all PII-safe)
 				logger.Info("Session connected", sessionInfo.ToMap())
 
-				// Track session with full info
-				analytics.TrackSessionConnectedWithInfo(sessionInfo)
+				// Bots still get the TUI but aren't counted as visitors
+				suspect := botGuard.Suspect(s.RemoteAddr())
+				// Left a nil interface for bots: a nil *Analytics in it
+				// would pass the model's nil checks
+				var sessionAnalytics app.Analytics
+				if suspect {
+					logger.Info("Session from suspected bot", telemetry.Ctx("session_hash", sessionID))
+				} else {
+					sessionAnalytics = analytics
+					// Track session with full info
+					analytics.TrackSessionConnectedWithInfo(sessionInfo)
+					visitPings.SessionStarted(notify.Visit{
+						Terminal: sessionInfo.Terminal,
+						Country:  notify.LocaleCountry(sessionInfo.EnvLang),
+					})
+				}
 
 				// Create renderer tied to SSH session for proper color support
 				renderer := bubbletea.MakeRenderer(s)
@@ -161,22 +589,87 @@ func main() {
 				// Create session-specific theme manager with the renderer
 				themeManager := theme.NewManager(width, height, renderer)
 
+				leave := registry.Join()
+				unlist := op.join(sessionID, s, control.Session{
+					Started:  sessionStart,
+					Terminal: sessionInfo.Terminal,
+					Admin:    access.Admin(s.Context()),
+					ReadOnly: access.ReadOnly(s.Context()),
+				})
+				visitorNumber, err := visitorStore.Count("visits")
+				if err != nil {
+					logger.Warn("Visit not counted", telemetry.Ctx("error", err.Error()))
+				}
+
+				// The program is created after the model, but Send is only
+				// used once the user starts a chat
+				var program *tea.Program
+
+				var announce func(string) int
+				if access.Admin(s.Context()) {
+					announce = func(text string) int {
+						n := announcements.Publish(text)
+						logger.Info("Announcement broadcast", telemetry.Ctx("session_hash", sessionID, "sessions", n))
+						return n
+					}
+				}
+
 				// Create model with analytics
-				model := app.NewModel(app.Config{
+				model := app.NewModel(current.Load().sessionConfig(app.Config{
 					ThemeManager: themeManager,
-					Resume:       resume,
-					Projects:     projects,
-					Bio:          bio,
 					AIService:    aiService,
 					SessionID:    sessionID,
 					Width:        width,
 					Height:       height,
-					Analytics:    analytics,
-				})
+					Analytics:    sessionAnalytics,
+					ServerStart:  serverStart,
+					Send:         func(msg tea.Msg) { program.Send(msg) },
+					Context:      ai.WithRateLimitKey(s.Context(), rateLimitKey(sessionInfo)),
+
+					ReducedMotion: sessionEnvFlag(s.Environ(), "REDUCED_MOTION"),
+					Accessible:    sessionEnvFlag(s.Environ(), "ACCESSIBLE"),
+					ASCII: theme.DetectGlyphSet(pty.Term,
+						sessionEnv(s.Environ(), "LC_ALL"),
+						sessionEnv(s.Environ(), "LC_CTYPE"),
+						sessionEnv(s.Environ(), "LANG"),
+					).ASCII,
+					Graphics: graphics.Detect(pty.Term, func(key string) string {
+						return sessionEnv(s.Environ(), key)
+					}),
+					MaxResponseLength: maxResponseLength,
+					// `ssh -t host projects/mohak-tui` deep links to a view
+					InitialRoute: strings.Join(s.Command(), "/"),
+					IdleTimeout:  idleTimeout,
+					MaxWidth:     maxWidth,
+					ReadOnly:     access.ReadOnly(s.Context()),
+					Store:        visitorStore,
+					VisitorKey:   app.PublicKeyVisitorKey(sessionInfo.PublicKeyHash),
+					ResumeWindow: resumeWindow,
+
+					VisitorNumber: visitorNumber,
+					LiveSessions:  registry.Live,
+					Counters:      visitorStore,
+					Activity:      activity,
+					Status:        ownerStatus,
+					Clock:         clock,
+					RecordingsDir: recordingsDir,
+					SCPPrefix:     scpPrefix(publicHost, publicPort),
+					Feedback:      feedback,
+					Share:         sharer,
+					Broadcast:     announce,
+					Conversations: aiConversations,
+				}))
 
 				// Track disconnect on session end
 				go func() {
 					<-s.Context().Done()
+					// A program stuck writing to the dead connection never
+					// reads the Quit it's sent, and a reply streaming to it
+					// would block with it; killing it unblocks both. It has
+					// usually exited by then, and killing it again is a no-op.
+					time.AfterFunc(programKillGrace, program.Kill)
+					leave()
+					unlist()
 					duration := time.Since(sessionStart).Milliseconds()
 					logger.Info("Session disconnected", telemetry.Ctx(
 						"session_hash", sessionID,
@@ -184,32 +677,42 @@ func main() {
 						"duration_ms", duration,
 						"terminal", sessionInfo.Terminal,
 					))
-					analytics.TrackSessionDisconnected(sessionID, duration)
+					if !suspect {
+						analytics.TrackSessionDisconnected(sessionID, duration)
+					}
 				}()
 
-				return model, []tea.ProgramOption{
-					tea.WithAltScreen(),
-				}
-			}),
+				opts := append([]tea.ProgramOption{tea.WithAltScreen()}, bubbletea.MakeOptions(s)...)
+				program = tea.NewProgram(model, opts...)
+				announcements.Subscribe(s.Context(), func(text string) {
+					program.Send(app.AnnouncementMsg{Text: text})
+				})
+				return program
+			}, termenv.Ascii),
 			// Active terminal middleware (ensures PTY)
 			activeterm.Middleware(),
-			// Session rate limiting
-			func(next ssh.Handler) ssh.Handler {
-				return func(s ssh.Session) {
-					addr := s.RemoteAddr().String()
-					if !sessionCounter.Acquire(addr) {
-						// Hash IP for logging (PII-safe)
-						logger.Warn("Rate limited connection", telemetry.Ctx(
-							"ip_hash", telemetry.ShortHash(addr),
-						))
-						s.Write([]byte("Too many sessions from your IP. Please try again later.\n"))
-						s.Exit(1)
-						return
-					}
-					defer sessionCounter.Release(addr)
-					next(s)
-				}
-			},
+			// `ssh host ask "<question>" [--json]` answers without a PTY
+			asker{
+				ai:                aiService,
+				site:              current,
+				model:             modelName,
+				maxResponseLength: maxResponseLength,
+				logger:            logger,
+				analytics:         analytics,
+			}.middleware(),
+			// `ssh host resume > resume.txt` writes the page as plain text
+			plainPages{site: current}.middleware(),
+			// `scp host:resume.pdf .` downloads the resume and
+			// `scp host:<token>.cast .` a /record cast; runs before
+			// activeterm since scp has no PTY
+			scp.Middleware(files, nil),
+			sessionLimit,
+			// Holds off all but the admins in maintenance mode
+			op.middleware(),
+			// Refuses denied clients before they count against the limit
+			accessLists.Middleware(),
+			// Marks connections that open a session, which bots rarely do
+			botGuard.Middleware(),
 			// Custom logging middleware (replaces wish/logging)
 			func(next ssh.Handler) ssh.Handler {
 				return func(s ssh.Session) {
@@ -261,6 +764,21 @@ func main() {
 	logger.Info("Server stopped")
 }
 
+// loadThemes validates the theme files in dir and adds their palettes,
+// returning how many were added
+func loadThemes(dir string) (int, error) {
+	palettes, err := theme.LoadPalettes(dir)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range palettes {
+		if !ui.HasSpinnerSet(p.Spinner) {
+			return 0, fmt.Errorf("theme %s: unknown spinner %q", p.Name, p.Spinner)
+		}
+	}
+	return len(palettes), theme.AddPalettes(palettes...)
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -296,52 +814,46 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	return parsed
 }
 
-// SessionCounter tracks sessions per IP for rate limiting
-type SessionCounter struct {
-	counts   map[string]int
-	maxPerIP int
+// sessionEnv returns a client-forwarded env var (ssh SendEnv / SetEnv)
+func sessionEnv(environ []string, key string) string {
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok && name == key {
+			return value
+		}
+	}
+	return ""
 }
 
-func NewSessionCounter(maxPerIP int) *SessionCounter {
-	return &SessionCounter{
-		counts:   make(map[string]int),
-		maxPerIP: maxPerIP,
+// sessionEnvFlag reports whether a client-forwarded env var is set to a truthy value
+func sessionEnvFlag(environ []string, key string) bool {
+	switch strings.ToLower(sessionEnv(environ, key)) {
+	case "1", "true", "yes", "on":
+		return true
 	}
+	return false
 }
 
-func (sc *SessionCounter) Acquire(addr string) bool {
-	ip := addr
-	if colonIdx := len(addr) - 1; colonIdx > 0 {
-		for i := len(addr) - 1; i >= 0; i-- {
-			if addr[i] == ':' {
-				ip = addr[:i]
-				break
-			}
-		}
+// sessionAudit describes a session's connection for the audit log
+func sessionAudit(s ssh.Session, outcome audit.Outcome, reason string) audit.Event {
+	e := audit.Event{
+		Outcome:       outcome,
+		Reason:        reason,
+		Addr:          s.RemoteAddr(),
+		User:          s.User(),
+		ClientVersion: s.Context().ClientVersion(),
 	}
-
-	if sc.counts[ip] >= sc.maxPerIP {
-		return false
+	if key := s.PublicKey(); key != nil {
+		e.KeyType = key.Type()
 	}
-	sc.counts[ip]++
-	return true
+	return e
 }
 
-func (sc *SessionCounter) Release(addr string) {
-	ip := addr
-	if colonIdx := len(addr) - 1; colonIdx > 0 {
-		for i := len(addr) - 1; i >= 0; i-- {
-			if addr[i] == ':' {
-				ip = addr[:i]
-				break
-			}
-		}
-	}
-
-	if sc.counts[ip] > 0 {
-		sc.counts[ip]--
-	}
-	if sc.counts[ip] == 0 {
-		delete(sc.counts, ip)
+// rateLimitKey is who AI requests are counted against: the visitor's key
+// when they offered one, else their address, so reconnecting doesn't
+// reset the allowance
+func rateLimitKey(info telemetry.SessionInfo) string {
+	if info.PublicKeyHash != "" {
+		return "key:" + info.PublicKeyHash
 	}
+	return "ip:" + info.IPHash
 }
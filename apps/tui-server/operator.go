@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/access"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ai"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/broadcast"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/control"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/version"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// sessionIDLength is how much of a session hash the control socket
+// shows; enough to tell the sessions of one server apart
+const sessionIDLength = 8
+
+// operator is the server as the control socket sees it: the sessions
+// open in this process, the content new ones get and whether they're let
+// in
+type operator struct {
+	started       time.Time
+	loader        *content.Loader
+	site          *liveSite
+	prompts       *ai.PromptBuilder
+	store         store.Store
+	announcements *broadcast.Hub
+	logger        *telemetry.Logger
+
+	maintenance atomic.Bool
+
+	mu       sync.Mutex
+	sessions map[string]*openSession // by full session hash
+}
+
+// openSession is a session the control socket can list and kick
+type openSession struct {
+	control.Session
+	close func() error
+}
+
+var _ control.Operator = (*operator)(nil)
+
+// join lists a session until the returned leave is called
+func (o *operator) join(id string, s ssh.Session, info control.Session) (leave func()) {
+	info.ID = id[:min(len(id), sessionIDLength)]
+	// Closing the connection, not just the channel, ends the session the
+	// way a dropped connection does
+	closeConn := s.Close
+	if conn, ok := s.Context().Value(ssh.ContextKeyConn).(gossh.Conn); ok {
+		closeConn = conn.Close
+	}
+
+	o.mu.Lock()
+	if o.sessions == nil {
+		o.sessions = map[string]*openSession{}
+	}
+	o.sessions[id] = &openSession{Session: info, close: closeConn}
+	o.mu.Unlock()
+	return func() {
+		o.mu.Lock()
+		delete(o.sessions, id)
+		o.mu.Unlock()
+	}
+}
+
+func (o *operator) Status() control.Status {
+	o.mu.Lock()
+	n := len(o.sessions)
+	o.mu.Unlock()
+	return control.Status{
+		Version:     version.String(),
+		Started:     o.started,
+		Sessions:    n,
+		Maintenance: o.maintenance.Load(),
+		Content:     o.site.Load().digest.Version(),
+	}
+}
+
+func (o *operator) Sessions() []control.Session {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	list := make([]control.Session, 0, len(o.sessions))
+	for _, s := range o.sessions {
+		list = append(list, s.Session)
+	}
+	slices.SortFunc(list, func(a, b control.Session) int { return a.Started.Compare(b.Started) })
+	return list
+}
+
+func (o *operator) Kick(id string) (control.Session, error) {
+	o.mu.Lock()
+	var found []*openSession
+	for hash, s := range o.sessions {
+		if id != "" && strings.HasPrefix(hash, id) {
+			found = append(found, s)
+		}
+	}
+	o.mu.Unlock()
+	if len(found) != 1 {
+		return control.Session{}, control.ErrNoSession
+	}
+	o.logger.Info("Session kicked", telemetry.Ctx("session_hash", found[0].ID))
+	return found[0].Session, found[0].close()
+}
+
+func (o *operator) Broadcast(text string) int {
+	n := o.announcements.Publish(text)
+	o.logger.Info("Announcement broadcast", telemetry.Ctx("source", "control", "sessions", n))
+	return n
+}
+
+// ReloadContent loads the content again for new sessions, downloads and
+// the AI. Sessions already open keep what they have. Content that
+// doesn't load leaves everything as it was.
+func (o *operator) ReloadContent() (string, error) {
+	next, err := loadSite(o.loader, time.Now())
+	if err != nil {
+		return "", err
+	}
+	o.site.Store(next)
+	o.prompts.SetContent(next.resume, next.projects, next.bio, next.digest.Version())
+	next.archive(o.store, o.logger)
+	o.logger.Info("Content reloaded", telemetry.Ctx("version", next.digest.Version()))
+	return next.digest.Version(), nil
+}
+
+func (o *operator) SetMaintenance(on bool) {
+	o.maintenance.Store(on)
+	o.logger.Info("Maintenance mode", telemetry.Ctx("on", on))
+}
+
+// middleware turns new sessions away while in maintenance mode, but for
+// the admins', so they can check the server before letting everyone back
+// in. It goes after the access lists, which find the admins.
+func (o *operator) middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if o.maintenance.Load() && !access.Admin(s.Context()) {
+				fmt.Fprintln(s.Stderr(), "Down for maintenance - back in a few minutes.")
+				_ = s.Exit(1)
+				return
+			}
+			next(s)
+		}
+	}
+}
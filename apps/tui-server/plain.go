@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/app"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// plainPages serves a deep link to a session without a PTY as
+// decoration-free text, so `ssh host resume > resume.txt` saves a clean
+// copy. With a PTY the same link opens the TUI on that view.
+type plainPages struct{ site *liveSite }
+
+// middleware serves view links without a PTY and passes every other
+// session on, so other commands still get the usual "no PTY" refusal
+func (p plainPages) middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if _, _, pty := s.Pty(); pty || len(s.Command()) == 0 {
+				next(s)
+				return
+			}
+			route, width, err := parsePageArgs(s.Command())
+			if err != nil {
+				fmt.Fprintln(s.Stderr(), "usage: ssh <host> <about|projects[/<id>]|resume|experience|uses|talks|certs> [--width N]")
+				_ = s.Exit(2)
+				return
+			}
+			page, arg, ok := app.PreviewPage(route)
+			if !ok {
+				next(s)
+				return
+			}
+			out, err := ui.Preview(ui.PlainStyles(width), p.site.Load().preview(), page, arg, width)
+			if err != nil {
+				fmt.Fprintln(s.Stderr(), err)
+				_ = s.Exit(1)
+				return
+			}
+			fmt.Fprintln(s, ui.Plain(out))
+			_ = s.Exit(0)
+		}
+	}
+}
+
+// parsePageArgs splits a command into its link and --width. Flags may
+// follow the link, as in `resume --width 100`.
+func parsePageArgs(args []string) (route string, width int, err error) {
+	fs := flag.NewFlagSet("page", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&width, "width", 80, "wrap the page at this width")
+
+	var parts []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return "", 0, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		parts = append(parts, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if err := checkWidth(width); err != nil {
+		return "", 0, err
+	}
+	return strings.Join(parts, "/"), width, nil
+}
+
+// Exec commands need no login, and their output grows with the width
+// they wrap at, so --width is held to a terminal's range
+const (
+	minWidth = 20
+	maxWidth = 300
+)
+
+// checkWidth rejects a --width outside minWidth..maxWidth
+func checkWidth(width int) error {
+	if width < minWidth || width > maxWidth {
+		return fmt.Errorf("width must be between %d and %d", minWidth, maxWidth)
+	}
+	return nil
+}
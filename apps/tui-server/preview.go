@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/theme"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+// runPreview handles `tui-server preview <page> [id] [--width N] [--color]`:
+// it renders one view to w so content edits can be checked without a
+// client. Plain output doesn't depend on the terminal, so it doubles as
+// golden test output.
+func runPreview(w io.Writer, contentPath string, args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	width := fs.Int("width", 80, "terminal width to render at")
+	color := fs.Bool("color", false, "keep colors and links")
+
+	// Flags may follow the page, as in `preview resume --width 100`
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) == 0 {
+		return errors.New("usage: tui-server preview <about|projects|project <id>|resume|experience|uses|talks|certs> [--width N] [--color]")
+	}
+	page, arg := positional[0], ""
+	if len(positional) > 1 {
+		arg = positional[1]
+	}
+
+	loader := content.NewLoader(contentPath)
+	resume, err := loader.LoadResume()
+	if err != nil {
+		return err
+	}
+	projects, err := loader.LoadProjects()
+	if err != nil {
+		return err
+	}
+	bio, err := loader.LoadBio()
+	if err != nil {
+		return err
+	}
+	uses, err := loader.LoadUses()
+	if err != nil {
+		return err
+	}
+	talks, err := loader.LoadTalks()
+	if err != nil {
+		return err
+	}
+	certs, err := loader.LoadCertifications()
+	if err != nil {
+		return err
+	}
+
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.TrueColor)
+	styles := theme.NewManager(*width, 24, renderer).Styles()
+	out, err := ui.Preview(styles, ui.PreviewContent{Resume: resume, Projects: projects, Bio: bio, Uses: uses, Talks: talks, Certs: certs}, page, arg, *width)
+	if err != nil {
+		return err
+	}
+	if !*color {
+		out = ansi.Strip(out)
+	}
+	_, err = fmt.Fprintln(w, out)
+	return err
+}
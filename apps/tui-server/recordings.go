@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish/scp"
+)
+
+// castName matches the random file names /record gives casts
+var castName = regexp.MustCompile(`^[0-9a-f]{32}\.cast$`)
+
+// castHandler serves /record casts over scp. Only exact names are
+// served: no globbing, listing or directories, so a cast can be fetched
+// only by whoever was shown its name.
+type castHandler struct{ dir string }
+
+var _ scp.CopyToClientHandler = castHandler{}
+
+func (h castHandler) Glob(_ ssh.Session, pattern string) ([]string, error) {
+	return []string{pattern}, nil
+}
+
+func (h castHandler) WalkDir(ssh.Session, string, fs.WalkDirFunc) error {
+	return fmt.Errorf("recursive copies are not supported")
+}
+
+func (h castHandler) NewDirEntry(_ ssh.Session, name string) (*scp.DirEntry, error) {
+	return nil, fmt.Errorf("%s: not a recording", name)
+}
+
+func (h castHandler) NewFileEntry(_ ssh.Session, name string) (*scp.FileEntry, func() error, error) {
+	f, info, err := h.open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &scp.FileEntry{
+		Name:     info.Name(),
+		Filepath: info.Name(),
+		Mode:     0o644,
+		Size:     info.Size(),
+		Mtime:    info.ModTime().Unix(),
+		Atime:    info.ModTime().Unix(),
+		Reader:   f,
+	}, f.Close, nil
+}
+
+// open opens the cast with the given name, which must be exact
+func (h castHandler) open(name string) (*os.File, fs.FileInfo, error) {
+	base := filepath.Base(name)
+	if !castName.MatchString(base) {
+		return nil, nil, fmt.Errorf("%s: not a recording", name)
+	}
+	f, err := os.Open(filepath.Join(h.dir, base))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: no such recording", base)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, info, nil
+}
+
+// scpPrefix is the download command for a cast on the server reachable at
+// host and port, e.g. "scp -P 2222 localhost:"
+func scpPrefix(host, port string) string {
+	if port == "22" {
+		return "scp " + host + ":"
+	}
+	return "scp -P " + port + " " + host + ":"
+}
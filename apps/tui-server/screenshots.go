@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+)
+
+// loadScreenshots decodes every project's images once, for all sessions
+// to draw, keyed by project ID
+func loadScreenshots(loader *content.Loader, projects *content.Projects) (map[string][]*graphics.Picture, error) {
+	shots := map[string][]*graphics.Picture{}
+	for _, project := range projects.Projects {
+		for _, name := range project.Images {
+			img, err := loader.LoadImage(name)
+			if err != nil {
+				return nil, err
+			}
+			shots[project.ID] = append(shots[project.ID], graphics.New(img))
+		}
+	}
+	return shots, nil
+}
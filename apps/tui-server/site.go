@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/app"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/content"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/export"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/graphics"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/store"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/telemetry"
+	"github.com/mohakbajaj/mohak-tui/apps/tui-server/internal/ui"
+)
+
+// site is the content, from CONTENT_PATH or embedded, and what's built
+// from it. Reloading swaps it whole: sessions keep the one they started
+// with, and new ones get the latest.
+type site struct {
+	resume      *content.Resume
+	projects    *content.Projects
+	screenshots map[string][]*graphics.Picture
+	bio         string
+	uses        string
+	talks       *content.Talks
+	certs       *content.Certifications
+	faq         *content.FAQ
+	digest      content.Digest
+	files       export.Files // resume.txt, resume.pdf and contact.vcf
+}
+
+// liveSite is the site new sessions and downloads get
+type liveSite struct{ atomic.Pointer[site] }
+
+// loadSite reads every content file, stopping at the first that's
+// missing or invalid. The resume files are dated at.
+func loadSite(loader *content.Loader, at time.Time) (*site, error) {
+	s := &site{}
+	var err error
+	if s.resume, err = loader.LoadResume(); err != nil {
+		return nil, fmt.Errorf("resume: %w", err)
+	}
+	if s.projects, err = loader.LoadProjects(); err != nil {
+		return nil, fmt.Errorf("projects: %w", err)
+	}
+	if s.screenshots, err = loadScreenshots(loader, s.projects); err != nil {
+		return nil, fmt.Errorf("screenshots: %w", err)
+	}
+	if s.bio, err = loader.LoadBio(); err != nil {
+		return nil, fmt.Errorf("bio: %w", err)
+	}
+	if s.uses, err = loader.LoadUses(); err != nil {
+		return nil, fmt.Errorf("uses: %w", err)
+	}
+	if s.talks, err = loader.LoadTalks(); err != nil {
+		return nil, fmt.Errorf("talks: %w", err)
+	}
+	if s.certs, err = loader.LoadCertifications(); err != nil {
+		return nil, fmt.Errorf("certifications: %w", err)
+	}
+	if s.faq, err = loader.LoadFAQ(); err != nil {
+		return nil, fmt.Errorf("FAQ: %w", err)
+	}
+	s.digest = content.NewDigest(s.resume, s.projects, s.talks, s.certs)
+	s.files = export.Build(s.resume, at)
+	return s, nil
+}
+
+// archive keeps the content's digest under its version. /whatsnew
+// compares the content with the version a returning visitor saw, so
+// every version is archived.
+func (s *site) archive(st store.Store, logger *telemetry.Logger) {
+	data, err := json.Marshal(s.digest)
+	if err == nil {
+		err = st.ArchiveContent(s.digest.Version(), data)
+	}
+	if err != nil {
+		logger.Warn("Failed to archive content", telemetry.Ctx("error", err.Error()))
+	}
+}
+
+// sessionConfig is cfg with the site's content filled in
+func (s *site) sessionConfig(cfg app.Config) app.Config {
+	cfg.Resume, cfg.Projects, cfg.Screenshots = s.resume, s.projects, s.screenshots
+	cfg.Bio, cfg.Uses, cfg.Talks, cfg.Certs = s.bio, s.uses, s.talks, s.certs
+	cfg.Digest, cfg.FAQ = s.digest, s.faq
+	return cfg
+}
+
+// preview is the content plain pages are rendered from
+func (s *site) preview() ui.PreviewContent {
+	return ui.PreviewContent{Resume: s.resume, Projects: s.projects, Bio: s.bio, Uses: s.uses, Talks: s.talks, Certs: s.certs}
+}
@@ -1,6 +1,9 @@
 import resume from "./resume.json";
 import projects from "./projects.json";
 import theme from "./theme.json";
+import faq from "./faq.json";
+import talks from "./talks.json";
+import certifications from "./certifications.json";
 import { readFileSync } from "fs";
 import { join, dirname } from "path";
 import { fileURLToPath } from "url";
@@ -53,6 +56,15 @@ export interface Project {
   description: string;
   tech: string[];
   status: "active" | "completed" | "archived";
+  /** When it was last worked on, as "2024-06" */
+  date?: string;
+  /** When it started */
+  year?: number;
+  tags?: string[];
+  /** The projects list's section */
+  category?: "AI" | "Systems" | "Web";
+  /** At most one project is featured */
+  featured?: boolean;
   links: {
     demo?: string;
     github?: string;
@@ -63,6 +75,42 @@ export interface Projects {
   projects: Project[];
 }
 
+export interface Talk {
+  title: string;
+  kind: "talk" | "publication" | "podcast";
+  event: string;
+  date: string;
+  url?: string;
+  summary?: string;
+}
+
+export interface Talks {
+  talks: Talk[];
+}
+
+export interface Certification {
+  name: string;
+  issuer: string;
+  date: string;
+  expires?: string;
+  credentialId?: string;
+  url?: string;
+}
+
+export interface Certifications {
+  certifications: Certification[];
+}
+
+export interface FAQEntry {
+  id: string;
+  questions: string[];
+  answer: string;
+}
+
+export interface FAQ {
+  faqs: FAQEntry[];
+}
+
 export interface ThemeColors {
   name: string;
   background: string;
@@ -86,11 +134,19 @@ export interface Theme {
 export const getResume = (): Resume => resume as Resume;
 export const getProjects = (): Projects => projects as Projects;
 export const getTheme = (): Theme => theme as Theme;
+export const getFAQ = (): FAQ => faq as FAQ;
+export const getTalks = (): Talks => talks as Talks;
+export const getCertifications = (): Certifications =>
+  certifications as Certifications;
 
 export const getBio = (): string => {
   return readFileSync(join(CONTENT_PATH, "bio.md"), "utf-8");
 };
 
+export const getUses = (): string => {
+  return readFileSync(join(CONTENT_PATH, "uses.md"), "utf-8");
+};
+
 /**
  * Query intent classification for smarter responses
  */
@@ -473,4 +529,4 @@ export function generateFollowUps(intent: QueryIntent): string[] {
   return followUps[intent] || followUps.general;
 }
 
-export { resume, projects, theme };
+export { resume, projects, theme, faq };